@@ -37,6 +37,10 @@ helm_labels_template_name := cert-manager-approver-policy.labels
 
 golangci_lint_config := .golangci.yaml
 
+# https://pkg.go.dev/google.golang.org/grpc/cmd/protoc-gen-go-grpc?tab=versions
+ADDITIONAL_TOOLS := protoc-gen-go-grpc=v1.5.1
+ADDITIONAL_GO_DEPENDENCIES := protoc-gen-go-grpc=google.golang.org/grpc/cmd/protoc-gen-go-grpc
+
 define helm_values_mutation_function
 $(YQ) \
 	'( .image.repository = "$(oci_manager_image_name)" ) | \
@@ -20,9 +20,13 @@ $(kind_cluster_config): make/config/kind/cluster.yaml | $(bin_dir)/scratch
 .PHONY: generate-protos
 ## Generate code containing DeepCopy, DeepCopyInto, and DeepCopyObject method implementations.
 ## @category Generate/ Verify
-generate-protos: | $(NEEDS_PROTOC) $(NEEDS_PROTOC-GEN-GO)
+generate-protos: | $(NEEDS_PROTOC) $(NEEDS_PROTOC-GEN-GO) $(NEEDS_PROTOC-GEN-GO-GRPC)
 	$(PROTOC) --plugin=$(PROTOC-GEN-GO) --proto_path=. --go_out=. --go_opt=paths=source_relative \
 		pkg/internal/approver/validation/certificaterequest.proto
+	$(PROTOC) --plugin=$(PROTOC-GEN-GO) --plugin=$(PROTOC-GEN-GO-GRPC) --proto_path=. \
+		--go_out=. --go_opt=paths=source_relative \
+		--go-grpc_out=. --go-grpc_opt=paths=source_relative \
+		pkg/approver/remote/plugin.proto
 
 shared_generate_targets += generate-protos
 
@@ -67,6 +67,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&CertificateRequestPolicy{},
 		&CertificateRequestPolicyList{},
+		&CertificateRequestPolicyProfile{},
+		&CertificateRequestPolicyProfileList{},
+		&CertificateRequestReview{},
+		&CertificateRequestReviewList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -18,12 +18,34 @@ package v1alpha1
 
 import (
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var CertificateRequestPolicyKind = "CertificateRequestPolicy"
 
+const (
+	// CertificateRequestAnnotationApprovedBy is the annotation key which is
+	// set on CertificateRequests that have been approved by approver-policy.
+	// The value is the name of the CertificateRequestPolicy which approved the
+	// request.
+	CertificateRequestAnnotationApprovedBy = "policy.cert-manager.io/approved-by"
+
+	// CertificateRequestAnnotationDeniedBy is the annotation key which is set
+	// on CertificateRequests that have been denied by approver-policy. The
+	// value is a comma separated list of the names of the
+	// CertificateRequestPolicies which denied the request.
+	CertificateRequestAnnotationDeniedBy = "policy.cert-manager.io/denied-by"
+
+	// CertificateRequestAnnotationDenials is the annotation key which is set
+	// on CertificateRequests that have been denied by approver-policy, if
+	// enabled. The value is a JSON list of the CertificateRequestPolicies
+	// which denied the request along with the reasons each denied it, i.e.
+	// `[{"policy":"my-policy","errors":["..."],"reasons":[{"reason":"DNSNameNotAllowed","field":"spec.allowed.dnsNames.values"}]}]`.
+	CertificateRequestAnnotationDenials = "policy.cert-manager.io/denials"
+)
+
 // +genclient
 // +genclient:nonNamespaced
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -72,6 +94,31 @@ type CertificateRequestPolicySpec struct {
 	// +optional
 	Constraints *CertificateRequestPolicyConstraints `json:"constraints,omitempty"`
 
+	// ProfileRef references a CertificateRequestPolicyProfile whose `allowed`
+	// and `constraints` fields are used by this policy.
+	// Fields of `allowed` and `constraints` which are set on this policy take
+	// precedence over the same fields of the profile; the profile only
+	// provides the fields which are omitted from this policy.
+	// If the profile does not exist, the policy will not become ready.
+	// An omitted field references no profile.
+	// +optional
+	ProfileRef *CertificateRequestPolicyProfileReference `json:"profileRef,omitempty"`
+
+	// InheritFrom names other CertificateRequestPolicies whose `allowed` and
+	// `constraints` fields are used by this policy.
+	// Fields of `allowed` and `constraints` which are set on this policy take
+	// precedence over the same fields of its parents, and parents earlier in
+	// the list take precedence over those later in the list. Parents are
+	// resolved with their own `inheritFrom` and `profileRef` applied, before
+	// the `profileRef` of this policy.
+	// Only the `allowed` and `constraints` fields are inherited; the
+	// selector, plugins and other fields of parents are not.
+	// If a parent does not exist, or the parents inherit from this policy,
+	// the policy will not become ready.
+	// An omitted field or `[]` inherits from no policies.
+	// +optional
+	InheritFrom []string `json:"inheritFrom,omitempty"`
+
 	// Plugins are approvers that are built into approver-policy at
 	// compile-time. This is an advanced feature typically used to extend
 	// approver-policy core features. This field define plugins and their
@@ -84,8 +131,137 @@ type CertificateRequestPolicySpec struct {
 	// CertificateRequestPolicy is appropriate for and so will be used for its
 	// approval evaluation.
 	Selector CertificateRequestPolicySelector `json:"selector"`
+
+	// Enforcement defines how a denial by this policy is combined with the
+	// results of the other policies selected for a request.
+	// A `Permissive` policy only contributes to a request being approved; a
+	// request is approved if any selected policy approves it, so a denial by
+	// a Permissive policy is overridden by any other approving policy.
+	// A `Strict` policy additionally denies the request whenever it denies,
+	// regardless of any other policy approving the request, i.e. a Strict
+	// denial always takes precedence over an approval.
+	// A request is not approved while a Strict policy is awaiting an external
+	// decision.
+	// An omitted field is defaulted to `Permissive`.
+	// +optional
+	Enforcement *CertificateRequestPolicyEnforcement `json:"enforcement,omitempty"`
+
+	// Fallback marks this policy as a fallback policy, i.e. a default policy
+	// for the namespaces it selects. Fallback policies are only evaluated
+	// for a request if no other policy is selected for it, after all of the
+	// selectors, readiness and RBAC have been checked. If any non-fallback
+	// policy is selected for a request, every fallback policy is ignored.
+	// An omitted field is `false`.
+	// +optional
+	Fallback *bool `json:"fallback,omitempty"`
+
+	// TestCases are sample requests along with the result this policy is
+	// expected to give for them. Once the policy is ready, it is evaluated
+	// against each sample whenever it is reconciled, and the outcome is
+	// reported by the `TestsPassed` condition. Failing test cases don't
+	// affect whether the policy is ready.
+	// Only this policy is evaluated for each sample, without regard to its
+	// selector or to other policies.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	TestCases []CertificateRequestPolicyTestCase `json:"testCases,omitempty"`
 }
 
+// CertificateRequestPolicyTestCase is a sample request paired with the result
+// a CertificateRequestPolicy is expected to give for it.
+type CertificateRequestPolicyTestCase struct {
+	// Name identifies the test case in the `TestsPassed` condition.
+	Name string `json:"name"`
+
+	// Request holds the attributes of the sample request.
+	Request CertificateRequestPolicyTestCaseRequest `json:"request"`
+
+	// Expect is the result the policy is expected to give for the request.
+	Expect CertificateRequestPolicyTestCaseResult `json:"expect"`
+}
+
+// CertificateRequestPolicyTestCaseRequest holds the attributes of a sample
+// CertificateRequest. A CSR is generated from the attributes, using an ECDSA
+// P-256 private key.
+type CertificateRequestPolicyTestCaseRequest struct {
+	// Namespace of the sample request, available to CEL expressions as
+	// `cr.namespace`.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Username of the requester, available to CEL expressions as
+	// `cr.username`.
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// IssuerRef of the sample request.
+	// +optional
+	IssuerRef cmmeta.ObjectReference `json:"issuerRef,omitempty"`
+
+	// CommonName requested in the CSR.
+	// +optional
+	CommonName string `json:"commonName,omitempty"`
+
+	// DNSNames requested in the CSR.
+	// +optional
+	DNSNames []string `json:"dnsNames,omitempty"`
+
+	// IPAddresses requested in the CSR.
+	// +optional
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+
+	// URIs requested in the CSR.
+	// +optional
+	URIs []string `json:"uris,omitempty"`
+
+	// EmailAddresses requested in the CSR.
+	// +optional
+	EmailAddresses []string `json:"emailAddresses,omitempty"`
+
+	// IsCA of the sample request.
+	// +optional
+	IsCA bool `json:"isCA,omitempty"`
+
+	// Usages of the sample request.
+	// +optional
+	Usages []cmapi.KeyUsage `json:"usages,omitempty"`
+
+	// Duration of the sample request.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+// CertificateRequestPolicyTestCaseResult is the result a
+// CertificateRequestPolicy is expected to give for a sample request.
+// +kubebuilder:validation:Enum=Approved;Denied
+type CertificateRequestPolicyTestCaseResult string
+
+const (
+	// CertificateRequestPolicyTestCaseResultApproved expects the policy to
+	// approve the sample request.
+	CertificateRequestPolicyTestCaseResultApproved CertificateRequestPolicyTestCaseResult = "Approved"
+
+	// CertificateRequestPolicyTestCaseResultDenied expects the policy to deny
+	// the sample request.
+	CertificateRequestPolicyTestCaseResultDenied CertificateRequestPolicyTestCaseResult = "Denied"
+)
+
+// CertificateRequestPolicyEnforcement defines how a denial by a policy is
+// combined with the results of other policies.
+// +kubebuilder:validation:Enum=Permissive;Strict
+type CertificateRequestPolicyEnforcement string
+
+const (
+	// CertificateRequestPolicyEnforcementPermissive allows a denial by the
+	// policy to be overridden by another policy approving the request.
+	CertificateRequestPolicyEnforcementPermissive CertificateRequestPolicyEnforcement = "Permissive"
+
+	// CertificateRequestPolicyEnforcementStrict denies the request whenever
+	// the policy denies it, regardless of other policies.
+	CertificateRequestPolicyEnforcementStrict CertificateRequestPolicyEnforcement = "Strict"
+)
+
 // CertificateRequestPolicyAllowed defines the allowed attributes for a
 // CertificateRequest.
 // A CertificateRequest can request _less_ than what is allowed,
@@ -99,10 +275,14 @@ type CertificateRequestPolicyAllowed struct {
 	CommonName *CertificateRequestPolicyAllowedString `json:"commonName,omitempty"`
 
 	// DNSNames defines the X.509 DNS SANs that may be requested.
+	// DNS names are matched case-insensitively, unless CaseSensitiveNames is
+	// true.
 	// +optional
 	DNSNames *CertificateRequestPolicyAllowedStringSlice `json:"dnsNames,omitempty"`
 
 	// IPAddresses defines the X.509 IP SANs that may be requested.
+	// Wildcard values which are CIDR ranges, e.g. `10.0.0.0/8`, allow any IP
+	// address contained in that range.
 	// +optional
 	IPAddresses *CertificateRequestPolicyAllowedStringSlice `json:"ipAddresses,omitempty"`
 
@@ -111,6 +291,8 @@ type CertificateRequestPolicyAllowed struct {
 	URIs *CertificateRequestPolicyAllowedStringSlice `json:"uris,omitempty"`
 
 	// EmailAddresses defines the X.509 Email SANs that may be requested.
+	// The domain of email addresses is matched case-insensitively, unless
+	// CaseSensitiveNames is true. The local-part is always case-sensitive.
 	// +optional
 	EmailAddresses *CertificateRequestPolicyAllowedStringSlice `json:"emailAddresses,omitempty"`
 
@@ -125,7 +307,11 @@ type CertificateRequestPolicyAllowed struct {
 	// CertificateRequest `spec.keyUsages` field.
 	// If set, `spec.keyUsages` in a CertificateRequest must be a subset of the
 	// specified values.
+	// Equivalent usage spellings are treated as the same usage, i.e.
+	// `signing` matches `digital signature` and `s/mime` matches `email
+	// protection`.
 	// If `[]` or unset, no `spec.keyUsages` are allowed.
+	// Duplicate usages are rejected.
 	// TODO: add x-kubernetes-list-type: set in v1alpha2
 	// +optional
 	Usages *[]cmapi.KeyUsage `json:"usages,omitempty"`
@@ -137,6 +323,26 @@ type CertificateRequestPolicyAllowed struct {
 	// attributes.
 	// +optional
 	Subject *CertificateRequestPolicyAllowedX509Subject `json:"subject,omitempty"`
+
+	// Annotations defines the values that may be set for the given annotation
+	// keys on a CertificateRequest, e.g. annotations which issuers read to
+	// select a signing profile. Only the annotation keys listed are checked;
+	// other annotations are not restricted.
+	// When evaluating `validations`, `self` is bound to the annotation value.
+	// +optional
+	Annotations map[string]CertificateRequestPolicyAllowedString `json:"annotations,omitempty"`
+
+	// CaseSensitiveNames, if true, matches requested DNS names and the domain
+	// of requested email addresses exactly as requested.
+	// Otherwise, DNS names and email domains are lowercased before being
+	// matched, along with `values` using the `Wildcard` value type. DNS name
+	// `values` using the `Regexp` value type match case-insensitively. Email
+	// address `values` using the `Regexp` value type and `validations` are
+	// evaluated against the lowercased name, so should be written in
+	// lowercase.
+	// An omitted field or false matches case-insensitively.
+	// +optional
+	CaseSensitiveNames *bool `json:"caseSensitiveNames,omitempty"`
 }
 
 // CertificateRequestPolicyAllowedX509Subject declares allowed X.509 Subject
@@ -186,15 +392,38 @@ type CertificateRequestPolicyAllowedX509Subject struct {
 // If neither allowed values nor validations are specified, the related field must be empty.
 type CertificateRequestPolicyAllowedStringSlice struct {
 	// Values defines allowed attribute values on the related CertificateRequest field.
-	// Accepts wildcards "*".
+	// Accepts wildcards "*", or regular expressions if ValueType is `Regexp`.
 	// If set, the related field can only include items contained in the allowed values.
 	//
 	// NOTE:`values: []` paired with `required: true` establishes a policy that
 	// will never grant a `CertificateRequest`, but other policies may.
+	// A warning is returned when such a policy is applied.
+	// Duplicate values are rejected.
 	// TODO: add x-kubernetes-list-type: set in v1alpha2
 	// +optional
 	Values *[]string `json:"values,omitempty"`
 
+	// ValuesFrom references a key of a ConfigMap containing further allowed
+	// attribute values, one per line. Surrounding whitespace and empty lines
+	// are ignored. The values are merged with Values and matched in the same
+	// way.
+	// If the ConfigMap does not exist, or doesn't contain the key, the policy
+	// will not become ready.
+	// An omitted field loads no further values.
+	// +optional
+	ValuesFrom *CertificateRequestPolicyConfigMapKeyReference `json:"valuesFrom,omitempty"`
+
+	// ValueType defines how Values are matched against the related
+	// CertificateRequest field. `Wildcard` matches using wildcards "*".
+	// `Regexp` matches using RE2 regular expressions, which must match the
+	// whole attribute value. `DNSWildcard` matches using wildcards "*" which
+	// only match within a single DNS label, as per RFC 6125, so
+	// `*.example.com` matches `a.example.com` but not `a.b.example.com`.
+	// `DNSWildcard` is only supported on `dnsNames`.
+	// Defaults to `Wildcard`.
+	// +optional
+	ValueType *CertificateRequestPolicyAllowedValueType `json:"valueType,omitempty"`
+
 	// Required controls whether the related field must have at least one value.
 	// Defaults to `false`.
 	// +optional
@@ -204,11 +433,41 @@ type CertificateRequestPolicyAllowedStringSlice struct {
 	// validate attribute values present on request beyond what is possible
 	// to express using values/required.
 	// ALL attribute values on the related CertificateRequest field must pass
-	// ALL validations for the request to be granted by this policy.
+	// the validations, combined using ValidationsOperator, for the request to
+	// be granted by this policy.
 	// +listType=map
 	// +listMapKey=rule
 	// +optional
 	Validations []ValidationRule `json:"validations,omitempty"`
+
+	// ValidationsOperator defines how Validations are combined. `And`
+	// requires an attribute value to pass ALL validations, `Or` requires it
+	// to pass at least one.
+	// Defaults to `And`.
+	// +optional
+	ValidationsOperator *CertificateRequestPolicyValidationsOperator `json:"validationsOperator,omitempty"`
+
+	// SPIFFETrustDomains defines the SPIFFE trust domains of URI SANs that
+	// may be requested. If set, every requested URI must be a valid SPIFFE ID
+	// whose trust domain is one of the given values, i.e. `cluster.local`.
+	// If Values and Validations are not set, any SPIFFE ID in these trust
+	// domains is allowed.
+	// Only supported on `uris`.
+	// +optional
+	SPIFFETrustDomains *[]string `json:"spiffeTrustDomains,omitempty"`
+
+	// AllowedDomains defines the domains of email address SANs that may be
+	// requested. If set, the domain after the `@` of every requested email
+	// address must be one of the given values, i.e. `example.com`. A value
+	// starting with `*.` matches any subdomain, i.e. `*.example.com` matches
+	// `team.example.com` but not `example.com`.
+	// Domains are matched case-insensitively, unless CaseSensitiveNames is
+	// true.
+	// If Values and Validations are not set, any email address in these
+	// domains is allowed.
+	// Only supported on `emailAddresses`.
+	// +optional
+	AllowedDomains *[]string `json:"allowedDomains,omitempty"`
 }
 
 // CertificateRequestPolicyAllowedString represents an allowed string value
@@ -216,14 +475,23 @@ type CertificateRequestPolicyAllowedStringSlice struct {
 // If no allowed value nor validations are specified, the related field must be empty.
 type CertificateRequestPolicyAllowedString struct {
 	// Value defines the allowed attribute value on the related CertificateRequest field.
-	// Accepts wildcards "*".
+	// Accepts wildcards "*", or a regular expression if ValueType is `Regexp`.
 	// If set, the related field must match the specified pattern.
 	//
 	// NOTE:`value: ""` paired with `required: true` establishes a policy that
 	// will never grant a `CertificateRequest`, but other policies may.
+	// A warning is returned when such a policy is applied.
 	// +optional
 	Value *string `json:"value,omitempty"`
 
+	// ValueType defines how Value is matched against the related
+	// CertificateRequest field. `Wildcard` matches using wildcards "*".
+	// `Regexp` matches using an RE2 regular expression, which must match the
+	// whole attribute value. `DNSWildcard` is not supported.
+	// Defaults to `Wildcard`.
+	// +optional
+	ValueType *CertificateRequestPolicyAllowedValueType `json:"valueType,omitempty"`
+
 	// Required marks that the related field must be provided and not be an
 	// empty string.
 	// Defaults to `false`.
@@ -234,13 +502,55 @@ type CertificateRequestPolicyAllowedString struct {
 	// validate attribute value present on request beyond what is possible
 	// to express using value/required.
 	// An attribute value on the related CertificateRequest field must pass
-	// ALL validations for the request to be granted by this policy.
+	// the validations, combined using ValidationsOperator, for the request to
+	// be granted by this policy.
 	// +listType=map
 	// +listMapKey=rule
 	// +optional
 	Validations []ValidationRule `json:"validations,omitempty"`
+
+	// ValidationsOperator defines how Validations are combined. `And`
+	// requires the attribute value to pass ALL validations, `Or` requires it
+	// to pass at least one.
+	// Defaults to `And`.
+	// +optional
+	ValidationsOperator *CertificateRequestPolicyValidationsOperator `json:"validationsOperator,omitempty"`
 }
 
+// CertificateRequestPolicyAllowedValueType defines how allowed values are
+// matched against the related CertificateRequest field.
+// +kubebuilder:validation:Enum=Wildcard;Regexp;DNSWildcard
+type CertificateRequestPolicyAllowedValueType string
+
+const (
+	// CertificateRequestPolicyAllowedValueTypeWildcard matches allowed values
+	// using wildcards "*".
+	CertificateRequestPolicyAllowedValueTypeWildcard CertificateRequestPolicyAllowedValueType = "Wildcard"
+
+	// CertificateRequestPolicyAllowedValueTypeRegexp matches allowed values as
+	// RE2 regular expressions.
+	CertificateRequestPolicyAllowedValueTypeRegexp CertificateRequestPolicyAllowedValueType = "Regexp"
+
+	// CertificateRequestPolicyAllowedValueTypeDNSWildcard matches allowed
+	// values using wildcards "*" which only match within a single DNS label.
+	CertificateRequestPolicyAllowedValueTypeDNSWildcard CertificateRequestPolicyAllowedValueType = "DNSWildcard"
+)
+
+// CertificateRequestPolicyValidationsOperator defines how multiple
+// validation rules are combined.
+// +kubebuilder:validation:Enum=And;Or
+type CertificateRequestPolicyValidationsOperator string
+
+const (
+	// CertificateRequestPolicyValidationsOperatorAnd requires all validation
+	// rules to pass.
+	CertificateRequestPolicyValidationsOperatorAnd CertificateRequestPolicyValidationsOperator = "And"
+
+	// CertificateRequestPolicyValidationsOperatorOr requires at least one
+	// validation rule to pass.
+	CertificateRequestPolicyValidationsOperatorOr CertificateRequestPolicyValidationsOperator = "Or"
+)
+
 // ValidationRule describes a validation rule expressed in CEL.
 type ValidationRule struct {
 	// Rule represents the expression which will be evaluated by CEL.
@@ -249,12 +559,21 @@ type ValidationRule struct {
 	// The `self` variable in the CEL expression is bound to the scoped value.
 	// To enable more advanced validation rules, approver-policy provides the
 	// `cr` (map) variable to the CEL expression containing `namespace` and
-	// `name` of the `CertificateRequest` resource.
+	// `name` of the `CertificateRequest` resource, the `username` of the
+	// requester, the requested `duration` in seconds (0 if not requested),
+	// whether the request `isCA`, and the `keyAlgorithm` (`RSA`, `ECDSA` or
+	// `Ed25519`) and `keySize` in bits (0 for Ed25519) of the CSR.
 	//
 	// Example (rule for namespaced DNSNames):
 	// ```
 	// rule: self.endsWith(cr.namespace + '.svc.cluster.local')
 	// ```
+	//
+	// Example (rule limiting the duration of requests with small keys to 90
+	// days):
+	// ```
+	// rule: cr.keySize >= 4096 || (cr.duration > 0 && cr.duration <= 7776000)
+	// ```
 	Rule string `json:"rule"`
 
 	// Message is the message to display when validation fails.
@@ -264,6 +583,20 @@ type ValidationRule struct {
 	// e.g. "must be a URL with the host matching spec.host"
 	// +optional
 	Message *string `json:"message,omitempty"`
+
+	// MessageExpression is a CEL expression which evaluates to the message to
+	// display when validation fails, taking precedence over Message. The same
+	// `self` and `cr` variables are available as in Rule. The expression must
+	// evaluate to a string without line breaks.
+	// If the expression fails to evaluate, or evaluates to an empty string,
+	// Message (or its fallback) is used instead.
+	//
+	// Example:
+	// ```
+	// messageExpression: "'DNS name ' + self + ' must end in ' + cr.namespace + '.svc'"
+	// ```
+	// +optional
+	MessageExpression *string `json:"messageExpression,omitempty"`
 }
 
 // CertificateRequestPolicyConstraints define fields that _must_ be satisfied
@@ -271,11 +604,21 @@ type ValidationRule struct {
 // Omitted fields will be satisfied by any value in the corresponding attribute
 // of the request.
 type CertificateRequestPolicyConstraints struct {
+	// Duration defines constraints on the requested duration of a
+	// certificate. Duration may not be combined with the deprecated
+	// MinDuration, MaxDuration, AllowedDurations and RequireDuration fields.
+	// An omitted field applies no duration constraints, other than those of
+	// the deprecated fields.
+	// +optional
+	Duration *CertificateRequestPolicyConstraintsDuration `json:"duration,omitempty"`
+
 	// MinDuration defines the minimum duration for a certificate request.
 	// Values are inclusive (i.e. a value of `1h` will accept a duration of
 	// `1h`). MinDuration and MaxDuration may be the same value.
-	// If set, a duration _must_ be requested in the CertificateRequest.
+	// If set, a duration _must_ be requested in the CertificateRequest, unless
+	// RequireDuration is false.
 	// An omitted field applies no minimum constraint for duration.
+	// This field is deprecated, use Duration.Min instead.
 	// +optional
 	MinDuration *metav1.Duration `json:"minDuration,omitempty"`
 
@@ -283,18 +626,268 @@ type CertificateRequestPolicyConstraints struct {
 	// for.
 	// Values are inclusive (i.e. a value of `1h` will accept a duration of
 	// `1h`). MinDuration and MaxDuration may be the same value.
-	// If set, a duration _must_ be requested in the CertificateRequest.
+	// If set, a duration _must_ be requested in the CertificateRequest, unless
+	// RequireDuration is false.
 	// An omitted field applies no maximum constraint for duration.
+	// This field is deprecated, use Duration.Max instead.
 	// +optional
 	MaxDuration *metav1.Duration `json:"maxDuration,omitempty"`
 
+	// AllowedDurations defines the exact durations which may be requested,
+	// i.e. `["24h", "168h", "2160h"]`. If MinDuration or MaxDuration is also
+	// set, the requested duration must satisfy all of them, so every allowed
+	// duration must be within MinDuration and MaxDuration.
+	// If set, a duration _must_ be requested in the CertificateRequest, unless
+	// RequireDuration is false.
+	// An omitted field applies no constraint on discrete durations.
+	// This field is deprecated, use Duration.Values instead.
+	// +optional
+	AllowedDurations *[]metav1.Duration `json:"allowedDurations,omitempty"`
+
+	// RequireDuration defines whether a duration _must_ be requested in the
+	// CertificateRequest when MinDuration, MaxDuration or AllowedDurations is
+	// set. When a request omits the duration, the issuer's default duration
+	// applies which cannot be checked against these constraints.
+	// If false, requests which omit the duration are not checked against
+	// MinDuration, MaxDuration or AllowedDurations.
+	// An omitted field defaults to true.
+	// This field is deprecated, use Duration.Required instead.
+	// +optional
+	RequireDuration *bool `json:"requireDuration,omitempty"`
+
 	// PrivateKey defines constraints on the shape of private key
 	// allowed for a CertificateRequest.
 	// An omitted field applies no private key shape constraints.
 	// +optional
 	PrivateKey *CertificateRequestPolicyConstraintsPrivateKey `json:"privateKey,omitempty"`
+
+	// CommonName defines constraints on the X.509 Common Name of a request.
+	// Requests which do not set a Common Name are unaffected.
+	// An omitted field applies no Common Name constraints.
+	// +optional
+	CommonName *CertificateRequestPolicyConstraintsCommonName `json:"commonName,omitempty"`
+
+	// DNSNames defines constraints on the DNS SANs of a request.
+	// An omitted field applies no DNS name constraints.
+	// +optional
+	DNSNames *CertificateRequestPolicyConstraintsDNSNames `json:"dnsNames,omitempty"`
+
+	// IPAddresses defines constraints on the IP SANs of a request.
+	// An omitted field applies no IP address constraints.
+	// +optional
+	IPAddresses *CertificateRequestPolicyConstraintsIPAddresses `json:"ipAddresses,omitempty"`
+
+	// RequireNamespacedSPIFFE, if true, requires that every URI SAN in a
+	// request is a SPIFFE ID whose path is scoped to the namespace of the
+	// CertificateRequest (i.e. `spiffe://<trust-domain>/ns/<namespace>/...`).
+	// Paths must be valid as per the SPIFFE ID specification, so IDs with
+	// empty, `.` or `..` path segments are denied.
+	// Requests containing no URI SANs are unaffected.
+	// An omitted field or false applies no SPIFFE ID constraint.
+	// +optional
+	RequireNamespacedSPIFFE *bool `json:"requireNamespacedSPIFFE,omitempty"`
+
+	// ForbidCommonNameWithSANs, if true, denies requests which set a
+	// CommonName as well as any Subject Alternative Name (DNS names, IP
+	// addresses, URIs or email addresses).
+	// Requests with only a CommonName, or only SANs, are unaffected.
+	// An omitted field or false applies no CommonName constraint.
+	// +optional
+	ForbidCommonNameWithSANs *bool `json:"forbidCommonNameWithSANs,omitempty"`
+
+	// RequireIdentity, if true, denies requests which set neither a
+	// CommonName nor any Subject Alternative Name (DNS names, IP addresses,
+	// URIs or email addresses), since a certificate without an identity is
+	// usually requested by mistake.
+	// An omitted field or false applies no identity constraint.
+	// +optional
+	RequireIdentity *bool `json:"requireIdentity,omitempty"`
+
+	// RequiredUsages defines the key usages that must be included in a
+	// CertificateRequest `spec.usages` field.
+	// If set, `spec.usages` in a CertificateRequest must be a superset of the
+	// specified values. Equivalent usage spellings are treated as the same
+	// usage, i.e. `signing` matches `digital signature`.
+	// An omitted field or `[]` requires no usages.
+	// +optional
+	RequiredUsages *[]cmapi.KeyUsage `json:"requiredUsages,omitempty"`
+
+	// MutuallyExclusiveUsages defines groups of key usages, of which at most
+	// one usage from each group may be included in a CertificateRequest
+	// `spec.usages` field, i.e. `[["server auth", "client auth"]]` denies
+	// requests for a certificate which is both a server and client
+	// certificate. Equivalent usage spellings are treated as the same usage.
+	// Each group must contain at least two usages.
+	// An omitted field applies no constraint.
+	// +optional
+	MutuallyExclusiveUsages [][]cmapi.KeyUsage `json:"mutuallyExclusiveUsages,omitempty"`
+
+	// EnforceDNSNameLimits, if true, denies requests containing DNS names
+	// which exceed the length limits of RFC 1035, i.e. a DNS name must be no
+	// more than 253 characters, and each of its labels no more than 63
+	// characters.
+	// An omitted field or false applies no DNS name length constraint.
+	// +optional
+	EnforceDNSNameLimits *bool `json:"enforceDNSNameLimits,omitempty"`
+
+	// IsCA, if set, requires the CertificateRequest `spec.isCA` field to be
+	// exactly this value. `true` requires requests to be for a CA, and `false`
+	// requires requests to not be for a CA, including requests whose CSR sets
+	// the CA flag in its basicConstraints extension.
+	// Note that `spec.allowed.isCA` must also be `true` for a CA request to be
+	// permitted.
+	// An omitted field applies no constraint.
+	// +optional
+	IsCA *bool `json:"isCA,omitempty"`
+
+	// CAMustIncludeUsages defines the key usages which _must_ be included in
+	// the CertificateRequest `spec.usages` field of requests for a CA, i.e.
+	// `["cert sign", "crl sign"]`. A request is for a CA if it sets
+	// `spec.isCA`, or its CSR sets the CA flag in its basicConstraints
+	// extension. Requests which are not for a CA are unaffected.
+	// Equivalent usage spellings are treated as the same usage.
+	// An omitted field or `[]` applies no constraint.
+	// +optional
+	CAMustIncludeUsages *[]cmapi.KeyUsage `json:"caMustIncludeUsages,omitempty"`
+
+	// IsCAAllowedForIssuers defines the issuers which requests for a CA may
+	// reference, as defense in depth alongside the selector of the policy.
+	// A request for a CA whose `spec.issuerRef` doesn't match any entry is
+	// denied. Entries are matched in the same way as
+	// `spec.selector.issuerRef`, except that `requireReady` is not
+	// supported. A request is for a CA if it sets `spec.isCA`, or its CSR
+	// sets the CA flag in its basicConstraints extension. Requests which are
+	// not for a CA are unaffected.
+	// An omitted field applies no constraint, and `[]` permits no issuer to
+	// be requested for a CA.
+	// +optional
+	IsCAAllowedForIssuers *[]CertificateRequestPolicySelectorIssuerRef `json:"isCAAllowedForIssuers,omitempty"`
+
+	// SingleValuedSubjectAttributes defines the subject attributes which
+	// must not have more than one value in a request, e.g. `organizations`
+	// denies requests with two Organization (O) entries in their subject.
+	// An omitted field or `[]` applies no constraint.
+	// +listType=set
+	// +optional
+	SingleValuedSubjectAttributes *[]CertificateRequestPolicySubjectAttribute `json:"singleValuedSubjectAttributes,omitempty"`
+
+	// ForbiddenSubjectAttributes defines the subject attributes which must
+	// not be present in a request, e.g. `organizations` denies requests with
+	// any Organization (O) entry in their subject. Listing every attribute
+	// except `commonName` requires the subject to be empty except for the
+	// Common Name.
+	// An omitted field or `[]` applies no constraint.
+	// +listType=set
+	// +optional
+	ForbiddenSubjectAttributes *[]CertificateRequestPolicySubjectAttribute `json:"forbiddenSubjectAttributes,omitempty"`
+
+	// ForbidDuplicateSANs, if true, denies requests whose DNS names, IP
+	// addresses, URIs or email addresses contain the same value more than
+	// once.
+	// An omitted field or false applies no duplicate constraint.
+	// +optional
+	ForbidDuplicateSANs *bool `json:"forbidDuplicateSANs,omitempty"`
+
+	// CommonNameMustBeInDNSNames, if true, denies requests which have a
+	// non-empty commonName that is not also one of the requested DNS names.
+	// As DNS names are case-insensitive, the commonName is compared to the
+	// DNS names case-insensitively.
+	// Requests without a commonName are not affected.
+	// An omitted field or false applies no constraint.
+	// +optional
+	CommonNameMustBeInDNSNames *bool `json:"commonNameMustBeInDNSNames,omitempty"`
+
+	// MaxPathLen defines the maximum X.509 basic constraints path length
+	// which a CA request may set in its CSR. Values are inclusive (i.e. a
+	// value of `0` will accept a path length of `0`).
+	// A CA request which does not set a path length, and so permits an
+	// unlimited number of intermediate CAs, is denied.
+	// A CSR with a malformed basicConstraints extension is denied.
+	// Requests which are not for a CA are unaffected.
+	// An omitted field applies no path length constraint.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxPathLen *int `json:"maxPathLen,omitempty"`
+
+	// AllowedCriticalExtensionOIDs defines the object identifiers, in dotted
+	// form (e.g. `2.5.29.19` for basicConstraints), of the extensions which a
+	// request may mark as critical. A request containing a critical extension
+	// whose identifier is not listed is denied, as is a request whose
+	// requested extensions cannot be parsed.
+	// Note that CSRs commonly mark the basicConstraints (`2.5.29.19`) and
+	// keyUsage (`2.5.29.15`) extensions as critical, and the
+	// subjectAltName (`2.5.29.17`) extension if the subject is empty.
+	// Non-critical extensions are unaffected.
+	// An omitted field applies no constraint, and `[]` permits no critical
+	// extension.
+	// +listType=set
+	// +optional
+	AllowedCriticalExtensionOIDs *[]string `json:"allowedCriticalExtensionOIDs,omitempty"`
+
+	// Subject defines constraints on the attributes of the X.509 subject of
+	// a request which are not modelled by `spec.allowed.subject`.
+	// An omitted field applies no subject constraints.
+	// +optional
+	Subject *CertificateRequestPolicyConstraintsSubject `json:"subject,omitempty"`
 }
 
+// CertificateRequestPolicyConstraintsSubject defines constraints on the X.509
+// subject of a CertificateRequest.
+type CertificateRequestPolicyConstraintsSubject struct {
+	// AllowedOIDs defines the object identifiers, in dotted form (e.g.
+	// `0.9.2342.19200300.100.1.25` for domainComponent), of the additional
+	// subject attributes which a request may contain. A request containing a
+	// subject attribute whose type is not listed is denied.
+	// The attributes which may be listed in `singleValuedSubjectAttributes`
+	// (e.g. commonName, organizations) are always permitted here, and are
+	// constrained by `spec.allowed` instead.
+	// An omitted field permits any attribute, and `[]` permits no additional
+	// attribute.
+	// +listType=set
+	// +optional
+	AllowedOIDs *[]string `json:"allowedOIDs,omitempty"`
+
+	// OrganizationsMustEqualNamespace, if true, requires every organization
+	// in the subject of a request to equal the namespace of the request.
+	// This is shorthand for the CEL validation `self == cr.namespace` on
+	// `spec.allowed.subject.organizations`. A request with no organizations
+	// is not denied, use `spec.allowed.subject.organizations.required` to
+	// require one.
+	// An omitted field or false applies no constraint.
+	// +optional
+	OrganizationsMustEqualNamespace *bool `json:"organizationsMustEqualNamespace,omitempty"`
+
+	// OrganizationalUnitsMustEqualNamespace, if true, requires every
+	// organizational unit in the subject of a request to equal the namespace
+	// of the request. A request with no organizational units is not denied.
+	// An omitted field or false applies no constraint.
+	// +optional
+	OrganizationalUnitsMustEqualNamespace *bool `json:"organizationalUnitsMustEqualNamespace,omitempty"`
+
+	// CommonNameMustEqualNamespace, if true, requires the common name in the
+	// subject of a request, if present, to equal the namespace of the request.
+	// An omitted field or false applies no constraint.
+	// +optional
+	CommonNameMustEqualNamespace *bool `json:"commonNameMustEqualNamespace,omitempty"`
+}
+
+// CertificateRequestPolicySubjectAttribute is the name of an X.509 subject
+// attribute, using the field name of the attribute in a CertificateRequest.
+// +kubebuilder:validation:Enum=commonName;organizations;countries;organizationalUnits;localities;provinces;streetAddresses;postalCodes;serialNumber
+type CertificateRequestPolicySubjectAttribute string
+
+const (
+	CertificateRequestPolicySubjectAttributeCommonName          CertificateRequestPolicySubjectAttribute = "commonName"
+	CertificateRequestPolicySubjectAttributeOrganizations       CertificateRequestPolicySubjectAttribute = "organizations"
+	CertificateRequestPolicySubjectAttributeCountries           CertificateRequestPolicySubjectAttribute = "countries"
+	CertificateRequestPolicySubjectAttributeOrganizationalUnits CertificateRequestPolicySubjectAttribute = "organizationalUnits"
+	CertificateRequestPolicySubjectAttributeLocalities          CertificateRequestPolicySubjectAttribute = "localities"
+	CertificateRequestPolicySubjectAttributeProvinces           CertificateRequestPolicySubjectAttribute = "provinces"
+	CertificateRequestPolicySubjectAttributeStreetAddresses     CertificateRequestPolicySubjectAttribute = "streetAddresses"
+	CertificateRequestPolicySubjectAttributePostalCodes         CertificateRequestPolicySubjectAttribute = "postalCodes"
+	CertificateRequestPolicySubjectAttributeSerialNumber        CertificateRequestPolicySubjectAttribute = "serialNumber"
+)
+
 // CertificateRequestPolicyConstraintsPrivateKey defines constraints on the shape of private key
 // allowed for a CertificateRequest.
 type CertificateRequestPolicyConstraintsPrivateKey struct {
@@ -317,6 +910,159 @@ type CertificateRequestPolicyConstraintsPrivateKey struct {
 	// An omitted field applies no maximum constraint on size.
 	// +optional
 	MaxSize *int `json:"maxSize,omitempty"`
+
+	// AllowedECDSACurves defines the elliptic curves which may be used by an
+	// ECDSA private key in a request, i.e. `["P-256", "P-384"]`.
+	// Accepted values are `P-224`, `P-256`, `P-384` and `P-521`.
+	// Requests using a non-ECDSA private key are unaffected.
+	// An omitted field permits any curve.
+	// +optional
+	AllowedECDSACurves *[]string `json:"allowedECDSACurves,omitempty"`
+
+	// AllowedEd25519, if false, denies requests using an Ed25519 private key.
+	// An omitted field or true permits Ed25519 private keys.
+	// +optional
+	AllowedEd25519 *bool `json:"allowedEd25519,omitempty"`
+
+	// MinRSAPublicExponent defines the minimum public exponent of an RSA
+	// private key in a request, i.e. `65537`. Values are inclusive.
+	// Requests using a non-RSA private key are unaffected.
+	// An omitted field applies no minimum constraint on the public exponent.
+	// +optional
+	MinRSAPublicExponent *int `json:"minRSAPublicExponent,omitempty"`
+
+	// AllowedPublicKeysConfigMapRef references a ConfigMap containing the
+	// public keys which may be requested.
+	// Each value in the ConfigMap's data is a hex encoded SHA-256 fingerprint
+	// of a DER encoded SubjectPublicKeyInfo. Colons in the fingerprint are
+	// ignored. The public key of a request must match one of the
+	// fingerprints.
+	// If the ConfigMap does not exist, the policy will not become ready.
+	// An omitted field permits any public key.
+	// +optional
+	AllowedPublicKeysConfigMapRef *CertificateRequestPolicyConfigMapReference `json:"allowedPublicKeysConfigMapRef,omitempty"`
+
+	// MatchCertificate, if true, requires that the private key of a request
+	// matches the algorithm and size declared in `spec.privateKey` of the
+	// Certificate which owns the CertificateRequest.
+	// Only the fields declared on the Certificate are compared. Requests
+	// which are not owned by a Certificate are unaffected.
+	// An omitted field or false applies no Certificate matching constraint.
+	// +optional
+	MatchCertificate *bool `json:"matchCertificate,omitempty"`
+}
+
+// CertificateRequestPolicyConstraintsCommonName defines constraints on the
+// X.509 Common Name of a CertificateRequest.
+type CertificateRequestPolicyConstraintsCommonName struct {
+	// MaxLength defines the maximum number of characters of the Common Name.
+	// Values are inclusive (i.e. a value of `64` will accept a Common Name of
+	// 64 characters).
+	// An omitted field applies no length constraint.
+	// +optional
+	MaxLength *int `json:"maxLength,omitempty"`
+
+	// AllowedCharacters is an RE2 regular expression which every character of
+	// the Common Name must match, i.e. `[ -~]` only allows printable ASCII
+	// characters.
+	// An omitted field permits any character.
+	// +optional
+	AllowedCharacters *string `json:"allowedCharacters,omitempty"`
+}
+
+// CertificateRequestPolicyConstraintsDuration defines constraints on the
+// requested duration of a certificate.
+type CertificateRequestPolicyConstraintsDuration struct {
+	// Min defines the minimum duration which may be requested. Values are
+	// inclusive (i.e. a value of `1h` will accept a duration of `1h`).
+	// An omitted field applies no minimum.
+	// +optional
+	Min *metav1.Duration `json:"min,omitempty"`
+
+	// Max defines the maximum duration which may be requested. Values are
+	// inclusive, and Max may be the same value as Min.
+	// An omitted field applies no maximum.
+	// +optional
+	Max *metav1.Duration `json:"max,omitempty"`
+
+	// Values defines the exact durations which may be requested, i.e.
+	// `["24h", "168h", "2160h"]`. Every value must be within Min and Max.
+	// An omitted field applies no constraint on discrete durations.
+	// +optional
+	Values *[]metav1.Duration `json:"values,omitempty"`
+
+	// Granularity defines the unit which the requested duration must be a
+	// whole multiple of, i.e. `24h` for CAs which only issue certificates in
+	// whole days. Every value in Values must also be a multiple.
+	// An omitted field applies no granularity constraint.
+	// +optional
+	Granularity *metav1.Duration `json:"granularity,omitempty"`
+
+	// Required defines whether a duration _must_ be requested in the
+	// CertificateRequest. When a request omits the duration, the issuer's
+	// default duration applies which cannot be checked against these
+	// constraints. If false, requests which omit the duration are permitted.
+	// An omitted field defaults to true.
+	// +optional
+	Required *bool `json:"required,omitempty"`
+}
+
+// CertificateRequestPolicyConstraintsDNSNames defines constraints on the DNS
+// SANs of a request.
+type CertificateRequestPolicyConstraintsDNSNames struct {
+	// ForbidPublicSuffix, if true, denies requests for DNS names which end in
+	// a suffix of the public suffix list (https://publicsuffix.org), i.e.
+	// `app.example.com`, unless the name matches AllowedPublicDomains. Names
+	// whose suffix isn't on the list, i.e. `app.svc.cluster.local`, are
+	// unaffected. Useful for issuers which must only issue for internal names.
+	// An omitted field or false applies no public suffix constraint.
+	// +optional
+	ForbidPublicSuffix *bool `json:"forbidPublicSuffix,omitempty"`
+
+	// AllowedPublicDomains defines wildcard patterns of DNS names which may be
+	// requested even though they end in a public suffix, i.e.
+	// `*.internal.example.com`. Only used if ForbidPublicSuffix is true.
+	// +optional
+	AllowedPublicDomains []string `json:"allowedPublicDomains,omitempty"`
+}
+
+// CertificateRequestPolicyConstraintsIPAddresses defines constraints on the
+// IP SANs of a request.
+type CertificateRequestPolicyConstraintsIPAddresses struct {
+	// Forbid, if true, denies requests which contain any IP SANs. Useful for
+	// public-facing issuers.
+	// An omitted field or false doesn't forbid IP SANs.
+	// +optional
+	Forbid *bool `json:"forbid,omitempty"`
+
+	// AllowedCIDRs defines the CIDR ranges which every requested IP SAN must
+	// be contained in, i.e. `["10.0.0.0/8", "172.16.0.0/12",
+	// "192.168.0.0/16"]` to restrict IP SANs to private ranges.
+	// An omitted field applies no range constraint.
+	// +optional
+	AllowedCIDRs []string `json:"allowedCIDRs,omitempty"`
+}
+
+// CertificateRequestPolicyConfigMapReference is a reference to a ConfigMap.
+type CertificateRequestPolicyConfigMapReference struct {
+	// Name is the name of the referenced ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the referenced ConfigMap.
+	Namespace string `json:"namespace"`
+}
+
+// CertificateRequestPolicyConfigMapKeyReference is a reference to a key of a
+// ConfigMap.
+type CertificateRequestPolicyConfigMapKeyReference struct {
+	// Name is the name of the referenced ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the referenced ConfigMap.
+	Namespace string `json:"namespace"`
+
+	// Key is the key of the referenced ConfigMap's data.
+	Key string `json:"key"`
 }
 
 // CertificateRequestPolicyPluginData is configuration needed by the plugin
@@ -334,7 +1080,8 @@ type CertificateRequestPolicyPluginData struct {
 // so, will be used to evaluate the request.
 // All selectors that have been configured must match a CertificateRequest
 // in order for the CertificateRequestPolicy to be chosen for evaluation.
-// At least one of IssuerRef or Namespace must be defined.
+// At least one of IssuerRef, IssuerRefs or Namespace must be defined. If all
+// of them are omitted, IssuerRef is defaulted to `{}`, matching all issuers.
 type CertificateRequestPolicySelector struct {
 	// IssuerRef is used to match by issuer, meaning the
 	// CertificateRequestPolicy will only evaluate CertificateRequests
@@ -346,15 +1093,43 @@ type CertificateRequestPolicySelector struct {
 	// ```
 	// issuerRef: {}
 	// ```
+	// Only one of IssuerRef or IssuerRefs may be defined.
 	// +optional
 	IssuerRef *CertificateRequestPolicySelectorIssuerRef `json:"issuerRef"`
 
+	// IssuerRefs is used to match by any of a list of issuers, meaning the
+	// CertificateRequestPolicy will only evaluate CertificateRequests
+	// referring to an issuer matching at least one of the entries.
+	// Entries are matched in the same way as IssuerRef.
+	// Only one of IssuerRef or IssuerRefs may be defined.
+	// +optional
+	IssuerRefs []CertificateRequestPolicySelectorIssuerRef `json:"issuerRefs,omitempty"`
+
 	// Namespace is used to match by namespace, meaning the
 	// CertificateRequestPolicy will only match CertificateRequests
 	// created in matching namespaces.
 	// If this field is omitted, resources in all namespaces are checked.
 	// +optional
 	Namespace *CertificateRequestPolicySelectorNamespace `json:"namespace"`
+
+	// CertificateRequest is used to match by the labels of the
+	// CertificateRequest itself, meaning the CertificateRequestPolicy will
+	// only match CertificateRequests whose labels match the selector.
+	// Combined with the other selectors, all of which must match.
+	// If this field is omitted, CertificateRequests with any labels are
+	// checked.
+	// +optional
+	CertificateRequest *CertificateRequestPolicySelectorCertificateRequest `json:"certificateRequest,omitempty"`
+
+	// Certificate is used to match by the labels of the Certificate which
+	// owns the CertificateRequest, as given by its controller owner
+	// reference. CertificateRequests which are not owned by a Certificate,
+	// or whose Certificate no longer exists, are not matched.
+	// Combined with the other selectors, all of which must match.
+	// If this field is omitted, CertificateRequests are checked regardless
+	// of their owner.
+	// +optional
+	Certificate *CertificateRequestPolicySelectorCertificate `json:"certificate,omitempty"`
 }
 
 // CertificateRequestPolicySelectorIssuerRef defines the selector for matching
@@ -367,6 +1142,17 @@ type CertificateRequestPolicySelectorIssuerRef struct {
 	// +optional
 	Name *string `json:"name,omitempty"`
 
+	// NameExpression is a CEL expression which evaluates to the name that the
+	// `spec.issuerRef.name` field of requests must equal, i.e.
+	// `cr.namespace + '-issuer'` to select issuers named after the namespace
+	// of the request.
+	// The request is available as `cr`, with the same fields as in the Rule
+	// of CEL validations.
+	// If Name is also set, both must match the name.
+	// An omitted field applies no expression.
+	// +optional
+	NameExpression *string `json:"nameExpression,omitempty"`
+
 	// Kind is the wildcard selector to match the `spec.issuerRef.kind` field
 	// on requests.
 	// Accepts wildcards "*".
@@ -380,6 +1166,45 @@ type CertificateRequestPolicySelectorIssuerRef struct {
 	// An omitted field matches all groups.
 	// +optional
 	Group *string `json:"group,omitempty"`
+
+	// ExcludeKinds is the set of `spec.issuerRef.kind` values of requests
+	// which are never matched, even if they match the other fields of this
+	// selector, i.e. `["ClusterIssuer"]` to match every kind of issuer except
+	// ClusterIssuers.
+	// Accepts wildcards "*".
+	// +listType=set
+	// +optional
+	ExcludeKinds []string `json:"excludeKinds,omitempty"`
+
+	// ExcludeGroups is the set of `spec.issuerRef.group` values of requests
+	// which are never matched, even if they match the other fields of this
+	// selector.
+	// Accepts wildcards "*".
+	// +listType=set
+	// +optional
+	ExcludeGroups []string `json:"excludeGroups,omitempty"`
+
+	// Namespaced, if true, only matches requests referencing a namespaced
+	// Issuer, and if false, only matches requests referencing a cluster
+	// scoped ClusterIssuer.
+	// A namespaced Issuer always lives in the namespace of the request, so
+	// use `spec.selector.namespace` to select requests for Issuers in
+	// particular namespaces.
+	// Only cert-manager.io Issuers and ClusterIssuers are known to be
+	// namespaced or cluster scoped, so requests referencing issuers of any
+	// other group never match.
+	// An omitted field matches both Issuers and ClusterIssuers.
+	// +optional
+	Namespaced *bool `json:"namespaced,omitempty"`
+
+	// RequireReady, if true, only matches requests whose referenced issuer
+	// exists and has a Ready condition set to True, so that requests are not
+	// approved for issuers which cannot sign them.
+	// Only cert-manager.io Issuers and ClusterIssuers can be resolved, so
+	// requests referencing issuers of any other group never match.
+	// An omitted field or false doesn't check the issuer.
+	// +optional
+	RequireReady *bool `json:"requireReady,omitempty"`
 }
 
 // CertificateRequestPolicySelectorNamespace defines the selector for matching
@@ -398,6 +1223,73 @@ type CertificateRequestPolicySelectorNamespace struct {
 	// selector.
 	// +optional
 	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+
+	// MatchExpressions is a list of Namespace label selector requirements that
+	// select on CertificateRequests which have been created in a namespace
+	// matching the selector. All requirements, along with MatchLabels, must
+	// match.
+	// +optional
+	MatchExpressions []metav1.LabelSelectorRequirement `json:"matchExpressions,omitempty"`
+
+	// ExcludeNames is the set of namespace names whose CertificateRequests are
+	// never selected, even if they match the other fields of this selector.
+	// Accepts wildcards "*".
+	// TODO: add x-kubernetes-list-type: set in v1alpha2
+	// +optional
+	ExcludeNames []string `json:"excludeNames,omitempty"`
+
+	// ExcludeLabels is the set of Namespace labels whose CertificateRequests
+	// are never selected, even if they match the other fields of this
+	// selector. A namespace is excluded if it has all of the given labels.
+	// +optional
+	ExcludeLabels map[string]string `json:"excludeLabels,omitempty"`
+}
+
+// CertificateRequestPolicySelectorCertificateRequest defines the selector for
+// matching the labels and annotations of requests.
+type CertificateRequestPolicySelectorCertificateRequest struct {
+	// MatchLabels is the set of labels that select on CertificateRequests
+	// which have matching labels.
+	// +optional
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+
+	// MatchExpressions is a list of label selector requirements that select
+	// on CertificateRequests with matching labels. All requirements, along
+	// with MatchLabels, must match.
+	// +optional
+	MatchExpressions []metav1.LabelSelectorRequirement `json:"matchExpressions,omitempty"`
+
+	// MatchAnnotations is the set of annotations that select on
+	// CertificateRequests which have matching annotations. Values must match
+	// exactly, i.e. `cert-manager.io/certificate-revision: "1"` selects only
+	// the first issuance of a Certificate.
+	// +optional
+	MatchAnnotations map[string]string `json:"matchAnnotations,omitempty"`
+
+	// MatchAnnotationExpressions is a list of selector requirements that
+	// select on CertificateRequests with matching annotations, evaluated the
+	// same as label selector requirements. I.e. the requirement
+	// `{key: cert-manager.io/certificate-revision, operator: NotIn, values: ["1"]}`
+	// selects renewals of a Certificate, as well as requests which are not
+	// owned by a Certificate. All requirements, along with MatchLabels,
+	// MatchExpressions and MatchAnnotations, must match.
+	// +optional
+	MatchAnnotationExpressions []metav1.LabelSelectorRequirement `json:"matchAnnotationExpressions,omitempty"`
+}
+
+// CertificateRequestPolicySelectorCertificate defines the selector for
+// matching the Certificate which owns requests.
+type CertificateRequestPolicySelectorCertificate struct {
+	// MatchLabels is the set of labels that select on CertificateRequests
+	// whose owning Certificate has matching labels.
+	// +optional
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+
+	// MatchExpressions is a list of label selector requirements that select
+	// on CertificateRequests whose owning Certificate has matching labels.
+	// All requirements, along with MatchLabels, must match.
+	// +optional
+	MatchExpressions []metav1.LabelSelectorRequirement `json:"matchExpressions,omitempty"`
 }
 
 // CertificateRequestPolicyStatus defines the observed state of the
@@ -456,4 +1348,20 @@ const (
 	// evaluating CertificateRequests.
 	// +k8s:deepcopy-gen=false
 	CertificateRequestPolicyConditionReady CertificateRequestPolicyConditionType = "Ready"
+
+	// CertificateRequestPolicyConditionWarning indicates that the
+	// CertificateRequestPolicy contains a suspicious configuration, such as a
+	// selector which is unlikely to ever match a request. The condition does
+	// not affect whether the CertificateRequestPolicy is ready. The condition
+	// is removed once the configuration is no longer suspicious.
+	// +k8s:deepcopy-gen=false
+	CertificateRequestPolicyConditionWarning CertificateRequestPolicyConditionType = "Warning"
+
+	// CertificateRequestPolicyConditionTestsPassed indicates whether the
+	// CertificateRequestPolicy gave the expected result for each of its test
+	// cases. The condition does not affect whether the
+	// CertificateRequestPolicy is ready, and is only set on ready policies
+	// which define test cases.
+	// +k8s:deepcopy-gen=false
+	CertificateRequestPolicyConditionTestsPassed CertificateRequestPolicyConditionType = "TestsPassed"
 )
@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var CertificateRequestPolicyProfileKind = "CertificateRequestPolicyProfile"
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+//+kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Timestamp CertificateRequestPolicyProfile was created"
+//+kubebuilder:resource:categories=cert-manager,shortName=crpp,scope=Cluster
+
+// CertificateRequestPolicyProfile is a reusable set of allowed attributes and
+// constraints which CertificateRequestPolicies may reference using
+// `spec.profileRef`, rather than duplicating the same fields across policies.
+type CertificateRequestPolicyProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CertificateRequestPolicyProfileSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+//+kubebuilder:object:root=true
+
+// CertificateRequestPolicyProfileList is a list of
+// CertificateRequestPolicyProfiles.
+type CertificateRequestPolicyProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []CertificateRequestPolicyProfile `json:"items"`
+}
+
+// CertificateRequestPolicyProfileSpec defines the allowed attributes and
+// constraints shared by the CertificateRequestPolicies which reference the
+// profile.
+type CertificateRequestPolicyProfileSpec struct {
+	// Allowed defines the allowed attributes for a CertificateRequest, as
+	// `spec.allowed` of a CertificateRequestPolicy.
+	// +optional
+	Allowed *CertificateRequestPolicyAllowed `json:"allowed,omitempty"`
+
+	// Constraints define fields that _must_ be satisfied by a
+	// CertificateRequest, as `spec.constraints` of a CertificateRequestPolicy.
+	// +optional
+	Constraints *CertificateRequestPolicyConstraints `json:"constraints,omitempty"`
+}
+
+// CertificateRequestPolicyProfileReference is a reference to a
+// CertificateRequestPolicyProfile.
+type CertificateRequestPolicyProfileReference struct {
+	// Name is the name of the CertificateRequestPolicyProfile.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
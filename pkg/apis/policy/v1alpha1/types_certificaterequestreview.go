@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var CertificateRequestReviewKind = "CertificateRequestReview"
+
+const (
+	// CertificateRequestAnnotationDebugReview is the annotation key which,
+	// when set to "true" on a CertificateRequest, causes approver-policy to
+	// record the context of its review in a CertificateRequestReview of the
+	// same name and namespace. Reviews are only recorded if approver-policy is
+	// run with --debug-reviews.
+	CertificateRequestAnnotationDebugReview = "policy.cert-manager.io/debug-review"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+//+kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Result",type="string",JSONPath=".status.result",description="Result of the review"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Timestamp CertificateRequestReview was created"
+//+kubebuilder:resource:categories=cert-manager,shortName=crr
+
+// CertificateRequestReview is a debug record of how approver-policy reviewed
+// a CertificateRequest. It is only written for CertificateRequests which
+// have the `policy.cert-manager.io/debug-review: "true"` annotation, is named
+// after the reviewed CertificateRequest, and is owned by it.
+type CertificateRequestReview struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status CertificateRequestReviewStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+//+kubebuilder:object:root=true
+
+// CertificateRequestReviewList is a list of CertificateRequestReviews.
+type CertificateRequestReviewList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []CertificateRequestReview `json:"items"`
+}
+
+// CertificateRequestReviewStatus records the selection of
+// CertificateRequestPolicies and the outcome of each evaluator for a single
+// review of a CertificateRequest. Only the CertificateRequestPolicies which
+// select the request and which the requester is bound to use are recorded.
+type CertificateRequestReviewStatus struct {
+	// Result is the result of the review.
+	Result CertificateRequestReviewResult `json:"result"`
+
+	// Message is the message given with the result of the review.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// ReviewTime is the time at which the review was recorded.
+	ReviewTime metav1.Time `json:"reviewTime"`
+
+	// Policies are the names of the CertificateRequestPolicies which were
+	// considered for the review, and which the requester is bound to use.
+	// +listType=atomic
+	// +optional
+	Policies []string `json:"policies,omitempty"`
+
+	// Predicates are the results of each predicate which was run to select
+	// the policies appropriate for the request, in the order they were run.
+	// +listType=atomic
+	// +optional
+	Predicates []CertificateRequestReviewPredicate `json:"predicates,omitempty"`
+
+	// Evaluations are the outcomes of each evaluator which was run against
+	// each selected policy.
+	// +listType=atomic
+	// +optional
+	Evaluations []CertificateRequestReviewEvaluation `json:"evaluations,omitempty"`
+}
+
+// CertificateRequestReviewResult is the result of a review.
+// +kubebuilder:validation:Enum=Approved;Denied;Unprocessed;Pending
+type CertificateRequestReviewResult string
+
+const (
+	// CertificateRequestReviewResultApproved is the result of a review which
+	// approved the request.
+	CertificateRequestReviewResultApproved CertificateRequestReviewResult = "Approved"
+
+	// CertificateRequestReviewResultDenied is the result of a review which
+	// denied the request.
+	CertificateRequestReviewResultDenied CertificateRequestReviewResult = "Denied"
+
+	// CertificateRequestReviewResultUnprocessed is the result of a review for
+	// which no policy was applicable.
+	CertificateRequestReviewResultUnprocessed CertificateRequestReviewResult = "Unprocessed"
+
+	// CertificateRequestReviewResultPending is the result of a review for
+	// which no policy approved the request, but at least one policy is
+	// awaiting an external decision.
+	CertificateRequestReviewResultPending CertificateRequestReviewResult = "Pending"
+)
+
+// CertificateRequestReviewPredicate is the result of a single predicate.
+type CertificateRequestReviewPredicate struct {
+	// Name of the predicate.
+	Name string `json:"name"`
+
+	// Policies are the names of the policies which passed the predicate.
+	// +listType=atomic
+	// +optional
+	Policies []string `json:"policies,omitempty"`
+}
+
+// CertificateRequestReviewEvaluation is the outcome of a single evaluator
+// run against a single policy.
+type CertificateRequestReviewEvaluation struct {
+	// Policy is the name of the policy which was evaluated.
+	Policy string `json:"policy"`
+
+	// Evaluator is the name of the evaluator.
+	Evaluator string `json:"evaluator"`
+
+	// Denied is true if the evaluator denied the request.
+	Denied bool `json:"denied"`
+
+	// Pending is true if the evaluator is awaiting an external decision.
+	// +optional
+	Pending bool `json:"pending,omitempty"`
+
+	// Message is the message returned by the evaluator.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
@@ -100,6 +100,18 @@ func (in *CertificateRequestPolicyAllowed) DeepCopyInto(out *CertificateRequestP
 		*out = new(CertificateRequestPolicyAllowedX509Subject)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]CertificateRequestPolicyAllowedString, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.CaseSensitiveNames != nil {
+		in, out := &in.CaseSensitiveNames, &out.CaseSensitiveNames
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyAllowed.
@@ -120,6 +132,11 @@ func (in *CertificateRequestPolicyAllowedString) DeepCopyInto(out *CertificateRe
 		*out = new(string)
 		**out = **in
 	}
+	if in.ValueType != nil {
+		in, out := &in.ValueType, &out.ValueType
+		*out = new(CertificateRequestPolicyAllowedValueType)
+		**out = **in
+	}
 	if in.Required != nil {
 		in, out := &in.Required, &out.Required
 		*out = new(bool)
@@ -132,6 +149,11 @@ func (in *CertificateRequestPolicyAllowedString) DeepCopyInto(out *CertificateRe
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ValidationsOperator != nil {
+		in, out := &in.ValidationsOperator, &out.ValidationsOperator
+		*out = new(CertificateRequestPolicyValidationsOperator)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyAllowedString.
@@ -156,6 +178,16 @@ func (in *CertificateRequestPolicyAllowedStringSlice) DeepCopyInto(out *Certific
 			copy(*out, *in)
 		}
 	}
+	if in.ValuesFrom != nil {
+		in, out := &in.ValuesFrom, &out.ValuesFrom
+		*out = new(CertificateRequestPolicyConfigMapKeyReference)
+		**out = **in
+	}
+	if in.ValueType != nil {
+		in, out := &in.ValueType, &out.ValueType
+		*out = new(CertificateRequestPolicyAllowedValueType)
+		**out = **in
+	}
 	if in.Required != nil {
 		in, out := &in.Required, &out.Required
 		*out = new(bool)
@@ -168,6 +200,29 @@ func (in *CertificateRequestPolicyAllowedStringSlice) DeepCopyInto(out *Certific
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ValidationsOperator != nil {
+		in, out := &in.ValidationsOperator, &out.ValidationsOperator
+		*out = new(CertificateRequestPolicyValidationsOperator)
+		**out = **in
+	}
+	if in.SPIFFETrustDomains != nil {
+		in, out := &in.SPIFFETrustDomains, &out.SPIFFETrustDomains
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.AllowedDomains != nil {
+		in, out := &in.AllowedDomains, &out.AllowedDomains
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyAllowedStringSlice.
@@ -254,9 +309,44 @@ func (in *CertificateRequestPolicyCondition) DeepCopy() *CertificateRequestPolic
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicyConfigMapKeyReference) DeepCopyInto(out *CertificateRequestPolicyConfigMapKeyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyConfigMapKeyReference.
+func (in *CertificateRequestPolicyConfigMapKeyReference) DeepCopy() *CertificateRequestPolicyConfigMapKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicyConfigMapKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicyConfigMapReference) DeepCopyInto(out *CertificateRequestPolicyConfigMapReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyConfigMapReference.
+func (in *CertificateRequestPolicyConfigMapReference) DeepCopy() *CertificateRequestPolicyConfigMapReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicyConfigMapReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateRequestPolicyConstraints) DeepCopyInto(out *CertificateRequestPolicyConstraints) {
 	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(CertificateRequestPolicyConstraintsDuration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.MinDuration != nil {
 		in, out := &in.MinDuration, &out.MinDuration
 		*out = new(metav1.Duration)
@@ -267,11 +357,152 @@ func (in *CertificateRequestPolicyConstraints) DeepCopyInto(out *CertificateRequ
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.AllowedDurations != nil {
+		in, out := &in.AllowedDurations, &out.AllowedDurations
+		*out = new([]metav1.Duration)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]metav1.Duration, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.RequireDuration != nil {
+		in, out := &in.RequireDuration, &out.RequireDuration
+		*out = new(bool)
+		**out = **in
+	}
 	if in.PrivateKey != nil {
 		in, out := &in.PrivateKey, &out.PrivateKey
 		*out = new(CertificateRequestPolicyConstraintsPrivateKey)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CommonName != nil {
+		in, out := &in.CommonName, &out.CommonName
+		*out = new(CertificateRequestPolicyConstraintsCommonName)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
+		*out = new(CertificateRequestPolicyConstraintsDNSNames)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IPAddresses != nil {
+		in, out := &in.IPAddresses, &out.IPAddresses
+		*out = new(CertificateRequestPolicyConstraintsIPAddresses)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequireNamespacedSPIFFE != nil {
+		in, out := &in.RequireNamespacedSPIFFE, &out.RequireNamespacedSPIFFE
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ForbidCommonNameWithSANs != nil {
+		in, out := &in.ForbidCommonNameWithSANs, &out.ForbidCommonNameWithSANs
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RequireIdentity != nil {
+		in, out := &in.RequireIdentity, &out.RequireIdentity
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RequiredUsages != nil {
+		in, out := &in.RequiredUsages, &out.RequiredUsages
+		*out = new([]v1.KeyUsage)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]v1.KeyUsage, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.MutuallyExclusiveUsages != nil {
+		in, out := &in.MutuallyExclusiveUsages, &out.MutuallyExclusiveUsages
+		*out = make([][]v1.KeyUsage, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make([]v1.KeyUsage, len(*in))
+				copy(*out, *in)
+			}
+		}
+	}
+	if in.EnforceDNSNameLimits != nil {
+		in, out := &in.EnforceDNSNameLimits, &out.EnforceDNSNameLimits
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IsCA != nil {
+		in, out := &in.IsCA, &out.IsCA
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CAMustIncludeUsages != nil {
+		in, out := &in.CAMustIncludeUsages, &out.CAMustIncludeUsages
+		*out = new([]v1.KeyUsage)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]v1.KeyUsage, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.IsCAAllowedForIssuers != nil {
+		in, out := &in.IsCAAllowedForIssuers, &out.IsCAAllowedForIssuers
+		*out = new([]CertificateRequestPolicySelectorIssuerRef)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]CertificateRequestPolicySelectorIssuerRef, len(*in))
+			for i := range *in {
+				(*in)[i].DeepCopyInto(&(*out)[i])
+			}
+		}
+	}
+	if in.SingleValuedSubjectAttributes != nil {
+		in, out := &in.SingleValuedSubjectAttributes, &out.SingleValuedSubjectAttributes
+		*out = new([]CertificateRequestPolicySubjectAttribute)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]CertificateRequestPolicySubjectAttribute, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.ForbiddenSubjectAttributes != nil {
+		in, out := &in.ForbiddenSubjectAttributes, &out.ForbiddenSubjectAttributes
+		*out = new([]CertificateRequestPolicySubjectAttribute)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]CertificateRequestPolicySubjectAttribute, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.ForbidDuplicateSANs != nil {
+		in, out := &in.ForbidDuplicateSANs, &out.ForbidDuplicateSANs
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CommonNameMustBeInDNSNames != nil {
+		in, out := &in.CommonNameMustBeInDNSNames, &out.CommonNameMustBeInDNSNames
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxPathLen != nil {
+		in, out := &in.MaxPathLen, &out.MaxPathLen
+		*out = new(int)
+		**out = **in
+	}
+	if in.AllowedCriticalExtensionOIDs != nil {
+		in, out := &in.AllowedCriticalExtensionOIDs, &out.AllowedCriticalExtensionOIDs
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.Subject != nil {
+		in, out := &in.Subject, &out.Subject
+		*out = new(CertificateRequestPolicyConstraintsSubject)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyConstraints.
@@ -285,140 +516,539 @@ func (in *CertificateRequestPolicyConstraints) DeepCopy() *CertificateRequestPol
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CertificateRequestPolicyConstraintsPrivateKey) DeepCopyInto(out *CertificateRequestPolicyConstraintsPrivateKey) {
+func (in *CertificateRequestPolicyConstraintsCommonName) DeepCopyInto(out *CertificateRequestPolicyConstraintsCommonName) {
 	*out = *in
-	if in.Algorithm != nil {
-		in, out := &in.Algorithm, &out.Algorithm
-		*out = new(v1.PrivateKeyAlgorithm)
-		**out = **in
-	}
-	if in.MinSize != nil {
-		in, out := &in.MinSize, &out.MinSize
+	if in.MaxLength != nil {
+		in, out := &in.MaxLength, &out.MaxLength
 		*out = new(int)
 		**out = **in
 	}
-	if in.MaxSize != nil {
-		in, out := &in.MaxSize, &out.MaxSize
-		*out = new(int)
+	if in.AllowedCharacters != nil {
+		in, out := &in.AllowedCharacters, &out.AllowedCharacters
+		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyConstraintsPrivateKey.
-func (in *CertificateRequestPolicyConstraintsPrivateKey) DeepCopy() *CertificateRequestPolicyConstraintsPrivateKey {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyConstraintsCommonName.
+func (in *CertificateRequestPolicyConstraintsCommonName) DeepCopy() *CertificateRequestPolicyConstraintsCommonName {
 	if in == nil {
 		return nil
 	}
-	out := new(CertificateRequestPolicyConstraintsPrivateKey)
+	out := new(CertificateRequestPolicyConstraintsCommonName)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CertificateRequestPolicyList) DeepCopyInto(out *CertificateRequestPolicyList) {
+func (in *CertificateRequestPolicyConstraintsDNSNames) DeepCopyInto(out *CertificateRequestPolicyConstraintsDNSNames) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]CertificateRequestPolicy, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.ForbidPublicSuffix != nil {
+		in, out := &in.ForbidPublicSuffix, &out.ForbidPublicSuffix
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AllowedPublicDomains != nil {
+		in, out := &in.AllowedPublicDomains, &out.AllowedPublicDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyList.
-func (in *CertificateRequestPolicyList) DeepCopy() *CertificateRequestPolicyList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyConstraintsDNSNames.
+func (in *CertificateRequestPolicyConstraintsDNSNames) DeepCopy() *CertificateRequestPolicyConstraintsDNSNames {
 	if in == nil {
 		return nil
 	}
-	out := new(CertificateRequestPolicyList)
+	out := new(CertificateRequestPolicyConstraintsDNSNames)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CertificateRequestPolicyList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CertificateRequestPolicyPluginData) DeepCopyInto(out *CertificateRequestPolicyPluginData) {
+func (in *CertificateRequestPolicyConstraintsDuration) DeepCopyInto(out *CertificateRequestPolicyConstraintsDuration) {
 	*out = *in
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.Values != nil {
 		in, out := &in.Values, &out.Values
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+		*out = new([]metav1.Duration)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]metav1.Duration, len(*in))
+			copy(*out, *in)
 		}
 	}
+	if in.Granularity != nil {
+		in, out := &in.Granularity, &out.Granularity
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Required != nil {
+		in, out := &in.Required, &out.Required
+		*out = new(bool)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyPluginData.
-func (in *CertificateRequestPolicyPluginData) DeepCopy() *CertificateRequestPolicyPluginData {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyConstraintsDuration.
+func (in *CertificateRequestPolicyConstraintsDuration) DeepCopy() *CertificateRequestPolicyConstraintsDuration {
 	if in == nil {
 		return nil
 	}
-	out := new(CertificateRequestPolicyPluginData)
+	out := new(CertificateRequestPolicyConstraintsDuration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CertificateRequestPolicySelector) DeepCopyInto(out *CertificateRequestPolicySelector) {
+func (in *CertificateRequestPolicyConstraintsIPAddresses) DeepCopyInto(out *CertificateRequestPolicyConstraintsIPAddresses) {
 	*out = *in
-	if in.IssuerRef != nil {
-		in, out := &in.IssuerRef, &out.IssuerRef
-		*out = new(CertificateRequestPolicySelectorIssuerRef)
-		(*in).DeepCopyInto(*out)
+	if in.Forbid != nil {
+		in, out := &in.Forbid, &out.Forbid
+		*out = new(bool)
+		**out = **in
 	}
-	if in.Namespace != nil {
-		in, out := &in.Namespace, &out.Namespace
-		*out = new(CertificateRequestPolicySelectorNamespace)
-		(*in).DeepCopyInto(*out)
+	if in.AllowedCIDRs != nil {
+		in, out := &in.AllowedCIDRs, &out.AllowedCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicySelector.
-func (in *CertificateRequestPolicySelector) DeepCopy() *CertificateRequestPolicySelector {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyConstraintsIPAddresses.
+func (in *CertificateRequestPolicyConstraintsIPAddresses) DeepCopy() *CertificateRequestPolicyConstraintsIPAddresses {
 	if in == nil {
 		return nil
 	}
-	out := new(CertificateRequestPolicySelector)
+	out := new(CertificateRequestPolicyConstraintsIPAddresses)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CertificateRequestPolicySelectorIssuerRef) DeepCopyInto(out *CertificateRequestPolicySelectorIssuerRef) {
+func (in *CertificateRequestPolicyConstraintsPrivateKey) DeepCopyInto(out *CertificateRequestPolicyConstraintsPrivateKey) {
 	*out = *in
-	if in.Name != nil {
-		in, out := &in.Name, &out.Name
-		*out = new(string)
+	if in.Algorithm != nil {
+		in, out := &in.Algorithm, &out.Algorithm
+		*out = new(v1.PrivateKeyAlgorithm)
 		**out = **in
 	}
-	if in.Kind != nil {
-		in, out := &in.Kind, &out.Kind
-		*out = new(string)
+	if in.MinSize != nil {
+		in, out := &in.MinSize, &out.MinSize
+		*out = new(int)
 		**out = **in
 	}
-	if in.Group != nil {
-		in, out := &in.Group, &out.Group
-		*out = new(string)
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		*out = new(int)
 		**out = **in
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicySelectorIssuerRef.
-func (in *CertificateRequestPolicySelectorIssuerRef) DeepCopy() *CertificateRequestPolicySelectorIssuerRef {
-	if in == nil {
-		return nil
+	if in.AllowedECDSACurves != nil {
+		in, out := &in.AllowedECDSACurves, &out.AllowedECDSACurves
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
 	}
-	out := new(CertificateRequestPolicySelectorIssuerRef)
+	if in.AllowedEd25519 != nil {
+		in, out := &in.AllowedEd25519, &out.AllowedEd25519
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MinRSAPublicExponent != nil {
+		in, out := &in.MinRSAPublicExponent, &out.MinRSAPublicExponent
+		*out = new(int)
+		**out = **in
+	}
+	if in.AllowedPublicKeysConfigMapRef != nil {
+		in, out := &in.AllowedPublicKeysConfigMapRef, &out.AllowedPublicKeysConfigMapRef
+		*out = new(CertificateRequestPolicyConfigMapReference)
+		**out = **in
+	}
+	if in.MatchCertificate != nil {
+		in, out := &in.MatchCertificate, &out.MatchCertificate
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyConstraintsPrivateKey.
+func (in *CertificateRequestPolicyConstraintsPrivateKey) DeepCopy() *CertificateRequestPolicyConstraintsPrivateKey {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicyConstraintsPrivateKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicyConstraintsSubject) DeepCopyInto(out *CertificateRequestPolicyConstraintsSubject) {
+	*out = *in
+	if in.AllowedOIDs != nil {
+		in, out := &in.AllowedOIDs, &out.AllowedOIDs
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.OrganizationsMustEqualNamespace != nil {
+		in, out := &in.OrganizationsMustEqualNamespace, &out.OrganizationsMustEqualNamespace
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OrganizationalUnitsMustEqualNamespace != nil {
+		in, out := &in.OrganizationalUnitsMustEqualNamespace, &out.OrganizationalUnitsMustEqualNamespace
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CommonNameMustEqualNamespace != nil {
+		in, out := &in.CommonNameMustEqualNamespace, &out.CommonNameMustEqualNamespace
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyConstraintsSubject.
+func (in *CertificateRequestPolicyConstraintsSubject) DeepCopy() *CertificateRequestPolicyConstraintsSubject {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicyConstraintsSubject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicyList) DeepCopyInto(out *CertificateRequestPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CertificateRequestPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyList.
+func (in *CertificateRequestPolicyList) DeepCopy() *CertificateRequestPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CertificateRequestPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicyPluginData) DeepCopyInto(out *CertificateRequestPolicyPluginData) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyPluginData.
+func (in *CertificateRequestPolicyPluginData) DeepCopy() *CertificateRequestPolicyPluginData {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicyPluginData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicyProfile) DeepCopyInto(out *CertificateRequestPolicyProfile) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyProfile.
+func (in *CertificateRequestPolicyProfile) DeepCopy() *CertificateRequestPolicyProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicyProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CertificateRequestPolicyProfile) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicyProfileList) DeepCopyInto(out *CertificateRequestPolicyProfileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CertificateRequestPolicyProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyProfileList.
+func (in *CertificateRequestPolicyProfileList) DeepCopy() *CertificateRequestPolicyProfileList {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicyProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CertificateRequestPolicyProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicyProfileReference) DeepCopyInto(out *CertificateRequestPolicyProfileReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyProfileReference.
+func (in *CertificateRequestPolicyProfileReference) DeepCopy() *CertificateRequestPolicyProfileReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicyProfileReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicyProfileSpec) DeepCopyInto(out *CertificateRequestPolicyProfileSpec) {
+	*out = *in
+	if in.Allowed != nil {
+		in, out := &in.Allowed, &out.Allowed
+		*out = new(CertificateRequestPolicyAllowed)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Constraints != nil {
+		in, out := &in.Constraints, &out.Constraints
+		*out = new(CertificateRequestPolicyConstraints)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyProfileSpec.
+func (in *CertificateRequestPolicyProfileSpec) DeepCopy() *CertificateRequestPolicyProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicyProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicySelector) DeepCopyInto(out *CertificateRequestPolicySelector) {
+	*out = *in
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(CertificateRequestPolicySelectorIssuerRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IssuerRefs != nil {
+		in, out := &in.IssuerRefs, &out.IssuerRefs
+		*out = make([]CertificateRequestPolicySelectorIssuerRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(CertificateRequestPolicySelectorNamespace)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertificateRequest != nil {
+		in, out := &in.CertificateRequest, &out.CertificateRequest
+		*out = new(CertificateRequestPolicySelectorCertificateRequest)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Certificate != nil {
+		in, out := &in.Certificate, &out.Certificate
+		*out = new(CertificateRequestPolicySelectorCertificate)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicySelector.
+func (in *CertificateRequestPolicySelector) DeepCopy() *CertificateRequestPolicySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicySelectorCertificate) DeepCopyInto(out *CertificateRequestPolicySelectorCertificate) {
+	*out = *in
+	if in.MatchLabels != nil {
+		in, out := &in.MatchLabels, &out.MatchLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MatchExpressions != nil {
+		in, out := &in.MatchExpressions, &out.MatchExpressions
+		*out = make([]metav1.LabelSelectorRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicySelectorCertificate.
+func (in *CertificateRequestPolicySelectorCertificate) DeepCopy() *CertificateRequestPolicySelectorCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicySelectorCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicySelectorCertificateRequest) DeepCopyInto(out *CertificateRequestPolicySelectorCertificateRequest) {
+	*out = *in
+	if in.MatchLabels != nil {
+		in, out := &in.MatchLabels, &out.MatchLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MatchExpressions != nil {
+		in, out := &in.MatchExpressions, &out.MatchExpressions
+		*out = make([]metav1.LabelSelectorRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MatchAnnotations != nil {
+		in, out := &in.MatchAnnotations, &out.MatchAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MatchAnnotationExpressions != nil {
+		in, out := &in.MatchAnnotationExpressions, &out.MatchAnnotationExpressions
+		*out = make([]metav1.LabelSelectorRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicySelectorCertificateRequest.
+func (in *CertificateRequestPolicySelectorCertificateRequest) DeepCopy() *CertificateRequestPolicySelectorCertificateRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicySelectorCertificateRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicySelectorIssuerRef) DeepCopyInto(out *CertificateRequestPolicySelectorIssuerRef) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.NameExpression != nil {
+		in, out := &in.NameExpression, &out.NameExpression
+		*out = new(string)
+		**out = **in
+	}
+	if in.Kind != nil {
+		in, out := &in.Kind, &out.Kind
+		*out = new(string)
+		**out = **in
+	}
+	if in.Group != nil {
+		in, out := &in.Group, &out.Group
+		*out = new(string)
+		**out = **in
+	}
+	if in.ExcludeKinds != nil {
+		in, out := &in.ExcludeKinds, &out.ExcludeKinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeGroups != nil {
+		in, out := &in.ExcludeGroups, &out.ExcludeGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaced != nil {
+		in, out := &in.Namespaced, &out.Namespaced
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RequireReady != nil {
+		in, out := &in.RequireReady, &out.RequireReady
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicySelectorIssuerRef.
+func (in *CertificateRequestPolicySelectorIssuerRef) DeepCopy() *CertificateRequestPolicySelectorIssuerRef {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicySelectorIssuerRef)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -438,6 +1068,25 @@ func (in *CertificateRequestPolicySelectorNamespace) DeepCopyInto(out *Certifica
 			(*out)[key] = val
 		}
 	}
+	if in.MatchExpressions != nil {
+		in, out := &in.MatchExpressions, &out.MatchExpressions
+		*out = make([]metav1.LabelSelectorRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExcludeNames != nil {
+		in, out := &in.ExcludeNames, &out.ExcludeNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeLabels != nil {
+		in, out := &in.ExcludeLabels, &out.ExcludeLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicySelectorNamespace.
@@ -463,6 +1112,16 @@ func (in *CertificateRequestPolicySpec) DeepCopyInto(out *CertificateRequestPoli
 		*out = new(CertificateRequestPolicyConstraints)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ProfileRef != nil {
+		in, out := &in.ProfileRef, &out.ProfileRef
+		*out = new(CertificateRequestPolicyProfileReference)
+		**out = **in
+	}
+	if in.InheritFrom != nil {
+		in, out := &in.InheritFrom, &out.InheritFrom
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Plugins != nil {
 		in, out := &in.Plugins, &out.Plugins
 		*out = make(map[string]CertificateRequestPolicyPluginData, len(*in))
@@ -471,6 +1130,23 @@ func (in *CertificateRequestPolicySpec) DeepCopyInto(out *CertificateRequestPoli
 		}
 	}
 	in.Selector.DeepCopyInto(&out.Selector)
+	if in.Enforcement != nil {
+		in, out := &in.Enforcement, &out.Enforcement
+		*out = new(CertificateRequestPolicyEnforcement)
+		**out = **in
+	}
+	if in.Fallback != nil {
+		in, out := &in.Fallback, &out.Fallback
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TestCases != nil {
+		in, out := &in.TestCases, &out.TestCases
+		*out = make([]CertificateRequestPolicyTestCase, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicySpec.
@@ -505,6 +1181,194 @@ func (in *CertificateRequestPolicyStatus) DeepCopy() *CertificateRequestPolicySt
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicyTestCase) DeepCopyInto(out *CertificateRequestPolicyTestCase) {
+	*out = *in
+	in.Request.DeepCopyInto(&out.Request)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyTestCase.
+func (in *CertificateRequestPolicyTestCase) DeepCopy() *CertificateRequestPolicyTestCase {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicyTestCase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicyTestCaseRequest) DeepCopyInto(out *CertificateRequestPolicyTestCaseRequest) {
+	*out = *in
+	out.IssuerRef = in.IssuerRef
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPAddresses != nil {
+		in, out := &in.IPAddresses, &out.IPAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.URIs != nil {
+		in, out := &in.URIs, &out.URIs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EmailAddresses != nil {
+		in, out := &in.EmailAddresses, &out.EmailAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Usages != nil {
+		in, out := &in.Usages, &out.Usages
+		*out = make([]v1.KeyUsage, len(*in))
+		copy(*out, *in)
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyTestCaseRequest.
+func (in *CertificateRequestPolicyTestCaseRequest) DeepCopy() *CertificateRequestPolicyTestCaseRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicyTestCaseRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestReview) DeepCopyInto(out *CertificateRequestReview) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestReview.
+func (in *CertificateRequestReview) DeepCopy() *CertificateRequestReview {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestReview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CertificateRequestReview) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestReviewEvaluation) DeepCopyInto(out *CertificateRequestReviewEvaluation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestReviewEvaluation.
+func (in *CertificateRequestReviewEvaluation) DeepCopy() *CertificateRequestReviewEvaluation {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestReviewEvaluation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestReviewList) DeepCopyInto(out *CertificateRequestReviewList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CertificateRequestReview, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestReviewList.
+func (in *CertificateRequestReviewList) DeepCopy() *CertificateRequestReviewList {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestReviewList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CertificateRequestReviewList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestReviewPredicate) DeepCopyInto(out *CertificateRequestReviewPredicate) {
+	*out = *in
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestReviewPredicate.
+func (in *CertificateRequestReviewPredicate) DeepCopy() *CertificateRequestReviewPredicate {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestReviewPredicate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestReviewStatus) DeepCopyInto(out *CertificateRequestReviewStatus) {
+	*out = *in
+	in.ReviewTime.DeepCopyInto(&out.ReviewTime)
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Predicates != nil {
+		in, out := &in.Predicates, &out.Predicates
+		*out = make([]CertificateRequestReviewPredicate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Evaluations != nil {
+		in, out := &in.Evaluations, &out.Evaluations
+		*out = make([]CertificateRequestReviewEvaluation, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestReviewStatus.
+func (in *CertificateRequestReviewStatus) DeepCopy() *CertificateRequestReviewStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestReviewStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValidationRule) DeepCopyInto(out *ValidationRule) {
 	*out = *in
@@ -513,6 +1377,11 @@ func (in *ValidationRule) DeepCopyInto(out *ValidationRule) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.MessageExpression != nil {
+		in, out := &in.MessageExpression, &out.MessageExpression
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationRule.
@@ -18,12 +18,18 @@ package approver
 
 import (
 	"context"
+	"errors"
 
 	"github.com/go-logr/logr"
 	"github.com/spf13/pflag"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
+// ErrNotPrepared is returned, wrapped with the name of the Approver, when an
+// evaluation needs to read objects from the cluster but the Approver has not
+// been prepared with access to it, i.e. when evaluating policies offline.
+var ErrNotPrepared = errors.New("approver has not been prepared with access to the cluster")
+
 // Interface is an Approver.
 // An Approver implements an Evaluator and Webhook.
 type Interface interface {
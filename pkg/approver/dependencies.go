@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approver
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+)
+
+// DependencyKind is the kind of object that a CertificateRequestPolicy may
+// depend on.
+type DependencyKind string
+
+const (
+	// DependencyKindSecret is a core/v1 Secret dependency.
+	DependencyKindSecret DependencyKind = "Secret"
+
+	// DependencyKindConfigMap is a core/v1 ConfigMap dependency.
+	DependencyKindConfigMap DependencyKind = "ConfigMap"
+)
+
+// Dependency is a reference to an object that a CertificateRequestPolicy
+// depends on to be ready for evaluation.
+type Dependency struct {
+	// Kind is the kind of the referenced object.
+	Kind DependencyKind
+
+	// Namespace is the namespace of the referenced object.
+	Namespace string
+
+	// Name is the name of the referenced object.
+	Name string
+
+	// FieldPath is the path of the CertificateRequestPolicy field which
+	// references the object. Used to give context when the object does not
+	// exist.
+	FieldPath *field.Path
+}
+
+// DependencyReconciler may optionally be implemented by Reconcilers whose
+// evaluation depends on objects other than the CertificateRequestPolicy.
+// Dependencies are not watched; the CertificateRequestPolicy has its Ready
+// condition re-evaluated every --policy-dependency-resync-period (one minute
+// by default) whilst it declares any dependencies, so a dependency being
+// created or deleted is reflected after at most that period. A
+// CertificateRequestPolicy is not ready whilst any of its dependencies do not
+// exist.
+// approver-policy is only granted access to read ConfigMaps by default;
+// Secret dependencies additionally require the Helm chart value
+// app.dependencies.secrets to be enabled.
+type DependencyReconciler interface {
+	// Dependencies returns the objects that the given CertificateRequestPolicy
+	// depends on, according to this Reconciler.
+	Dependencies(*policyapi.CertificateRequestPolicy) []Dependency
+}
@@ -51,6 +51,26 @@ type EvaluationResponse struct {
 	// Message is optional context as to why the evaluator has given the result
 	// it has.
 	Message string
+
+	// Reasons are optional stable codes for each of the violations described
+	// in Message, so that denials can be handled programmatically. Reasons
+	// are ignored if Result is ResultNotDenied.
+	Reasons []DenialReason
+
+	// Annotations are optional annotations to add to the CertificateRequest
+	// if it is approved by the evaluated policy, e.g. to reference the ticket
+	// through which the request was approved. Annotations are ignored if the
+	// policy does not approve the request. Annotations in the
+	// cert-manager.io domain or its subdomains, e.g. policy.cert-manager.io,
+	// are reserved and dropped.
+	Annotations map[string]string
+
+	// Pending signals that the evaluator has not denied the request, but is
+	// awaiting a decision from an external system, e.g. a human approving a
+	// ticket. The evaluated policy will not approve the request whilst any of
+	// its evaluators are pending, and the request will be reviewed again
+	// later. Pending is ignored if Result is ResultDenied.
+	Pending bool
 }
 
 // Evaluator is responsible for making decisions on whether a
@@ -70,3 +90,38 @@ type Evaluator interface {
 	// manager may re-evaluate an evaluation if an error is returned.
 	Evaluate(context.Context, *policyapi.CertificateRequestPolicy, *cmapi.CertificateRequest) (EvaluationResponse, error)
 }
+
+// EvaluationStage is the stage in which an Evaluator is run. Evaluators are
+// run in order of their stage, so that a request denied by cheap checks is
+// never evaluated by more expensive evaluators.
+type EvaluationStage int
+
+const (
+	// EvaluationStageBuiltin is the stage of the built-in evaluators, which
+	// check the request against the policy's allowed and constraints fields.
+	EvaluationStageBuiltin EvaluationStage = iota
+
+	// EvaluationStageCEL is the stage of evaluators which evaluate CEL
+	// expressions against the request.
+	EvaluationStageCEL
+
+	// EvaluationStagePlugin is the stage of plugin evaluators, which may read
+	// cluster state or call external services. Evaluators which don't
+	// implement StagedEvaluator are run in this stage.
+	EvaluationStagePlugin
+)
+
+// StagedEvaluator may optionally be implemented by Evaluators to declare the
+// stage in which they are run.
+type StagedEvaluator interface {
+	// EvaluationStage returns the stage in which the Evaluator is run.
+	EvaluationStage() EvaluationStage
+}
+
+// StageOf returns the stage in which the given Evaluator is run.
+func StageOf(evaluator Evaluator) EvaluationStage {
+	if staged, ok := evaluator.(StagedEvaluator); ok {
+		return staged.EvaluationStage()
+	}
+	return EvaluationStagePlugin
+}
@@ -31,6 +31,7 @@ var _ approver.Evaluator = &FakeEvaluator{}
 // pre-determined response.
 type FakeEvaluator struct {
 	evaluateFunc func(context.Context, *policyapi.CertificateRequestPolicy, *cmapi.CertificateRequest) (approver.EvaluationResponse, error)
+	stage        *approver.EvaluationStage
 }
 
 func NewFakeEvaluator() *FakeEvaluator {
@@ -45,3 +46,24 @@ func (f *FakeEvaluator) WithEvaluate(fn func(context.Context, *policyapi.Certifi
 func (f *FakeEvaluator) Evaluate(ctx context.Context, policy *policyapi.CertificateRequestPolicy, cr *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
 	return f.evaluateFunc(ctx, policy, cr)
 }
+
+// WithEvaluationStage sets the stage the evaluator is run in. Defaults to the
+// plugin stage.
+func (f *FakeEvaluator) WithEvaluationStage(stage approver.EvaluationStage) *FakeEvaluator {
+	f.stage = &stage
+	return f
+}
+
+func (f *FakeEvaluator) EvaluationStage() approver.EvaluationStage {
+	if f.stage == nil {
+		return approver.EvaluationStagePlugin
+	}
+	return *f.stage
+}
+
+// WithResponse sets the evaluator to always return the given response.
+func (f *FakeEvaluator) WithResponse(response approver.EvaluationResponse) *FakeEvaluator {
+	return f.WithEvaluate(func(context.Context, *policyapi.CertificateRequestPolicy, *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+		return response, nil
+	})
+}
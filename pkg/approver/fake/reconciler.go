@@ -23,7 +23,10 @@ import (
 	"github.com/cert-manager/approver-policy/pkg/approver"
 )
 
-var _ approver.Reconciler = &FakeReconciler{}
+var (
+	_ approver.Reconciler           = &FakeReconciler{}
+	_ approver.DependencyReconciler = &FakeReconciler{}
+)
 
 // FakeReconciler is a testing reconciler designed to mock Reconcilers with a
 // pre-determined response.
@@ -31,11 +34,13 @@ type FakeReconciler struct {
 	name        string
 	readyFunc   func(context.Context, *policyapi.CertificateRequestPolicy) (approver.ReconcilerReadyResponse, error)
 	enqueueFunc func() <-chan string
+	depsFunc    func(*policyapi.CertificateRequestPolicy) []approver.Dependency
 }
 
 func NewFakeReconciler() *FakeReconciler {
 	return &FakeReconciler{
 		enqueueFunc: func() <-chan string { return nil },
+		depsFunc:    func(*policyapi.CertificateRequestPolicy) []approver.Dependency { return nil },
 	}
 }
 
@@ -54,6 +59,11 @@ func (f *FakeReconciler) WithEnqueueChan(fn func() <-chan string) *FakeReconcile
 	return f
 }
 
+func (f *FakeReconciler) WithDependencies(fn func(*policyapi.CertificateRequestPolicy) []approver.Dependency) *FakeReconciler {
+	f.depsFunc = fn
+	return f
+}
+
 func (f *FakeReconciler) Name() string {
 	return f.name
 }
@@ -65,3 +75,7 @@ func (f *FakeReconciler) Ready(ctx context.Context, policy *policyapi.Certificat
 func (f *FakeReconciler) EnqueueChan() <-chan string {
 	return f.enqueueFunc()
 }
+
+func (f *FakeReconciler) Dependencies(policy *policyapi.CertificateRequestPolicy) []approver.Dependency {
+	return f.depsFunc(policy)
+}
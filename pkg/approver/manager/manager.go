@@ -20,6 +20,8 @@ import (
 	"context"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+
+	"github.com/cert-manager/approver-policy/pkg/approver"
 )
 
 // ReviewResult is the result from an approver manager reviewing a
@@ -39,6 +41,29 @@ const (
 	// that the request is not appropriate for any evaluators given the current
 	// policy. It is neither approved or denied by the manager.
 	ResultUnprocessed
+
+	// ResultPending is the result of a review where no policy approved the
+	// request, but at least one policy which didn't deny the request is
+	// awaiting a decision from an external system. It is neither approved or
+	// denied by the manager, and should be reviewed again later.
+	ResultPending
+)
+
+const (
+	// MessageNoPolicies is the message of a ResultUnprocessed review where no
+	// CertificateRequestPolicies exist.
+	MessageNoPolicies = "No CertificateRequestPolicies exist"
+
+	// MessageNoApplicablePolicies is the message of a ResultUnprocessed review
+	// where CertificateRequestPolicies exist, but none are bound to the
+	// requester or applicable to the request.
+	MessageNoApplicablePolicies = "No CertificateRequestPolicies bound or applicable"
+
+	// MessageNoApplicablePoliciesDenied is the message of a ResultDenied
+	// review where CertificateRequestPolicies exist, but none are bound to the
+	// requester or applicable to the request, and the manager is configured to
+	// deny such requests.
+	MessageNoApplicablePoliciesDenied = "No CertificateRequestPolicies bound or applicable, denying by default"
 )
 
 // ReviewResponse is the response to an approver manager request review.
@@ -49,6 +74,38 @@ type ReviewResponse struct {
 	// Message is optional context as to why the manager has given the result it
 	// has.
 	Message string
+
+	// Policies are the names of the CertificateRequestPolicies that resulted
+	// in the given Result. For ResultApproved this is the policy which approved
+	// the request, for ResultDenied this is every policy which denied the
+	// request, and for ResultPending every policy awaiting a decision, sorted
+	// by name.
+	Policies []string
+
+	// Annotations are the annotations returned by the evaluators of the
+	// policy which approved the request, to be added to the
+	// CertificateRequest. Only set for ResultApproved.
+	Annotations map[string]string
+
+	// Denials are the policies which denied the request, along with the
+	// messages and reasons of their evaluators, sorted by policy name.
+	// Denials is the machine-readable equivalent of the denial reasons in
+	// Message. Only set for ResultDenied.
+	Denials []Denial
+}
+
+// Denial is the denial of a request by a single CertificateRequestPolicy.
+type Denial struct {
+	// Policy is the name of the CertificateRequestPolicy which denied the
+	// request.
+	Policy string `json:"policy"`
+
+	// Errors are the messages returned by the evaluators of the policy.
+	Errors []string `json:"errors,omitempty"`
+
+	// Reasons are the stable codes of each violation of the policy, as
+	// returned by its evaluators.
+	Reasons []approver.DenialReason `json:"reasons,omitempty"`
 }
 
 // Interface is an Approver Manager that responsible for evaluating whether
@@ -65,6 +122,9 @@ type Interface interface {
 	// - Consumers should consider a ResultUnprocessed response to mean the
 	//   manager doesn't consider the request to be appropriate for any evaluator
 	//   and so no review was run. The request is neither approved or denied.
+	// - Consumers should consider a ResultPending response to mean the
+	//   CertificateRequest is neither approved or denied yet, and should be
+	//   reviewed again later once the external decision has been made.
 	// - Consumers should treat any error response as marking the
 	//   CertificateRequest as neither approved nor denied, and may consider
 	//   re-evaluation at a later time.
@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// PolicyMessages are the messages returned by the evaluators for a single
+// CertificateRequestPolicy during a review.
+type PolicyMessages struct {
+	// Policy is the name of the CertificateRequestPolicy.
+	Policy string
+
+	// Messages are the messages returned by the evaluators of the policy.
+	Messages []string
+}
+
+// FormatPolicyMessages returns the messages of the given policies sorted by
+// policy name, in the format used by review messages, i.e.
+// `[policy-a: message-1, message-2] [policy-b: message-3]`.
+func FormatPolicyMessages(policies []PolicyMessages) string {
+	policies = slices.Clone(policies)
+	slices.SortStableFunc(policies, func(a, b PolicyMessages) int {
+		return strings.Compare(a.Policy, b.Policy)
+	})
+
+	formatted := make([]string, 0, len(policies))
+	for _, policy := range policies {
+		formatted = append(formatted, fmt.Sprintf("[%s: %s]", policy.Policy, strings.Join(policy.Messages, ", ")))
+	}
+	return strings.Join(formatted, " ")
+}
+
+// FormatApprovedMessage returns the message of a ResultApproved review where
+// the named policy approved the request.
+func FormatApprovedMessage(policy string) string {
+	return fmt.Sprintf("Approved by CertificateRequestPolicy: %q", policy)
+}
+
+// FormatDeniedMessage returns the message of a ResultDenied review where none
+// of the given policies approved the request.
+func FormatDeniedMessage(policies []PolicyMessages) string {
+	return "No policy approved this request: " + FormatPolicyMessages(policies)
+}
+
+// FormatStrictDeniedMessage returns the message of a ResultDenied review
+// where the given Strict policies denied the request.
+func FormatStrictDeniedMessage(policies []PolicyMessages) string {
+	return "Denied by Strict CertificateRequestPolicy: " + FormatPolicyMessages(policies)
+}
+
+// FormatPendingMessage returns the message of a ResultPending review where
+// the given policies are awaiting an external decision.
+func FormatPendingMessage(policies []PolicyMessages) string {
+	return "Awaiting external approval: " + FormatPolicyMessages(policies)
+}
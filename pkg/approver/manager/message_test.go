@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FormatPolicyMessages(t *testing.T) {
+	tests := map[string]struct {
+		policies []PolicyMessages
+		exp      string
+	}{
+		"if no policies, return empty": {
+			policies: nil,
+			exp:      "",
+		},
+		"if a single policy with a single message, return it": {
+			policies: []PolicyMessages{{Policy: "policy-a", Messages: []string{"denied"}}},
+			exp:      "[policy-a: denied]",
+		},
+		"if a policy has no messages, return it with an empty message": {
+			policies: []PolicyMessages{{Policy: "policy-a"}},
+			exp:      "[policy-a: ]",
+		},
+		"if multiple policies and messages, return them sorted by policy with messages joined": {
+			policies: []PolicyMessages{
+				{Policy: "policy-c", Messages: []string{"c-1"}},
+				{Policy: "policy-a", Messages: []string{"a-1", "a-2"}},
+				{Policy: "policy-b", Messages: []string{"b-1"}},
+			},
+			exp: "[policy-a: a-1, a-2] [policy-b: b-1] [policy-c: c-1]",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			policies := append([]PolicyMessages(nil), test.policies...)
+			assert.Equal(t, test.exp, FormatPolicyMessages(test.policies))
+			assert.Equal(t, policies, test.policies, "policies must not be modified")
+		})
+	}
+}
+
+func Test_FormatMessages(t *testing.T) {
+	policies := []PolicyMessages{
+		{Policy: "policy-b", Messages: []string{"spec.allowed.dnsNames.values: Invalid value: \"example.com\": example.org"}},
+		{Policy: "policy-a", Messages: []string{"awaiting approval"}},
+	}
+
+	assert.Equal(t, `Approved by CertificateRequestPolicy: "policy-a"`, FormatApprovedMessage("policy-a"))
+	assert.Equal(t, `No policy approved this request: [policy-a: awaiting approval] [policy-b: spec.allowed.dnsNames.values: Invalid value: "example.com": example.org]`, FormatDeniedMessage(policies))
+	assert.Equal(t, `Denied by Strict CertificateRequestPolicy: [policy-a: awaiting approval] [policy-b: spec.allowed.dnsNames.values: Invalid value: "example.com": example.org]`, FormatStrictDeniedMessage(policies))
+	assert.Equal(t, `Awaiting external approval: [policy-a: awaiting approval] [policy-b: spec.allowed.dnsNames.values: Invalid value: "example.com": example.org]`, FormatPendingMessage(policies))
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"time"
+
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+// ReviewTrace records the context of how a manager came to the result of a
+// review. A trace is only recorded when requested using WithReviewTrace, since
+// recording is not free and the trace is only useful for debugging.
+type ReviewTrace struct {
+	// Policies are the names of all CertificateRequestPolicies that were
+	// considered for the review.
+	Policies []string
+
+	// Predicates are the results of each predicate that was run to filter the
+	// considered policies, in the order they were run.
+	Predicates []PredicateTrace
+
+	// BoundPolicies are the names of the considered policies which passed the
+	// RBACBound predicate, i.e. which select the request and which the
+	// requester is bound to use.
+	BoundPolicies []string
+
+	// Evaluations are the results of each evaluator that was run against each
+	// policy which passed all predicates.
+	Evaluations []EvaluationTrace
+
+	// Duration is the total time taken to review the request.
+	Duration time.Duration
+}
+
+// PredicateTrace is the result of a single predicate run during a review.
+type PredicateTrace struct {
+	// Name of the predicate.
+	Name string
+
+	// Policies are the names of the policies which passed the predicate.
+	Policies []string
+
+	// Duration is the time taken to run the predicate.
+	Duration time.Duration
+}
+
+// EvaluationTrace is the result of a single evaluator run against a single
+// policy during a review.
+type EvaluationTrace struct {
+	// Policy is the name of the policy that was evaluated.
+	Policy string
+
+	// Evaluator is the name of the evaluator.
+	Evaluator string
+
+	// Result is the result returned by the evaluator.
+	Result approver.EvaluationResult
+
+	// Pending is whether the evaluator is awaiting an external decision.
+	Pending bool
+
+	// Message is the message returned by the evaluator.
+	Message string
+
+	// Duration is the time taken to run the evaluator.
+	Duration time.Duration
+}
+
+type reviewTraceKey struct{}
+
+// WithReviewTrace returns a copy of the context which requests that the
+// manager records the context of the review into the given trace.
+func WithReviewTrace(ctx context.Context, trace *ReviewTrace) context.Context {
+	return context.WithValue(ctx, reviewTraceKey{}, trace)
+}
+
+// ReviewTraceFromContext returns the trace which has been requested to be
+// recorded in the given context. Returns nil if no trace has been requested.
+func ReviewTraceFromContext(ctx context.Context) *ReviewTrace {
+	trace, _ := ctx.Value(reviewTraceKey{}).(*ReviewTrace)
+	return trace
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approver
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Reason is a stable, machine-readable code for why an evaluator denied a
+// CertificateRequest, e.g. `DNSNameNotAllowed`. Unlike messages, reasons do
+// not change between releases, so can be used to handle denials
+// programmatically.
+type Reason string
+
+// ReasonDenied is the reason for a violation which has no more specific
+// reason.
+const ReasonDenied Reason = "Denied"
+
+// Reasons given by the built-in evaluators for requests which violate the
+// `allowed` fields of a policy.
+const (
+	ReasonInvalidCSR               Reason = "InvalidCSR"
+	ReasonRequiredAttributeMissing Reason = "RequiredAttributeMissing"
+	ReasonCommonNameNotAllowed     Reason = "CommonNameNotAllowed"
+	ReasonDNSNameNotAllowed        Reason = "DNSNameNotAllowed"
+	ReasonIPAddressNotAllowed      Reason = "IPAddressNotAllowed"
+	ReasonURINotAllowed            Reason = "URINotAllowed"
+	ReasonEmailAddressNotAllowed   Reason = "EmailAddressNotAllowed"
+	ReasonIsCANotAllowed           Reason = "IsCANotAllowed"
+	ReasonUsageNotAllowed          Reason = "UsageNotAllowed"
+	ReasonSubjectNotAllowed        Reason = "SubjectNotAllowed"
+	ReasonAnnotationNotAllowed     Reason = "AnnotationNotAllowed"
+)
+
+// Reasons given by the built-in evaluators for requests which violate the
+// `constraints` fields of a policy.
+const (
+	ReasonDurationTooLong             Reason = "DurationTooLong"
+	ReasonDurationTooShort            Reason = "DurationTooShort"
+	ReasonDurationNotAllowed          Reason = "DurationNotAllowed"
+	ReasonKeyAlgorithmNotAllowed      Reason = "KeyAlgorithmNotAllowed"
+	ReasonKeyTooLarge                 Reason = "KeyTooLarge"
+	ReasonKeyTooSmall                 Reason = "KeyTooSmall"
+	ReasonKeyCurveNotAllowed          Reason = "KeyCurveNotAllowed"
+	ReasonKeyExponentTooSmall         Reason = "KeyExponentTooSmall"
+	ReasonPublicKeyNotAllowed         Reason = "PublicKeyNotAllowed"
+	ReasonKeyMismatch                 Reason = "KeyMismatch"
+	ReasonCommonNameTooLong           Reason = "CommonNameTooLong"
+	ReasonCommonNameInvalidCharacters Reason = "CommonNameInvalidCharacters"
+	ReasonCommonNameWithSANs          Reason = "CommonNameWithSANs"
+	ReasonCommonNameNotInDNSNames     Reason = "CommonNameNotInDNSNames"
+	ReasonIdentityMissing             Reason = "IdentityMissing"
+	ReasonURINotNamespaced            Reason = "URINotNamespaced"
+	ReasonDNSNameInvalid              Reason = "DNSNameInvalid"
+	ReasonDNSNamePublicSuffix         Reason = "DNSNamePublicSuffix"
+	ReasonIPAddressForbidden          Reason = "IPAddressForbidden"
+	ReasonIPAddressOutOfRange         Reason = "IPAddressOutOfRange"
+	ReasonDuplicateSANs               Reason = "DuplicateSANs"
+	ReasonUsageMissing                Reason = "UsageMissing"
+	ReasonUsagesMutuallyExclusive     Reason = "UsagesMutuallyExclusive"
+	ReasonIsCAMismatch                Reason = "IsCAMismatch"
+	ReasonCAUsageMissing              Reason = "CAUsageMissing"
+	ReasonCAIssuerNotAllowed          Reason = "CAIssuerNotAllowed"
+	ReasonPathLenTooLong              Reason = "PathLenTooLong"
+	ReasonSubjectAttributeMultiValued Reason = "SubjectAttributeMultiValued"
+	ReasonSubjectAttributeForbidden   Reason = "SubjectAttributeForbidden"
+	ReasonSubjectOIDNotAllowed        Reason = "SubjectOIDNotAllowed"
+	ReasonSubjectNamespaceMismatch    Reason = "SubjectNamespaceMismatch"
+	ReasonCriticalExtensionNotAllowed Reason = "CriticalExtensionNotAllowed"
+)
+
+// DenialReason is the reason for a single violation of a policy by a denied
+// request.
+type DenialReason struct {
+	// Reason is the stable code of the violation.
+	Reason Reason `json:"reason"`
+
+	// Field is the path of the policy field which the request violated, e.g.
+	// `spec.allowed.dnsNames.values`, if any.
+	Field string `json:"field,omitempty"`
+}
+
+// FieldReasons returns a DenialReason for each of the given field errors. The
+// reason of each error is the reason of the longest field path in reasons
+// which is the error's field or a parent of it, or fallback if there is none,
+// so that reasons can be given for a field and all its children at once.
+func FieldReasons(el field.ErrorList, reasons map[string]Reason, fallback Reason) []DenialReason {
+	denialReasons := make([]DenialReason, 0, len(el))
+	for _, err := range el {
+		reason, matched := fallback, ""
+		for path, r := range reasons {
+			if len(path) > len(matched) && isFieldOrChild(err.Field, path) {
+				reason, matched = r, path
+			}
+		}
+		denialReasons = append(denialReasons, DenialReason{Reason: reason, Field: err.Field})
+	}
+	return denialReasons
+}
+
+// isFieldOrChild returns true if the field path is the given parent path or
+// a child, index or key of it.
+func isFieldOrChild(fieldPath, parent string) bool {
+	rest, ok := strings.CutPrefix(fieldPath, parent)
+	return ok && (len(rest) == 0 || rest[0] == '.' || rest[0] == '[')
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approver
+
+import (
+	"context"
+)
+
+// Reloader may optionally be implemented by Approvers which hold
+// configuration read from files (e.g. plugin defaults or variable maps
+// mounted from ConfigMaps), so that it can be reloaded without restarting
+// approver-policy.
+// Of the in-tree Approvers, only reverse-dns reads configuration from a file
+// (--reverse-dns-config-file); all other in-tree configuration is read from
+// flags, so is only changed by a restart. Without reverse-dns configured or
+// external plugins implementing Reloader, a SIGHUP reloads nothing.
+type Reloader interface {
+	// Reload is called when approver-policy receives a SIGHUP. Reload should
+	// re-read file based configuration and apply it to subsequent evaluations.
+	// Once all Reloaders have been called, all CertificateRequestPolicies are
+	// reconciled so that their Ready condition reflects the reloaded
+	// configuration.
+	// A returned error is logged, and the Approver is expected to continue
+	// using its previous configuration.
+	Reload(context.Context) error
+}
+
+// FileReloader may optionally be implemented by Reloaders which know the
+// files their configuration is read from. The files are periodically checked
+// for changes, and Reload is called when the contents of any of them change,
+// so that configuration mounted from a ConfigMap or Secret is picked up
+// without needing to send a SIGHUP.
+type FileReloader interface {
+	Reloader
+
+	// ReloadFiles returns the paths of the files which the configuration is
+	// read from. Files which do not exist are watched for being created.
+	ReloadFiles() []string
+}
@@ -0,0 +1,260 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package remote allows approver-policy plugins to be served out-of-tree over
+// gRPC, using the Plugin service defined in plugin.proto.
+//
+// External plugins written in Go implement Approver, the same interfaces as
+// in-tree plugins, and serve it with RegisterPluginServer(grpcServer,
+// NewServer(approver)). Plugins written in other languages implement the
+// Plugin service directly. approver-policy registers remote plugins with
+// Register, e.g. through the --remote-plugins flag.
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/go-logr/logr"
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/registry"
+)
+
+var _ approver.Interface = &remote{}
+
+// remote is an Approver which forwards evaluations, validations and
+// readiness checks to a plugin served over gRPC.
+type remote struct {
+	name   string
+	client PluginClient
+}
+
+// New returns an Approver with the given name, which forwards to the plugin
+// served on the given gRPC connection. The Approver only calls the plugin for
+// CertificateRequestPolicies which configure the plugin name in
+// `spec.plugins`.
+func New(name string, conn grpc.ClientConnInterface) approver.Interface {
+	return &remote{
+		name:   name,
+		client: NewPluginClient(conn),
+	}
+}
+
+// Register registers an Approver with the given name to the registry, which
+// forwards to the plugin served at the given gRPC address, e.g.
+// "unix:///var/run/plugin.sock" or "localhost:9443". If no dial options are
+// given, the connection is not encrypted, so only local addresses are
+// accepted: unix sockets and loopback addresses, e.g. a sidecar. Plugins at
+// any other address must be given dial options with transport credentials.
+func Register(reg *registry.Registry, name, address string, opts ...grpc.DialOption) error {
+	for _, existing := range reg.Approvers() {
+		if existing.Name() == name {
+			return fmt.Errorf("approver already registered with same name: %s", name)
+		}
+	}
+
+	if len(opts) == 0 {
+		if !isLocalAddress(address) {
+			return fmt.Errorf("remote plugin %q at %q must be served on a unix socket or loopback address, since the connection is not encrypted", name, address)
+		}
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+
+	conn, err := grpc.NewClient(address, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to create client for remote plugin %q at %q: %w", name, address, err)
+	}
+
+	reg.Store(New(name, conn))
+	return nil
+}
+
+// isLocalAddress returns true if the given gRPC target address is a unix
+// socket, or a host and port whose host is "localhost" or a loopback IP
+// address.
+func isLocalAddress(address string) bool {
+	if strings.HasPrefix(address, "unix:") || strings.HasPrefix(address, "unix-abstract:") {
+		return true
+	}
+	// Strip the resolver scheme and authority of targets such as
+	// "dns:///localhost:9443" or "passthrough:///127.0.0.1:9443".
+	if scheme, rest, ok := strings.Cut(address, "://"); ok {
+		if scheme != "dns" && scheme != "passthrough" {
+			return false
+		}
+		_, address, ok = strings.Cut(rest, "/")
+		if !ok {
+			return false
+		}
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Name is the name of the remote plugin, as used in CertificateRequestPolicy
+// `spec.plugins`.
+func (r *remote) Name() string {
+	return r.name
+}
+
+// RegisterFlags is a no-op, remote plugins are configured by their own
+// process.
+func (r *remote) RegisterFlags(_ *pflag.FlagSet) {}
+
+// Prepare is a no-op, remote plugins are prepared by their own process.
+func (r *remote) Prepare(_ context.Context, _ logr.Logger, _ manager.Manager) error {
+	return nil
+}
+
+// Evaluate forwards the evaluation of the request to the remote plugin.
+// Policies which do not configure the plugin are not evaluated.
+func (r *remote) Evaluate(ctx context.Context, policy *policyapi.CertificateRequestPolicy, request *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+	plugin, ok := policy.Spec.Plugins[r.name]
+	if !ok {
+		return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
+	}
+
+	policyJSON, err := json.Marshal(policy)
+	if err != nil {
+		return approver.EvaluationResponse{}, err
+	}
+	requestJSON, err := json.Marshal(request)
+	if err != nil {
+		return approver.EvaluationResponse{}, err
+	}
+
+	resp, err := r.client.Evaluate(ctx, &EvaluateRequest{
+		Policy:             policyJSON,
+		CertificateRequest: requestJSON,
+		PluginData:         &PluginData{Values: plugin.Values},
+	})
+	if err != nil {
+		return approver.EvaluationResponse{}, fmt.Errorf("remote plugin %q failed to evaluate request: %w", r.name, err)
+	}
+
+	return approver.EvaluationResponse{
+		Result:      approver.EvaluationResult(!resp.GetDenied()),
+		Message:     resp.GetMessage(),
+		Annotations: resp.GetAnnotations(),
+		Pending:     resp.GetPending(),
+	}, nil
+}
+
+// Validate forwards the validation of the policy to the remote plugin.
+// Policies which do not configure the plugin are allowed.
+func (r *remote) Validate(ctx context.Context, policy *policyapi.CertificateRequestPolicy) (approver.WebhookValidationResponse, error) {
+	plugin, ok := policy.Spec.Plugins[r.name]
+	if !ok {
+		return approver.WebhookValidationResponse{
+			Allowed: true,
+			Errors:  nil,
+		}, nil
+	}
+
+	policyJSON, err := json.Marshal(policy)
+	if err != nil {
+		return approver.WebhookValidationResponse{}, err
+	}
+
+	resp, err := r.client.Validate(ctx, &ValidateRequest{
+		Policy:     policyJSON,
+		PluginData: &PluginData{Values: plugin.Values},
+	})
+	if err != nil {
+		return approver.WebhookValidationResponse{}, fmt.Errorf("remote plugin %q failed to validate policy: %w", r.name, err)
+	}
+
+	return approver.WebhookValidationResponse{
+		Allowed:  resp.GetAllowed(),
+		Errors:   fromFieldErrors(resp.GetErrors()),
+		Warnings: resp.GetWarnings(),
+	}, nil
+}
+
+// Ready forwards the readiness check of the policy to the remote plugin.
+// Policies which do not configure the plugin are ready.
+func (r *remote) Ready(ctx context.Context, policy *policyapi.CertificateRequestPolicy) (approver.ReconcilerReadyResponse, error) {
+	plugin, ok := policy.Spec.Plugins[r.name]
+	if !ok {
+		return approver.ReconcilerReadyResponse{Ready: true}, nil
+	}
+
+	policyJSON, err := json.Marshal(policy)
+	if err != nil {
+		return approver.ReconcilerReadyResponse{}, err
+	}
+
+	resp, err := r.client.Ready(ctx, &ReadyRequest{
+		Policy:     policyJSON,
+		PluginData: &PluginData{Values: plugin.Values},
+	})
+	if err != nil {
+		return approver.ReconcilerReadyResponse{}, fmt.Errorf("remote plugin %q failed to check policy readiness: %w", r.name, err)
+	}
+
+	response := approver.ReconcilerReadyResponse{
+		Ready:  resp.GetReady(),
+		Errors: fromFieldErrors(resp.GetErrors()),
+	}
+	if seconds := resp.GetRequeueAfterSeconds(); seconds > 0 {
+		response.Requeue = true
+		response.RequeueAfter = time.Duration(seconds) * time.Second
+	}
+	return response, nil
+}
+
+// EnqueueChan returns nil, remote plugins can't enqueue policies.
+func (r *remote) EnqueueChan() <-chan string {
+	return nil
+}
+
+// fromFieldErrors converts FieldErrors received from a remote plugin into a
+// field.ErrorList.
+func fromFieldErrors(errs []*FieldError) field.ErrorList {
+	var el field.ErrorList
+	for _, err := range errs {
+		errType := field.ErrorType(err.GetType())
+		if len(errType) == 0 {
+			errType = field.ErrorTypeInvalid
+		}
+		el = append(el, &field.Error{
+			Type:     errType,
+			Field:    err.GetField(),
+			BadValue: err.GetBadValue(),
+			Detail:   err.GetDetail(),
+		})
+	}
+	return el
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/approver/fake"
+	"github.com/cert-manager/approver-policy/pkg/registry"
+)
+
+// serve serves the Approver on an in-process gRPC server, and returns a
+// remote Approver connected to it.
+func serve(t *testing.T, a Approver) approver.Interface {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	RegisterPluginServer(srv, NewServer(a))
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return New("my-plugin", conn)
+}
+
+func Test_Remote(t *testing.T) {
+	var (
+		pluginPolicy = &policyapi.CertificateRequestPolicy{
+			Spec: policyapi.CertificateRequestPolicySpec{
+				Plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+					"my-plugin": {Values: map[string]string{"team": "payments"}},
+				},
+			},
+		}
+		request = gen.CertificateRequest("my-request", gen.SetCertificateRequestNamespace("sandbox"))
+
+		calls int
+	)
+
+	evaluator := fake.NewFakeEvaluator().WithEvaluate(func(_ context.Context, policy *policyapi.CertificateRequestPolicy, cr *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+		calls++
+		assert.Equal(t, "payments", policy.Spec.Plugins["my-plugin"].Values["team"], "expected the policy to be sent to the plugin")
+		assert.Equal(t, "sandbox/my-request", cr.Namespace+"/"+cr.Name, "expected the request to be sent to the plugin")
+		return approver.EvaluationResponse{
+			Result:      approver.ResultDenied,
+			Message:     "team payments may not request certificates",
+			Annotations: map[string]string{"example.com/ticket": "1234"},
+		}, nil
+	})
+	webhook := fake.NewFakeWebhook().WithValidate(func(_ context.Context, _ *policyapi.CertificateRequestPolicy) (approver.WebhookValidationResponse, error) {
+		calls++
+		return approver.WebhookValidationResponse{
+			Allowed: false,
+			Errors: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "plugins", "my-plugin", "values").Key("team"), "payments", "unknown team"),
+				field.Required(field.NewPath("spec", "plugins", "my-plugin", "values").Key("owner"), "must define an owner"),
+			},
+			Warnings: []string{"team is deprecated"},
+		}, nil
+	})
+	reconciler := fake.NewFakeReconciler().WithReady(func(_ context.Context, _ *policyapi.CertificateRequestPolicy) (approver.ReconcilerReadyResponse, error) {
+		calls++
+		return approver.ReconcilerReadyResponse{
+			Ready:  true,
+			Result: ctrl.Result{Requeue: true, RequeueAfter: 1500 * time.Millisecond},
+		}, nil
+	})
+
+	plugin := fake.NewFakeApprover().WithEvaluator(evaluator).WithReconciler(reconciler)
+	plugin.FakeWebhook = webhook
+	r := serve(t, plugin)
+	assert.Equal(t, "my-plugin", r.Name())
+
+	evalResp, err := r.Evaluate(context.TODO(), pluginPolicy, request)
+	require.NoError(t, err)
+	assert.Equal(t, approver.EvaluationResponse{
+		Result:      approver.ResultDenied,
+		Message:     "team payments may not request certificates",
+		Annotations: map[string]string{"example.com/ticket": "1234"},
+	}, evalResp)
+
+	validateResp, err := r.Validate(context.TODO(), pluginPolicy)
+	require.NoError(t, err)
+	assert.Equal(t, approver.WebhookValidationResponse{
+		Allowed: false,
+		Errors: field.ErrorList{
+			field.Invalid(field.NewPath("spec", "plugins", "my-plugin", "values").Key("team"), "payments", "unknown team"),
+			field.Required(field.NewPath("spec", "plugins", "my-plugin", "values").Key("owner"), "must define an owner"),
+		},
+		Warnings: []string{"team is deprecated"},
+	}, validateResp)
+
+	readyResp, err := r.Ready(context.TODO(), pluginPolicy)
+	require.NoError(t, err)
+	assert.Equal(t, approver.ReconcilerReadyResponse{
+		Ready:  true,
+		Result: ctrl.Result{Requeue: true, RequeueAfter: 2 * time.Second},
+	}, readyResp)
+
+	assert.Equal(t, 3, calls)
+
+	// Policies which don't configure the plugin must not be sent to it.
+	evalResp, err = r.Evaluate(context.TODO(), &policyapi.CertificateRequestPolicy{}, request)
+	require.NoError(t, err)
+	assert.Equal(t, approver.ResultNotDenied, evalResp.Result)
+	validateResp, err = r.Validate(context.TODO(), &policyapi.CertificateRequestPolicy{})
+	require.NoError(t, err)
+	assert.True(t, validateResp.Allowed)
+	readyResp, err = r.Ready(context.TODO(), &policyapi.CertificateRequestPolicy{})
+	require.NoError(t, err)
+	assert.True(t, readyResp.Ready)
+	assert.Equal(t, 3, calls, "expected policies without the plugin not to be sent to the plugin")
+}
+
+func Test_Remote_Error(t *testing.T) {
+	evaluator := fake.NewFakeEvaluator().WithEvaluate(func(context.Context, *policyapi.CertificateRequestPolicy, *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+		return approver.EvaluationResponse{}, assert.AnError
+	})
+	r := serve(t, fake.NewFakeApprover().WithEvaluator(evaluator))
+
+	policy := &policyapi.CertificateRequestPolicy{
+		Spec: policyapi.CertificateRequestPolicySpec{
+			Plugins: map[string]policyapi.CertificateRequestPolicyPluginData{"my-plugin": {}},
+		},
+	}
+	_, err := r.Evaluate(context.TODO(), policy, gen.CertificateRequest("my-request"))
+	assert.ErrorContains(t, err, `remote plugin "my-plugin" failed to evaluate request`)
+	assert.ErrorContains(t, err, assert.AnError.Error())
+}
+
+func Test_Register(t *testing.T) {
+	reg := new(registry.Registry)
+	require.NoError(t, Register(reg, "my-plugin", "localhost:9443"))
+	require.Len(t, reg.Approvers(), 1)
+	assert.Equal(t, "my-plugin", reg.Approvers()[0].Name())
+
+	assert.EqualError(t, Register(reg, "my-plugin", "localhost:9444"), "approver already registered with same name: my-plugin")
+}
+
+func Test_RegisterInsecure(t *testing.T) {
+	tests := map[string]struct {
+		address string
+		opts    []grpc.DialOption
+		expErr  bool
+	}{
+		"unix socket is accepted": {
+			address: "unix:///var/run/plugin.sock",
+		},
+		"localhost is accepted": {
+			address: "localhost:9443",
+		},
+		"loopback IPv4 address is accepted": {
+			address: "127.0.0.1:9443",
+		},
+		"loopback IPv6 address is accepted": {
+			address: "[::1]:9443",
+		},
+		"dns target of localhost is accepted": {
+			address: "dns:///localhost:9443",
+		},
+		"remote host is refused": {
+			address: "plugin.example.com:9443",
+			expErr:  true,
+		},
+		"remote IP address is refused": {
+			address: "10.0.0.1:9443",
+			expErr:  true,
+		},
+		"dns target of remote host is refused": {
+			address: "dns:///plugin.example.com:9443",
+			expErr:  true,
+		},
+		"remote host with dial options is accepted": {
+			address: "plugin.example.com:9443",
+			opts:    []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := Register(new(registry.Registry), "my-plugin", test.address, test.opts...)
+			if test.expErr {
+				assert.EqualError(t, err, fmt.Sprintf("remote plugin \"my-plugin\" at %q must be served on a unix socket or loopback address, since the connection is not encrypted", test.address))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+// Approver is implemented by plugins which are served out-of-tree with
+// NewServer. It is the subset of approver.Interface which is served over the
+// wire, so that an in-tree Approver can be served as is.
+type Approver interface {
+	approver.Evaluator
+	approver.Webhook
+	Ready(context.Context, *policyapi.CertificateRequestPolicy) (approver.ReconcilerReadyResponse, error)
+}
+
+var _ PluginServer = &server{}
+
+// server serves an Approver as a Plugin over gRPC.
+type server struct {
+	UnimplementedPluginServer
+
+	approver Approver
+}
+
+// NewServer returns a PluginServer which serves the given Approver. The
+// returned server should be registered to a gRPC server with
+// RegisterPluginServer.
+func NewServer(approver Approver) PluginServer {
+	return &server{approver: approver}
+}
+
+// Evaluate decodes the request and forwards it to the Approver.
+func (s *server) Evaluate(ctx context.Context, req *EvaluateRequest) (*EvaluateResponse, error) {
+	policy, err := decodePolicy(req.GetPolicy())
+	if err != nil {
+		return nil, err
+	}
+
+	var request cmapi.CertificateRequest
+	if err := json.Unmarshal(req.GetCertificateRequest(), &request); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to decode CertificateRequest: %s", err)
+	}
+
+	resp, err := s.approver.Evaluate(ctx, policy, &request)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &EvaluateResponse{
+		Denied:      resp.Result == approver.ResultDenied,
+		Message:     resp.Message,
+		Annotations: resp.Annotations,
+		Pending:     resp.Pending,
+	}, nil
+}
+
+// Validate decodes the policy and forwards it to the Approver.
+func (s *server) Validate(ctx context.Context, req *ValidateRequest) (*ValidateResponse, error) {
+	policy, err := decodePolicy(req.GetPolicy())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.approver.Validate(ctx, policy)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &ValidateResponse{
+		Allowed:  resp.Allowed,
+		Errors:   toFieldErrors(resp.Errors),
+		Warnings: resp.Warnings,
+	}, nil
+}
+
+// Ready decodes the policy and forwards it to the Approver.
+func (s *server) Ready(ctx context.Context, req *ReadyRequest) (*ReadyResponse, error) {
+	policy, err := decodePolicy(req.GetPolicy())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.approver.Ready(ctx, policy)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	readyResp := &ReadyResponse{
+		Ready:  resp.Ready,
+		Errors: toFieldErrors(resp.Errors),
+	}
+	if resp.RequeueAfter > 0 {
+		// Round up so that a requeue is never dropped.
+		readyResp.RequeueAfterSeconds = int64((resp.RequeueAfter + time.Second - 1) / time.Second)
+	}
+	return readyResp, nil
+}
+
+// decodePolicy decodes a JSON encoded CertificateRequestPolicy.
+func decodePolicy(data []byte) (*policyapi.CertificateRequestPolicy, error) {
+	var policy policyapi.CertificateRequestPolicy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to decode CertificateRequestPolicy: %s", err)
+	}
+	return &policy, nil
+}
+
+// toFieldErrors converts a field.ErrorList into FieldErrors to be sent over
+// the wire.
+func toFieldErrors(el field.ErrorList) []*FieldError {
+	var errs []*FieldError
+	for _, err := range el {
+		fieldErr := &FieldError{
+			Type:   string(err.Type),
+			Field:  err.Field,
+			Detail: err.Detail,
+		}
+		if err.BadValue != nil {
+			fieldErr.BadValue = fmt.Sprint(err.BadValue)
+		}
+		errs = append(errs, fieldErr)
+	}
+	return errs
+}
@@ -0,0 +1,32 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approver
+
+import (
+	"context"
+)
+
+// StartupChecker may optionally be implemented by Approvers which must
+// complete initialization, such as syncing caches or connecting to an
+// external service, before they are able to evaluate requests.
+type StartupChecker interface {
+	// StartupCheck returns nil once the Approver is able to evaluate
+	// requests. approver-policy reports as not ready until every
+	// StartupChecker has passed. Once passed, StartupCheck is not called
+	// again.
+	StartupCheck(context.Context) error
+}
@@ -26,6 +26,10 @@ import (
 
 	_ "github.com/cert-manager/approver-policy/pkg/internal/approver/allowed"
 	_ "github.com/cert-manager/approver-policy/pkg/internal/approver/constraints"
+	_ "github.com/cert-manager/approver-policy/pkg/internal/approver/ingresshosts"
+	_ "github.com/cert-manager/approver-policy/pkg/internal/approver/namespacequota"
+	_ "github.com/cert-manager/approver-policy/pkg/internal/approver/reversedns"
+	_ "github.com/cert-manager/approver-policy/pkg/internal/approver/serviceips"
 )
 
 // ExecutePolicyApprover executes the main approver-policy program making use
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package eval evaluates CertificateRequests against CertificateRequestPolicies
+// without a Kubernetes cluster. It is intended for tooling, such as linting
+// policies in CI, that needs to know the decision approver-policy would make
+// for a request.
+package eval
+
+import (
+	"context"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver/manager"
+	internalmanager "github.com/cert-manager/approver-policy/pkg/internal/approver/manager"
+	"github.com/cert-manager/approver-policy/pkg/internal/util"
+	"github.com/cert-manager/approver-policy/pkg/registry"
+
+	// The base approvers are always registered.
+	_ "github.com/cert-manager/approver-policy/pkg/internal/approver/allowed"
+	_ "github.com/cert-manager/approver-policy/pkg/internal/approver/constraints"
+)
+
+// EvaluatePolicies returns the decision of evaluating the given
+// CertificateRequest against the given CertificateRequestPolicies, using every
+// evaluator registered to the shared registry.
+//
+// Unlike a review made by the approver-policy controller, the policies are not
+// filtered by readiness, selector or RBAC. Every given policy is evaluated, so
+// the request is approved if any one of them permits it. Approvers are not
+// prepared, so evaluations which depend on objects in the cluster will
+// return an error. CertificateRequestPolicyProfiles referenced by the policies
+// and the policies named in `spec.inheritFrom` are not resolved, so only the
+// fields set on each policy are evaluated; use EffectivePolicy to resolve
+// them beforehand.
+func EvaluatePolicies(ctx context.Context, policies []policyapi.CertificateRequestPolicy, cr *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
+	if len(policies) == 0 {
+		return manager.ReviewResponse{
+			Result:  manager.ResultUnprocessed,
+			Message: "No CertificateRequestPolicies to evaluate",
+		}, nil
+	}
+
+	return internalmanager.Evaluate(ctx, registry.Shared.Evaluators(), policies, cr)
+}
+
+// EffectivePolicy returns the spec a CertificateRequest is evaluated against
+// for the given policy, with the CertificateRequestPolicies named in
+// `spec.inheritFrom` and the CertificateRequestPolicyProfile referenced by
+// `spec.profileRef` merged in the same way as during a review. Parents and
+// profiles are read from the given reader, which may be any client.Reader,
+// such as a fake client populated from files, when there is no cluster.
+// Once merged, `spec.inheritFrom` and `spec.profileRef` are removed from the
+// returned spec so that it is self-contained and can be serialized for audit.
+func EffectivePolicy(ctx context.Context, reader client.Reader, policy *policyapi.CertificateRequestPolicy) (policyapi.CertificateRequestPolicySpec, error) {
+	resolved, err := util.ResolvePolicy(ctx, reader, policy)
+	if err != nil {
+		return policyapi.CertificateRequestPolicySpec{}, err
+	}
+
+	spec := *resolved.Spec.DeepCopy()
+	spec.InheritFrom = nil
+	spec.ProfileRef = nil
+	return spec, nil
+}
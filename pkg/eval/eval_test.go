@@ -0,0 +1,206 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eval
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/approver/manager"
+)
+
+func Test_EvaluatePolicies(t *testing.T) {
+	csr, _, err := gen.CSR(x509.ECDSA, gen.SetCSRDNSNames("app.example.com"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	request := gen.CertificateRequest("test", gen.SetCertificateRequestNamespace("sandbox"), gen.SetCertificateRequestCSR(csr))
+
+	allowingPolicy := policyapi.CertificateRequestPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "allow-example"},
+		Spec: policyapi.CertificateRequestPolicySpec{
+			Allowed: &policyapi.CertificateRequestPolicyAllowed{
+				DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*.example.com"}},
+			},
+		},
+	}
+	denyingPolicy := policyapi.CertificateRequestPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "deny-example"},
+		Spec: policyapi.CertificateRequestPolicySpec{
+			Allowed: &policyapi.CertificateRequestPolicyAllowed{
+				DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*.example.org"}},
+			},
+		},
+	}
+	clusterPolicy := policyapi.CertificateRequestPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Spec: policyapi.CertificateRequestPolicySpec{
+			Allowed: &policyapi.CertificateRequestPolicyAllowed{
+				DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*.example.com"}},
+			},
+			Constraints: &policyapi.CertificateRequestPolicyConstraints{
+				PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+					MatchCertificate: ptr.To(true),
+				},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		policies    []policyapi.CertificateRequestPolicy
+		request     *cmapi.CertificateRequest
+		expResponse manager.ReviewResponse
+		expErr      bool
+	}{
+		"if no policies are given, return unprocessed": {
+			policies: nil,
+			request:  request,
+			expResponse: manager.ReviewResponse{
+				Result:  manager.ResultUnprocessed,
+				Message: "No CertificateRequestPolicies to evaluate",
+			},
+		},
+		"if a policy permits the request, return approved": {
+			policies: []policyapi.CertificateRequestPolicy{denyingPolicy, allowingPolicy},
+			request:  request,
+			expResponse: manager.ReviewResponse{
+				Result:   manager.ResultApproved,
+				Message:  `Approved by CertificateRequestPolicy: "allow-example"`,
+				Policies: []string{"allow-example"},
+			},
+		},
+		"if no policy permits the request, return denied": {
+			policies: []policyapi.CertificateRequestPolicy{denyingPolicy},
+			request:  request,
+			expResponse: manager.ReviewResponse{
+				Result:   manager.ResultDenied,
+				Message:  `No policy approved this request: [deny-example: spec.allowed.dnsNames.values: Invalid value: []string{"app.example.com"}: *.example.org]`,
+				Policies: []string{"deny-example"},
+				Denials: []manager.Denial{{
+					Policy: "deny-example",
+					Errors: []string{`spec.allowed.dnsNames.values: Invalid value: []string{"app.example.com"}: *.example.org`},
+					Reasons: []approver.DenialReason{
+						{Reason: approver.ReasonDNSNameNotAllowed, Field: "spec.allowed.dnsNames.values"},
+					},
+				}},
+			},
+		},
+		"if a policy needs access to the cluster, return error": {
+			policies: []policyapi.CertificateRequestPolicy{clusterPolicy},
+			request: gen.CertificateRequestFrom(request, func(cr *cmapi.CertificateRequest) {
+				cr.OwnerReferences = []metav1.OwnerReference{{APIVersion: "cert-manager.io/v1", Kind: "Certificate", Name: "test", Controller: ptr.To(true)}}
+			}),
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			response, err := EvaluatePolicies(context.TODO(), test.policies, test.request)
+			assert.Equal(t, test.expErr, err != nil, "%v", err)
+			assert.Equal(t, test.expResponse, response)
+		})
+	}
+}
+
+func Test_EffectivePolicy(t *testing.T) {
+	profileDNSNames := &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*.example.com"}}
+	policyDNSNames := &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*.internal.example.com"}}
+	parentIPAddresses := &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"10.0.0.0/8"}}
+
+	profile := &policyapi.CertificateRequestPolicyProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "defaults"},
+		Spec: policyapi.CertificateRequestPolicyProfileSpec{
+			Allowed:     &policyapi.CertificateRequestPolicyAllowed{DNSNames: profileDNSNames, IsCA: ptr.To(false)},
+			Constraints: &policyapi.CertificateRequestPolicyConstraints{MaxDuration: &metav1.Duration{Duration: 3600}},
+		},
+	}
+	parent := &policyapi.CertificateRequestPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "parent"},
+		Spec: policyapi.CertificateRequestPolicySpec{
+			Allowed: &policyapi.CertificateRequestPolicyAllowed{DNSNames: profileDNSNames, IPAddresses: parentIPAddresses},
+		},
+	}
+
+	tests := map[string]struct {
+		policy policyapi.CertificateRequestPolicySpec
+		exp    policyapi.CertificateRequestPolicySpec
+		expErr bool
+	}{
+		"if the policy references no profile or parents, return the policy spec unchanged": {
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{DNSNames: policyDNSNames},
+			},
+			exp: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{DNSNames: policyDNSNames},
+			},
+		},
+		"if the policy references a profile, return the merged spec without the profile reference": {
+			policy: policyapi.CertificateRequestPolicySpec{
+				ProfileRef: &policyapi.CertificateRequestPolicyProfileReference{Name: "defaults"},
+				Allowed:    &policyapi.CertificateRequestPolicyAllowed{DNSNames: policyDNSNames},
+			},
+			exp: policyapi.CertificateRequestPolicySpec{
+				Allowed:     &policyapi.CertificateRequestPolicyAllowed{DNSNames: policyDNSNames, IsCA: ptr.To(false)},
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{MaxDuration: &metav1.Duration{Duration: 3600}},
+			},
+		},
+		"if the policy inherits from a parent, return the merged spec without the parent reference": {
+			policy: policyapi.CertificateRequestPolicySpec{
+				InheritFrom: []string{"parent"},
+				Allowed:     &policyapi.CertificateRequestPolicyAllowed{DNSNames: policyDNSNames},
+			},
+			exp: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{DNSNames: policyDNSNames, IPAddresses: parentIPAddresses},
+			},
+		},
+		"if the policy inherits from a parent which doesn't exist, return an error": {
+			policy: policyapi.CertificateRequestPolicySpec{
+				InheritFrom: []string{"missing"},
+			},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			reader := fakeclient.NewClientBuilder().
+				WithScheme(policyapi.GlobalScheme).
+				WithObjects(profile, parent).
+				Build()
+
+			policy := &policyapi.CertificateRequestPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-policy"},
+				Spec:       test.policy,
+			}
+			spec, err := EffectivePolicy(context.TODO(), reader, policy)
+			assert.Equal(t, test.expErr, err != nil, "%v", err)
+			assert.Equal(t, test.exp, spec)
+			assert.Equal(t, test.policy, policy.Spec, "policy should not be modified")
+		})
+	}
+}
@@ -18,14 +18,22 @@ package allowed
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
 	"github.com/cert-manager/approver-policy/pkg/approver"
 	"github.com/cert-manager/approver-policy/pkg/internal/approver/validation"
+	"github.com/cert-manager/approver-policy/pkg/internal/util"
 	"github.com/cert-manager/approver-policy/pkg/registry"
 )
 
@@ -34,10 +42,16 @@ func init() {
 	registry.Shared.Store(Approver())
 }
 
+var _ approver.DependencyReconciler = &allowed{}
+
 // Approver returns an instance on the allowed approver.
 func Approver() approver.Interface {
-	return allowed{
-		validators: validation.NewCache(),
+	return &allowed{
+		validators:    validation.NewCache(),
+		messages:      validation.NewMessageCache(),
+		valuesFrom:    util.NewConfigMapCache(parseValues),
+		regexps:       util.NewRegexpCache(util.CompileRegexp),
+		foldedRegexps: util.NewRegexpCache(util.CompileRegexpIgnoreCase),
 	}
 }
 
@@ -49,28 +63,177 @@ func Approver() approver.Interface {
 // approver-policy builds.
 type allowed struct {
 	validators validation.Cache
+	messages   validation.MessageCache
+
+	// reader is used for fetching ConfigMaps referenced by `valuesFrom`.
+	// Reads are made directly against the API server so that approver-policy
+	// is not required to cache all ConfigMaps in the cluster.
+	reader client.Reader
+
+	// valuesFrom caches the values of ConfigMaps referenced by `valuesFrom`,
+	// so that they are not read on every evaluation. They are re-read when
+	// the readiness of a policy referencing them is re-synced.
+	valuesFrom *util.ConfigMapCache[map[string][]string]
+
+	// regexps caches compiled `values` using the `Regexp` value type, so that
+	// they are not recompiled on every evaluation.
+	regexps *util.RegexpCache
+
+	// foldedRegexps caches compiled `values` using the `Regexp` value type
+	// which match case-insensitively, i.e. for DNS names.
+	foldedRegexps *util.RegexpCache
 }
 
 // Name of Approver is "allowed"
-func (a allowed) Name() string {
+func (a *allowed) Name() string {
 	return "allowed"
 }
 
+// EvaluationStage of allowed is builtin, so it is run before plugins. CEL
+// validations are only run once the cheaper value checks have passed.
+func (a *allowed) EvaluationStage() approver.EvaluationStage {
+	return approver.EvaluationStageBuiltin
+}
+
 // RegisterFlags is a no-op, allowed doesn't need any flags.
-func (a allowed) RegisterFlags(_ *pflag.FlagSet) {}
+func (a *allowed) RegisterFlags(_ *pflag.FlagSet) {}
 
-// Prepare is a no-op, allowed doesn't need to prepare anything.
-func (a allowed) Prepare(_ context.Context, _ logr.Logger, _ manager.Manager) error {
+// Prepare sets up the reader used to fetch ConfigMaps referenced by policies.
+func (a *allowed) Prepare(_ context.Context, _ logr.Logger, mgr manager.Manager) error {
+	a.reader = mgr.GetAPIReader()
 	return nil
 }
 
-// Ready always returns ready, allowed doesn't have any dependencies to
-// block readiness.
-func (a allowed) Ready(_ context.Context, _ *policyapi.CertificateRequestPolicy) (approver.ReconcilerReadyResponse, error) {
-	return approver.ReconcilerReadyResponse{Ready: true}, nil
+// Ready returns not ready if a ConfigMap referenced by `valuesFrom` doesn't
+// contain the referenced key. ConfigMaps which don't exist are reported by
+// Dependencies instead.
+func (a *allowed) Ready(ctx context.Context, policy *policyapi.CertificateRequestPolicy) (approver.ReconcilerReadyResponse, error) {
+	var (
+		el        field.ErrorList
+		refreshed = make(map[client.ObjectKey]bool)
+	)
+	for _, stringSlice := range stringSlices(policy.Spec.Allowed, field.NewPath("spec", "allowed")) {
+		ref := stringSlice.slice.ValuesFrom
+		if ref == nil {
+			continue
+		}
+
+		// Each referenced ConfigMap is re-read once, so that evaluations
+		// observe changes to its values once the policy has been re-synced.
+		key := client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}
+		if _, err := a.loadValues(ctx, ref, !refreshed[key]); apierrors.IsNotFound(err) {
+			continue
+		} else if errors.Is(err, errKeyNotFound) {
+			el = append(el, field.NotFound(stringSlice.path.Child("valuesFrom", "key"), ref.Key))
+		} else if err != nil {
+			return approver.ReconcilerReadyResponse{}, err
+		}
+		refreshed[key] = true
+	}
+
+	return approver.ReconcilerReadyResponse{Ready: len(el) == 0, Errors: el}, nil
+}
+
+// Dependencies returns the ConfigMaps referenced by `valuesFrom` of the
+// policy's allowed fields. The policy is not ready while any of them do not
+// exist.
+func (a *allowed) Dependencies(policy *policyapi.CertificateRequestPolicy) []approver.Dependency {
+	var dependencies []approver.Dependency
+	for _, stringSlice := range stringSlices(policy.Spec.Allowed, field.NewPath("spec", "allowed")) {
+		if ref := stringSlice.slice.ValuesFrom; ref != nil {
+			dependencies = append(dependencies, approver.Dependency{
+				Kind:      approver.DependencyKindConfigMap,
+				Namespace: ref.Namespace,
+				Name:      ref.Name,
+				FieldPath: stringSlice.path.Child("valuesFrom"),
+			})
+		}
+	}
+	return dependencies
 }
 
 // allowed never needs to manually enqueue policies.
-func (a allowed) EnqueueChan() <-chan string {
+func (a *allowed) EnqueueChan() <-chan string {
 	return nil
 }
+
+// errKeyNotFound is returned when a ConfigMap referenced by `valuesFrom`
+// doesn't contain the referenced key.
+var errKeyNotFound = errors.New("key not found in ConfigMap")
+
+// loadValues returns the values stored, one per line, in the key of the
+// referenced ConfigMap. The ConfigMap is read from the cache unless refresh
+// is true, or it hasn't been read before.
+func (a *allowed) loadValues(ctx context.Context, ref *policyapi.CertificateRequestPolicyConfigMapKeyReference, refresh bool) ([]string, error) {
+	if a.reader == nil {
+		return nil, fmt.Errorf("%s %w", a.Name(), approver.ErrNotPrepared)
+	}
+
+	var (
+		key    = client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}
+		values map[string][]string
+		err    error
+	)
+	if refresh {
+		values, err = a.valuesFrom.Refresh(ctx, a.reader, key)
+	} else {
+		values, err = a.valuesFrom.Get(ctx, a.reader, key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allowed values ConfigMap %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+
+	keyValues, ok := values[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s/%s %q", errKeyNotFound, ref.Namespace, ref.Name, ref.Key)
+	}
+	return keyValues, nil
+}
+
+// parseValues returns the values stored, one per line, in each key of the
+// given ConfigMap.
+func parseValues(cm *corev1.ConfigMap) map[string][]string {
+	values := make(map[string][]string, len(cm.Data))
+	for key, data := range cm.Data {
+		var keyValues []string
+		for _, line := range strings.Split(data, "\n") {
+			if value := strings.TrimSpace(line); len(value) > 0 {
+				keyValues = append(keyValues, value)
+			}
+		}
+		values[key] = keyValues
+	}
+	return values
+}
+
+// resolveValuesFrom returns a copy of the given allowed fields with the values
+// of every `valuesFrom` merged into `values`. The given allowed fields are
+// returned as is if none of them reference a ConfigMap.
+func (a *allowed) resolveValuesFrom(ctx context.Context, allowed *policyapi.CertificateRequestPolicyAllowed) (*policyapi.CertificateRequestPolicyAllowed, error) {
+	resolved := allowed.DeepCopy()
+	var found bool
+	for _, stringSlice := range stringSlices(resolved, field.NewPath("spec", "allowed")) {
+		ref := stringSlice.slice.ValuesFrom
+		if ref == nil {
+			continue
+		}
+		found = true
+
+		values, err := a.loadValues(ctx, ref, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", stringSlice.path.Child("valuesFrom"), err)
+		}
+
+		var merged []string
+		if stringSlice.slice.Values != nil {
+			merged = append(merged, *stringSlice.slice.Values...)
+		}
+		merged = append(merged, values...)
+		stringSlice.slice.Values = &merged
+	}
+
+	if !found {
+		return allowed, nil
+	}
+	return resolved, nil
+}
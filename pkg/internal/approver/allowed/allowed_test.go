@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package allowed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+func Test_Dependencies(t *testing.T) {
+	ref := &policyapi.CertificateRequestPolicyConfigMapKeyReference{Name: "allowed-values", Namespace: "cert-manager", Key: "dnsNames"}
+
+	tests := map[string]struct {
+		policy          policyapi.CertificateRequestPolicySpec
+		expDependencies []approver.Dependency
+	}{
+		"if policy contains no allowed, return no dependencies": {
+			policy:          policyapi.CertificateRequestPolicySpec{},
+			expDependencies: nil,
+		},
+		"if policy contains allowed fields without valuesFrom, return no dependencies": {
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*.example.com"}},
+				},
+			},
+			expDependencies: nil,
+		},
+		"if policy references ConfigMaps with valuesFrom, return the ConfigMaps as dependencies": {
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{ValuesFrom: ref},
+					Subject: &policyapi.CertificateRequestPolicyAllowedX509Subject{
+						Organizations: &policyapi.CertificateRequestPolicyAllowedStringSlice{ValuesFrom: ref},
+					},
+				},
+			},
+			expDependencies: []approver.Dependency{
+				{
+					Kind:      approver.DependencyKindConfigMap,
+					Namespace: "cert-manager",
+					Name:      "allowed-values",
+					FieldPath: field.NewPath("spec", "allowed", "dnsNames", "valuesFrom"),
+				},
+				{
+					Kind:      approver.DependencyKindConfigMap,
+					Namespace: "cert-manager",
+					Name:      "allowed-values",
+					FieldPath: field.NewPath("spec", "allowed", "subject", "organizations", "valuesFrom"),
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dependencies := new(allowed).Dependencies(&policyapi.CertificateRequestPolicy{Spec: test.policy})
+			assert.Equal(t, test.expDependencies, dependencies)
+		})
+	}
+}
+
+func Test_Ready(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "allowed-values", Namespace: "cert-manager"},
+		Data:       map[string]string{"dnsNames": "*.example.com\n"},
+	}
+
+	tests := map[string]struct {
+		existingObjects []runtime.Object
+		ref             *policyapi.CertificateRequestPolicyConfigMapKeyReference
+		expResponse     approver.ReconcilerReadyResponse
+	}{
+		"if policy contains no valuesFrom, return ready": {
+			ref:         nil,
+			expResponse: approver.ReconcilerReadyResponse{Ready: true},
+		},
+		"if referenced ConfigMap contains the key, return ready": {
+			existingObjects: []runtime.Object{configMap},
+			ref:             &policyapi.CertificateRequestPolicyConfigMapKeyReference{Name: "allowed-values", Namespace: "cert-manager", Key: "dnsNames"},
+			expResponse:     approver.ReconcilerReadyResponse{Ready: true},
+		},
+		"if referenced ConfigMap doesn't exist, return ready as the missing dependency is reported separately": {
+			ref:         &policyapi.CertificateRequestPolicyConfigMapKeyReference{Name: "allowed-values", Namespace: "cert-manager", Key: "dnsNames"},
+			expResponse: approver.ReconcilerReadyResponse{Ready: true},
+		},
+		"if referenced ConfigMap doesn't contain the key, return not ready": {
+			existingObjects: []runtime.Object{configMap},
+			ref:             &policyapi.CertificateRequestPolicyConfigMapKeyReference{Name: "allowed-values", Namespace: "cert-manager", Key: "uris"},
+			expResponse: approver.ReconcilerReadyResponse{
+				Ready: false,
+				Errors: field.ErrorList{
+					field.NotFound(field.NewPath("spec.allowed.dnsNames.valuesFrom.key"), "uris"),
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := Approver().(*allowed)
+			a.reader = fakeclient.NewClientBuilder().
+				WithScheme(policyapi.GlobalScheme).
+				WithRuntimeObjects(test.existingObjects...).
+				Build()
+			policy := &policyapi.CertificateRequestPolicy{Spec: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{ValuesFrom: test.ref},
+				},
+			}}
+			response, err := a.Ready(context.TODO(), policy)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expResponse, response)
+		})
+	}
+}
@@ -21,6 +21,9 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"fmt"
+	"maps"
+	"net"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -31,6 +34,7 @@ import (
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
 	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/internal/approver/validation"
 	"github.com/cert-manager/approver-policy/pkg/internal/util"
 )
 
@@ -40,7 +44,7 @@ import (
 // If the request is denied by the allowed attributes an explanation is
 // returned.
 // An error signals that the policy couldn't be evaluated to completion.
-func (a allowed) Evaluate(_ context.Context, policy *policyapi.CertificateRequestPolicy, request *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+func (a *allowed) Evaluate(ctx context.Context, policy *policyapi.CertificateRequestPolicy, request *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
 	var (
 		// el will contain a list of policy violations for fields, if there are
 		// items in the list, then the request does not meet the allowed
@@ -54,17 +58,31 @@ func (a allowed) Evaluate(_ context.Context, policy *policyapi.CertificateReques
 		allowed = new(policyapi.CertificateRequestPolicyAllowed)
 	}
 
-	csr, err := utilpki.DecodeX509CertificateRequestBytes(request.Spec.Request)
+	// Values loaded from ConfigMaps are merged into the inline values, so
+	// that they are matched in the same way.
+	allowed, err := a.resolveValuesFrom(ctx, allowed)
 	if err != nil {
 		return approver.EvaluationResponse{}, err
 	}
 
+	// A request with a CSR that cannot be parsed will never be valid, so deny
+	// it rather than erroring and retrying forever.
+	csr, err := utilpki.DecodeX509CertificateRequestBytes(request.Spec.Request)
+	if err != nil {
+		return approver.EvaluationResponse{
+			Result:  approver.ResultDenied,
+			Message: fmt.Sprintf("could not parse CSR: %s", err),
+			Reasons: []approver.DenialReason{{Reason: approver.ReasonInvalidCSR}},
+		}, nil
+	}
+
 	evaluate := evaluator{
-		a:       a,
-		request: request,
-		csr:     csr,
-		allowed: allowed,
-		fldPath: fldPath,
+		a:           a,
+		request:     request,
+		csr:         csr,
+		allowed:     allowed,
+		fldPath:     fldPath,
+		validations: &validations{a: a, request: request},
 	}
 	evaluateSubject := evaluate.Subject()
 
@@ -84,6 +102,7 @@ func (a allowed) Evaluate(_ context.Context, policy *policyapi.CertificateReques
 		evaluateSubject.StreetAddress,
 		evaluateSubject.PostalCode,
 		evaluateSubject.SerialNumber,
+		evaluate.Annotations,
 	}
 	for _, fn := range evaluateFns {
 		if e := fn(); e != nil {
@@ -91,49 +110,224 @@ func (a allowed) Evaluate(_ context.Context, policy *policyapi.CertificateReques
 		}
 	}
 
+	// CEL validations are comparatively expensive, so are only run if the
+	// request hasn't already been denied by the value checks.
+	if len(el) == 0 {
+		el = evaluate.validations.run()
+	}
+
 	// If there are errors, then return not approved and the aggregated errors
 	if len(el) > 0 {
-		return approver.EvaluationResponse{Result: approver.ResultDenied, Message: el.ToAggregate().Error()}, nil
+		return approver.EvaluationResponse{Result: approver.ResultDenied, Message: el.ToAggregate().Error(), Reasons: denialReasons(el)}, nil
 	}
 
 	// If no evaluation errors resulting from this policy, return not denied
 	return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
 }
 
+// fieldReasons are the reasons given for violations of each allowed field,
+// including its children.
+var fieldReasons = map[string]approver.Reason{
+	"spec.allowed.commonName":     approver.ReasonCommonNameNotAllowed,
+	"spec.allowed.dnsNames":       approver.ReasonDNSNameNotAllowed,
+	"spec.allowed.ipAddresses":    approver.ReasonIPAddressNotAllowed,
+	"spec.allowed.uris":           approver.ReasonURINotAllowed,
+	"spec.allowed.emailAddresses": approver.ReasonEmailAddressNotAllowed,
+	"spec.allowed.isCA":           approver.ReasonIsCANotAllowed,
+	"spec.allowed.usages":         approver.ReasonUsageNotAllowed,
+	"spec.allowed.subject":        approver.ReasonSubjectNotAllowed,
+	"spec.allowed.annotations":    approver.ReasonAnnotationNotAllowed,
+}
+
+// denialReasons returns the reason for each of the given violations.
+// Attributes which are required but missing from the request are given the
+// same reason regardless of the attribute.
+func denialReasons(el field.ErrorList) []approver.DenialReason {
+	reasons := approver.FieldReasons(el, fieldReasons, approver.ReasonDenied)
+	for i, err := range el {
+		if err.Type == field.ErrorTypeRequired {
+			reasons[i].Reason = approver.ReasonRequiredAttributeMissing
+		}
+	}
+	return reasons
+}
+
 type evaluator struct {
-	a       allowed
+	a           *allowed
+	request     *cmapi.CertificateRequest
+	csr         *x509.CertificateRequest
+	allowed     *policyapi.CertificateRequestPolicyAllowed
+	fldPath     *field.Path
+	validations *validations
+}
+
+// validations collects the CEL validations of the request's attributes, so
+// that they are only compiled and run once every attribute has passed the
+// cheaper value checks.
+type validations struct {
+	a       *allowed
 	request *cmapi.CertificateRequest
-	csr     *x509.CertificateRequest
-	allowed *policyapi.CertificateRequestPolicyAllowed
-	fldPath *field.Path
+	fns     []func(cr *validation.CertificateRequest) field.ErrorList
+}
+
+// add defers running the given validations against the value.
+func (v *validations) add(rules []policyapi.ValidationRule, operator *policyapi.CertificateRequestPolicyValidationsOperator, s string, fldPath *field.Path) {
+	v.fns = append(v.fns, func(cr *validation.CertificateRequest) field.ErrorList {
+		return v.a.runValidations(cr, rules, operator, s, fldPath)
+	})
+}
+
+// run runs every deferred validation, in the order they were added. The `cr`
+// variable is built once, and shared by every validation.
+func (v *validations) run() field.ErrorList {
+	if len(v.fns) == 0 {
+		return nil
+	}
+
+	cr := validation.NewCELRequest(*v.request)
+	var el field.ErrorList
+	for _, fn := range v.fns {
+		el = append(el, fn(cr)...)
+	}
+	return el
 }
 
 func (e evaluator) CommonName() field.ErrorList {
-	return e.a.evaluateString(e.request, e.csr.Subject.CommonName, e.allowed.CommonName, e.fldPath.Child("commonName"))
+	return e.a.evaluateString(e.validations, e.csr.Subject.CommonName, e.allowed.CommonName, e.fldPath.Child("commonName"))
 }
 
+// DNSNames are case-insensitive (RFC 4343), so are lowercased before being
+// matched unless the policy requires case-sensitive names. Regexp values can't
+// be lowercased, so are compiled to match case-insensitively instead.
 func (e evaluator) DNSNames() field.ErrorList {
-	return e.a.evaluateSlice(e.request, e.csr.DNSNames, e.allowed.DNSNames, e.fldPath.Child("dnsNames"))
+	dnsNames, crp, regexps := e.csr.DNSNames, e.allowed.DNSNames, e.a.regexps
+	if !ptr.Deref(e.allowed.CaseSensitiveNames, false) {
+		dnsNames, crp = normalizeSlice(dnsNames, crp, strings.ToLower)
+		regexps = e.a.foldedRegexps
+	}
+	contains := util.WildcardContains
+	if crp != nil && isDNSWildcard(crp.ValueType) {
+		contains = util.DNSWildcardContains
+	}
+	return e.a.evaluateSliceMatching(e.validations, dnsNames, crp, e.fldPath.Child("dnsNames"), contains, regexps)
 }
 
+// IPAddresses may additionally be allowed by values which are CIDR ranges,
+// e.g. `10.0.0.0/8`, which allow any IP address in that range.
 func (e evaluator) IPAddresses() field.ErrorList {
 	var ips []string
 	for _, ip := range e.csr.IPAddresses {
 		ips = append(ips, ip.String())
 	}
-	return e.a.evaluateSlice(e.request, ips, e.allowed.IPAddresses, e.fldPath.Child("ipAddresses"))
+	return e.a.evaluateSliceMatching(e.validations, ips, e.allowed.IPAddresses, e.fldPath.Child("ipAddresses"), ipContains, e.a.regexps)
 }
 
+// ipContains returns true if the given IP address matches a wildcard pattern,
+// or is contained in a pattern which is a CIDR range.
+func ipContains(patterns []string, ip string) bool {
+	if util.WildcardContains(patterns, ip) {
+		return true
+	}
+
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, pattern := range patterns {
+		if _, network, err := net.ParseCIDR(pattern); err == nil && network.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// URIs may be restricted to SPIFFE IDs of the allowed trust domains, in which
+// case Values and Validations are only evaluated if they are set.
 func (e evaluator) URIs() field.ErrorList {
 	var uris []string
 	for _, uri := range e.csr.URIs {
 		uris = append(uris, uri.String())
 	}
-	return e.a.evaluateSlice(e.request, uris, e.allowed.URIs, e.fldPath.Child("uris"))
+
+	crp, fldPath := e.allowed.URIs, e.fldPath.Child("uris")
+	if crp == nil || crp.SPIFFETrustDomains == nil {
+		return e.a.evaluateSlice(e.validations, uris, crp, fldPath)
+	}
+
+	el := evaluateSPIFFETrustDomains(uris, *crp.SPIFFETrustDomains, fldPath.Child("spiffeTrustDomains"))
+	if len(uris) == 0 || crp.Values != nil || len(crp.Validations) > 0 {
+		el = append(el, e.a.evaluateSlice(e.validations, uris, crp, fldPath)...)
+	}
+	return el
+}
+
+// evaluateSPIFFETrustDomains returns an error for every URI which is not a
+// valid SPIFFE ID, or whose trust domain is not one of the given trust domains.
+func evaluateSPIFFETrustDomains(uris, trustDomains []string, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+	for _, uri := range uris {
+		trustDomain, err := util.SPIFFETrustDomain(uri)
+		if err != nil {
+			el = append(el, field.Invalid(fldPath, uri, fmt.Sprintf("not a valid SPIFFE ID: %s", err)))
+			continue
+		}
+		if !slices.Contains(trustDomains, trustDomain) {
+			el = append(el, field.Invalid(fldPath, uri, strings.Join(trustDomains, ", ")))
+		}
+	}
+	return el
 }
 
+// EmailAddresses have a case-insensitive domain, so the domain is lowercased
+// before being matched unless the policy requires case-sensitive names. The
+// local-part is left as requested. Email addresses may be restricted to the
+// allowed domains, in which case Values and Validations are only evaluated if
+// they are set.
 func (e evaluator) EmailAddresses() field.ErrorList {
-	return e.a.evaluateSlice(e.request, e.csr.EmailAddresses, e.allowed.EmailAddresses, e.fldPath.Child("emailAddresses"))
+	emails, crp := e.csr.EmailAddresses, e.allowed.EmailAddresses
+	caseSensitive := ptr.Deref(e.allowed.CaseSensitiveNames, false)
+	if !caseSensitive {
+		emails, crp = normalizeSlice(emails, crp, lowerEmailDomain)
+	}
+
+	fldPath := e.fldPath.Child("emailAddresses")
+	if crp == nil || crp.AllowedDomains == nil {
+		return e.a.evaluateSlice(e.validations, emails, crp, fldPath)
+	}
+
+	el := evaluateEmailDomains(emails, *crp.AllowedDomains, caseSensitive, fldPath.Child("allowedDomains"))
+	if len(emails) == 0 || crp.Values != nil || len(crp.Validations) > 0 {
+		el = append(el, e.a.evaluateSlice(e.validations, emails, crp, fldPath)...)
+	}
+	return el
+}
+
+// evaluateEmailDomains returns an error for every email address whose domain
+// is not one of the given domains. Domains starting with `*.` match any
+// subdomain.
+func evaluateEmailDomains(emails, domains []string, caseSensitive bool, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+	for _, email := range emails {
+		i := strings.LastIndex(email, "@")
+		if i < 0 {
+			el = append(el, field.Invalid(fldPath, email, "not a valid email address: missing '@'"))
+			continue
+		}
+
+		domain := email[i+1:]
+		if !slices.ContainsFunc(domains, func(allowed string) bool {
+			if !caseSensitive {
+				allowed = strings.ToLower(allowed)
+			}
+			if suffix, ok := strings.CutPrefix(allowed, "*"); ok {
+				return len(domain) > len(suffix) && strings.HasSuffix(domain, suffix)
+			}
+			return domain == allowed
+		}) {
+			el = append(el, field.Invalid(fldPath, email, strings.Join(domains, ", ")))
+		}
+	}
+	return el
 }
 
 func (e evaluator) IsCA() field.ErrorList {
@@ -143,18 +337,20 @@ func (e evaluator) IsCA() field.ErrorList {
 func (e evaluator) Usages() field.ErrorList {
 	var el field.ErrorList
 	if len(e.request.Spec.Usages) > 0 {
-		var requestUsages []string
+		var requestUsages, canonicalRequestUsages []string
 		for _, usage := range e.request.Spec.Usages {
 			requestUsages = append(requestUsages, string(usage))
+			canonicalRequestUsages = append(canonicalRequestUsages, util.CanonicalKeyUsage(usage))
 		}
 		if e.allowed.Usages == nil {
 			el = append(el, field.Invalid(e.fldPath.Child("usages"), requestUsages, "nil"))
 		} else {
-			var policyUsages []string
+			var policyUsages, canonicalPolicyUsages []string
 			for _, usage := range *e.allowed.Usages {
 				policyUsages = append(policyUsages, string(usage))
+				canonicalPolicyUsages = append(canonicalPolicyUsages, util.CanonicalKeyUsage(usage))
 			}
-			if !util.WildcardSubset(policyUsages, requestUsages) {
+			if !util.WildcardSubset(canonicalPolicyUsages, canonicalRequestUsages) {
 				el = append(el, field.Invalid(e.fldPath.Child("usages"), requestUsages, strings.Join(policyUsages, ", ")))
 			}
 		}
@@ -162,61 +358,70 @@ func (e evaluator) Usages() field.ErrorList {
 	return el
 }
 
+func (e evaluator) Annotations() field.ErrorList {
+	var el field.ErrorList
+	for _, key := range slices.Sorted(maps.Keys(e.allowed.Annotations)) {
+		crp := e.allowed.Annotations[key]
+		el = append(el, e.a.evaluateString(e.validations, e.request.Annotations[key], &crp, e.fldPath.Child("annotations").Key(key))...)
+	}
+	return el
+}
+
 func (e evaluator) Subject() subjectEvaluator {
 	allowed := e.allowed.Subject
 	if allowed == nil {
 		allowed = new(policyapi.CertificateRequestPolicyAllowedX509Subject)
 	}
 	return subjectEvaluator{
-		a:       e.a,
-		request: e.request,
-		sub:     e.csr.Subject,
-		allowed: allowed,
-		fldPath: e.fldPath.Child("subject"),
+		a:           e.a,
+		sub:         e.csr.Subject,
+		allowed:     allowed,
+		fldPath:     e.fldPath.Child("subject"),
+		validations: e.validations,
 	}
 }
 
 type subjectEvaluator struct {
-	a       allowed
-	request *cmapi.CertificateRequest
-	sub     pkix.Name
-	allowed *policyapi.CertificateRequestPolicyAllowedX509Subject
-	fldPath *field.Path
+	a           *allowed
+	sub         pkix.Name
+	allowed     *policyapi.CertificateRequestPolicyAllowedX509Subject
+	fldPath     *field.Path
+	validations *validations
 }
 
 func (e subjectEvaluator) Organization() field.ErrorList {
-	return e.a.evaluateSlice(e.request, e.sub.Organization, e.allowed.Organizations, e.fldPath.Child("organizations"))
+	return e.a.evaluateSlice(e.validations, e.sub.Organization, e.allowed.Organizations, e.fldPath.Child("organizations"))
 }
 
 func (e subjectEvaluator) Country() field.ErrorList {
-	return e.a.evaluateSlice(e.request, e.sub.Country, e.allowed.Countries, e.fldPath.Child("countries"))
+	return e.a.evaluateSlice(e.validations, e.sub.Country, e.allowed.Countries, e.fldPath.Child("countries"))
 }
 
 func (e subjectEvaluator) OrganizationalUnit() field.ErrorList {
-	return e.a.evaluateSlice(e.request, e.sub.OrganizationalUnit, e.allowed.OrganizationalUnits, e.fldPath.Child("organizationalUnits"))
+	return e.a.evaluateSlice(e.validations, e.sub.OrganizationalUnit, e.allowed.OrganizationalUnits, e.fldPath.Child("organizationalUnits"))
 }
 
 func (e subjectEvaluator) Locality() field.ErrorList {
-	return e.a.evaluateSlice(e.request, e.sub.Locality, e.allowed.Localities, e.fldPath.Child("localities"))
+	return e.a.evaluateSlice(e.validations, e.sub.Locality, e.allowed.Localities, e.fldPath.Child("localities"))
 }
 
 func (e subjectEvaluator) Province() field.ErrorList {
-	return e.a.evaluateSlice(e.request, e.sub.Province, e.allowed.Provinces, e.fldPath.Child("provinces"))
+	return e.a.evaluateSlice(e.validations, e.sub.Province, e.allowed.Provinces, e.fldPath.Child("provinces"))
 }
 
 func (e subjectEvaluator) StreetAddress() field.ErrorList {
-	return e.a.evaluateSlice(e.request, e.sub.StreetAddress, e.allowed.StreetAddresses, e.fldPath.Child("streetAddresses"))
+	return e.a.evaluateSlice(e.validations, e.sub.StreetAddress, e.allowed.StreetAddresses, e.fldPath.Child("streetAddresses"))
 }
 
 func (e subjectEvaluator) PostalCode() field.ErrorList {
-	return e.a.evaluateSlice(e.request, e.sub.PostalCode, e.allowed.PostalCodes, e.fldPath.Child("postalCodes"))
+	return e.a.evaluateSlice(e.validations, e.sub.PostalCode, e.allowed.PostalCodes, e.fldPath.Child("postalCodes"))
 }
 
 func (e subjectEvaluator) SerialNumber() field.ErrorList {
-	return e.a.evaluateString(e.request, e.sub.SerialNumber, e.allowed.SerialNumber, e.fldPath.Child("serialNumber"))
+	return e.a.evaluateString(e.validations, e.sub.SerialNumber, e.allowed.SerialNumber, e.fldPath.Child("serialNumber"))
 }
 
-func (a allowed) evaluateString(request *cmapi.CertificateRequest, s string, crp *policyapi.CertificateRequestPolicyAllowedString, fldPath *field.Path) field.ErrorList {
+func (a *allowed) evaluateString(v *validations, s string, crp *policyapi.CertificateRequestPolicyAllowedString, fldPath *field.Path) field.ErrorList {
 	if len(s) == 0 {
 		// Attribute not set in request. We will only check if it's a required attribute
 		// and not run any validations specified by the policy.
@@ -233,17 +438,32 @@ func (a allowed) evaluateString(request *cmapi.CertificateRequest, s string, crp
 	}
 
 	var el field.ErrorList
-	if crp.Value != nil && !util.WildcardMatches(*crp.Value, s) {
-		el = append(el, field.Invalid(fldPath.Child("value"), s, *crp.Value))
+	if crp.Value != nil {
+		if isRegexp(crp.ValueType) {
+			if matches, err := a.regexps.Matches(*crp.Value, s); err != nil {
+				el = append(el, field.InternalError(fldPath.Child("value"), err))
+			} else if !matches {
+				el = append(el, field.Invalid(fldPath.Child("value"), s, *crp.Value))
+			}
+		} else if !util.WildcardMatches(*crp.Value, s) {
+			el = append(el, field.Invalid(fldPath.Child("value"), s, *crp.Value))
+		}
 	}
 
 	if len(crp.Validations) > 0 {
-		el = append(el, a.runValidations(request, crp.Validations, s, fldPath.Child("validations"))...)
+		v.add(crp.Validations, crp.ValidationsOperator, s, fldPath.Child("validations"))
 	}
 	return el
 }
 
-func (a allowed) evaluateSlice(request *cmapi.CertificateRequest, s []string, crp *policyapi.CertificateRequestPolicyAllowedStringSlice, fldPath *field.Path) field.ErrorList {
+func (a *allowed) evaluateSlice(v *validations, s []string, crp *policyapi.CertificateRequestPolicyAllowedStringSlice, fldPath *field.Path) field.ErrorList {
+	return a.evaluateSliceMatching(v, s, crp, fldPath, util.WildcardContains, a.regexps)
+}
+
+// evaluateSliceMatching evaluates the slice as evaluateSlice, using contains to
+// match values of the request against wildcard values of the policy, and
+// regexps to compile regular expression values of the policy.
+func (a *allowed) evaluateSliceMatching(v *validations, s []string, crp *policyapi.CertificateRequestPolicyAllowedStringSlice, fldPath *field.Path, contains func(patterns []string, member string) bool, regexps *util.RegexpCache) field.ErrorList {
 	if len(s) == 0 {
 		// Attribute not set in request. We will only check if it's a required attribute
 		// and not run any validations specified by the policy.
@@ -260,20 +480,70 @@ func (a allowed) evaluateSlice(request *cmapi.CertificateRequest, s []string, cr
 	}
 
 	var el field.ErrorList
-	if crp.Values != nil && !util.WildcardSubset(*crp.Values, s) {
-		el = append(el, field.Invalid(fldPath.Child("values"), s, strings.Join(*crp.Values, ", ")))
+	if crp.Values != nil {
+		if isRegexp(crp.ValueType) {
+			if subset, err := regexps.Subset(*crp.Values, s); err != nil {
+				el = append(el, field.InternalError(fldPath.Child("values"), err))
+			} else if !subset {
+				el = append(el, field.Invalid(fldPath.Child("values"), s, strings.Join(*crp.Values, ", ")))
+			}
+		} else if slices.ContainsFunc(s, func(member string) bool { return !contains(*crp.Values, member) }) {
+			el = append(el, field.Invalid(fldPath.Child("values"), s, strings.Join(*crp.Values, ", ")))
+		}
 	}
 
 	if len(crp.Validations) > 0 {
 		fldPath := fldPath.Child("validations")
-		for _, v := range s {
-			el = append(el, a.runValidations(request, crp.Validations, v, fldPath)...)
+		for _, value := range s {
+			v.add(crp.Validations, crp.ValidationsOperator, value, fldPath)
 		}
 	}
 	return el
 }
 
-func (a allowed) evaluateBool(b bool, crp *bool, fldPath *field.Path) field.ErrorList {
+// normalizeSlice returns the given values, and the wildcard values of the
+// given allowed field, normalized with the given function. Regular expression
+// values are returned as is, so are matched against the normalized values.
+func normalizeSlice(values []string, crp *policyapi.CertificateRequestPolicyAllowedStringSlice, normalize func(string) string) ([]string, *policyapi.CertificateRequestPolicyAllowedStringSlice) {
+	normalized := make([]string, len(values))
+	for i, value := range values {
+		normalized[i] = normalize(value)
+	}
+
+	if crp == nil || crp.Values == nil || isRegexp(crp.ValueType) {
+		return normalized, crp
+	}
+
+	crp = crp.DeepCopy()
+	for i, value := range *crp.Values {
+		(*crp.Values)[i] = normalize(value)
+	}
+	return normalized, crp
+}
+
+// lowerEmailDomain returns the email address with the domain after the last
+// "@" lowercased.
+func lowerEmailDomain(email string) string {
+	i := strings.LastIndex(email, "@")
+	if i < 0 {
+		return email
+	}
+	return email[:i+1] + strings.ToLower(email[i+1:])
+}
+
+// isRegexp returns true if the given value type matches values as regular
+// expressions. An omitted value type defaults to wildcard matching.
+func isRegexp(valueType *policyapi.CertificateRequestPolicyAllowedValueType) bool {
+	return valueType != nil && *valueType == policyapi.CertificateRequestPolicyAllowedValueTypeRegexp
+}
+
+// isDNSWildcard returns true if the given value type matches values using
+// wildcards which only match within a single DNS label.
+func isDNSWildcard(valueType *policyapi.CertificateRequestPolicyAllowedValueType) bool {
+	return valueType != nil && *valueType == policyapi.CertificateRequestPolicyAllowedValueTypeDNSWildcard
+}
+
+func (a *allowed) evaluateBool(b bool, crp *bool, fldPath *field.Path) field.ErrorList {
 	var el field.ErrorList
 	if b {
 		if crp == nil {
@@ -285,7 +555,30 @@ func (a allowed) evaluateBool(b bool, crp *bool, fldPath *field.Path) field.Erro
 	return el
 }
 
-func (a allowed) runValidations(request *cmapi.CertificateRequest, validations []policyapi.ValidationRule, s string, fldPath *field.Path) field.ErrorList {
+// runValidations evaluates the CEL validations against the given value,
+// combining the results using the operator. With the `Or` operator, the value
+// is only denied if every validation fails, and the returned errors describe
+// each failed rule of the group.
+func (a *allowed) runValidations(cr *validation.CertificateRequest, validations []policyapi.ValidationRule, operator *policyapi.CertificateRequestPolicyValidationsOperator, s string, fldPath *field.Path) field.ErrorList {
+	el := a.evaluateValidations(cr, validations, s, fldPath)
+	if operator == nil || *operator != policyapi.CertificateRequestPolicyValidationsOperatorOr {
+		return el
+	}
+
+	// Or: a single passing validation is sufficient.
+	if len(el) < len(validations) {
+		return nil
+	}
+	for _, err := range el {
+		err.Detail = fmt.Sprintf("none of the validations (operator %s) passed: %s",
+			policyapi.CertificateRequestPolicyValidationsOperatorOr, err.Detail)
+	}
+	return el
+}
+
+// evaluateValidations evaluates each of the CEL validations against the given
+// value, returning an error for every validation that did not pass.
+func (a *allowed) evaluateValidations(cr *validation.CertificateRequest, validations []policyapi.ValidationRule, s string, fldPath *field.Path) field.ErrorList {
 	var el field.ErrorList
 	for i, v := range validations {
 		validator, err := a.validators.Get(v.Rule)
@@ -293,15 +586,33 @@ func (a allowed) runValidations(request *cmapi.CertificateRequest, validations [
 			el = append(el, field.InternalError(fldPath.Index(i), err))
 			continue
 		}
-		valid, err := validator.Validate(s, *request)
+		valid, err := validator.Validate(s, cr)
 		if err != nil {
 			el = append(el, field.InternalError(fldPath.Index(i), err))
 			continue
 		}
 		if !valid {
-			detail := ptr.Deref(v.Message, fmt.Sprintf("failed rule: %s", v.Rule))
-			el = append(el, field.Invalid(fldPath.Index(i), s, detail))
+			el = append(el, field.Invalid(fldPath.Index(i), s, a.validationMessage(cr, v, s)))
 		}
 	}
 	return el
 }
+
+// validationMessage returns the message of a failed validation. The
+// MessageExpression is used if set, falling back to the static Message if it
+// fails to evaluate.
+func (a *allowed) validationMessage(cr *validation.CertificateRequest, v policyapi.ValidationRule, s string) string {
+	message := ptr.Deref(v.Message, fmt.Sprintf("failed rule: %s", v.Rule))
+	if v.MessageExpression == nil {
+		return message
+	}
+
+	expr, err := a.messages.Get(*v.MessageExpression)
+	if err != nil {
+		return message
+	}
+	if detail, err := expr.Evaluate(s, cr); err == nil {
+		return detail
+	}
+	return message
+}
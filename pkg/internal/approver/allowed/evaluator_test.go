@@ -22,13 +22,20 @@ import (
 	"net"
 	"net/url"
 	"testing"
+	"time"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
 	"github.com/cert-manager/approver-policy/pkg/approver"
@@ -57,6 +64,13 @@ func Test_Evaluate(t *testing.T) {
 			},
 			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""},
 		},
+		"if the request contains a CSR that cannot be parsed, return Denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR([]byte("not a csr"))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: nil,
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultDenied, Message: "could not parse CSR: error decoding certificate request PEM block"},
+		},
 		"if no allowed defined, all attributes set in request, return Denied": {
 			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
 				gen.SetCSRCommonName("hello-world"),
@@ -331,6 +345,103 @@ func Test_Evaluate(t *testing.T) {
 				Message: "",
 			},
 		},
+		"if validation has a message expression, return Denied with the evaluated message": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRDNSNames("foo.svc", "example.com"),
+			)), gen.SetCertificateRequestNamespace("foo")),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Validations: []policyapi.ValidationRule{{
+						Rule:              "self.endsWith(cr.namespace + '.svc')",
+						Message:           ptr.To("only local namespace DNS names are allowed"),
+						MessageExpression: ptr.To("'DNS name ' + self + ' must end in ' + cr.namespace + '.svc'"),
+					}}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.dnsNames.validations[0]"), "example.com", "DNS name example.com must end in foo.svc"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if validation message expression fails to evaluate, return Denied with the static message": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRDNSNames("example.com"),
+			)), gen.SetCertificateRequestNamespace("foo")),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Validations: []policyapi.ValidationRule{{
+						Rule:              "self.endsWith(cr.namespace + '.svc')",
+						Message:           ptr.To("only local namespace DNS names are allowed"),
+						MessageExpression: ptr.To("self.substring(100)"),
+					}}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.dnsNames.validations[0]"), "example.com", "only local namespace DNS names are allowed"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if validation message expression evaluates to an empty string and no message, return Denied with the fallback message": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRCommonName("hello-world"),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					CommonName: &policyapi.CertificateRequestPolicyAllowedString{Validations: []policyapi.ValidationRule{{
+						Rule:              "self.contains('cn-1')",
+						MessageExpression: ptr.To("''"),
+					}}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.commonName.validations[0]"), "hello-world", "failed rule: self.contains('cn-1')"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if validation combines key size and duration and the request has a small key and long duration, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, gen.SetCSRCommonName("hello-world"))),
+				gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour * 24 * 365}),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					CommonName: &policyapi.CertificateRequestPolicyAllowedString{Validations: []policyapi.ValidationRule{{
+						Rule:    "cr.keySize >= 4096 || (cr.duration > 0 && cr.duration <= 7776000)",
+						Message: ptr.To("keys smaller than 4096 bits must not be valid for more than 90 days"),
+					}}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.commonName.validations[0]"), "hello-world", "keys smaller than 4096 bits must not be valid for more than 90 days"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if validation combines key size and duration and the request has a small key and short duration, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, gen.SetCSRCommonName("hello-world"))),
+				gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour * 24 * 90}),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					CommonName: &policyapi.CertificateRequestPolicyAllowedString{Validations: []policyapi.ValidationRule{{
+						Rule:    "cr.keySize >= 4096 || (cr.duration > 0 && cr.duration <= 7776000)",
+						Message: ptr.To("keys smaller than 4096 bits must not be valid for more than 90 days"),
+					}}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result:  approver.ResultNotDenied,
+				Message: "",
+			},
+		},
 		"if all has validation, but all attributes are invalid, return Denied": {
 			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
 				gen.SetCSRCommonName("hello-world"),
@@ -427,7 +538,7 @@ func Test_Evaluate(t *testing.T) {
 				Message: "",
 			},
 		},
-		"if has values AND validations, both should apply": {
+		"if has values AND validations, and values deny, validations should not be run": {
 			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
 				gen.SetCSRCommonName("hello-world"),
 				gen.SetCSRDNSNames("foo.com"),
@@ -450,23 +561,884 @@ func Test_Evaluate(t *testing.T) {
 				Result: approver.ResultDenied,
 				Message: field.ErrorList{
 					field.Invalid(field.NewPath("spec.allowed.commonName.value"), "hello-world", "hello-world2"),
-					field.Invalid(field.NewPath("spec.allowed.uris.validations[0]"), "spiffe://cluster.local/ns/foo/sa/bar", "failed rule: self.startsWith('spiffe://foo.bar/ns/')"),
 					field.Invalid(field.NewPath("spec.allowed.emailAddresses.values"), []string{"foo@example.com", "bar@example.com"}, "foo@example.com"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if values deny, validations should never be compiled": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRDNSNames("foo.example.net"),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					// The rule fails to compile, which would deny with an
+					// internal error if it were evaluated.
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*.example.com"}, Validations: []policyapi.ValidationRule{{Rule: "self.invalid("}}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.dnsNames.values"), []string{"foo.example.net"}, "*.example.com"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if has values AND validations, and values allow, validations should apply": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRCommonName("hello-world"),
+				gen.SetCSRDNSNames("foo.com"),
+				gen.SetCSRURIs(uri1),
+				gen.SetCSREmails([]string{"foo@example.com", "bar@example.com"}),
+			)), gen.SetCertificateRequestNamespace("foo")),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					// Allowed by value and validation
+					CommonName: &policyapi.CertificateRequestPolicyAllowedString{Value: ptr.To("hello-*"), Validations: []policyapi.ValidationRule{{Rule: "self.contains('hello')"}}},
+					// Allowed by values and validations
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*.com"}, Validations: []policyapi.ValidationRule{{Rule: "self.endsWith(cr.namespace + '.com')"}}},
+					// Denied by validation
+					URIs: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"spiffe://*"}, Validations: []policyapi.ValidationRule{{Rule: "self.startsWith('spiffe://foo.bar/ns/')"}}},
+					// Denied by validation
+					EmailAddresses: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*@example.com"}, Validations: []policyapi.ValidationRule{{Rule: "self == cr.namespace + '@example.com'"}}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.uris.validations[0]"), "spiffe://cluster.local/ns/foo/sa/bar", "failed rule: self.startsWith('spiffe://foo.bar/ns/')"),
 					field.Invalid(field.NewPath("spec.allowed.emailAddresses.validations[0]"), "bar@example.com", "failed rule: self == cr.namespace + '@example.com'"),
 				}.ToAggregate().Error(),
 			},
 		},
+		"if usages requested with an alias of an allowed usage, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t)),
+				gen.SetCertificateRequestKeyUsages(cmapi.UsageSigning, cmapi.UsageSMIME),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					Usages: &[]cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageEmailProtection},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if usages allowed with an alias of a requested usage, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t)),
+				gen.SetCertificateRequestKeyUsages(cmapi.UsageDigitalSignature, cmapi.UsageEmailProtection),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					Usages: &[]cmapi.KeyUsage{"Signing", " s/mime "},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if usages requested with an alias of a usage which is not allowed, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t)),
+				gen.SetCertificateRequestKeyUsages(cmapi.UsageSigning),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					Usages: &[]cmapi.KeyUsage{cmapi.UsageKeyEncipherment},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.usages"), []string{"signing"}, "key encipherment"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if allowed values use Regexp value type and request matches, return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRCommonName("app-1"),
+				gen.SetCSRDNSNames("foo.example.com", "bar.example.com"),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					CommonName: &policyapi.CertificateRequestPolicyAllowedString{
+						Value:     ptr.To("app-[0-9]+"),
+						ValueType: ptr.To(policyapi.CertificateRequestPolicyAllowedValueTypeRegexp),
+					},
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+						Values:    &[]string{`[a-z]+\.example\.com`},
+						ValueType: ptr.To(policyapi.CertificateRequestPolicyAllowedValueTypeRegexp),
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if allowed values use Regexp value type and request does not match, return Denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRCommonName("app-1.example.com"),
+				gen.SetCSRDNSNames("foo.example.com", "foo-1.example.com"),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					CommonName: &policyapi.CertificateRequestPolicyAllowedString{
+						Value:     ptr.To("app-[0-9]+"),
+						ValueType: ptr.To(policyapi.CertificateRequestPolicyAllowedValueTypeRegexp),
+					},
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+						Values:    &[]string{`[a-z]+\.example\.com`},
+						ValueType: ptr.To(policyapi.CertificateRequestPolicyAllowedValueTypeRegexp),
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.commonName.value"), "app-1.example.com", "app-[0-9]+"),
+					field.Invalid(field.NewPath("spec.allowed.dnsNames.values"), []string{"foo.example.com", "foo-1.example.com"}, `[a-z]+\.example\.com`),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if allowed values use Wildcard value type, regular expression syntax is matched literally": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRDNSNames("foo.example.com"),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+						Values:    &[]string{`[a-z]+.example.com`},
+						ValueType: ptr.To(policyapi.CertificateRequestPolicyAllowedValueTypeWildcard),
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.dnsNames.values"), []string{"foo.example.com"}, `[a-z]+.example.com`),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if validations use the Or operator and one rule passes, should return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestNamespace("team-a"), gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRCommonName("app.team-a.svc"),
+				gen.SetCSRDNSNames("app.team-a.svc", "app.example.com"),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					CommonName: &policyapi.CertificateRequestPolicyAllowedString{
+						Validations: []policyapi.ValidationRule{
+							{Rule: "self.endsWith('.example.com')"},
+							{Rule: "self.endsWith(cr.namespace + '.svc')"},
+						},
+						ValidationsOperator: ptr.To(policyapi.CertificateRequestPolicyValidationsOperatorOr),
+					},
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+						Validations: []policyapi.ValidationRule{
+							{Rule: "self.endsWith('.example.com')"},
+							{Rule: "self.endsWith(cr.namespace + '.svc')"},
+						},
+						ValidationsOperator: ptr.To(policyapi.CertificateRequestPolicyValidationsOperatorOr),
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""},
+		},
+		"if validations use the Or operator and no rule passes, should return Denied listing every rule of the group": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestNamespace("team-a"), gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRDNSNames("app.team-a.svc", "app.other.org"),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+						Validations: []policyapi.ValidationRule{
+							{Rule: "self.endsWith('.example.com')"},
+							{Rule: "self.endsWith(cr.namespace + '.svc')", Message: ptr.To("must be a local service name")},
+						},
+						ValidationsOperator: ptr.To(policyapi.CertificateRequestPolicyValidationsOperatorOr),
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.dnsNames.validations[0]"), "app.other.org", "none of the validations (operator Or) passed: failed rule: self.endsWith('.example.com')"),
+					field.Invalid(field.NewPath("spec.allowed.dnsNames.validations[1]"), "app.other.org", "none of the validations (operator Or) passed: must be a local service name"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if validations use the And operator and one rule fails, should return Denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestNamespace("team-a"), gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRCommonName("app.team-a.svc"),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					CommonName: &policyapi.CertificateRequestPolicyAllowedString{
+						Validations: []policyapi.ValidationRule{
+							{Rule: "self.endsWith('.example.com')"},
+							{Rule: "self.endsWith(cr.namespace + '.svc')"},
+						},
+						ValidationsOperator: ptr.To(policyapi.CertificateRequestPolicyValidationsOperatorAnd),
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.commonName.validations[0]"), "app.team-a.svc", "failed rule: self.endsWith('.example.com')"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if annotation matches allowed wildcard value, should return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestAnnotations(map[string]string{"issuer.example.com/profile": "tls-server", "unrelated": "anything"}),
+				gen.SetCertificateRequestCSR(csrFrom(t)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					Annotations: map[string]policyapi.CertificateRequestPolicyAllowedString{
+						"issuer.example.com/profile": {Value: ptr.To("tls-*")},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if annotation does not match allowed value, should return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestAnnotations(map[string]string{"issuer.example.com/profile": "code-signing"}),
+				gen.SetCertificateRequestCSR(csrFrom(t)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					Annotations: map[string]policyapi.CertificateRequestPolicyAllowedString{
+						"issuer.example.com/profile": {Value: ptr.To("tls-*")},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec", "allowed", "annotations").Key("issuer.example.com/profile").Child("value"), "code-signing", "tls-*"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if annotation fails validations, should return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestNamespace("team-a"),
+				gen.SetCertificateRequestAnnotations(map[string]string{"issuer.example.com/profile": "team-b-server"}),
+				gen.SetCertificateRequestCSR(csrFrom(t)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					Annotations: map[string]policyapi.CertificateRequestPolicyAllowedString{
+						"issuer.example.com/profile": {Validations: []policyapi.ValidationRule{{Rule: "self.startsWith(cr.namespace + '-')"}}},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec", "allowed", "annotations").Key("issuer.example.com/profile").Child("validations").Index(0), "team-b-server", "failed rule: self.startsWith(cr.namespace + '-')"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if required annotation is missing, should return Denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					Annotations: map[string]policyapi.CertificateRequestPolicyAllowedString{
+						"issuer.example.com/profile": {Value: ptr.To("*"), Required: ptr.To(true)},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Required(field.NewPath("spec", "allowed", "annotations").Key("issuer.example.com/profile").Child("required"), "true"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if annotation is not set and not required, should return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					Annotations: map[string]policyapi.CertificateRequestPolicyAllowedString{
+						"issuer.example.com/profile": {Value: ptr.To("tls-*")},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if mixed case DNS names match lowercase allowed values, return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRDNSNames("Example.com", "FOO.example.COM"),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"example.com", "*.example.com"}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""},
+		},
+		"if lowercase DNS names match mixed case allowed values, return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRDNSNames("foo.example.com"),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*.EXAMPLE.com"}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""},
+		},
+		"if mixed case DNS names match lowercase regexp values, return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRDNSNames("Example.com", "FOO.example.COM"),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+						Values:    &[]string{`^([a-z]+\.)?example\.com$`},
+						ValueType: ptr.To(policyapi.CertificateRequestPolicyAllowedValueTypeRegexp),
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""},
+		},
+		"if mixed case DNS names don't match allowed values and names are case-sensitive, return Denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRDNSNames("Example.com", "FOO.example.COM"),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					DNSNames:           &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"example.com", "*.example.com"}},
+					CaseSensitiveNames: ptr.To(true),
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.dnsNames.values"), []string{"Example.com", "FOO.example.COM"}, "example.com, *.example.com"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if mixed case DNS names match an uppercase regexp value, return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRDNSNames("Example.com", "foo.EXAMPLE.com"),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+						Values:    &[]string{`([A-Z]+\.)?EXAMPLE\.COM`},
+						ValueType: ptr.To(policyapi.CertificateRequestPolicyAllowedValueTypeRegexp),
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""},
+		},
+		"if mixed case DNS names don't match a regexp value and names are case-sensitive, return Denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRDNSNames("Example.com"),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+						Values:    &[]string{`example\.com`},
+						ValueType: ptr.To(policyapi.CertificateRequestPolicyAllowedValueTypeRegexp),
+					},
+					CaseSensitiveNames: ptr.To(true),
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.dnsNames.values"), []string{"Example.com"}, `example\.com`),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if DNS names match a wildcard across multiple labels, return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRDNSNames("a.example.com", "a.b.example.com"),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*.example.com"}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""},
+		},
+		"if DNS names match a DNSWildcard within a single label, return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRDNSNames("a.example.com", "*.example.com", "B.Example.com"),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+						Values:    &[]string{"*.example.com"},
+						ValueType: ptr.To(policyapi.CertificateRequestPolicyAllowedValueTypeDNSWildcard),
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""},
+		},
+		"if a DNS name only matches a DNSWildcard across multiple labels, return Denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRDNSNames("a.example.com", "a.b.example.com"),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+						Values:    &[]string{"*.example.com"},
+						ValueType: ptr.To(policyapi.CertificateRequestPolicyAllowedValueTypeDNSWildcard),
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.dnsNames.values"), []string{"a.example.com", "a.b.example.com"}, "*.example.com"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if email with mixed case domain matches lowercase allowed value, return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSREmails([]string{"Foo@Example.COM"}),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					EmailAddresses: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"Foo@example.com"}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""},
+		},
+		"if email with mixed case local-part doesn't match lowercase allowed value, return Denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSREmails([]string{"Foo@Example.COM"}),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					EmailAddresses: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"foo@example.com"}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.emailAddresses.values"), []string{"Foo@example.com"}, "foo@example.com"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if email with mixed case domain doesn't match allowed value and names are case-sensitive, return Denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSREmails([]string{"Foo@Example.COM"}),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					EmailAddresses:     &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"Foo@example.com"}},
+					CaseSensitiveNames: ptr.To(true),
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.emailAddresses.values"), []string{"Foo@Example.COM"}, "Foo@example.com"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if multiple DNS names fail validations, return Denied reporting every failing value": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRDNSNames("a.example.com", "bad-1.example.net", "bad-2.example.org", "b.example.com"),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+						Values: &[]string{"*"},
+						Validations: []policyapi.ValidationRule{
+							{Rule: "!self.startsWith('bad-')", Message: ptr.To("must not start with bad-")},
+						},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.dnsNames.validations[0]"), "bad-1.example.net", "must not start with bad-"),
+					field.Invalid(field.NewPath("spec.allowed.dnsNames.validations[0]"), "bad-2.example.org", "must not start with bad-"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if ipAddresses values contain CIDR ranges and exact IPs which contain all requested IPs, return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRIPAddresses(net.ParseIP("10.1.2.3"), net.ParseIP("192.168.0.1"), net.ParseIP("2001:db8::1"), net.ParseIP("fd00::1")),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					IPAddresses: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"10.0.0.0/8", "192.168.0.1", "2001:db8::/32", "fd00::*"}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if ipAddresses values contain CIDR ranges which don't contain a requested IP, return Denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRIPAddresses(net.ParseIP("10.1.2.3"), net.ParseIP("11.0.0.1"), net.ParseIP("2001:db9::1")),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					IPAddresses: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"10.0.0.0/8", "2001:db8::/32"}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.ipAddresses.values"), []string{"10.1.2.3", "11.0.0.1", "2001:db9::1"}, "10.0.0.0/8, 2001:db8::/32"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if ipAddresses values contain an IPv4 CIDR range, IPv6 requested IPs are not contained, return Denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRIPAddresses(net.ParseIP("::1")),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					IPAddresses: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"0.0.0.0/0"}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.ipAddresses.values"), []string{"::1"}, "0.0.0.0/0"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if spiffeTrustDomains is set and URIs are SPIFFE IDs of an allowed trust domain, return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRURIs(uri1, mustParseURL(t, "spiffe://example.org/workload")),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					URIs: &policyapi.CertificateRequestPolicyAllowedStringSlice{SPIFFETrustDomains: &[]string{"cluster.local", "example.org"}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if spiffeTrustDomains is set and a URI is in the wrong trust domain, return Denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRURIs(uri1, mustParseURL(t, "spiffe://example.com/workload")),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					URIs: &policyapi.CertificateRequestPolicyAllowedStringSlice{SPIFFETrustDomains: &[]string{"cluster.local", "example.org"}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.uris.spiffeTrustDomains"), "spiffe://example.com/workload", "cluster.local, example.org"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if spiffeTrustDomains is set and a URI is not a SPIFFE ID, return Denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRURIs(uri1, mustParseURL(t, "https://cluster.local/ns/foo")),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					URIs: &policyapi.CertificateRequestPolicyAllowedStringSlice{SPIFFETrustDomains: &[]string{"cluster.local"}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.uris.spiffeTrustDomains"), "https://cluster.local/ns/foo", `not a valid SPIFFE ID: scheme must be "spiffe"`),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if spiffeTrustDomains and values are set, URIs must match both": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRURIs(uri1),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					URIs: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+						SPIFFETrustDomains: &[]string{"cluster.local"},
+						Values:             &[]string{"spiffe://cluster.local/ns/bar/*"},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.uris.values"), []string{"spiffe://cluster.local/ns/foo/sa/bar"}, "spiffe://cluster.local/ns/bar/*"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if spiffeTrustDomains is set with required and no URIs are requested, return Denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					URIs: &policyapi.CertificateRequestPolicyAllowedStringSlice{SPIFFETrustDomains: &[]string{"cluster.local"}, Required: ptr.To(true)},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Required(field.NewPath("spec.allowed.uris.required"), "true"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if allowedDomains is set and email addresses are in allowed domains, return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSREmails([]string{"alice@example.com", "bob@Team.Example.org"}),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					EmailAddresses: &policyapi.CertificateRequestPolicyAllowedStringSlice{AllowedDomains: &[]string{"example.com", "*.example.org"}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if allowedDomains is set and an email address is in the wrong domain, return Denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSREmails([]string{"alice@example.com", "bob@example.org", "carol@evil-example.com"}),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					EmailAddresses: &policyapi.CertificateRequestPolicyAllowedStringSlice{AllowedDomains: &[]string{"example.com", "*.example.org"}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.emailAddresses.allowedDomains"), "bob@example.org", "example.com, *.example.org"),
+					field.Invalid(field.NewPath("spec.allowed.emailAddresses.allowedDomains"), "carol@evil-example.com", "example.com, *.example.org"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if allowedDomains is set with case-sensitive names and the domain case differs, return Denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSREmails([]string{"alice@Example.com"}),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					CaseSensitiveNames: ptr.To(true),
+					EmailAddresses:     &policyapi.CertificateRequestPolicyAllowedStringSlice{AllowedDomains: &[]string{"example.com"}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.emailAddresses.allowedDomains"), "alice@Example.com", "example.com"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if allowedDomains and values are set, email addresses must match both": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSREmails([]string{"alice@example.com", "bob@example.com"}),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					EmailAddresses: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+						AllowedDomains: &[]string{"example.com"},
+						Values:         &[]string{"alice@*"},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.emailAddresses.values"), []string{"alice@example.com", "bob@example.com"}, "alice@*"),
+				}.ToAggregate().Error(),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			response, err := Approver().Evaluate(context.TODO(), &policyapi.CertificateRequestPolicy{Spec: test.policy}, test.request)
+			assert.Equal(t, test.expErr, err != nil, "%v", err)
+			if diff := cmp.Diff(withoutReasons(t, response), test.expResponse); diff != "" {
+				t.Errorf("unexpected evaluation response (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func Test_denialReasons(t *testing.T) {
+	tests := map[string]struct {
+		err       *field.Error
+		expReason approver.Reason
+	}{
+		"commonName":                   {field.Invalid(field.NewPath("spec.allowed.commonName.value"), "", ""), approver.ReasonCommonNameNotAllowed},
+		"dnsNames":                     {field.Invalid(field.NewPath("spec.allowed.dnsNames.values"), "", ""), approver.ReasonDNSNameNotAllowed},
+		"ipAddresses":                  {field.Invalid(field.NewPath("spec.allowed.ipAddresses.values"), "", ""), approver.ReasonIPAddressNotAllowed},
+		"uris":                         {field.Invalid(field.NewPath("spec.allowed.uris.spiffeTrustDomains"), "", ""), approver.ReasonURINotAllowed},
+		"emailAddresses":               {field.Invalid(field.NewPath("spec.allowed.emailAddresses.allowedDomains"), "", ""), approver.ReasonEmailAddressNotAllowed},
+		"isCA":                         {field.Invalid(field.NewPath("spec.allowed.isCA"), "", ""), approver.ReasonIsCANotAllowed},
+		"usages":                       {field.Invalid(field.NewPath("spec.allowed.usages"), "", ""), approver.ReasonUsageNotAllowed},
+		"subject":                      {field.Invalid(field.NewPath("spec.allowed.subject.organizations.values"), "", ""), approver.ReasonSubjectNotAllowed},
+		"annotations":                  {field.Invalid(field.NewPath("spec.allowed.annotations").Key("example.com/ticket").Child("value"), "", ""), approver.ReasonAnnotationNotAllowed},
+		"validations":                  {field.Invalid(field.NewPath("spec.allowed.dnsNames.validations").Index(0), "", ""), approver.ReasonDNSNameNotAllowed},
+		"required attribute missing":   {field.Required(field.NewPath("spec.allowed.dnsNames.required"), ""), approver.ReasonRequiredAttributeMissing},
+		"required annotation missing":  {field.Required(field.NewPath("spec.allowed.annotations").Key("example.com/ticket").Child("required"), ""), approver.ReasonRequiredAttributeMissing},
+		"unknown field uses fallback":  {field.Invalid(field.NewPath("spec.allowed.unknown"), "", ""), approver.ReasonDenied},
+		"field prefix is not a parent": {field.Invalid(field.NewPath("spec.allowed.urisUnknown"), "", ""), approver.ReasonDenied},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			exp := []approver.DenialReason{{Reason: test.expReason, Field: test.err.Field}}
+			assert.Equal(t, exp, denialReasons(field.ErrorList{test.err}))
+		})
+	}
+}
+
+func Test_EvaluateValuesFrom(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "allowed-values", Namespace: "cert-manager"},
+		Data:       map[string]string{"dnsNames": "*.example.com\n\n  *.example.org  \n"},
+	}
+	ref := &policyapi.CertificateRequestPolicyConfigMapKeyReference{Name: "allowed-values", Namespace: "cert-manager", Key: "dnsNames"}
+
+	tests := map[string]struct {
+		existingObjects []runtime.Object
+		request         *cmapi.CertificateRequest
+		policy          policyapi.CertificateRequestPolicySpec
+		expResponse     approver.EvaluationResponse
+		expErr          bool
+	}{
+		"if request matches values loaded from the ConfigMap, return NotDenied": {
+			existingObjects: []runtime.Object{configMap},
+			request:         gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t, gen.SetCSRDNSNames("a.example.com", "b.example.org")))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{ValuesFrom: ref},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if request matches inline values and values loaded from the ConfigMap, return NotDenied": {
+			existingObjects: []runtime.Object{configMap},
+			request:         gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t, gen.SetCSRDNSNames("a.example.com", "example.net")))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"example.net"}, ValuesFrom: ref},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if request doesn't match the merged values, return Denied": {
+			existingObjects: []runtime.Object{configMap},
+			request:         gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t, gen.SetCSRDNSNames("a.example.com", "example.io")))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"example.net"}, ValuesFrom: ref},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.dnsNames.values"), []string{"a.example.com", "example.io"}, "example.net, *.example.com, *.example.org"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if the referenced ConfigMap doesn't exist, return error": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t, gen.SetCSRDNSNames("a.example.com")))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{ValuesFrom: ref},
+				},
+			},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := Approver().(*allowed)
+			a.reader = fakeclient.NewClientBuilder().
+				WithScheme(policyapi.GlobalScheme).
+				WithRuntimeObjects(test.existingObjects...).
+				Build()
+			response, err := a.Evaluate(context.TODO(), &policyapi.CertificateRequestPolicy{Spec: test.policy}, test.request)
+			assert.Equal(t, test.expErr, err != nil, "%v", err)
+			assert.Equal(t, test.expResponse, withoutReasons(t, response))
+		})
+	}
+}
+
+func Test_EvaluateValuesFromCache(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "allowed-values", Namespace: "cert-manager"},
+		Data:       map[string]string{"dnsNames": "*.example.com"},
+	}
+	policy := &policyapi.CertificateRequestPolicy{Spec: policyapi.CertificateRequestPolicySpec{
+		Allowed: &policyapi.CertificateRequestPolicyAllowed{
+			DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+				ValuesFrom: &policyapi.CertificateRequestPolicyConfigMapKeyReference{Name: "allowed-values", Namespace: "cert-manager", Key: "dnsNames"},
+			},
+		},
+	}}
+	request := gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t, gen.SetCSRDNSNames("a.example.org"))))
+
+	var gets int
+	fakeclient := fakeclient.NewClientBuilder().
+		WithScheme(policyapi.GlobalScheme).
+		WithObjects(configMap).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				gets++
+				return c.Get(ctx, key, obj, opts...)
+			},
+		}).
+		Build()
+
+	a := Approver().(*allowed)
+	a.reader = fakeclient
+
+	evaluate := func() approver.EvaluationResult {
+		t.Helper()
+		response, err := a.Evaluate(context.TODO(), policy, request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return response.Result
+	}
+
+	// The ConfigMap is only read once for repeated evaluations.
+	assert.Equal(t, approver.ResultDenied, evaluate())
+	assert.Equal(t, approver.ResultDenied, evaluate())
+	assert.Equal(t, 1, gets)
+
+	configMap.Data["dnsNames"] = "*.example.org"
+	if err := fakeclient.Update(context.TODO(), configMap); err != nil {
+		t.Fatal(err)
+	}
+
+	// Changes are observed once the readiness of the policy is re-synced.
+	assert.Equal(t, approver.ResultDenied, evaluate())
+	if _, err := a.Ready(context.TODO(), policy); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, approver.ResultNotDenied, evaluate())
+	assert.Equal(t, 2, gets)
+}
+
+// withoutReasons asserts that a response denying the request gives a specific
+// reason for each of its violations, and returns the response without its
+// reasons so that it can be compared with the expected response.
+func withoutReasons(t *testing.T, response approver.EvaluationResponse) approver.EvaluationResponse {
+	t.Helper()
+	if response.Result == approver.ResultDenied && len(response.Message) > 0 {
+		assert.NotEmpty(t, response.Reasons, "expected a denied response to give reasons")
+		for _, reason := range response.Reasons {
+			assert.NotEqual(t, approver.ReasonDenied, reason.Reason, "expected a specific reason for %q", reason.Field)
+		}
+	}
+	response.Reasons = nil
+	return response
+}
+
+func mustParseURL(t *testing.T, s string) *url.URL {
+	t.Helper()
+	uri, err := url.Parse(s)
+	if err != nil {
+		t.Fatal(err)
 	}
-
-	for name, test := range tests {
-		t.Run(name, func(t *testing.T) {
-			response, err := Approver().Evaluate(context.TODO(), &policyapi.CertificateRequestPolicy{Spec: test.policy}, test.request)
-			assert.Equal(t, test.expErr, err != nil, "%v", err)
-			if diff := cmp.Diff(response, test.expResponse); diff != "" {
-				t.Errorf("unexpected evaluation response (-want +got):\n%v", diff)
-			}
-		})
-	}
+	return uri
 }
 
 func noErrModifier(fn func(*x509.CertificateRequest)) func(*x509.CertificateRequest) error {
@@ -18,16 +18,22 @@ package allowed
 
 import (
 	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
 	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/internal/util"
 )
 
 // Validate validates that the processed CertificateRequestPolicy has valid
 // allowed fields defined and there are no parsing errors in the values.
-func (a allowed) Validate(_ context.Context, policy *policyapi.CertificateRequestPolicy) (approver.WebhookValidationResponse, error) {
+func (a *allowed) Validate(_ context.Context, policy *policyapi.CertificateRequestPolicy) (approver.WebhookValidationResponse, error) {
 	// If no allowed fields are defined we can exit early
 	if policy.Spec.Allowed == nil {
 		return approver.WebhookValidationResponse{
@@ -37,22 +43,12 @@ func (a allowed) Validate(_ context.Context, policy *policyapi.CertificateReques
 	}
 
 	var (
-		el      field.ErrorList
-		allowed = policy.Spec.Allowed
-		fldPath = field.NewPath("spec", "allowed")
+		el       field.ErrorList
+		warnings field.ErrorList
+		allowed  = policy.Spec.Allowed
+		fldPath  = field.NewPath("spec", "allowed")
 	)
 
-	type stringSlicePair struct {
-		path  *field.Path
-		slice *policyapi.CertificateRequestPolicyAllowedStringSlice
-	}
-	stringSlices := []stringSlicePair{
-		{fldPath.Child("dnsNames"), allowed.DNSNames},
-		{fldPath.Child("ipAddresses"), allowed.IPAddresses},
-		{fldPath.Child("uris"), allowed.URIs},
-		{fldPath.Child("emailAddresses"), allowed.EmailAddresses},
-	}
-
 	type stringPair struct {
 		path   *field.Path
 		string *policyapi.CertificateRequestPolicyAllowedString
@@ -62,32 +58,85 @@ func (a allowed) Validate(_ context.Context, policy *policyapi.CertificateReques
 	}
 
 	if allowedSub := allowed.Subject; allowedSub != nil {
-		fldPathSub := fldPath.Child("subject")
+		strings = append(strings, stringPair{fldPath.Child("subject", "serialNumber"), allowedSub.SerialNumber})
+	}
 
-		stringSlices = append(stringSlices, stringSlicePair{fldPathSub.Child("organizations"), allowedSub.Organizations})
-		stringSlices = append(stringSlices, stringSlicePair{fldPathSub.Child("countries"), allowedSub.Countries})
-		stringSlices = append(stringSlices, stringSlicePair{fldPathSub.Child("organizationalUnits"), allowedSub.OrganizationalUnits})
-		stringSlices = append(stringSlices, stringSlicePair{fldPathSub.Child("localities"), allowedSub.Localities})
-		stringSlices = append(stringSlices, stringSlicePair{fldPathSub.Child("provinces"), allowedSub.Provinces})
-		stringSlices = append(stringSlices, stringSlicePair{fldPathSub.Child("streetAddresses"), allowedSub.StreetAddresses})
-		stringSlices = append(stringSlices, stringSlicePair{fldPathSub.Child("postalCodes"), allowedSub.PostalCodes})
+	for _, key := range slices.Sorted(maps.Keys(allowed.Annotations)) {
+		annotation := allowed.Annotations[key]
+		strings = append(strings, stringPair{fldPath.Child("annotations").Key(key), &annotation})
+	}
 
-		strings = append(strings, stringPair{fldPathSub.Child("serialNumber"), allowedSub.SerialNumber})
+	if allowed.URIs != nil && allowed.URIs.SPIFFETrustDomains != nil {
+		for i, trustDomain := range *allowed.URIs.SPIFFETrustDomains {
+			if err := util.ValidateSPIFFETrustDomain(trustDomain); err != nil {
+				el = append(el, field.Invalid(fldPath.Child("uris", "spiffeTrustDomains").Index(i), trustDomain, err.Error()))
+			}
+		}
 	}
 
-	for _, stringSlice := range stringSlices {
-		if stringSlice.slice != nil {
-			if stringSlice.slice.Required != nil && *stringSlice.slice.Required {
-				if stringSlice.slice.Values == nil && len(stringSlice.slice.Validations) == 0 {
-					el = append(el, field.Required(stringSlice.path.Child("values"), "at least one of 'values' or 'validations' must be defined if field is 'required'"))
-				}
+	if allowed.EmailAddresses != nil && allowed.EmailAddresses.AllowedDomains != nil {
+		fldPath := fldPath.Child("emailAddresses", "allowedDomains")
+		for i, domain := range *allowed.EmailAddresses.AllowedDomains {
+			if err := validateEmailDomain(domain); err != nil {
+				el = append(el, field.Invalid(fldPath.Index(i), domain, err.Error()))
+			}
+		}
+		el = append(el, util.ValidateSet(fldPath, *allowed.EmailAddresses.AllowedDomains)...)
+	}
+
+	if allowed.Usages != nil {
+		el = append(el, util.ValidateSet(fldPath.Child("usages"), *allowed.Usages)...)
+	}
+
+	for _, stringSlice := range stringSlices(allowed, fldPath) {
+		if stringSlice.slice.Values != nil {
+			el = append(el, util.ValidateSet(stringSlice.path.Child("values"), *stringSlice.slice.Values)...)
+		}
+		if stringSlice.slice.SPIFFETrustDomains != nil && stringSlice.slice != allowed.URIs {
+			el = append(el, field.Forbidden(stringSlice.path.Child("spiffeTrustDomains"), "spiffeTrustDomains is only supported on uris"))
+		}
+		if stringSlice.slice.AllowedDomains != nil && stringSlice.slice != allowed.EmailAddresses {
+			el = append(el, field.Forbidden(stringSlice.path.Child("allowedDomains"), "allowedDomains is only supported on emailAddresses"))
+		}
+		if isDNSWildcard(stringSlice.slice.ValueType) && stringSlice.slice != allowed.DNSNames {
+			el = append(el, field.Forbidden(stringSlice.path.Child("valueType"), "DNSWildcard is only supported on dnsNames"))
+		}
+		if ref := stringSlice.slice.ValuesFrom; ref != nil {
+			fldPath := stringSlice.path.Child("valuesFrom")
+			if len(ref.Name) == 0 {
+				el = append(el, field.Required(fldPath.Child("name"), "must define the name of the ConfigMap"))
+			}
+			if len(ref.Namespace) == 0 {
+				el = append(el, field.Required(fldPath.Child("namespace"), "must define the namespace of the ConfigMap"))
 			}
-			for i, validation := range stringSlice.slice.Validations {
-				if _, err := a.validators.Get(validation.Rule); err != nil {
-					el = append(el, field.Invalid(stringSlice.path.Child("validations").Index(i), validation.Rule, err.Error()))
+			if len(ref.Key) == 0 {
+				el = append(el, field.Required(fldPath.Child("key"), "must define the key of the ConfigMap"))
+			}
+		}
+		if stringSlice.slice.Required != nil && *stringSlice.slice.Required {
+			if stringSlice.slice.Values == nil && stringSlice.slice.ValuesFrom == nil && len(stringSlice.slice.Validations) == 0 && stringSlice.slice.SPIFFETrustDomains == nil && stringSlice.slice.AllowedDomains == nil {
+				el = append(el, field.Required(stringSlice.path.Child("values"), "at least one of 'values' or 'validations' must be defined if field is 'required'"))
+			}
+			// Validations are applied in addition to values, so can't
+			// permit a value which values doesn't.
+			if stringSlice.slice.Values != nil && len(*stringSlice.slice.Values) == 0 && stringSlice.slice.ValuesFrom == nil {
+				warnings = append(warnings, field.Invalid(stringSlice.path.Child("values"), *stringSlice.slice.Values,
+					"no values are allowed but the field is required, so this policy can never approve a request"))
+			}
+		}
+		if isRegexp(stringSlice.slice.ValueType) && stringSlice.slice.Values != nil {
+			for i, value := range *stringSlice.slice.Values {
+				if _, err := util.CompileRegexp(value); err != nil {
+					el = append(el, field.Invalid(stringSlice.path.Child("values").Index(i), value, err.Error()))
 				}
 			}
 		}
+		if stringSlice.slice.ValidationsOperator != nil && len(stringSlice.slice.Validations) == 0 {
+			el = append(el, field.Required(stringSlice.path.Child("validations"), "'validations' must be defined if 'validationsOperator' is set"))
+		}
+		for i, validation := range stringSlice.slice.Validations {
+			el = append(el, a.validateValidationRule(stringSlice.path.Child("validations").Index(i), validation)...)
+		}
 	}
 
 	for _, stringI := range strings {
@@ -96,17 +145,105 @@ func (a allowed) Validate(_ context.Context, policy *policyapi.CertificateReques
 				if stringI.string.Value == nil && len(stringI.string.Validations) == 0 {
 					el = append(el, field.Required(stringI.path.Child("value"), "at least one of 'value' or 'validations' must be defined if field is 'required'"))
 				}
+				if stringI.string.Value != nil && len(*stringI.string.Value) == 0 {
+					warnings = append(warnings, field.Invalid(stringI.path.Child("value"), *stringI.string.Value,
+						"only an empty value is allowed but the field is required, so this policy can never approve a request"))
+				}
 			}
-			for i, validation := range stringI.string.Validations {
-				if _, err := a.validators.Get(validation.Rule); err != nil {
-					el = append(el, field.Invalid(stringI.path.Child("validations").Index(i), validation.Rule, err.Error()))
+			if isDNSWildcard(stringI.string.ValueType) {
+				el = append(el, field.Forbidden(stringI.path.Child("valueType"), "DNSWildcard is only supported on dnsNames"))
+			}
+			if isRegexp(stringI.string.ValueType) && stringI.string.Value != nil {
+				if _, err := util.CompileRegexp(*stringI.string.Value); err != nil {
+					el = append(el, field.Invalid(stringI.path.Child("value"), *stringI.string.Value, err.Error()))
 				}
 			}
+			if stringI.string.ValidationsOperator != nil && len(stringI.string.Validations) == 0 {
+				el = append(el, field.Required(stringI.path.Child("validations"), "'validations' must be defined if 'validationsOperator' is set"))
+			}
+			for i, validation := range stringI.string.Validations {
+				el = append(el, a.validateValidationRule(stringI.path.Child("validations").Index(i), validation)...)
+			}
 		}
 	}
 
+	// Policies which can never approve a request are permitted, since other
+	// policies may approve it, but are likely a mistake.
+	var admissionWarnings admission.Warnings
+	for _, warning := range warnings {
+		admissionWarnings = append(admissionWarnings, warning.Error())
+	}
+
 	return approver.WebhookValidationResponse{
-		Allowed: len(el) == 0,
-		Errors:  el,
+		Allowed:  len(el) == 0,
+		Errors:   el,
+		Warnings: admissionWarnings,
 	}, nil
 }
+
+// stringSlicePair is an allowed string slice field along with its path.
+type stringSlicePair struct {
+	path  *field.Path
+	slice *policyapi.CertificateRequestPolicyAllowedStringSlice
+}
+
+// stringSlices returns the allowed string slice fields which are set, along
+// with their paths.
+func stringSlices(allowed *policyapi.CertificateRequestPolicyAllowed, fldPath *field.Path) []stringSlicePair {
+	if allowed == nil {
+		return nil
+	}
+
+	pairs := []stringSlicePair{
+		{fldPath.Child("dnsNames"), allowed.DNSNames},
+		{fldPath.Child("ipAddresses"), allowed.IPAddresses},
+		{fldPath.Child("uris"), allowed.URIs},
+		{fldPath.Child("emailAddresses"), allowed.EmailAddresses},
+	}
+
+	if allowedSub := allowed.Subject; allowedSub != nil {
+		fldPathSub := fldPath.Child("subject")
+
+		pairs = append(pairs,
+			stringSlicePair{fldPathSub.Child("organizations"), allowedSub.Organizations},
+			stringSlicePair{fldPathSub.Child("countries"), allowedSub.Countries},
+			stringSlicePair{fldPathSub.Child("organizationalUnits"), allowedSub.OrganizationalUnits},
+			stringSlicePair{fldPathSub.Child("localities"), allowedSub.Localities},
+			stringSlicePair{fldPathSub.Child("provinces"), allowedSub.Provinces},
+			stringSlicePair{fldPathSub.Child("streetAddresses"), allowedSub.StreetAddresses},
+			stringSlicePair{fldPathSub.Child("postalCodes"), allowedSub.PostalCodes},
+		)
+	}
+
+	return slices.DeleteFunc(pairs, func(pair stringSlicePair) bool { return pair.slice == nil })
+}
+
+// validateValidationRule validates that the rule, and message expression if
+// set, of a validation compile.
+func (a *allowed) validateValidationRule(fldPath *field.Path, validation policyapi.ValidationRule) field.ErrorList {
+	var el field.ErrorList
+	if _, err := a.validators.Get(validation.Rule); err != nil {
+		el = append(el, field.Invalid(fldPath, validation.Rule, err.Error()))
+	}
+	if validation.MessageExpression != nil {
+		if _, err := a.messages.Get(*validation.MessageExpression); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("messageExpression"), *validation.MessageExpression, err.Error()))
+		}
+	}
+	return el
+}
+
+// validateEmailDomain validates that the given allowed email domain is a
+// non-empty domain, optionally prefixed with a `*.` subdomain wildcard.
+func validateEmailDomain(domain string) error {
+	name := strings.TrimPrefix(domain, "*.")
+	switch {
+	case len(name) == 0:
+		return errors.New("domain must not be empty")
+	case strings.Contains(name, "*"):
+		return errors.New("only a leading '*.' wildcard is supported")
+	case strings.Contains(name, "@"):
+		return errors.New("must be a domain, not an email address")
+	}
+	return nil
+}
@@ -20,15 +20,36 @@ import (
 	"context"
 	"testing"
 
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
 	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/internal/util"
 )
 
+// variablesHint is appended to CEL compilation errors which reference
+// undeclared variables.
+const variablesHint = "\nonly the variables self, cr.name, cr.namespace, cr.username, cr.duration, cr.keyAlgorithm, cr.keySize, cr.isCA may be referenced"
+
 func Test_Validate(t *testing.T) {
+	regexpErr := func(pattern string) string {
+		_, err := util.CompileRegexp(pattern)
+		if err == nil {
+			t.Fatalf("expected pattern %q to fail to compile", pattern)
+		}
+		return err.Error()
+	}
+	neverApprovesSlice := func(path string) string {
+		return field.Invalid(field.NewPath(path), []string{}, "no values are allowed but the field is required, so this policy can never approve a request").Error()
+	}
+	neverApprovesString := func(path string) string {
+		return field.Invalid(field.NewPath(path), "", "only an empty value is allowed but the field is required, so this policy can never approve a request").Error()
+	}
+
 	tests := map[string]struct {
 		policy      *policyapi.CertificateRequestPolicy
 		expResponse approver.WebhookValidationResponse
@@ -44,6 +65,60 @@ func Test_Validate(t *testing.T) {
 				Errors:  nil,
 			},
 		},
+		"if policy contains duplicate usages or values, expect an Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{
+						Usages:   &[]cmapi.KeyUsage{cmapi.UsageServerAuth, cmapi.UsageDigitalSignature, cmapi.UsageServerAuth},
+						DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*.example.com", "example.com", "*.example.com"}},
+						Subject: &policyapi.CertificateRequestPolicyAllowedX509Subject{
+							Organizations: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"org", "org"}},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Duplicate(field.NewPath("spec.allowed.usages[2]"), cmapi.UsageServerAuth),
+					field.Duplicate(field.NewPath("spec.allowed.dnsNames.values[2]"), "*.example.com"),
+					field.Duplicate(field.NewPath("spec.allowed.subject.organizations.values[1]"), "org"),
+				},
+			},
+		},
+		"if policy contains valuesFrom without a name, namespace or key, expect an Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{
+						DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+							ValuesFrom: &policyapi.CertificateRequestPolicyConfigMapKeyReference{},
+							Required:   ptr.To(true),
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Required(field.NewPath("spec.allowed.dnsNames.valuesFrom.name"), "must define the name of the ConfigMap"),
+					field.Required(field.NewPath("spec.allowed.dnsNames.valuesFrom.namespace"), "must define the namespace of the ConfigMap"),
+					field.Required(field.NewPath("spec.allowed.dnsNames.valuesFrom.key"), "must define the key of the ConfigMap"),
+				},
+			},
+		},
+		"if policy requires a field with only valuesFrom, expect a Allowed=true response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{
+						DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+							ValuesFrom: &policyapi.CertificateRequestPolicyConfigMapKeyReference{Name: "allowed-values", Namespace: "cert-manager", Key: "dnsNames"},
+							Required:   ptr.To(true),
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{Allowed: true},
+		},
 		"if policy contains 'required' validation errors, expect an Allowed=false response": {
 			policy: &policyapi.CertificateRequestPolicy{
 				Spec: policyapi.CertificateRequestPolicySpec{
@@ -110,6 +185,14 @@ func Test_Validate(t *testing.T) {
 			expResponse: approver.WebhookValidationResponse{
 				Allowed: true,
 				Errors:  nil,
+				Warnings: admission.Warnings{
+					neverApprovesSlice("spec.allowed.dnsNames.values"),
+					neverApprovesSlice("spec.allowed.ipAddresses.values"),
+					neverApprovesSlice("spec.allowed.uris.values"),
+					neverApprovesSlice("spec.allowed.emailAddresses.values"),
+					neverApprovesString("spec.allowed.commonName.value"),
+					neverApprovesString("spec.allowed.subject.serialNumber.value"),
+				},
 			},
 		},
 		"if policy contains all required but values are defined, expect a Allowed=true response": {
@@ -137,8 +220,57 @@ func Test_Validate(t *testing.T) {
 			expResponse: approver.WebhookValidationResponse{
 				Allowed: true,
 				Errors:  nil,
+				Warnings: admission.Warnings{
+					neverApprovesSlice("spec.allowed.dnsNames.values"),
+					neverApprovesSlice("spec.allowed.ipAddresses.values"),
+					neverApprovesSlice("spec.allowed.uris.values"),
+					neverApprovesSlice("spec.allowed.emailAddresses.values"),
+					neverApprovesSlice("spec.allowed.subject.organizations.values"),
+					neverApprovesSlice("spec.allowed.subject.countries.values"),
+					neverApprovesSlice("spec.allowed.subject.organizationalUnits.values"),
+					neverApprovesSlice("spec.allowed.subject.localities.values"),
+					neverApprovesSlice("spec.allowed.subject.provinces.values"),
+					neverApprovesSlice("spec.allowed.subject.streetAddresses.values"),
+					neverApprovesSlice("spec.allowed.subject.postalCodes.values"),
+					neverApprovesString("spec.allowed.commonName.value"),
+					neverApprovesString("spec.allowed.subject.serialNumber.value"),
+				},
+			},
+		},
+		"if policy requires a field which allows no values, expect a Allowed=true response with a warning": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{
+						DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+							Required:    ptr.To(true),
+							Values:      &[]string{},
+							Validations: []policyapi.ValidationRule{{Rule: "self.endsWith('.com')"}},
+						},
+						URIs:           &policyapi.CertificateRequestPolicyAllowedStringSlice{Required: ptr.To(false), Values: &[]string{}},
+						EmailAddresses: &policyapi.CertificateRequestPolicyAllowedStringSlice{Required: ptr.To(true), Values: &[]string{"*@example.com"}},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed:  true,
+				Errors:   nil,
+				Warnings: admission.Warnings{neverApprovesSlice("spec.allowed.dnsNames.values")},
 			},
 		},
+		"if policy requires a field which allows no inline values but loads values from a ConfigMap, expect a Allowed=true response without warnings": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{
+						DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+							Required:   ptr.To(true),
+							Values:     &[]string{},
+							ValuesFrom: &policyapi.CertificateRequestPolicyConfigMapKeyReference{Name: "allowed-values", Namespace: "cert-manager", Key: "dnsNames"},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{Allowed: true},
+		},
 		"if policy contains invalid CEL validations, expect an Allowed=false response": {
 			policy: &policyapi.CertificateRequestPolicy{
 				Spec: policyapi.CertificateRequestPolicySpec{
@@ -166,17 +298,133 @@ func Test_Validate(t *testing.T) {
 				Errors: field.ErrorList{
 					field.Invalid(field.NewPath("spec.allowed.dnsNames.validations[0]"), "self > 2", "ERROR: <input>:1:6: found no matching overload for '_>_' applied to '(string, int)'\n | self > 2\n | .....^"),
 					field.Invalid(field.NewPath("spec.allowed.ipAddresses.validations[0]"), "self && false", "ERROR: <input>:1:1: expected type 'bool' but found 'string'\n | self && false\n | ^"),
-					field.Invalid(field.NewPath("spec.allowed.uris.validations[0]"), "self.exists(x, p)", "ERROR: <input>:1:1: expression of type 'string' cannot be range of a comprehension (must be list, map, or dynamic)\n | self.exists(x, p)\n | ^\nERROR: <input>:1:16: undeclared reference to 'p' (in container '')\n | self.exists(x, p)\n | ...............^"),
+					field.Invalid(field.NewPath("spec.allowed.uris.validations[0]"), "self.exists(x, p)", "ERROR: <input>:1:1: expression of type 'string' cannot be range of a comprehension (must be list, map, or dynamic)\n | self.exists(x, p)\n | ^\nERROR: <input>:1:16: undeclared reference to 'p' (in container '')\n | self.exists(x, p)\n | ...............^"+variablesHint),
 					field.Invalid(field.NewPath("spec.allowed.emailAddresses.validations[0]"), "self", "got string, wanted bool result type"),
 					field.Invalid(field.NewPath("spec.allowed.subject.organizations.validations[0]"), "self == '", "ERROR: <input>:1:9: Syntax error: token recognition error at: '''\n | self == '\n | ........^\nERROR: <input>:1:10: Syntax error: mismatched input '<EOF>' expecting {'[', '{', '(', '.', '-', '!', 'true', 'false', 'null', NUM_FLOAT, NUM_INT, NUM_UINT, STRING, BYTES, IDENTIFIER}\n | self == '\n | .........^"),
 					field.Invalid(field.NewPath("spec.allowed.subject.countries.validations[0]"), "self.length < 24", "ERROR: <input>:1:5: type 'string' does not support field selection\n | self.length < 24\n | ....^"),
 					field.Invalid(field.NewPath("spec.allowed.subject.organizationalUnits.validations[0]"), "", "ERROR: <input>:1:0: Syntax error: mismatched input '<EOF>' expecting {'[', '{', '(', '.', '-', '!', 'true', 'false', 'null', NUM_FLOAT, NUM_INT, NUM_UINT, STRING, BYTES, IDENTIFIER}"),
 					field.Invalid(field.NewPath("spec.allowed.subject.localities.validations[0]"), "cr.name[1] > 2", "ERROR: <input>:1:8: found no matching overload for '_[_]' applied to '(string, int)'\n | cr.name[1] > 2\n | .......^"),
-					field.Invalid(field.NewPath("spec.allowed.subject.provinces.validations[0]"), "cel", "ERROR: <input>:1:1: undeclared reference to 'cel' (in container '')\n | cel\n | ^"),
-					field.Invalid(field.NewPath("spec.allowed.subject.streetAddresses.validations[0]"), "cel", "ERROR: <input>:1:1: undeclared reference to 'cel' (in container '')\n | cel\n | ^"),
-					field.Invalid(field.NewPath("spec.allowed.subject.postalCodes.validations[0]"), "cel", "ERROR: <input>:1:1: undeclared reference to 'cel' (in container '')\n | cel\n | ^"),
-					field.Invalid(field.NewPath("spec.allowed.commonName.validations[0]"), "cel", "ERROR: <input>:1:1: undeclared reference to 'cel' (in container '')\n | cel\n | ^"),
-					field.Invalid(field.NewPath("spec.allowed.subject.serialNumber.validations[0]"), "cel", "ERROR: <input>:1:1: undeclared reference to 'cel' (in container '')\n | cel\n | ^"),
+					field.Invalid(field.NewPath("spec.allowed.subject.provinces.validations[0]"), "cel", "ERROR: <input>:1:1: undeclared reference to 'cel' (in container '')\n | cel\n | ^"+variablesHint),
+					field.Invalid(field.NewPath("spec.allowed.subject.streetAddresses.validations[0]"), "cel", "ERROR: <input>:1:1: undeclared reference to 'cel' (in container '')\n | cel\n | ^"+variablesHint),
+					field.Invalid(field.NewPath("spec.allowed.subject.postalCodes.validations[0]"), "cel", "ERROR: <input>:1:1: undeclared reference to 'cel' (in container '')\n | cel\n | ^"+variablesHint),
+					field.Invalid(field.NewPath("spec.allowed.commonName.validations[0]"), "cel", "ERROR: <input>:1:1: undeclared reference to 'cel' (in container '')\n | cel\n | ^"+variablesHint),
+					field.Invalid(field.NewPath("spec.allowed.subject.serialNumber.validations[0]"), "cel", "ERROR: <input>:1:1: undeclared reference to 'cel' (in container '')\n | cel\n | ^"+variablesHint),
+				},
+			},
+		},
+		"if policy contains CEL validations referencing undeclared variables, expect an Allowed=false response listing the allowed variables": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{
+						DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Validations: []policyapi.ValidationRule{
+							{Rule: "foo.bar == self"},
+							{Rule: "self.endsWith(cr.namespace)", MessageExpression: ptr.To("foo.bar")},
+						}},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.dnsNames.validations[0]"), "foo.bar == self", "ERROR: <input>:1:1: undeclared reference to 'foo' (in container '')\n | foo.bar == self\n | ^"+variablesHint),
+					field.Invalid(field.NewPath("spec.allowed.dnsNames.validations[1].messageExpression"), "foo.bar", "ERROR: <input>:1:1: undeclared reference to 'foo' (in container '')\n | foo.bar\n | ^"+variablesHint),
+				},
+			},
+		},
+		"if policy contains CEL validations calling undeclared functions, expect an Allowed=false response without listing the allowed variables": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{
+						DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Validations: []policyapi.ValidationRule{
+							{Rule: "foo(self)"},
+						}},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.dnsNames.validations[0]"), "foo(self)", "ERROR: <input>:1:4: undeclared reference to 'foo' (in container '')\n | foo(self)\n | ...^"),
+				},
+			},
+		},
+		"if policy sets validationsOperator without validations, expect an Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{
+						CommonName: &policyapi.CertificateRequestPolicyAllowedString{Value: ptr.To("*"), ValidationsOperator: ptr.To(policyapi.CertificateRequestPolicyValidationsOperatorOr)},
+						DNSNames:   &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*"}, ValidationsOperator: ptr.To(policyapi.CertificateRequestPolicyValidationsOperatorAnd)},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Required(field.NewPath("spec.allowed.dnsNames.validations"), "'validations' must be defined if 'validationsOperator' is set"),
+					field.Required(field.NewPath("spec.allowed.commonName.validations"), "'validations' must be defined if 'validationsOperator' is set"),
+				},
+			},
+		},
+		"if policy contains an invalid CEL validation in an Or group, expect the rule to be reported individually": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{
+						DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+							Validations: []policyapi.ValidationRule{
+								{Rule: "self.endsWith('.svc')"},
+								{Rule: "cel"},
+							},
+							ValidationsOperator: ptr.To(policyapi.CertificateRequestPolicyValidationsOperatorOr),
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.dnsNames.validations[1]"), "cel", "ERROR: <input>:1:1: undeclared reference to 'cel' (in container '')\n | cel\n | ^"+variablesHint),
+				},
+			},
+		},
+		"if policy contains invalid annotation values or validations, expect an Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{
+						Annotations: map[string]policyapi.CertificateRequestPolicyAllowedString{
+							"example.com/b": {Validations: []policyapi.ValidationRule{{Rule: "cel"}}},
+							"example.com/a": {Required: ptr.To(true)},
+							"example.com/c": {Validations: []policyapi.ValidationRule{{Rule: "self.startsWith('tls-')"}}},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Required(field.NewPath("spec", "allowed", "annotations").Key("example.com/a").Child("value"), "at least one of 'value' or 'validations' must be defined if field is 'required'"),
+					field.Invalid(field.NewPath("spec", "allowed", "annotations").Key("example.com/b").Child("validations").Index(0), "cel", "ERROR: <input>:1:1: undeclared reference to 'cel' (in container '')\n | cel\n | ^"+variablesHint),
+				},
+			},
+		},
+		"if policy contains invalid CEL message expressions, expect an Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{
+						CommonName: &policyapi.CertificateRequestPolicyAllowedString{Validations: []policyapi.ValidationRule{
+							{Rule: "self.size() > 2", MessageExpression: ptr.To("self.size() > 2")},
+						}},
+						DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Validations: []policyapi.ValidationRule{
+							{Rule: "self.size() > 2", MessageExpression: ptr.To("'DNS name ' + self + ' is too short'")},
+							{Rule: "self.size() > 2", MessageExpression: ptr.To("cel")},
+						}},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.dnsNames.validations[1].messageExpression"), "cel", "ERROR: <input>:1:1: undeclared reference to 'cel' (in container '')\n | cel\n | ^"+variablesHint),
+					field.Invalid(field.NewPath("spec.allowed.commonName.validations[0].messageExpression"), "self.size() > 2", "got bool, wanted string result type"),
 				},
 			},
 		},
@@ -207,6 +455,175 @@ func Test_Validate(t *testing.T) {
 				Errors:  nil,
 			},
 		},
+		"if policy contains invalid regular expressions with Regexp value type, expect an Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{
+						CommonName: &policyapi.CertificateRequestPolicyAllowedString{
+							Value:     ptr.To("(foo"),
+							ValueType: ptr.To(policyapi.CertificateRequestPolicyAllowedValueTypeRegexp),
+						},
+						DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+							Values:    &[]string{`[a-z]+\.example\.com`, "[a-z"},
+							ValueType: ptr.To(policyapi.CertificateRequestPolicyAllowedValueTypeRegexp),
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.dnsNames.values").Index(1), "[a-z", regexpErr("[a-z")),
+					field.Invalid(field.NewPath("spec.allowed.commonName.value"), "(foo", regexpErr("(foo")),
+				},
+			},
+		},
+		"if policy contains valid regular expressions with Regexp value type, expect an Allowed=true response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{
+						CommonName: &policyapi.CertificateRequestPolicyAllowedString{
+							Value:     ptr.To("(foo|bar)"),
+							ValueType: ptr.To(policyapi.CertificateRequestPolicyAllowedValueTypeRegexp),
+						},
+						DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+							Values:    &[]string{`[a-z]+\.example\.com`},
+							ValueType: ptr.To(policyapi.CertificateRequestPolicyAllowedValueTypeRegexp),
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: true,
+				Errors:  nil,
+			},
+		},
+		"if policy contains invalid regular expressions with Wildcard value type, expect an Allowed=true response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{
+						CommonName: &policyapi.CertificateRequestPolicyAllowedString{
+							Value: ptr.To("(foo"),
+						},
+						DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+							Values:    &[]string{"[a-z"},
+							ValueType: ptr.To(policyapi.CertificateRequestPolicyAllowedValueTypeWildcard),
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: true,
+				Errors:  nil,
+			},
+		},
+		"if policy contains valid spiffeTrustDomains on uris with required, expect an Allowed=true response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{
+						URIs: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+							Required:           ptr.To(true),
+							SPIFFETrustDomains: &[]string{"cluster.local", "example.org"},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: true,
+				Errors:  nil,
+			},
+		},
+		"if policy contains invalid spiffeTrustDomains, or spiffeTrustDomains on other fields, expect an Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{
+						DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+							Values:             &[]string{"*"},
+							SPIFFETrustDomains: &[]string{"cluster.local"},
+						},
+						URIs: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+							SPIFFETrustDomains: &[]string{"cluster.local", "Example.org", ""},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.uris.spiffeTrustDomains[1]"), "Example.org", `trust domain "Example.org" contains invalid character 'E'`),
+					field.Invalid(field.NewPath("spec.allowed.uris.spiffeTrustDomains[2]"), "", "trust domain is missing"),
+					field.Forbidden(field.NewPath("spec.allowed.dnsNames.spiffeTrustDomains"), "spiffeTrustDomains is only supported on uris"),
+				},
+			},
+		},
+		"if policy contains valid allowedDomains on emailAddresses with required, expect an Allowed=true response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{
+						EmailAddresses: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+							Required:       ptr.To(true),
+							AllowedDomains: &[]string{"example.com", "*.example.org"},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: true,
+				Errors:  nil,
+			},
+		},
+		"if policy contains invalid allowedDomains, or allowedDomains on other fields, expect an Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{
+						DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+							Values:         &[]string{"*"},
+							AllowedDomains: &[]string{"example.com"},
+						},
+						EmailAddresses: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+							AllowedDomains: &[]string{"example.com", "", "foo.*.com", "alice@example.com", "example.com"},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.emailAddresses.allowedDomains[1]"), "", "domain must not be empty"),
+					field.Invalid(field.NewPath("spec.allowed.emailAddresses.allowedDomains[2]"), "foo.*.com", "only a leading '*.' wildcard is supported"),
+					field.Invalid(field.NewPath("spec.allowed.emailAddresses.allowedDomains[3]"), "alice@example.com", "must be a domain, not an email address"),
+					field.Duplicate(field.NewPath("spec.allowed.emailAddresses.allowedDomains[4]"), "example.com"),
+					field.Forbidden(field.NewPath("spec.allowed.dnsNames.allowedDomains"), "allowedDomains is only supported on emailAddresses"),
+				},
+			},
+		},
+		"if policy uses DNSWildcard on fields other than dnsNames, expect an Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{
+						CommonName: &policyapi.CertificateRequestPolicyAllowedString{
+							Value:     ptr.To("*.example.com"),
+							ValueType: ptr.To(policyapi.CertificateRequestPolicyAllowedValueTypeDNSWildcard),
+						},
+						DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+							Values:    &[]string{"*.example.com"},
+							ValueType: ptr.To(policyapi.CertificateRequestPolicyAllowedValueTypeDNSWildcard),
+						},
+						URIs: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+							Values:    &[]string{"spiffe://*"},
+							ValueType: ptr.To(policyapi.CertificateRequestPolicyAllowedValueTypeDNSWildcard),
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Forbidden(field.NewPath("spec.allowed.uris.valueType"), "DNSWildcard is only supported on dnsNames"),
+					field.Forbidden(field.NewPath("spec.allowed.commonName.valueType"), "DNSWildcard is only supported on dnsNames"),
+				},
+			},
+		},
 	}
 
 	for name, test := range tests {
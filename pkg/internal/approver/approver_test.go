@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approver_test
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/internal/approver/allowed"
+	"github.com/cert-manager/approver-policy/pkg/internal/approver/constraints"
+	"github.com/cert-manager/approver-policy/pkg/internal/approver/ingresshosts"
+	"github.com/cert-manager/approver-policy/pkg/internal/approver/namespacequota"
+	"github.com/cert-manager/approver-policy/pkg/internal/approver/serviceips"
+)
+
+// Test_EvaluateNotPrepared ensures that in-tree approvers which read objects
+// from the cluster return ErrNotPrepared rather than panic when they have not
+// been prepared, such as when evaluating policies offline.
+func Test_EvaluateNotPrepared(t *testing.T) {
+	csr, _, err := gen.CSR(x509.ECDSA, gen.SetCSRDNSNames("app.example.com"), gen.SetCSRIPAddressesFromStrings("10.96.0.10"))
+	require.NoError(t, err)
+	request := gen.CertificateRequest("new", gen.SetCertificateRequestNamespace("sandbox"), gen.SetCertificateRequestCSR(csr))
+
+	tests := map[string]struct {
+		approver approver.Interface
+		policy   policyapi.CertificateRequestPolicySpec
+	}{
+		"allowed with valuesFrom": {
+			approver: allowed.Approver(),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+						ValuesFrom: &policyapi.CertificateRequestPolicyConfigMapKeyReference{Namespace: "cert-manager", Name: "values", Key: "dnsNames"},
+					},
+				},
+			},
+		},
+		"constraints with allowedPublicKeysConfigMapRef": {
+			approver: constraints.Approver(),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						AllowedPublicKeysConfigMapRef: &policyapi.CertificateRequestPolicyConfigMapReference{Namespace: "cert-manager", Name: "public-keys"},
+					},
+				},
+			},
+		},
+		"ingress-hosts": {
+			approver: ingresshosts.Approver(),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Plugins: map[string]policyapi.CertificateRequestPolicyPluginData{ingresshosts.Name: {}},
+			},
+		},
+		"namespace-quota": {
+			approver: namespacequota.Approver(),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Plugins: map[string]policyapi.CertificateRequestPolicyPluginData{namespacequota.Name: {Values: map[string]string{"maxCertificateRequests": "3"}}},
+			},
+		},
+		"service-ips": {
+			approver: serviceips.Approver(),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Plugins: map[string]policyapi.CertificateRequestPolicyPluginData{serviceips.Name: {}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			policy := &policyapi.CertificateRequestPolicy{Spec: test.policy}
+			response, err := test.approver.Evaluate(context.TODO(), policy, request.DeepCopy())
+			assert.ErrorIs(t, err, approver.ErrNotPrepared)
+			assert.ErrorContains(t, err, test.approver.Name())
+			assert.Equal(t, approver.EvaluationResponse{}, response)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constraints
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+)
+
+// basicConstraints is the BasicConstraints extension requested by a CSR.
+type basicConstraints struct {
+	// isCA is whether the CSR requests a CA.
+	isCA bool
+
+	// maxPathLen is the requested path length, or nil if the CSR doesn't
+	// limit the path length.
+	maxPathLen *int
+}
+
+// basicConstraintsASN1 is the ASN.1 structure of the BasicConstraints
+// extension. The path length is decoded as a raw value so that an omitted
+// path length can be told apart from a negative one.
+type basicConstraintsASN1 struct {
+	IsCA       bool          `asn1:"optional"`
+	MaxPathLen asn1.RawValue `asn1:"optional"`
+}
+
+// requestIsCA returns true if the request asks for a CA, either through
+// `spec.isCA` or through the basicConstraints extension of its CSR, which some
+// issuers honour regardless of `spec.isCA`. An error is returned if the
+// extension is malformed.
+func requestIsCA(request *cmapi.CertificateRequest, csr *x509.CertificateRequest) (bool, error) {
+	bc, err := parseBasicConstraints(csr)
+	if err != nil {
+		return false, err
+	}
+	return request.Spec.IsCA || bc.isCA, nil
+}
+
+// caConstraintsPath returns the path of the first configured constraint which
+// depends on whether the request asks for a CA, or nil if none are
+// configured. Requiring `isCA: true` only depends on `spec.isCA`.
+func caConstraintsPath(consts *policyapi.CertificateRequestPolicyConstraints, fldPath *field.Path) *field.Path {
+	switch {
+	case consts.IsCA != nil && !*consts.IsCA:
+		return fldPath.Child("isCA")
+	case consts.CAMustIncludeUsages != nil && len(*consts.CAMustIncludeUsages) > 0:
+		return fldPath.Child("caMustIncludeUsages")
+	case consts.IsCAAllowedForIssuers != nil:
+		return fldPath.Child("isCAAllowedForIssuers")
+	default:
+		return nil
+	}
+}
+
+// parseBasicConstraints returns the BasicConstraints extension requested by
+// the CSR. A CSR which doesn't request the extension is returned as not
+// requesting a CA. An error is returned if the extension is requested more
+// than once, or is malformed, since an issuer may interpret such a request
+// differently to approver-policy.
+func parseBasicConstraints(csr *x509.CertificateRequest) (basicConstraints, error) {
+	var (
+		bc    basicConstraints
+		found bool
+	)
+
+	for _, ext := range csr.Extensions {
+		if !ext.Id.Equal(utilpki.OIDExtensionBasicConstraints) {
+			continue
+		}
+		if found {
+			return basicConstraints{}, errors.New("basicConstraints extension must not be requested more than once")
+		}
+		found = true
+
+		var raw basicConstraintsASN1
+		if rest, err := asn1.Unmarshal(ext.Value, &raw); err != nil {
+			return basicConstraints{}, fmt.Errorf("malformed basicConstraints extension: %w", err)
+		} else if len(rest) > 0 {
+			return basicConstraints{}, errors.New("malformed basicConstraints extension: trailing data")
+		}
+
+		bc.isCA = raw.IsCA
+		if len(raw.MaxPathLen.FullBytes) > 0 {
+			var maxPathLen int
+			if _, err := asn1.Unmarshal(raw.MaxPathLen.FullBytes, &maxPathLen); err != nil {
+				return basicConstraints{}, fmt.Errorf("malformed basicConstraints path length: %w", err)
+			}
+			if maxPathLen < 0 {
+				return basicConstraints{}, fmt.Errorf("basicConstraints path length must not be negative, got %d", maxPathLen)
+			}
+			if !raw.IsCA {
+				return basicConstraints{}, errors.New("basicConstraints path length must only be set for a CA")
+			}
+			bc.maxPathLen = &maxPathLen
+		}
+	}
+
+	return bc, nil
+}
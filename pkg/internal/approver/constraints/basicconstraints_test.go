@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constraints
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"testing"
+
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+func Test_parseBasicConstraints(t *testing.T) {
+	marshal := func(v any) []byte {
+		b, err := asn1.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	ext := func(value []byte) pkix.Extension {
+		return pkix.Extension{Id: utilpki.OIDExtensionBasicConstraints, Critical: true, Value: value}
+	}
+
+	tests := map[string]struct {
+		extensions []pkix.Extension
+		exp        basicConstraints
+		expErr     bool
+	}{
+		"if no basicConstraints extension, return not a CA": {
+			exp: basicConstraints{},
+		},
+		"if unrelated extensions only, return not a CA": {
+			extensions: []pkix.Extension{{Id: asn1.ObjectIdentifier{1, 2, 3}, Value: marshal(true)}},
+			exp:        basicConstraints{},
+		},
+		"if CA with path length 0, return path length 0": {
+			extensions: []pkix.Extension{ext(marshal(struct {
+				IsCA       bool
+				MaxPathLen int
+			}{true, 0}))},
+			exp: basicConstraints{isCA: true, maxPathLen: ptr.To(0)},
+		},
+		"if CA with path length 1, return path length 1": {
+			extensions: []pkix.Extension{ext(marshal(struct {
+				IsCA       bool
+				MaxPathLen int
+			}{true, 1}))},
+			exp: basicConstraints{isCA: true, maxPathLen: ptr.To(1)},
+		},
+		"if CA without path length, return unlimited": {
+			extensions: []pkix.Extension{ext(marshal(struct{ IsCA bool }{true}))},
+			exp:        basicConstraints{isCA: true},
+		},
+		"if not a CA, return not a CA": {
+			extensions: []pkix.Extension{ext(marshal(struct{}{}))},
+			exp:        basicConstraints{},
+		},
+		"if extension requested twice, return error": {
+			extensions: []pkix.Extension{
+				ext(marshal(struct{ IsCA bool }{true})),
+				ext(marshal(struct{ IsCA bool }{true})),
+			},
+			expErr: true,
+		},
+		"if extension is malformed, return error": {
+			extensions: []pkix.Extension{ext([]byte{0x01, 0x02})},
+			expErr:     true,
+		},
+		"if extension has trailing data, return error": {
+			extensions: []pkix.Extension{ext(append(marshal(struct{ IsCA bool }{true}), 0x00))},
+			expErr:     true,
+		},
+		"if path length is negative, return error": {
+			extensions: []pkix.Extension{ext(marshal(struct {
+				IsCA       bool
+				MaxPathLen int
+			}{true, -1}))},
+			expErr: true,
+		},
+		"if path length is set for a non-CA, return error": {
+			extensions: []pkix.Extension{ext(marshal(struct {
+				MaxPathLen int
+			}{1}))},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			bc, err := parseBasicConstraints(&x509.CertificateRequest{Extensions: test.extensions})
+			assert.Equal(t, test.expErr, err != nil, "%v", err)
+			assert.Equal(t, test.exp, bc)
+		})
+	}
+}
@@ -21,10 +21,15 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/spf13/pflag"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
 	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/internal/util"
 	"github.com/cert-manager/approver-policy/pkg/registry"
 )
 
@@ -33,37 +38,101 @@ func init() {
 	registry.Shared.Store(Approver())
 }
 
+var _ approver.DependencyReconciler = &constraints{}
+
 // Approver returns an instance on the constraints approver.
 func Approver() approver.Interface {
-	return constraints{}
+	return &constraints{
+		publicKeys:        util.NewConfigMapCache(parsePublicKeys),
+		allowedCharacters: util.NewRegexpCache(compileAllowedCharacters),
+	}
 }
 
 // constraints is a base approver-policy Approver that is responsible for
 // ensuring incoming requests satisfy the constraints defined on
 // CertificateRequestPolicies. It is expected that constraints must _always_ be
 // registered for all approver-policy builds.
-type constraints struct{}
+type constraints struct {
+	// reader is used for fetching ConfigMaps referenced by policies. Reads are
+	// made directly against the API server so that approver-policy is not
+	// required to cache all ConfigMaps in the cluster.
+	reader client.Reader
+
+	// lister is used for fetching the Certificates which own requests from
+	// the informer cache.
+	lister client.Reader
+
+	// publicKeys caches the fingerprints of allowed public keys ConfigMaps,
+	// so that they are not read on every evaluation. They are re-read when
+	// the readiness of a policy referencing them is re-synced.
+	publicKeys *util.ConfigMapCache[sets.Set[string]]
+
+	// allowedCharacters caches the compiled Common Name allowedCharacters
+	// patterns of policies.
+	allowedCharacters *util.RegexpCache
+}
 
 // Name of Approver is "constraints"
-func (c constraints) Name() string {
+func (c *constraints) Name() string {
 	return "constraints"
 }
 
+// EvaluationStage of constraints is builtin, so it is run before plugins.
+func (c *constraints) EvaluationStage() approver.EvaluationStage {
+	return approver.EvaluationStageBuiltin
+}
+
 // RegisterFlags is a no-op, constraints doesn't need any flags.
-func (c constraints) RegisterFlags(_ *pflag.FlagSet) {}
+func (c *constraints) RegisterFlags(_ *pflag.FlagSet) {}
 
-// Prepare is a no-op, constraints doesn't need to prepare anything.
-func (c constraints) Prepare(_ context.Context, _ logr.Logger, _ manager.Manager) error {
+// Prepare sets up the reader used to fetch ConfigMaps referenced by policies,
+// and the lister used to fetch the Certificates which own requests.
+func (c *constraints) Prepare(_ context.Context, _ logr.Logger, mgr manager.Manager) error {
+	c.reader = mgr.GetAPIReader()
+	c.lister = mgr.GetCache()
 	return nil
 }
 
-// Ready always returns ready, constraints doesn't have any dependencies to
-// block readiness.
-func (c constraints) Ready(_ context.Context, _ *policyapi.CertificateRequestPolicy) (approver.ReconcilerReadyResponse, error) {
+// Ready returns not ready if the policy's constraints contradict its allowed
+// block, since the policy would otherwise silently deny every request.
+// Constraints has no external readiness requirements beyond the dependencies
+// it declares. The allowed public keys ConfigMap referenced by the policy, if
+// any, is re-read so that evaluations observe changes to it once the policy
+// has been re-synced.
+func (c *constraints) Ready(ctx context.Context, policy *policyapi.CertificateRequestPolicy) (approver.ReconcilerReadyResponse, error) {
+	if el := contradictions(policy); len(el) > 0 {
+		return approver.ReconcilerReadyResponse{Ready: false, Errors: el}, nil
+	}
+
+	if consts := policy.Spec.Constraints; consts != nil && consts.PrivateKey != nil && consts.PrivateKey.AllowedPublicKeysConfigMapRef != nil {
+		// A ConfigMap which doesn't exist is reported as a missing dependency.
+		if _, err := c.allowedPublicKeys(ctx, consts.PrivateKey.AllowedPublicKeysConfigMapRef, true); err != nil && !apierrors.IsNotFound(err) {
+			return approver.ReconcilerReadyResponse{}, err
+		}
+	}
+
 	return approver.ReconcilerReadyResponse{Ready: true}, nil
 }
 
+// Dependencies returns the ConfigMap referenced by the policy's allowed public
+// keys constraint, if any. The policy is not ready while the ConfigMap does
+// not exist.
+func (c *constraints) Dependencies(policy *policyapi.CertificateRequestPolicy) []approver.Dependency {
+	consts := policy.Spec.Constraints
+	if consts == nil || consts.PrivateKey == nil || consts.PrivateKey.AllowedPublicKeysConfigMapRef == nil {
+		return nil
+	}
+
+	ref := consts.PrivateKey.AllowedPublicKeysConfigMapRef
+	return []approver.Dependency{{
+		Kind:      approver.DependencyKindConfigMap,
+		Namespace: ref.Namespace,
+		Name:      ref.Name,
+		FieldPath: field.NewPath("spec", "constraints", "privateKey", "allowedPublicKeysConfigMapRef"),
+	}}
+}
+
 // constraints never needs to manually enqueue policies.
-func (c constraints) EnqueueChan() <-chan string {
+func (c *constraints) EnqueueChan() <-chan string {
 	return nil
 }
@@ -0,0 +1,275 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constraints
+
+import (
+	"context"
+	"testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+func Test_Dependencies(t *testing.T) {
+	ref := &policyapi.CertificateRequestPolicyConfigMapReference{Name: "allowed-public-keys", Namespace: "cert-manager"}
+
+	tests := map[string]struct {
+		policy          policyapi.CertificateRequestPolicySpec
+		expDependencies []approver.Dependency
+	}{
+		"if policy contains no constraints, return no dependencies": {
+			policy:          policyapi.CertificateRequestPolicySpec{},
+			expDependencies: nil,
+		},
+		"if policy contains private key constraints without an allowed public keys ConfigMap, return no dependencies": {
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						MinSize: ptr.To(2048),
+					},
+				},
+			},
+			expDependencies: nil,
+		},
+		"if policy references an allowed public keys ConfigMap, return the ConfigMap as a dependency": {
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						AllowedPublicKeysConfigMapRef: ref,
+					},
+				},
+			},
+			expDependencies: []approver.Dependency{{
+				Kind:      approver.DependencyKindConfigMap,
+				Namespace: "cert-manager",
+				Name:      "allowed-public-keys",
+				FieldPath: field.NewPath("spec", "constraints", "privateKey", "allowedPublicKeysConfigMapRef"),
+			}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dependencies := new(constraints).Dependencies(&policyapi.CertificateRequestPolicy{Spec: test.policy})
+			assert.Equal(t, test.expDependencies, dependencies)
+		})
+	}
+}
+
+func Test_Ready(t *testing.T) {
+	var (
+		requiredCommonName = &policyapi.CertificateRequestPolicyAllowedString{Value: ptr.To("*.example.com"), Required: ptr.To(true)}
+		requiredSANs       = &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*"}, Required: ptr.To(true)}
+	)
+
+	tests := map[string]struct {
+		policy      policyapi.CertificateRequestPolicySpec
+		expResponse approver.ReconcilerReadyResponse
+	}{
+		"if policy contains no constraints, return ready": {
+			policy:      policyapi.CertificateRequestPolicySpec{},
+			expResponse: approver.ReconcilerReadyResponse{Ready: true},
+		},
+		"if policy forbids common name with SANs but only requires a common name, return ready": {
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					CommonName: requiredCommonName,
+					DNSNames:   &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*"}},
+				},
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					ForbidCommonNameWithSANs: ptr.To(true),
+				},
+			},
+			expResponse: approver.ReconcilerReadyResponse{Ready: true},
+		},
+		"if policy forbids common name with SANs but requires both, return not ready": {
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					CommonName:  requiredCommonName,
+					DNSNames:    requiredSANs,
+					IPAddresses: requiredSANs,
+				},
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					ForbidCommonNameWithSANs: ptr.To(true),
+				},
+			},
+			expResponse: approver.ReconcilerReadyResponse{
+				Ready: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.forbidCommonNameWithSANs"), true, "contradicts spec.allowed.commonName.required and spec.allowed.dnsNames.required, no request can satisfy both"),
+					field.Invalid(field.NewPath("spec.constraints.forbidCommonNameWithSANs"), true, "contradicts spec.allowed.commonName.required and spec.allowed.ipAddresses.required, no request can satisfy both"),
+				},
+			},
+		},
+		"if policy requires CA requests which are allowed, return ready": {
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed:     &policyapi.CertificateRequestPolicyAllowed{IsCA: ptr.To(true)},
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{IsCA: ptr.To(true)},
+			},
+			expResponse: approver.ReconcilerReadyResponse{Ready: true},
+		},
+		"if policy requires CA requests which are not allowed, return not ready": {
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{IsCA: ptr.To(true)},
+			},
+			expResponse: approver.ReconcilerReadyResponse{
+				Ready: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.isCA"), true, "contradicts spec.allowed.isCA which does not permit CA requests"),
+				},
+			},
+		},
+		"if policy forbids IP addresses which are required by allowed, return not ready": {
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					IPAddresses: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*"}, Required: ptr.To(true)},
+				},
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					IPAddresses: &policyapi.CertificateRequestPolicyConstraintsIPAddresses{Forbid: ptr.To(true)},
+				},
+			},
+			expResponse: approver.ReconcilerReadyResponse{
+				Ready: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.ipAddresses.forbid"), true, "contradicts spec.allowed.ipAddresses.required, no request can satisfy both"),
+				},
+			},
+		},
+		"if policy forbids subject attributes which are required by allowed, return not ready": {
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					CommonName: &policyapi.CertificateRequestPolicyAllowedString{Value: ptr.To("*"), Required: ptr.To(true)},
+					Subject: &policyapi.CertificateRequestPolicyAllowedX509Subject{
+						Organizations: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*"}, Required: ptr.To(true)},
+						Countries:     &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*"}},
+					},
+				},
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					ForbiddenSubjectAttributes: &[]policyapi.CertificateRequestPolicySubjectAttribute{
+						policyapi.CertificateRequestPolicySubjectAttributeCommonName,
+						policyapi.CertificateRequestPolicySubjectAttributeOrganizations,
+						policyapi.CertificateRequestPolicySubjectAttributeCountries,
+					},
+				},
+			},
+			expResponse: approver.ReconcilerReadyResponse{
+				Ready: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.forbiddenSubjectAttributes"), policyapi.CertificateRequestPolicySubjectAttributeCommonName, "contradicts spec.allowed.commonName.required which requires the attribute, no request can satisfy both"),
+					field.Invalid(field.NewPath("spec.constraints.forbiddenSubjectAttributes"), policyapi.CertificateRequestPolicySubjectAttributeOrganizations, "contradicts spec.allowed.subject.organizations.required which requires the attribute, no request can satisfy both"),
+				},
+			},
+		},
+		"if policy requires usages which are allowed, return ready": {
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					Usages: &[]cmapi.KeyUsage{cmapi.UsageSigning, cmapi.UsageServerAuth},
+				},
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					RequiredUsages: &[]cmapi.KeyUsage{cmapi.UsageDigitalSignature},
+				},
+			},
+			expResponse: approver.ReconcilerReadyResponse{Ready: true},
+		},
+		"if policy requires usages which are not allowed, return not ready": {
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					Usages: &[]cmapi.KeyUsage{cmapi.UsageServerAuth},
+				},
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					RequiredUsages: &[]cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageServerAuth, cmapi.UsageClientAuth},
+				},
+			},
+			expResponse: approver.ReconcilerReadyResponse{
+				Ready: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.requiredUsages"), []string{"digital signature", "client auth"}, "required usages are not permitted by spec.allowed.usages"),
+				},
+			},
+		},
+		"if policy requires usages but allows none, return not ready": {
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					RequiredUsages: &[]cmapi.KeyUsage{cmapi.UsageServerAuth},
+				},
+			},
+			expResponse: approver.ReconcilerReadyResponse{
+				Ready: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.requiredUsages"), []string{"server auth"}, "required usages are not permitted by spec.allowed.usages"),
+				},
+			},
+		},
+		"if policy requires usages which are mutually exclusive, return not ready": {
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					Usages: &[]cmapi.KeyUsage{cmapi.UsageServerAuth, cmapi.UsageClientAuth},
+				},
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					RequiredUsages:          &[]cmapi.KeyUsage{cmapi.UsageServerAuth, cmapi.UsageClientAuth},
+					MutuallyExclusiveUsages: [][]cmapi.KeyUsage{{cmapi.UsageServerAuth, cmapi.UsageClientAuth}},
+				},
+			},
+			expResponse: approver.ReconcilerReadyResponse{
+				Ready: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.mutuallyExclusiveUsages").Index(0), []string{"server auth", "client auth"}, "contradicts spec.constraints.requiredUsages which requires more than one of these usages"),
+				},
+			},
+		},
+		"if policy limits common name length below the required common name, return not ready": {
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					CommonName: requiredCommonName,
+				},
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					CommonName: &policyapi.CertificateRequestPolicyConstraintsCommonName{MaxLength: ptr.To(10)},
+				},
+			},
+			expResponse: approver.ReconcilerReadyResponse{
+				Ready: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.commonName.maxLength"), 10, "contradicts spec.allowed.commonName which requires a Common Name of at least 12 characters"),
+				},
+			},
+		},
+		"if policy limits common name length above the required common name, return ready": {
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					CommonName: requiredCommonName,
+				},
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					CommonName: &policyapi.CertificateRequestPolicyConstraintsCommonName{MaxLength: ptr.To(64)},
+				},
+			},
+			expResponse: approver.ReconcilerReadyResponse{Ready: true},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			response, err := new(constraints).Ready(context.TODO(), &policyapi.CertificateRequestPolicy{Spec: test.policy})
+			assert.NoError(t, err)
+			assert.Equal(t, test.expResponse, response)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constraints
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/internal/util"
+)
+
+// contradictions returns the constraints of the policy which contradict the
+// policy's allowed block, such that no request could ever be permitted by the
+// policy.
+func contradictions(policy *policyapi.CertificateRequestPolicy) field.ErrorList {
+	consts := policy.Spec.Constraints
+	if consts == nil {
+		return nil
+	}
+
+	var (
+		el      field.ErrorList
+		fldPath = field.NewPath("spec", "constraints")
+		allowed = policy.Spec.Allowed
+	)
+
+	if allowed == nil {
+		allowed = new(policyapi.CertificateRequestPolicyAllowed)
+	}
+
+	if consts.ForbidCommonNameWithSANs != nil && *consts.ForbidCommonNameWithSANs && isRequired(allowed.CommonName) {
+		for _, san := range []struct {
+			name    string
+			allowed *policyapi.CertificateRequestPolicyAllowedStringSlice
+		}{
+			{"dnsNames", allowed.DNSNames},
+			{"ipAddresses", allowed.IPAddresses},
+			{"uris", allowed.URIs},
+			{"emailAddresses", allowed.EmailAddresses},
+		} {
+			if san.allowed != nil && san.allowed.Required != nil && *san.allowed.Required {
+				el = append(el, field.Invalid(fldPath.Child("forbidCommonNameWithSANs"), true,
+					fmt.Sprintf("contradicts spec.allowed.commonName.required and spec.allowed.%s.required, no request can satisfy both", san.name)))
+			}
+		}
+	}
+
+	if consts.IPAddresses != nil && consts.IPAddresses.Forbid != nil && *consts.IPAddresses.Forbid &&
+		allowed.IPAddresses != nil && allowed.IPAddresses.Required != nil && *allowed.IPAddresses.Required {
+		el = append(el, field.Invalid(fldPath.Child("ipAddresses", "forbid"), true,
+			"contradicts spec.allowed.ipAddresses.required, no request can satisfy both"))
+	}
+
+	if consts.RequiredUsages != nil && len(*consts.RequiredUsages) > 0 {
+		var canonicalAllowed []string
+		if allowed.Usages != nil {
+			for _, usage := range *allowed.Usages {
+				canonicalAllowed = append(canonicalAllowed, util.CanonicalKeyUsage(usage))
+			}
+		}
+
+		var forbidden []string
+		for _, usage := range *consts.RequiredUsages {
+			if !util.WildcardContains(canonicalAllowed, util.CanonicalKeyUsage(usage)) {
+				forbidden = append(forbidden, string(usage))
+			}
+		}
+		if len(forbidden) > 0 {
+			el = append(el, field.Invalid(fldPath.Child("requiredUsages"), forbidden, "required usages are not permitted by spec.allowed.usages"))
+		}
+	}
+
+	if consts.RequiredUsages != nil {
+		required := sets.New[string]()
+		for _, usage := range *consts.RequiredUsages {
+			required.Insert(util.CanonicalKeyUsage(usage))
+		}
+
+		for i, group := range consts.MutuallyExclusiveUsages {
+			var both []string
+			requiredInGroup := sets.New[string]()
+			for _, usage := range group {
+				if canonical := util.CanonicalKeyUsage(usage); required.Has(canonical) {
+					requiredInGroup.Insert(canonical)
+					both = append(both, string(usage))
+				}
+			}
+			if requiredInGroup.Len() > 1 {
+				el = append(el, field.Invalid(fldPath.Child("mutuallyExclusiveUsages").Index(i), both,
+					"contradicts spec.constraints.requiredUsages which requires more than one of these usages"))
+			}
+		}
+	}
+
+	if consts.CommonName != nil && consts.CommonName.MaxLength != nil && isRequired(allowed.CommonName) &&
+		allowed.CommonName.Value != nil && !isRegexp(allowed.CommonName.ValueType) {
+		// Wildcards may match the empty string, so the shortest Common Name
+		// permitted is the value without its wildcards.
+		if minLength := utf8.RuneCountInString(strings.ReplaceAll(*allowed.CommonName.Value, "*", "")); minLength > *consts.CommonName.MaxLength {
+			el = append(el, field.Invalid(fldPath.Child("commonName", "maxLength"), *consts.CommonName.MaxLength,
+				fmt.Sprintf("contradicts spec.allowed.commonName which requires a Common Name of at least %d characters", minLength)))
+		}
+	}
+
+	if consts.ForbiddenSubjectAttributes != nil {
+		for _, attribute := range *consts.ForbiddenSubjectAttributes {
+			if path, required := allowedSubjectAttributeRequired(allowed, attribute); required {
+				el = append(el, field.Invalid(fldPath.Child("forbiddenSubjectAttributes"), attribute,
+					fmt.Sprintf("contradicts %s which requires the attribute, no request can satisfy both", path)))
+			}
+		}
+	}
+
+	if consts.IsCA != nil && *consts.IsCA && (allowed.IsCA == nil || !*allowed.IsCA) {
+		el = append(el, field.Invalid(fldPath.Child("isCA"), true, "contradicts spec.allowed.isCA which does not permit CA requests"))
+	}
+
+	return el
+}
+
+// allowedSubjectAttributeRequired returns whether the allowed field of the
+// given subject attribute is marked as required, along with the path of its
+// required field.
+func allowedSubjectAttributeRequired(allowed *policyapi.CertificateRequestPolicyAllowed, attribute policyapi.CertificateRequestPolicySubjectAttribute) (string, bool) {
+	if attribute == policyapi.CertificateRequestPolicySubjectAttributeCommonName {
+		return "spec.allowed.commonName.required", isRequired(allowed.CommonName)
+	}
+
+	subject := allowed.Subject
+	if subject == nil {
+		return "", false
+	}
+
+	path := fmt.Sprintf("spec.allowed.subject.%s.required", attribute)
+	if attribute == policyapi.CertificateRequestPolicySubjectAttributeSerialNumber {
+		return path, isRequired(subject.SerialNumber)
+	}
+
+	var slice *policyapi.CertificateRequestPolicyAllowedStringSlice
+	switch attribute {
+	case policyapi.CertificateRequestPolicySubjectAttributeOrganizations:
+		slice = subject.Organizations
+	case policyapi.CertificateRequestPolicySubjectAttributeCountries:
+		slice = subject.Countries
+	case policyapi.CertificateRequestPolicySubjectAttributeOrganizationalUnits:
+		slice = subject.OrganizationalUnits
+	case policyapi.CertificateRequestPolicySubjectAttributeLocalities:
+		slice = subject.Localities
+	case policyapi.CertificateRequestPolicySubjectAttributeProvinces:
+		slice = subject.Provinces
+	case policyapi.CertificateRequestPolicySubjectAttributeStreetAddresses:
+		slice = subject.StreetAddresses
+	case policyapi.CertificateRequestPolicySubjectAttributePostalCodes:
+		slice = subject.PostalCodes
+	}
+	return path, slice != nil && slice.Required != nil && *slice.Required
+}
+
+// isRequired returns true if the given allowed string is marked as required.
+func isRequired(allowed *policyapi.CertificateRequestPolicyAllowedString) bool {
+	return allowed != nil && allowed.Required != nil && *allowed.Required
+}
+
+// isRegexp returns true if the given value type is Regexp.
+func isRegexp(valueType *policyapi.CertificateRequestPolicyAllowedValueType) bool {
+	return valueType != nil && *valueType == policyapi.CertificateRequestPolicyAllowedValueTypeRegexp
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constraints
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+)
+
+// durationConstraint is a set of duration constraints of a policy, along with
+// the names of the fields they were defined by, which are used to report
+// violations.
+type durationConstraint struct {
+	*policyapi.CertificateRequestPolicyConstraintsDuration
+
+	fldPath                      *field.Path
+	minName, maxName, valuesName string
+}
+
+// durationConstraints returns the duration constraints of the given
+// constraints. The deprecated top-level duration fields are mapped to a
+// duration constraint which reports violations against the deprecated fields,
+// so that existing policies behave as before.
+func durationConstraints(consts *policyapi.CertificateRequestPolicyConstraints, fldPath *field.Path) []durationConstraint {
+	var dcs []durationConstraint
+
+	if consts.MinDuration != nil || consts.MaxDuration != nil || consts.AllowedDurations != nil {
+		dcs = append(dcs, durationConstraint{
+			CertificateRequestPolicyConstraintsDuration: &policyapi.CertificateRequestPolicyConstraintsDuration{
+				Min:      consts.MinDuration,
+				Max:      consts.MaxDuration,
+				Values:   consts.AllowedDurations,
+				Required: consts.RequireDuration,
+			},
+			fldPath:    fldPath,
+			minName:    "minDuration",
+			maxName:    "maxDuration",
+			valuesName: "allowedDurations",
+		})
+	}
+
+	if consts.Duration != nil {
+		dcs = append(dcs, durationConstraint{
+			CertificateRequestPolicyConstraintsDuration: consts.Duration,
+			fldPath:    fldPath.Child("duration"),
+			minName:    "min",
+			maxName:    "max",
+			valuesName: "values",
+		})
+	}
+
+	return dcs
+}
+
+// evaluate returns the violations of the duration constraint by the
+// requested duration.
+func (dc durationConstraint) evaluate(request *cmapi.CertificateRequest) field.ErrorList {
+	var el field.ErrorList
+
+	// A request without a duration is issued for the issuer's default
+	// duration, which is unbounded as far as the policy is concerned.
+	required := dc.Required == nil || *dc.Required
+
+	if dc.Max != nil {
+		// If the request contains no duration or the maximum is smaller than requested, append error.
+		if request.Spec.Duration == nil {
+			if required {
+				el = append(el, field.Invalid(dc.fldPath.Child(dc.maxName), request.Spec.Duration.String(), fmt.Sprintf("duration must be specified and <= %s", dc.Max.Duration)))
+			}
+		} else if dc.Max.Duration < request.Spec.Duration.Duration {
+			el = append(el, field.Invalid(dc.fldPath.Child(dc.maxName), request.Spec.Duration.Duration.String(), dc.Max.Duration.String()))
+		}
+	}
+
+	if dc.Min != nil {
+		// If the request contains no duration or the minimum is larger than requested, append error.
+		if request.Spec.Duration == nil {
+			if required {
+				el = append(el, field.Invalid(dc.fldPath.Child(dc.minName), request.Spec.Duration.String(), dc.Min.Duration.String()))
+			}
+		} else if dc.Min.Duration > request.Spec.Duration.Duration {
+			el = append(el, field.Invalid(dc.fldPath.Child(dc.minName), request.Spec.Duration.Duration.String(), dc.Min.Duration.String()))
+		}
+	}
+
+	if dc.Values != nil {
+		allowed := make([]string, 0, len(*dc.Values))
+		for _, duration := range *dc.Values {
+			allowed = append(allowed, duration.Duration.String())
+		}
+
+		// If the request contains no duration or the requested duration isn't
+		// one of the allowed durations, append error.
+		if request.Spec.Duration == nil {
+			if required {
+				el = append(el, field.Invalid(dc.fldPath.Child(dc.valuesName), request.Spec.Duration.String(), fmt.Sprintf("duration must be specified and one of: %s", strings.Join(allowed, ", "))))
+			}
+		} else if !slices.ContainsFunc(*dc.Values, func(duration metav1.Duration) bool {
+			return duration.Duration == request.Spec.Duration.Duration
+		}) {
+			el = append(el, field.Invalid(dc.fldPath.Child(dc.valuesName), request.Spec.Duration.Duration.String(), fmt.Sprintf("duration must be one of: %s", strings.Join(allowed, ", "))))
+		}
+	}
+
+	if dc.Granularity != nil && dc.Granularity.Duration > 0 {
+		fldPath := dc.fldPath.Child("granularity")
+		if request.Spec.Duration == nil {
+			if required {
+				el = append(el, field.Invalid(fldPath, request.Spec.Duration.String(), fmt.Sprintf("duration must be specified and a multiple of %s", dc.Granularity.Duration)))
+			}
+		} else if request.Spec.Duration.Duration%dc.Granularity.Duration != 0 {
+			el = append(el, field.Invalid(fldPath, request.Spec.Duration.Duration.String(), fmt.Sprintf("duration must be a multiple of %s", dc.Granularity.Duration)))
+		}
+	}
+
+	return el
+}
+
+// validate returns the errors of the duration constraint, i.e. bounds which
+// are negative, or allowed values outside of the bounds or granularity.
+func (dc durationConstraint) validate() field.ErrorList {
+	var el field.ErrorList
+
+	if dc.Max != nil && dc.Min != nil && dc.Max.Duration < dc.Min.Duration {
+		el = append(el, field.Invalid(dc.fldPath.Child(dc.maxName), dc.Max.Duration.String(), fmt.Sprintf("%s must be the same value as %s or larger", dc.maxName, dc.minName)))
+	}
+	if dc.Max != nil && dc.Max.Duration < 0 {
+		el = append(el, field.Invalid(dc.fldPath.Child(dc.maxName), dc.Max.Duration.String(), fmt.Sprintf("%s must be a value greater or equal to 0", dc.maxName)))
+	}
+	if dc.Min != nil && dc.Min.Duration < 0 {
+		el = append(el, field.Invalid(dc.fldPath.Child(dc.minName), dc.Min.Duration.String(), fmt.Sprintf("%s must be a value greater or equal to 0", dc.minName)))
+	}
+	if dc.Granularity != nil && dc.Granularity.Duration <= 0 {
+		el = append(el, field.Invalid(dc.fldPath.Child("granularity"), dc.Granularity.Duration.String(), "granularity must be greater than 0"))
+	}
+
+	if dc.Values != nil {
+		if len(*dc.Values) == 0 {
+			el = append(el, field.Required(dc.fldPath.Child(dc.valuesName), "must contain at least one duration if defined"))
+		}
+
+		seen := sets.New[time.Duration]()
+		for i, duration := range *dc.Values {
+			fldPath := dc.fldPath.Child(dc.valuesName).Index(i)
+			switch {
+			case duration.Duration <= 0:
+				el = append(el, field.Invalid(fldPath, duration.Duration.String(), "must be greater than 0"))
+			case seen.Has(duration.Duration):
+				el = append(el, field.Duplicate(fldPath, duration.Duration.String()))
+			case dc.Min != nil && duration.Duration < dc.Min.Duration:
+				el = append(el, field.Invalid(fldPath, duration.Duration.String(), fmt.Sprintf("must not be less than %s, since the request must satisfy both", dc.minName)))
+			case dc.Max != nil && duration.Duration > dc.Max.Duration:
+				el = append(el, field.Invalid(fldPath, duration.Duration.String(), fmt.Sprintf("must not be greater than %s, since the request must satisfy both", dc.maxName)))
+			case dc.Granularity != nil && dc.Granularity.Duration > 0 && duration.Duration%dc.Granularity.Duration != 0:
+				el = append(el, field.Invalid(fldPath, duration.Duration.String(), "must be a multiple of granularity, since the request must satisfy both"))
+			}
+			seen.Insert(duration.Duration)
+		}
+	}
+
+	return el
+}
@@ -21,15 +21,31 @@ import (
 	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"slices"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+	"golang.org/x/net/publicsuffix"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
 	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/internal/util"
 )
 
 // Evaluate evaluates whether the given CertificateRequest satisfies the
@@ -38,7 +54,7 @@ import (
 // permitted by the passed policy.
 // If the request is denied by the constraints an explanation is returned.
 // An error signals that the policy couldn't be evaluated to completion.
-func (c constraints) Evaluate(_ context.Context, policy *policyapi.CertificateRequestPolicy, request *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+func (c *constraints) Evaluate(ctx context.Context, policy *policyapi.CertificateRequestPolicy, request *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
 	// If no constraints defined, exit early.
 	if policy.Spec.Constraints == nil {
 		return approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""}, nil
@@ -50,31 +66,29 @@ func (c constraints) Evaluate(_ context.Context, policy *policyapi.CertificateRe
 		el      field.ErrorList
 		consts  = policy.Spec.Constraints
 		fldPath = field.NewPath("spec", "constraints")
+
+		// csr is decoded lazily, only once a constraint requires it.
+		csr *x509.CertificateRequest
 	)
 
-	if consts.MaxDuration != nil {
-		// If the request contains no duration or the maxDuration is smaller than requested, append error.
-		if request.Spec.Duration == nil {
-			el = append(el, field.Invalid(fldPath.Child("maxDuration"), request.Spec.Duration.String(), consts.MaxDuration.Duration.String()))
-		} else if consts.MaxDuration.Duration < request.Spec.Duration.Duration {
-			el = append(el, field.Invalid(fldPath.Child("maxDuration"), request.Spec.Duration.Duration.String(), consts.MaxDuration.Duration.String()))
+	decodeCSR := func() (*x509.CertificateRequest, error) {
+		if csr != nil {
+			return csr, nil
 		}
+		var err error
+		csr, err = utilpki.DecodeX509CertificateRequestBytes(request.Spec.Request)
+		return csr, err
 	}
 
-	if consts.MinDuration != nil {
-		// If the request contains no duration or the minDuration is larger than requested, append error.
-		if request.Spec.Duration == nil {
-			el = append(el, field.Invalid(fldPath.Child("minDuration"), request.Spec.Duration.String(), consts.MinDuration.Duration.String()))
-		} else if consts.MinDuration.Duration > request.Spec.Duration.Duration {
-			el = append(el, field.Invalid(fldPath.Child("minDuration"), request.Spec.Duration.Duration.String(), consts.MinDuration.Duration.String()))
-		}
+	for _, dc := range durationConstraints(consts, fldPath) {
+		el = append(el, dc.evaluate(request)...)
 	}
 
 	if consts.PrivateKey != nil {
 		fldPath := fldPath.Child("privateKey")
 
 		// Decode CSR from CertificateRequest
-		csr, err := utilpki.DecodeX509CertificateRequestBytes(request.Spec.Request)
+		csr, err := decodeCSR()
 		if err != nil {
 			return approver.EvaluationResponse{}, err
 		}
@@ -85,7 +99,7 @@ func (c constraints) Evaluate(_ context.Context, policy *policyapi.CertificateRe
 		}
 
 		if consts.PrivateKey.Algorithm != nil && *consts.PrivateKey.Algorithm != alg {
-			el = append(el, field.Invalid(fldPath.Child("algorithm"), string(alg), string(*consts.PrivateKey.Algorithm)))
+			el = append(el, field.Invalid(fldPath.Child("algorithm"), string(alg), fmt.Sprintf("found %s, requires %s", alg, *consts.PrivateKey.Algorithm)))
 		}
 
 		if consts.PrivateKey.MaxSize != nil && *consts.PrivateKey.MaxSize < size {
@@ -95,17 +109,453 @@ func (c constraints) Evaluate(_ context.Context, policy *policyapi.CertificateRe
 		if consts.PrivateKey.MinSize != nil && *consts.PrivateKey.MinSize > size {
 			el = append(el, field.Invalid(fldPath.Child("minSize"), strconv.Itoa(size), strconv.Itoa(*consts.PrivateKey.MinSize)))
 		}
+
+		if curves := consts.PrivateKey.AllowedECDSACurves; curves != nil {
+			if pubKey, ok := csr.PublicKey.(*ecdsa.PublicKey); ok {
+				if curve := pubKey.Curve.Params().Name; !slices.Contains(*curves, curve) {
+					el = append(el, field.Invalid(fldPath.Child("allowedECDSACurves"), curve, fmt.Sprintf("ECDSA curve must be one of %v", *curves)))
+				}
+			}
+		}
+
+		if minExponent := consts.PrivateKey.MinRSAPublicExponent; minExponent != nil {
+			if pubKey, ok := csr.PublicKey.(*rsa.PublicKey); ok && pubKey.E < *minExponent {
+				el = append(el, field.Invalid(fldPath.Child("minRSAPublicExponent"), pubKey.E, fmt.Sprintf("RSA public exponent must be at least %d", *minExponent)))
+			}
+		}
+
+		if consts.PrivateKey.AllowedEd25519 != nil && !*consts.PrivateKey.AllowedEd25519 && alg == cmapi.Ed25519KeyAlgorithm {
+			el = append(el, field.Invalid(fldPath.Child("allowedEd25519"), string(alg), "Ed25519 private keys are not allowed"))
+		}
+
+		if ref := consts.PrivateKey.AllowedPublicKeysConfigMapRef; ref != nil {
+			allowed, err := c.allowedPublicKeys(ctx, ref, false)
+			if err != nil {
+				return approver.EvaluationResponse{}, err
+			}
+			if fingerprint := publicKeyFingerprint(csr.RawSubjectPublicKeyInfo); !allowed.Has(fingerprint) {
+				el = append(el, field.Invalid(fldPath.Child("allowedPublicKeys"), fingerprint, "public key is not allowed"))
+			}
+		}
+
+		if consts.PrivateKey.MatchCertificate != nil && *consts.PrivateKey.MatchCertificate {
+			cert, err := c.owningCertificate(ctx, request)
+			if err != nil {
+				return approver.EvaluationResponse{}, err
+			}
+
+			// Requests which are not owned by a Certificate have nothing to be
+			// matched against.
+			if cert != nil && cert.Spec.PrivateKey != nil {
+				fldPath := fldPath.Child("matchCertificate")
+				certName := cert.Namespace + "/" + cert.Name
+
+				if declared := cert.Spec.PrivateKey.Algorithm; len(declared) > 0 && declared != alg {
+					el = append(el, field.Invalid(fldPath, string(alg), fmt.Sprintf("must match algorithm %q of Certificate %s", declared, certName)))
+				} else if declared := cert.Spec.PrivateKey.Size; declared > 0 && alg != cmapi.Ed25519KeyAlgorithm && declared != size {
+					el = append(el, field.Invalid(fldPath, strconv.Itoa(size), fmt.Sprintf("must match size %d of Certificate %s", declared, certName)))
+				}
+			}
+		}
+	}
+
+	if consts.CommonName != nil {
+		fldPath := fldPath.Child("commonName")
+
+		csr, err := decodeCSR()
+		if err != nil {
+			return approver.EvaluationResponse{}, err
+		}
+
+		if cn := csr.Subject.CommonName; len(cn) > 0 {
+			if maxLength := consts.CommonName.MaxLength; maxLength != nil && utf8.RuneCountInString(cn) > *maxLength {
+				el = append(el, field.Invalid(fldPath.Child("maxLength"), cn, fmt.Sprintf("must be no more than %d characters", *maxLength)))
+			}
+
+			if allowed := consts.CommonName.AllowedCharacters; allowed != nil {
+				re, err := c.allowedCharacters.Get(*allowed)
+				if err != nil {
+					el = append(el, field.InternalError(fldPath.Child("allowedCharacters"), err))
+				} else if end := re.FindStringIndex(cn)[1]; end < len(cn) {
+					char, _ := utf8.DecodeRuneInString(cn[end:])
+					el = append(el, field.Invalid(fldPath.Child("allowedCharacters"), cn, fmt.Sprintf("character %q does not match %q", char, *allowed)))
+				}
+			}
+		}
+	}
+
+	if consts.RequireNamespacedSPIFFE != nil && *consts.RequireNamespacedSPIFFE {
+		csr, err := decodeCSR()
+		if err != nil {
+			return approver.EvaluationResponse{}, err
+		}
+
+		for _, uri := range csr.URIs {
+			if !isNamespacedSPIFFEID(uri, request.Namespace) {
+				el = append(el, field.Invalid(fldPath.Child("requireNamespacedSPIFFE"), uri.String(), fmt.Sprintf("must be a SPIFFE ID in namespace %q", request.Namespace)))
+			}
+		}
+	}
+
+	if consts.ForbidCommonNameWithSANs != nil && *consts.ForbidCommonNameWithSANs {
+		csr, err := decodeCSR()
+		if err != nil {
+			return approver.EvaluationResponse{}, err
+		}
+
+		if cn := csr.Subject.CommonName; len(cn) > 0 && hasSANs(csr) {
+			el = append(el, field.Invalid(fldPath.Child("forbidCommonNameWithSANs"), cn, "commonName must not be set when the request contains subject alternative names"))
+		}
+	}
+
+	if consts.RequireIdentity != nil && *consts.RequireIdentity {
+		csr, err := decodeCSR()
+		if err != nil {
+			return approver.EvaluationResponse{}, err
+		}
+
+		if len(csr.Subject.CommonName) == 0 && !hasSANs(csr) {
+			el = append(el, field.Invalid(fldPath.Child("requireIdentity"), true, "request must contain a commonName or at least one subject alternative name"))
+		}
+	}
+
+	if consts.RequiredUsages != nil && len(*consts.RequiredUsages) > 0 {
+		if missing := missingUsages(request.Spec.Usages, *consts.RequiredUsages); len(missing) > 0 {
+			el = append(el, field.Invalid(fldPath.Child("requiredUsages"), usageStrings(request.Spec.Usages), fmt.Sprintf("missing required usages: %s", strings.Join(missing, ", "))))
+		}
+	}
+
+	for i, group := range consts.MutuallyExclusiveUsages {
+		exclusive := sets.New[string]()
+		for _, usage := range group {
+			exclusive.Insert(util.CanonicalKeyUsage(usage))
+		}
+
+		requested := sets.New[string]()
+		var requestUsages []string
+		for _, usage := range request.Spec.Usages {
+			if canonical := util.CanonicalKeyUsage(usage); exclusive.Has(canonical) && !requested.Has(canonical) {
+				requested.Insert(canonical)
+				requestUsages = append(requestUsages, string(usage))
+			}
+		}
+
+		if requested.Len() > 1 {
+			var groupUsages []string
+			for _, usage := range group {
+				groupUsages = append(groupUsages, string(usage))
+			}
+			el = append(el, field.Invalid(fldPath.Child("mutuallyExclusiveUsages").Index(i), requestUsages,
+				fmt.Sprintf("at most one of the usages %s may be requested", strings.Join(groupUsages, ", "))))
+		}
+	}
+
+	if consts.EnforceDNSNameLimits != nil && *consts.EnforceDNSNameLimits {
+		csr, err := decodeCSR()
+		if err != nil {
+			return approver.EvaluationResponse{}, err
+		}
+
+		for _, dnsName := range csr.DNSNames {
+			if msg := dnsNameLimitViolation(dnsName); len(msg) > 0 {
+				el = append(el, field.Invalid(fldPath.Child("enforceDNSNameLimits"), dnsName, msg))
+			}
+		}
+	}
+
+	if consts.DNSNames != nil && consts.DNSNames.ForbidPublicSuffix != nil && *consts.DNSNames.ForbidPublicSuffix {
+		csr, err := decodeCSR()
+		if err != nil {
+			return approver.EvaluationResponse{}, err
+		}
+
+		for _, dnsName := range csr.DNSNames {
+			if util.WildcardContains(consts.DNSNames.AllowedPublicDomains, dnsName) {
+				continue
+			}
+			if suffix, ok := publicSuffix(dnsName); ok {
+				el = append(el, field.Invalid(fldPath.Child("dnsNames", "forbidPublicSuffix"), dnsName, fmt.Sprintf("must not end in the public suffix %q", suffix)))
+			}
+		}
+	}
+
+	if ipConsts := consts.IPAddresses; ipConsts != nil {
+		fldPath := fldPath.Child("ipAddresses")
+		forbid := ipConsts.Forbid != nil && *ipConsts.Forbid
+
+		csr, err := decodeCSR()
+		if err != nil {
+			return approver.EvaluationResponse{}, err
+		}
+
+		if forbid {
+			for _, ip := range csr.IPAddresses {
+				el = append(el, field.Invalid(fldPath.Child("forbid"), ip.String(), "IP SANs must not be requested"))
+			}
+		} else if len(ipConsts.AllowedCIDRs) > 0 {
+			for _, ip := range csr.IPAddresses {
+				if !cidrsContain(ipConsts.AllowedCIDRs, ip) {
+					el = append(el, field.Invalid(fldPath.Child("allowedCIDRs"), ip.String(), fmt.Sprintf("must be within one of: %s", strings.Join(ipConsts.AllowedCIDRs, ", "))))
+				}
+			}
+		}
+	}
+
+	// The CA constraints share whether the request asks for a CA. The CSR is
+	// only inspected if `spec.isCA` is unset and one of them depends on it.
+	isCA := request.Spec.IsCA
+	if caFldPath := caConstraintsPath(consts, fldPath); caFldPath != nil && !isCA {
+		csr, err := decodeCSR()
+		if err != nil {
+			return approver.EvaluationResponse{}, err
+		}
+
+		// A malformed extension is denied rather than erroring, as it will
+		// never become valid on retry.
+		if isCA, err = requestIsCA(request, csr); err != nil {
+			el = append(el, field.Invalid(caFldPath, "basicConstraints", err.Error()))
+		}
+	}
+
+	if consts.IsCA != nil {
+		if request.Spec.IsCA != *consts.IsCA {
+			el = append(el, field.Invalid(fldPath.Child("isCA"), request.Spec.IsCA, fmt.Sprintf("must be %t", *consts.IsCA)))
+		} else if !*consts.IsCA && isCA {
+			el = append(el, field.Invalid(fldPath.Child("isCA"), true, "must be false, but the CSR requests a CA in its basicConstraints extension"))
+		}
+	}
+
+	if consts.CAMustIncludeUsages != nil && len(*consts.CAMustIncludeUsages) > 0 && isCA {
+		if missing := missingUsages(request.Spec.Usages, *consts.CAMustIncludeUsages); len(missing) > 0 {
+			el = append(el, field.Invalid(fldPath.Child("caMustIncludeUsages"), usageStrings(request.Spec.Usages), fmt.Sprintf("CA requests are missing required usages: %s", strings.Join(missing, ", "))))
+		}
+	}
+
+	if issuerRefs := consts.IsCAAllowedForIssuers; issuerRefs != nil && isCA {
+		kind, group := util.IssuerRefKindGroup(request)
+		var matches bool
+		for i := range *issuerRefs {
+			var err error
+			matches, err = util.IssuerRefMatches(&(*issuerRefs)[i], request, kind, group, issuerReadyUnsupported)
+			if err != nil {
+				return approver.EvaluationResponse{}, fmt.Errorf("failed to match isCAAllowedForIssuers: %w", err)
+			}
+			if matches {
+				break
+			}
+		}
+		if !matches {
+			issuerRef := fmt.Sprintf("%s/%s/%s", group, kind, request.Spec.IssuerRef.Name)
+			el = append(el, field.Invalid(fldPath.Child("isCAAllowedForIssuers"), issuerRef, "CA requests must reference one of the allowed issuers"))
+		}
+	}
+
+	if consts.SingleValuedSubjectAttributes != nil && len(*consts.SingleValuedSubjectAttributes) > 0 {
+		csr, err := decodeCSR()
+		if err != nil {
+			return approver.EvaluationResponse{}, err
+		}
+
+		for _, attribute := range *consts.SingleValuedSubjectAttributes {
+			if values := subjectAttributeValues(csr, attribute); len(values) > 1 {
+				el = append(el, field.Invalid(fldPath.Child("singleValuedSubjectAttributes"), values, fmt.Sprintf("subject attribute %s must not have more than one value", attribute)))
+			}
+		}
+	}
+
+	if consts.ForbiddenSubjectAttributes != nil && len(*consts.ForbiddenSubjectAttributes) > 0 {
+		csr, err := decodeCSR()
+		if err != nil {
+			return approver.EvaluationResponse{}, err
+		}
+
+		for _, attribute := range *consts.ForbiddenSubjectAttributes {
+			if values := subjectAttributeValues(csr, attribute); len(values) > 0 {
+				el = append(el, field.Invalid(fldPath.Child("forbiddenSubjectAttributes"), values, fmt.Sprintf("subject attribute %s must not be present", attribute)))
+			}
+		}
+	}
+
+	if consts.Subject != nil && consts.Subject.AllowedOIDs != nil {
+		csr, err := decodeCSR()
+		if err != nil {
+			return approver.EvaluationResponse{}, err
+		}
+
+		oids, err := unmodelledSubjectOIDs(csr)
+		if err != nil {
+			return approver.EvaluationResponse{}, err
+		}
+
+		for _, oid := range oids {
+			if !slices.Contains(*consts.Subject.AllowedOIDs, oid) {
+				el = append(el, field.Invalid(fldPath.Child("subject", "allowedOIDs"), oid, fmt.Sprintf("subject attribute must be one of %v", *consts.Subject.AllowedOIDs)))
+			}
+		}
+	}
+
+	if consts.Subject != nil {
+		for _, mustEqual := range []struct {
+			enabled   *bool
+			name      string
+			attribute policyapi.CertificateRequestPolicySubjectAttribute
+		}{
+			{consts.Subject.OrganizationsMustEqualNamespace, "organizationsMustEqualNamespace", policyapi.CertificateRequestPolicySubjectAttributeOrganizations},
+			{consts.Subject.OrganizationalUnitsMustEqualNamespace, "organizationalUnitsMustEqualNamespace", policyapi.CertificateRequestPolicySubjectAttributeOrganizationalUnits},
+			{consts.Subject.CommonNameMustEqualNamespace, "commonNameMustEqualNamespace", policyapi.CertificateRequestPolicySubjectAttributeCommonName},
+		} {
+			if mustEqual.enabled == nil || !*mustEqual.enabled {
+				continue
+			}
+
+			csr, err := decodeCSR()
+			if err != nil {
+				return approver.EvaluationResponse{}, err
+			}
+
+			for _, value := range subjectAttributeValues(csr, mustEqual.attribute) {
+				if value != request.Namespace {
+					el = append(el, field.Invalid(fldPath.Child("subject", mustEqual.name), value, fmt.Sprintf("subject attribute %s must equal the request namespace %q", mustEqual.attribute, request.Namespace)))
+				}
+			}
+		}
+	}
+
+	if allowed := consts.AllowedCriticalExtensionOIDs; allowed != nil {
+		csr, err := decodeCSR()
+		if err != nil {
+			return approver.EvaluationResponse{}, err
+		}
+
+		fldPath := fldPath.Child("allowedCriticalExtensionOIDs")
+		extensions, err := requestedExtensions(csr)
+		if err != nil {
+			el = append(el, field.Invalid(fldPath, "extensionRequest", err.Error()))
+		}
+		for _, extension := range extensions {
+			if oid := extension.Id.String(); extension.Critical && !slices.Contains(*allowed, oid) {
+				el = append(el, field.Invalid(fldPath, oid, fmt.Sprintf("critical extension must be one of %v", *allowed)))
+			}
+		}
+	}
+
+	if consts.ForbidDuplicateSANs != nil && *consts.ForbidDuplicateSANs {
+		csr, err := decodeCSR()
+		if err != nil {
+			return approver.EvaluationResponse{}, err
+		}
+
+		for _, san := range []struct {
+			name   string
+			values []string
+		}{
+			{"dnsNames", csr.DNSNames},
+			{"ipAddresses", ipStrings(csr.IPAddresses)},
+			{"uris", uriStrings(csr.URIs)},
+			{"emailAddresses", csr.EmailAddresses},
+		} {
+			for _, value := range duplicates(san.values) {
+				el = append(el, field.Invalid(fldPath.Child("forbidDuplicateSANs"), value, fmt.Sprintf("duplicate value in %s", san.name)))
+			}
+		}
+	}
+
+	if consts.CommonNameMustBeInDNSNames != nil && *consts.CommonNameMustBeInDNSNames {
+		csr, err := decodeCSR()
+		if err != nil {
+			return approver.EvaluationResponse{}, err
+		}
+
+		if cn := csr.Subject.CommonName; len(cn) > 0 && !slices.ContainsFunc(csr.DNSNames, func(dnsName string) bool {
+			return strings.EqualFold(dnsName, cn)
+		}) {
+			el = append(el, field.Invalid(fldPath.Child("commonNameMustBeInDNSNames"), cn, fmt.Sprintf("commonName must be one of the requested DNS names %v", csr.DNSNames)))
+		}
+	}
+
+	if consts.MaxPathLen != nil {
+		csr, err := decodeCSR()
+		if err != nil {
+			return approver.EvaluationResponse{}, err
+		}
+
+		// A malformed extension is denied rather than erroring, as it will
+		// never become valid on retry.
+		if bc, err := parseBasicConstraints(csr); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("maxPathLen"), "basicConstraints", err.Error()))
+		} else if bc.isCA || request.Spec.IsCA {
+			if bc.maxPathLen == nil {
+				el = append(el, field.Invalid(fldPath.Child("maxPathLen"), "unlimited", fmt.Sprintf("a CA must request a path length <= %d", *consts.MaxPathLen)))
+			} else if *bc.maxPathLen > *consts.MaxPathLen {
+				el = append(el, field.Invalid(fldPath.Child("maxPathLen"), strconv.Itoa(*bc.maxPathLen), strconv.Itoa(*consts.MaxPathLen)))
+			}
+		}
 	}
 
 	// If there are errors, then return not approved and the aggregated errors
 	if len(el) > 0 {
-		return approver.EvaluationResponse{Result: approver.ResultDenied, Message: el.ToAggregate().Error()}, nil
+		return approver.EvaluationResponse{
+			Result:  approver.ResultDenied,
+			Message: el.ToAggregate().Error(),
+			Reasons: approver.FieldReasons(el, fieldReasons, approver.ReasonDenied),
+		}, nil
 	}
 
 	// If no evaluation errors resulting from this policy, return not denied
 	return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
 }
 
+// fieldReasons are the reasons given for violations of each constraint,
+// including its children.
+var fieldReasons = map[string]approver.Reason{
+	"spec.constraints.maxDuration":                                   approver.ReasonDurationTooLong,
+	"spec.constraints.minDuration":                                   approver.ReasonDurationTooShort,
+	"spec.constraints.allowedDurations":                              approver.ReasonDurationNotAllowed,
+	"spec.constraints.duration.max":                                  approver.ReasonDurationTooLong,
+	"spec.constraints.duration.min":                                  approver.ReasonDurationTooShort,
+	"spec.constraints.duration.values":                               approver.ReasonDurationNotAllowed,
+	"spec.constraints.duration.granularity":                          approver.ReasonDurationNotAllowed,
+	"spec.constraints.privateKey.algorithm":                          approver.ReasonKeyAlgorithmNotAllowed,
+	"spec.constraints.privateKey.maxSize":                            approver.ReasonKeyTooLarge,
+	"spec.constraints.privateKey.minSize":                            approver.ReasonKeyTooSmall,
+	"spec.constraints.privateKey.allowedECDSACurves":                 approver.ReasonKeyCurveNotAllowed,
+	"spec.constraints.privateKey.allowedEd25519":                     approver.ReasonKeyAlgorithmNotAllowed,
+	"spec.constraints.privateKey.minRSAPublicExponent":               approver.ReasonKeyExponentTooSmall,
+	"spec.constraints.privateKey.allowedPublicKeys":                  approver.ReasonPublicKeyNotAllowed,
+	"spec.constraints.privateKey.matchCertificate":                   approver.ReasonKeyMismatch,
+	"spec.constraints.commonName.maxLength":                          approver.ReasonCommonNameTooLong,
+	"spec.constraints.commonName.allowedCharacters":                  approver.ReasonCommonNameInvalidCharacters,
+	"spec.constraints.forbidCommonNameWithSANs":                      approver.ReasonCommonNameWithSANs,
+	"spec.constraints.requireIdentity":                               approver.ReasonIdentityMissing,
+	"spec.constraints.commonNameMustBeInDNSNames":                    approver.ReasonCommonNameNotInDNSNames,
+	"spec.constraints.requireNamespacedSPIFFE":                       approver.ReasonURINotNamespaced,
+	"spec.constraints.enforceDNSNameLimits":                          approver.ReasonDNSNameInvalid,
+	"spec.constraints.dnsNames.forbidPublicSuffix":                   approver.ReasonDNSNamePublicSuffix,
+	"spec.constraints.ipAddresses.forbid":                            approver.ReasonIPAddressForbidden,
+	"spec.constraints.ipAddresses.allowedCIDRs":                      approver.ReasonIPAddressOutOfRange,
+	"spec.constraints.forbidDuplicateSANs":                           approver.ReasonDuplicateSANs,
+	"spec.constraints.requiredUsages":                                approver.ReasonUsageMissing,
+	"spec.constraints.mutuallyExclusiveUsages":                       approver.ReasonUsagesMutuallyExclusive,
+	"spec.constraints.isCA":                                          approver.ReasonIsCAMismatch,
+	"spec.constraints.caMustIncludeUsages":                           approver.ReasonCAUsageMissing,
+	"spec.constraints.isCAAllowedForIssuers":                         approver.ReasonCAIssuerNotAllowed,
+	"spec.constraints.maxPathLen":                                    approver.ReasonPathLenTooLong,
+	"spec.constraints.singleValuedSubjectAttributes":                 approver.ReasonSubjectAttributeMultiValued,
+	"spec.constraints.forbiddenSubjectAttributes":                    approver.ReasonSubjectAttributeForbidden,
+	"spec.constraints.subject.allowedOIDs":                           approver.ReasonSubjectOIDNotAllowed,
+	"spec.constraints.allowedCriticalExtensionOIDs":                  approver.ReasonCriticalExtensionNotAllowed,
+	"spec.constraints.subject.organizationsMustEqualNamespace":       approver.ReasonSubjectNamespaceMismatch,
+	"spec.constraints.subject.organizationalUnitsMustEqualNamespace": approver.ReasonSubjectNamespaceMismatch,
+	"spec.constraints.subject.commonNameMustEqualNamespace":          approver.ReasonSubjectNamespaceMismatch,
+}
+
+// issuerReadyUnsupported is passed as the issuer readiness check when
+// matching issuerRefs of constraints, which cannot require the issuer to be
+// ready. Validation rejects `requireReady` in these issuerRefs.
+func issuerReadyUnsupported() (bool, error) {
+	return false, errors.New("requireReady is not supported")
+}
+
 // decodePublicKey will return the algorithm and size of the given public key.
 // If the public key cannot be decoded, an error is returned.
 func decodePublicKey(pub interface{}) (cmapi.PrivateKeyAlgorithm, int, error) {
@@ -123,3 +573,243 @@ func decodePublicKey(pub interface{}) (cmapi.PrivateKeyAlgorithm, int, error) {
 		return "", -1, fmt.Errorf("unrecognised public key type %T", pub)
 	}
 }
+
+// compileAllowedCharacters compiles an allowedCharacters pattern so that it
+// matches the longest prefix of a Common Name whose characters all match it.
+// A Common Name is allowed if the whole of it is matched, i.e. it matches
+// `^(?:allowed)*$`, otherwise the character following the match is the first
+// which is not allowed.
+func compileAllowedCharacters(allowed string) (*regexp.Regexp, error) {
+	return regexp.Compile(fmt.Sprintf("^(?:%s)*", allowed))
+}
+
+// isNamespacedSPIFFEID returns true if the given URI is a well formed SPIFFE
+// ID whose path is scoped to the given namespace.
+func isNamespacedSPIFFEID(uri *url.URL, namespace string) bool {
+	if uri.Scheme != "spiffe" || len(uri.Host) == 0 || len(uri.Port()) > 0 {
+		return false
+	}
+	if uri.User != nil || len(uri.Opaque) > 0 || len(uri.RawQuery) > 0 || len(uri.Fragment) > 0 {
+		return false
+	}
+	// The path is checked as written, so that percent-encoded segments are
+	// rejected rather than decoded.
+	segments := strings.Split(uri.EscapedPath(), "/")
+	if len(segments) < 4 || len(segments[0]) > 0 {
+		return false
+	}
+	for _, segment := range segments[1:] {
+		if !isSPIFFEPathSegment(segment) {
+			return false
+		}
+	}
+	return len(namespace) > 0 && segments[1] == "ns" && segments[2] == namespace
+}
+
+// isSPIFFEPathSegment returns true if the given segment of a SPIFFE ID path is
+// valid according to the SPIFFE ID specification: it must not be empty, `.`
+// or `..`, and may only contain letters, digits, dots, dashes and
+// underscores.
+func isSPIFFEPathSegment(segment string) bool {
+	if len(segment) == 0 || segment == "." || segment == ".." {
+		return false
+	}
+	for _, r := range segment {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '.', r == '-', r == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+const (
+	// maxDNSNameLength is the maximum length of a DNS name, excluding the
+	// optional trailing dot, as defined by RFC 1035.
+	maxDNSNameLength = 253
+
+	// maxDNSLabelLength is the maximum length of a single DNS label as defined
+	// by RFC 1035.
+	maxDNSLabelLength = 63
+)
+
+// dnsNameLimitViolation returns a description of how the given DNS name
+// exceeds the RFC 1035 length limits. Returns an empty string if the DNS name
+// is within the limits.
+func dnsNameLimitViolation(dnsName string) string {
+	name := strings.TrimSuffix(dnsName, ".")
+	if len(name) > maxDNSNameLength {
+		return fmt.Sprintf("must be no more than %d characters", maxDNSNameLength)
+	}
+	for _, label := range strings.Split(name, ".") {
+		if len(label) > maxDNSLabelLength {
+			return fmt.Sprintf("label %q must be no more than %d characters", label, maxDNSLabelLength)
+		}
+	}
+	return ""
+}
+
+// missingUsages returns the required usages which are not in the requested
+// usages, comparing equivalent usage spellings as the same usage.
+func missingUsages(requested, required []cmapi.KeyUsage) []string {
+	canonical := sets.New[string]()
+	for _, usage := range requested {
+		canonical.Insert(util.CanonicalKeyUsage(usage))
+	}
+
+	var missing []string
+	for _, usage := range required {
+		if !canonical.Has(util.CanonicalKeyUsage(usage)) {
+			missing = append(missing, string(usage))
+		}
+	}
+	return missing
+}
+
+// usageStrings returns the given usages as strings.
+func usageStrings(usages []cmapi.KeyUsage) []string {
+	var strs []string
+	for _, usage := range usages {
+		strs = append(strs, string(usage))
+	}
+	return strs
+}
+
+// publicSuffix returns the suffix of the given DNS name on the public suffix
+// list, and true if it has one. Names whose suffix isn't on the list, such as
+// `svc.cluster.local`, return false.
+func publicSuffix(dnsName string) (string, bool) {
+	name := strings.ToLower(strings.TrimSuffix(strings.TrimPrefix(dnsName, "*."), "."))
+	suffix, icann := publicsuffix.PublicSuffix(name)
+	// Suffixes which aren't on the list are returned as the last label and
+	// are not ICANN managed. Privately managed suffixes on the list, such as
+	// `github.io`, always have more than one label.
+	if !icann && !strings.Contains(suffix, ".") {
+		return "", false
+	}
+	return suffix, true
+}
+
+// hasSANs returns true if the given CSR requests any subject alternative
+// names.
+func hasSANs(csr *x509.CertificateRequest) bool {
+	return len(csr.DNSNames) > 0 || len(csr.IPAddresses) > 0 || len(csr.URIs) > 0 || len(csr.EmailAddresses) > 0
+}
+
+// duplicates returns the values which appear more than once in the given
+// list, in the order they are first repeated.
+func duplicates(values []string) []string {
+	var dups []string
+	seen, reported := sets.New[string](), sets.New[string]()
+	for _, value := range values {
+		if seen.Has(value) && !reported.Has(value) {
+			dups = append(dups, value)
+			reported.Insert(value)
+		}
+		seen.Insert(value)
+	}
+	return dups
+}
+
+// ipStrings returns the string form of the given IP addresses.
+func ipStrings(ips []net.IP) []string {
+	var strs []string
+	for _, ip := range ips {
+		strs = append(strs, ip.String())
+	}
+	return strs
+}
+
+// uriStrings returns the string form of the given URIs.
+func uriStrings(uris []*url.URL) []string {
+	var strs []string
+	for _, uri := range uris {
+		strs = append(strs, uri.String())
+	}
+	return strs
+}
+
+// owningCertificate returns the Certificate which controls the given request.
+// If the request is not owned by a Certificate, nil is returned.
+func (c *constraints) owningCertificate(ctx context.Context, request *cmapi.CertificateRequest) (*cmapi.Certificate, error) {
+	name, ok := util.OwningCertificateName(request)
+	if !ok {
+		return nil, nil
+	}
+
+	if c.lister == nil {
+		return nil, fmt.Errorf("%s %w", c.Name(), approver.ErrNotPrepared)
+	}
+
+	// Requests whose owning Certificate no longer exists are treated as
+	// standalone, as the SelectorCertificate predicate does.
+	var cert cmapi.Certificate
+	err := c.lister.Get(ctx, client.ObjectKey{Namespace: request.Namespace, Name: name}, &cert)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owning Certificate %s/%s: %w", request.Namespace, name, err)
+	}
+	return &cert, nil
+}
+
+// allowedPublicKeys returns the set of normalised public key fingerprints
+// stored in the referenced ConfigMap. The ConfigMap is read from the cache
+// unless refresh is true, or it hasn't been read before.
+func (c *constraints) allowedPublicKeys(ctx context.Context, ref *policyapi.CertificateRequestPolicyConfigMapReference, refresh bool) (sets.Set[string], error) {
+	if c.reader == nil {
+		return nil, fmt.Errorf("%s %w", c.Name(), approver.ErrNotPrepared)
+	}
+
+	var (
+		key     = client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}
+		allowed sets.Set[string]
+		err     error
+	)
+	if refresh {
+		allowed, err = c.publicKeys.Refresh(ctx, c.reader, key)
+	} else {
+		allowed, err = c.publicKeys.Get(ctx, c.reader, key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allowed public keys ConfigMap %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+	return allowed, nil
+}
+
+// parsePublicKeys returns the set of normalised public key fingerprints
+// stored in the values of the given ConfigMap.
+func parsePublicKeys(cm *corev1.ConfigMap) sets.Set[string] {
+	allowed := sets.New[string]()
+	for _, fingerprint := range cm.Data {
+		allowed.Insert(normaliseFingerprint(fingerprint))
+	}
+	return allowed
+}
+
+// publicKeyFingerprint returns the hex encoded SHA-256 fingerprint of the
+// given DER encoded SubjectPublicKeyInfo.
+func publicKeyFingerprint(spki []byte) string {
+	sum := sha256.Sum256(spki)
+	return hex.EncodeToString(sum[:])
+}
+
+// normaliseFingerprint removes whitespace and colons from the given
+// fingerprint, and returns it in lower case.
+func normaliseFingerprint(fingerprint string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(fingerprint), ":", ""))
+}
+
+// cidrsContain returns true if the given IP address is contained in at least
+// one of the given CIDR ranges. Ranges which fail to parse contain nothing.
+func cidrsContain(cidrs []string, ip net.IP) bool {
+	for _, cidr := range cidrs {
+		if _, network, err := net.ParseCIDR(cidr); err == nil && network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
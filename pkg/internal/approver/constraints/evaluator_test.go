@@ -18,16 +18,31 @@ package constraints
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"math/big"
+	"strings"
 	"testing"
 	"time"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
 	"github.com/cert-manager/approver-policy/pkg/approver"
@@ -38,13 +53,47 @@ func Test_Evaluate(t *testing.T) {
 		ecdsaAlg   = cmapi.ECDSAKeyAlgorithm
 		ed25519Alg = cmapi.Ed25519KeyAlgorithm
 		rsaAlg     = cmapi.RSAKeyAlgorithm
+
+		rsaCSR   = csrFrom(t, x509.RSA)
+		ecdsaCSR = csrFrom(t, x509.ECDSA)
+
+		spiffeCSR = csrFrom(t, x509.ECDSA,
+			gen.SetCSRURIsFromStrings("spiffe://cluster.local/ns/sandbox/sa/app"),
+		)
+		nonSPIFFECSR = csrFrom(t, x509.ECDSA,
+			gen.SetCSRURIsFromStrings("spiffe://cluster.local/ns/sandbox/sa/app", "https://example.com/ns/sandbox/sa/app"),
+		)
+
+		allowedPublicKeysRef = &policyapi.CertificateRequestPolicyConfigMapReference{Name: "allowed-public-keys", Namespace: "cert-manager"}
+
+		ecdsaCert = gen.Certificate("ecdsa-cert",
+			gen.SetCertificateNamespace("sandbox"),
+			gen.SetCertificateKeyAlgorithm(cmapi.ECDSAKeyAlgorithm),
+			gen.SetCertificateKeySize(256),
+		)
+		ecdsa384Cert = gen.Certificate("ecdsa-384-cert",
+			gen.SetCertificateNamespace("sandbox"),
+			gen.SetCertificateKeyAlgorithm(cmapi.ECDSAKeyAlgorithm),
+			gen.SetCertificateKeySize(384),
+		)
+		undeclaredCert = gen.Certificate("undeclared-cert",
+			gen.SetCertificateNamespace("sandbox"),
+		)
+		matchCertificatePolicy = policyapi.CertificateRequestPolicySpec{
+			Constraints: &policyapi.CertificateRequestPolicyConstraints{
+				PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+					MatchCertificate: ptr.To(true),
+				},
+			},
+		}
 	)
 
 	tests := map[string]struct {
-		policy      policyapi.CertificateRequestPolicySpec
-		request     *cmapi.CertificateRequest
-		expResponse approver.EvaluationResponse
-		expErr      bool
+		policy          policyapi.CertificateRequestPolicySpec
+		request         *cmapi.CertificateRequest
+		existingObjects []runtime.Object
+		expResponse     approver.EvaluationResponse
+		expErr          bool
 	}{
 		"passes on Ed25519 CSR with private key constraints set": {
 			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t, x509.Ed25519))),
@@ -57,6 +106,274 @@ func Test_Evaluate(t *testing.T) {
 			},
 			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""},
 		},
+		"if constraints contains allowed ECDSA curves and CSR uses P-224, return Denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFromCurve(t, elliptic.P224()))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						AllowedECDSACurves: &[]string{"P-256", "P-384"},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.privateKey.allowedECDSACurves"), "P-224", "ECDSA curve must be one of [P-256 P-384]"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints contains allowed ECDSA curves and CSR uses P-256, return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFromCurve(t, elliptic.P256()))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						AllowedECDSACurves: &[]string{"P-256", "P-384"},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""},
+		},
+		"if constraints contains allowed ECDSA curves and CSR uses P-384, return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFromCurve(t, elliptic.P384()))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						AllowedECDSACurves: &[]string{"P-256", "P-384"},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""},
+		},
+		"if constraints contains allowed ECDSA curves and CSR uses P-521, return Denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFromCurve(t, elliptic.P521()))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						AllowedECDSACurves: &[]string{"P-256", "P-384"},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.privateKey.allowedECDSACurves"), "P-521", "ECDSA curve must be one of [P-256 P-384]"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints contains allowed ECDSA curves and CSR uses RSA, return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t, x509.RSA))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						AllowedECDSACurves: &[]string{"P-256"},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""},
+		},
+		"if constraints contains min RSA public exponent and CSR uses exponent 3, return Denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFromRSAExponent(t, 3))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						MinRSAPublicExponent: ptr.To(65537),
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.privateKey.minRSAPublicExponent"), 3, "RSA public exponent must be at least 65537"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints contains min RSA public exponent and CSR uses exponent 65537, return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFromRSAExponent(t, 65537))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						MinRSAPublicExponent: ptr.To(65537),
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""},
+		},
+		"if constraints contains min RSA public exponent and CSR uses ECDSA, return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						MinRSAPublicExponent: ptr.To(65537),
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""},
+		},
+		"if constraints disallows Ed25519 and CSR uses Ed25519, return Denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t, x509.Ed25519))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						AllowedEd25519: ptr.To(false),
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.privateKey.allowedEd25519"), "Ed25519", "Ed25519 private keys are not allowed"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints disallows Ed25519 and CSR uses ECDSA, return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						AllowedEd25519: ptr.To(false),
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""},
+		},
+		"if constraints allows Ed25519 and CSR uses Ed25519, return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t, x509.Ed25519))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						AllowedEd25519: ptr.To(true),
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""},
+		},
+		"if constraints contains max path length and request is not for a CA, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{MaxPathLen: ptr.To(0)},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""},
+		},
+		"if constraints contains max path length and CA request has a lower path length, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA, setCSRBasicConstraints(t, true, ptr.To(0)))),
+				gen.SetCertificateRequestIsCA(true),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{MaxPathLen: ptr.To(1)},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""},
+		},
+		"if constraints contains max path length and CA request has an equal path length, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA, setCSRBasicConstraints(t, true, ptr.To(1)))),
+				gen.SetCertificateRequestIsCA(true),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{MaxPathLen: ptr.To(1)},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""},
+		},
+		"if constraints contains max path length and CA request has a greater path length, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA, setCSRBasicConstraints(t, true, ptr.To(2)))),
+				gen.SetCertificateRequestIsCA(true),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{MaxPathLen: ptr.To(1)},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.maxPathLen"), "2", "1"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints contains max path length and CA request has no path length, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA, setCSRBasicConstraints(t, true, nil))),
+				gen.SetCertificateRequestIsCA(true),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{MaxPathLen: ptr.To(1)},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.maxPathLen"), "unlimited", "a CA must request a path length <= 1"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints contains max path length and CA request has no basic constraints, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA)),
+				gen.SetCertificateRequestIsCA(true),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{MaxPathLen: ptr.To(0)},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.maxPathLen"), "unlimited", "a CA must request a path length <= 0"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints contains max path length and CSR requests a CA with a greater path length, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA, setCSRBasicConstraints(t, true, ptr.To(3)))),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{MaxPathLen: ptr.To(2)},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.maxPathLen"), "3", "2"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints require maxPathLen and CSR requests a negative path length, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA, setCSRRawBasicConstraints(t, struct {
+					IsCA       bool
+					MaxPathLen int
+				}{true, -1}))),
+				gen.SetCertificateRequestIsCA(true),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{MaxPathLen: ptr.To(1)},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.maxPathLen"), "basicConstraints", "basicConstraints path length must not be negative, got -1"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if several CA constraints are defined and the CSR has a malformed basicConstraints extension, return Denied once": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA, setCSRRawBasicConstraints(t, struct {
+					IsCA       bool
+					MaxPathLen int
+				}{true, -1}))),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					IsCA:                  ptr.To(false),
+					CAMustIncludeUsages:   &[]cmapi.KeyUsage{cmapi.UsageCertSign},
+					IsCAAllowedForIssuers: &[]policyapi.CertificateRequestPolicySelectorIssuerRef{},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.isCA"), "basicConstraints", "basicConstraints path length must not be negative, got -1"),
+				}.ToAggregate().Error(),
+			},
+		},
 		"if no constraints defined, should return NotDenied": {
 			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA))),
 			policy: policyapi.CertificateRequestPolicySpec{
@@ -77,7 +394,7 @@ func Test_Evaluate(t *testing.T) {
 			expResponse: approver.EvaluationResponse{
 				Result: approver.ResultDenied,
 				Message: field.ErrorList{
-					field.Invalid(field.NewPath("spec.constraints.maxDuration"), "nil", "24h0m0s"),
+					field.Invalid(field.NewPath("spec.constraints.maxDuration"), "nil", "duration must be specified and <= 24h0m0s"),
 					field.Invalid(field.NewPath("spec.constraints.minDuration"), "nil", "1h0m0s"),
 				}.ToAggregate().Error(),
 			},
@@ -114,73 +431,1917 @@ func Test_Evaluate(t *testing.T) {
 				}.ToAggregate().Error(),
 			},
 		},
-		"if constraints contains private key but CSR fails to decode, return error": {
+		"if constraints contains max duration but duration wasn't requested, return Denied": {
 			request: gen.CertificateRequest("",
 				gen.SetCertificateRequestDuration(nil),
 			),
 			policy: policyapi.CertificateRequestPolicySpec{
 				Constraints: &policyapi.CertificateRequestPolicyConstraints{
-					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{},
+					MaxDuration: &metav1.Duration{Duration: time.Hour * 24},
 				},
 			},
-			expErr:      true,
-			expResponse: approver.EvaluationResponse{},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.maxDuration"), "nil", "duration must be specified and <= 24h0m0s"),
+				}.ToAggregate().Error(),
+			},
 		},
-		"if constraints contains private key but CSR uses the wrong key type and is too small, return error": {
+		"if constraints contains max duration and requested duration is smaller, return NotDenied": {
 			request: gen.CertificateRequest("",
-				gen.SetCertificateRequestCSR(csrFrom(t, x509.RSA)),
+				gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour}),
 			),
 			policy: policyapi.CertificateRequestPolicySpec{
 				Constraints: &policyapi.CertificateRequestPolicyConstraints{
-					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
-						Algorithm: &ecdsaAlg,
-						MinSize:   ptr.To(4000),
-					},
+					MaxDuration: &metav1.Duration{Duration: time.Hour * 24},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints contains max duration and requested duration is equal, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour * 24}),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					MaxDuration: &metav1.Duration{Duration: time.Hour * 24},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints contains max duration and requested duration is larger, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour * 25}),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					MaxDuration: &metav1.Duration{Duration: time.Hour * 24},
 				},
 			},
 			expResponse: approver.EvaluationResponse{
 				Result: approver.ResultDenied,
 				Message: field.ErrorList{
-					field.Invalid(field.NewPath("spec.constraints.privateKey.algorithm"), "RSA", "ECDSA"),
-					field.Invalid(field.NewPath("spec.constraints.privateKey.minSize"), "2048", "4000"),
+					field.Invalid(field.NewPath("spec.constraints.maxDuration"), "25h0m0s", "24h0m0s"),
 				}.ToAggregate().Error(),
 			},
 		},
-		"if constraints contains private key but CSR uses the wrong key type and is too large, return error": {
+		"if constraints does not require duration and duration wasn't requested, return NotDenied": {
 			request: gen.CertificateRequest("",
-				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA)),
+				gen.SetCertificateRequestDuration(nil),
 			),
 			policy: policyapi.CertificateRequestPolicySpec{
 				Constraints: &policyapi.CertificateRequestPolicyConstraints{
-					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
-						Algorithm: &rsaAlg,
-						MaxSize:   ptr.To(200),
-					},
+					MinDuration:     &metav1.Duration{Duration: time.Hour},
+					MaxDuration:     &metav1.Duration{Duration: time.Hour * 24},
+					RequireDuration: ptr.To(false),
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints does not require duration and requested duration is larger, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour * 25}),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					MaxDuration:     &metav1.Duration{Duration: time.Hour * 24},
+					RequireDuration: ptr.To(false),
 				},
 			},
 			expResponse: approver.EvaluationResponse{
 				Result: approver.ResultDenied,
 				Message: field.ErrorList{
-					field.Invalid(field.NewPath("spec.constraints.privateKey.algorithm"), "ECDSA", "RSA"),
-					field.Invalid(field.NewPath("spec.constraints.privateKey.maxSize"), "256", "200"),
+					field.Invalid(field.NewPath("spec.constraints.maxDuration"), "25h0m0s", "24h0m0s"),
 				}.ToAggregate().Error(),
 			},
 		},
-	}
-
-	for name, test := range tests {
-		t.Run(name, func(t *testing.T) {
-			response, err := Approver().Evaluate(context.TODO(), &policyapi.CertificateRequestPolicy{Spec: test.policy}, test.request)
-			assert.Equal(t, test.expErr, err != nil, "%v", err)
-			assert.Equal(t, test.expResponse, response, "unexpected evaluation response")
-		})
-	}
-}
-
-func csrFrom(t *testing.T, keyAlgorithm x509.PublicKeyAlgorithm) []byte {
-	csr, _, err := gen.CSR(keyAlgorithm)
-	if err != nil {
-		t.Fatal(err)
+		"if constraints contains allowed durations and requested duration is allowed, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour * 168}),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					AllowedDurations: &[]metav1.Duration{{Duration: time.Hour * 24}, {Duration: time.Hour * 168}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints contains allowed durations and requested duration is not allowed, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour * 48}),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					AllowedDurations: &[]metav1.Duration{{Duration: time.Hour * 24}, {Duration: time.Hour * 168}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.allowedDurations"), "48h0m0s", "duration must be one of: 24h0m0s, 168h0m0s"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints contains allowed durations but duration wasn't requested, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestDuration(nil),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					AllowedDurations: &[]metav1.Duration{{Duration: time.Hour * 24}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.allowedDurations"), "nil", "duration must be specified and one of: 24h0m0s"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints contains allowed durations and does not require duration and duration wasn't requested, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestDuration(nil),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					AllowedDurations: &[]metav1.Duration{{Duration: time.Hour * 24}},
+					RequireDuration:  ptr.To(false),
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints contains allowed durations and max duration and requested duration satisfies only max duration, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour * 48}),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					MaxDuration:      &metav1.Duration{Duration: time.Hour * 168},
+					AllowedDurations: &[]metav1.Duration{{Duration: time.Hour * 24}, {Duration: time.Hour * 168}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.allowedDurations"), "48h0m0s", "duration must be one of: 24h0m0s, 168h0m0s"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if duration constraint is satisfied by the requested duration, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour * 24}),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					Duration: &policyapi.CertificateRequestPolicyConstraintsDuration{
+						Min:    &metav1.Duration{Duration: time.Hour},
+						Max:    &metav1.Duration{Duration: time.Hour * 168},
+						Values: &[]metav1.Duration{{Duration: time.Hour * 24}, {Duration: time.Hour * 168}},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""},
+		},
+		"if duration constraint isn't satisfied by the requested duration, return Denied under the duration path": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour * 336}),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					Duration: &policyapi.CertificateRequestPolicyConstraintsDuration{
+						Max:    &metav1.Duration{Duration: time.Hour * 168},
+						Values: &[]metav1.Duration{{Duration: time.Hour * 24}, {Duration: time.Hour * 168}},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.duration.max"), "336h0m0s", "168h0m0s"),
+					field.Invalid(field.NewPath("spec.constraints.duration.values"), "336h0m0s", "duration must be one of: 24h0m0s, 168h0m0s"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if duration constraint requires a duration which wasn't requested, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestDuration(nil),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					Duration: &policyapi.CertificateRequestPolicyConstraintsDuration{
+						Min: &metav1.Duration{Duration: time.Hour},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.duration.min"), "nil", "1h0m0s"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if duration constraint doesn't require a duration and none was requested, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestDuration(nil),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					Duration: &policyapi.CertificateRequestPolicyConstraintsDuration{
+						Min:      &metav1.Duration{Duration: time.Hour},
+						Max:      &metav1.Duration{Duration: time.Hour * 24},
+						Required: ptr.To(false),
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""},
+		},
+		"if duration constraint contains a granularity and the duration is not a multiple, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour * 36}),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					Duration: &policyapi.CertificateRequestPolicyConstraintsDuration{
+						Granularity: &metav1.Duration{Duration: time.Hour * 24},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.duration.granularity"), "36h0m0s", "duration must be a multiple of 24h0m0s"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if duration constraint contains a granularity and the duration is a multiple, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour * 48}),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					Duration: &policyapi.CertificateRequestPolicyConstraintsDuration{
+						Granularity: &metav1.Duration{Duration: time.Hour * 24},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""},
+		},
+		"if duration constraint contains a granularity and no duration was requested, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestDuration(nil),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					Duration: &policyapi.CertificateRequestPolicyConstraintsDuration{
+						Granularity: &metav1.Duration{Duration: time.Hour * 24},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.duration.granularity"), "nil", "duration must be specified and a multiple of 24h0m0s"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if both duration constraint and deprecated duration fields are set, e.g. merged from a profile, return Denied if either is violated": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour * 48}),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					MaxDuration: &metav1.Duration{Duration: time.Hour * 24},
+					Duration: &policyapi.CertificateRequestPolicyConstraintsDuration{
+						Max: &metav1.Duration{Duration: time.Hour * 168},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.maxDuration"), "48h0m0s", "24h0m0s"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints contains private key but CSR fails to decode, return error": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestDuration(nil),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{},
+				},
+			},
+			expErr:      true,
+			expResponse: approver.EvaluationResponse{},
+		},
+		"if constraints contains private key but CSR uses the wrong key type and is too small, return error": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.RSA)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						Algorithm: &ecdsaAlg,
+						MinSize:   ptr.To(4000),
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.privateKey.algorithm"), "RSA", "found RSA, requires ECDSA"),
+					field.Invalid(field.NewPath("spec.constraints.privateKey.minSize"), "2048", "4000"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints contains private key but CSR uses the wrong key type and is too large, return error": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						Algorithm: &rsaAlg,
+						MaxSize:   ptr.To(200),
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.privateKey.algorithm"), "ECDSA", "found ECDSA, requires RSA"),
+					field.Invalid(field.NewPath("spec.constraints.privateKey.maxSize"), "256", "200"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints contains allowed public keys but the ConfigMap doesn't exist, return error": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(rsaCSR),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						AllowedPublicKeysConfigMapRef: allowedPublicKeysRef,
+					},
+				},
+			},
+			expErr:      true,
+			expResponse: approver.EvaluationResponse{},
+		},
+		"if constraints contains allowed public keys and RSA public key is allowed, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(rsaCSR),
+			),
+			existingObjects: []runtime.Object{allowedPublicKeysConfigMap(allowedPublicKeysRef, map[string]string{
+				"rsa":   fingerprintFrom(t, rsaCSR),
+				"other": fingerprintFrom(t, ecdsaCSR),
+			})},
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						AllowedPublicKeysConfigMapRef: allowedPublicKeysRef,
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints contains allowed public keys and RSA public key is not allowed, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(rsaCSR),
+			),
+			existingObjects: []runtime.Object{allowedPublicKeysConfigMap(allowedPublicKeysRef, map[string]string{
+				"other": fingerprintFrom(t, ecdsaCSR),
+			})},
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						AllowedPublicKeysConfigMapRef: allowedPublicKeysRef,
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.privateKey.allowedPublicKeys"), fingerprintFrom(t, rsaCSR), "public key is not allowed"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints contains allowed public keys and ECDSA public key is allowed with colon separated upper case fingerprint, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(ecdsaCSR),
+			),
+			existingObjects: []runtime.Object{allowedPublicKeysConfigMap(allowedPublicKeysRef, map[string]string{
+				"ecdsa": colonSeparated(strings.ToUpper(fingerprintFrom(t, ecdsaCSR))),
+			})},
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						AllowedPublicKeysConfigMapRef: allowedPublicKeysRef,
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints contains allowed public keys and ECDSA public key is not allowed, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(ecdsaCSR),
+			),
+			existingObjects: []runtime.Object{allowedPublicKeysConfigMap(allowedPublicKeysRef, map[string]string{
+				"rsa": fingerprintFrom(t, rsaCSR),
+			})},
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						AllowedPublicKeysConfigMapRef: allowedPublicKeysRef,
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.privateKey.allowedPublicKeys"), fingerprintFrom(t, ecdsaCSR), "public key is not allowed"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints requires namespaced SPIFFE IDs and request contains no URIs, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestNamespace("sandbox"),
+				gen.SetCertificateRequestCSR(ecdsaCSR),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					RequireNamespacedSPIFFE: ptr.To(true),
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints requires namespaced SPIFFE IDs and request contains SPIFFE ID in its namespace, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestNamespace("sandbox"),
+				gen.SetCertificateRequestCSR(spiffeCSR),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					RequireNamespacedSPIFFE: ptr.To(true),
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints requires namespaced SPIFFE IDs and request contains SPIFFE ID in another namespace, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestNamespace("sandbox-2"),
+				gen.SetCertificateRequestCSR(spiffeCSR),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					RequireNamespacedSPIFFE: ptr.To(true),
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.requireNamespacedSPIFFE"), "spiffe://cluster.local/ns/sandbox/sa/app", `must be a SPIFFE ID in namespace "sandbox-2"`),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints requires namespaced SPIFFE IDs and request contains a non SPIFFE URI, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestNamespace("sandbox"),
+				gen.SetCertificateRequestCSR(nonSPIFFECSR),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					RequireNamespacedSPIFFE: ptr.To(true),
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.requireNamespacedSPIFFE"), "https://example.com/ns/sandbox/sa/app", `must be a SPIFFE ID in namespace "sandbox"`),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints requires namespaced SPIFFE IDs and request contains SPIFFE ID escaping its namespace with a dot-dot segment, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestNamespace("default"),
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRURIsFromStrings("spiffe://cluster.local/ns/default/../kube-system/sa/app"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					RequireNamespacedSPIFFE: ptr.To(true),
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.requireNamespacedSPIFFE"), "spiffe://cluster.local/ns/default/../kube-system/sa/app", `must be a SPIFFE ID in namespace "default"`),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints does not require namespaced SPIFFE IDs and request contains SPIFFE ID in another namespace, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestNamespace("sandbox-2"),
+				gen.SetCertificateRequestCSR(spiffeCSR),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					RequireNamespacedSPIFFE: ptr.To(false),
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints forbids common name with SANs and request contains both, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRCommonName("example.com"),
+					gen.SetCSRDNSNames("example.com"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					ForbidCommonNameWithSANs: ptr.To(true),
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.forbidCommonNameWithSANs"), "example.com", "commonName must not be set when the request contains subject alternative names"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints forbids common name with SANs and request contains common name and IP SAN, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRCommonName("example.com"),
+					gen.SetCSRIPAddressesFromStrings("10.0.0.1"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					ForbidCommonNameWithSANs: ptr.To(true),
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.forbidCommonNameWithSANs"), "example.com", "commonName must not be set when the request contains subject alternative names"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints forbids common name with SANs and request contains only common name, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRCommonName("example.com"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					ForbidCommonNameWithSANs: ptr.To(true),
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints forbids common name with SANs and request contains only SANs, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRDNSNames("example.com"),
+					gen.SetCSRURIsFromStrings("spiffe://cluster.local/ns/sandbox/sa/app"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					ForbidCommonNameWithSANs: ptr.To(true),
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints requires identity and request contains no common name or SANs, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					RequireIdentity: ptr.To(true),
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.requireIdentity"), true, "request must contain a commonName or at least one subject alternative name"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints requires identity and request contains only common name, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRCommonName("example.com"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					RequireIdentity: ptr.To(true),
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints requires identity and request contains only an email SAN, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSREmails([]string{"alice@example.com"}),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					RequireIdentity: ptr.To(true),
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints does not require identity and request contains no common name or SANs, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					RequireIdentity: ptr.To(false),
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints requires usages and request contains all of them, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestKeyUsages(cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageServerAuth),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					RequiredUsages: &[]cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageServerAuth},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints requires usages and request contains an equivalent spelling, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestKeyUsages(cmapi.UsageSigning, cmapi.UsageServerAuth),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					RequiredUsages: &[]cmapi.KeyUsage{cmapi.UsageDigitalSignature},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints requires usages and request is missing some, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestKeyUsages(cmapi.UsageKeyEncipherment, cmapi.UsageServerAuth),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					RequiredUsages: &[]cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageServerAuth, cmapi.UsageClientAuth},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.requiredUsages"), []string{"key encipherment", "server auth"}, "missing required usages: digital signature, client auth"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints requires usages and request contains no usages, return Denied": {
+			request: gen.CertificateRequest(""),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					RequiredUsages: &[]cmapi.KeyUsage{cmapi.UsageDigitalSignature},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.requiredUsages"), []string(nil), "missing required usages: digital signature"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints defines mutually exclusive usages and request contains one of them, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestKeyUsages(cmapi.UsageDigitalSignature, cmapi.UsageServerAuth),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					MutuallyExclusiveUsages: [][]cmapi.KeyUsage{{cmapi.UsageServerAuth, cmapi.UsageClientAuth}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints defines mutually exclusive usages and request contains equivalent spellings of one, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestKeyUsages(cmapi.UsageSigning, cmapi.UsageDigitalSignature),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					MutuallyExclusiveUsages: [][]cmapi.KeyUsage{{cmapi.UsageDigitalSignature, cmapi.UsageKeyAgreement}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints defines mutually exclusive usages and request contains server auth and client auth, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestKeyUsages(cmapi.UsageDigitalSignature, cmapi.UsageServerAuth, cmapi.UsageClientAuth),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					MutuallyExclusiveUsages: [][]cmapi.KeyUsage{
+						{cmapi.UsageCodeSigning, cmapi.UsageTimestamping},
+						{cmapi.UsageServerAuth, cmapi.UsageClientAuth},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.mutuallyExclusiveUsages").Index(1), []string{"server auth", "client auth"}, "at most one of the usages server auth, client auth may be requested"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints matches Certificate and request is not owned by a Certificate, return NotDenied": {
+			request:     gen.CertificateRequest("", gen.SetCertificateRequestNamespace("sandbox"), gen.SetCertificateRequestCSR(rsaCSR)),
+			policy:      matchCertificatePolicy,
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints matches Certificate and request key matches the Certificate, return NotDenied": {
+			request:         gen.CertificateRequest("", gen.SetCertificateRequestNamespace("sandbox"), gen.SetCertificateRequestCSR(ecdsaCSR), ownedBy(ecdsaCert)),
+			policy:          matchCertificatePolicy,
+			existingObjects: []runtime.Object{ecdsaCert},
+			expResponse:     approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints matches Certificate and Certificate declares no private key, return NotDenied": {
+			request:         gen.CertificateRequest("", gen.SetCertificateRequestNamespace("sandbox"), gen.SetCertificateRequestCSR(rsaCSR), ownedBy(undeclaredCert)),
+			policy:          matchCertificatePolicy,
+			existingObjects: []runtime.Object{undeclaredCert},
+			expResponse:     approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints matches Certificate and request key algorithm differs from the Certificate, return Denied": {
+			request:         gen.CertificateRequest("", gen.SetCertificateRequestNamespace("sandbox"), gen.SetCertificateRequestCSR(rsaCSR), ownedBy(ecdsaCert)),
+			policy:          matchCertificatePolicy,
+			existingObjects: []runtime.Object{ecdsaCert},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.privateKey.matchCertificate"), "RSA", `must match algorithm "ECDSA" of Certificate sandbox/ecdsa-cert`),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints matches Certificate and request key size differs from the Certificate, return Denied": {
+			request:         gen.CertificateRequest("", gen.SetCertificateRequestNamespace("sandbox"), gen.SetCertificateRequestCSR(ecdsaCSR), ownedBy(ecdsa384Cert)),
+			policy:          matchCertificatePolicy,
+			existingObjects: []runtime.Object{ecdsa384Cert},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.privateKey.matchCertificate"), "256", "must match size 384 of Certificate sandbox/ecdsa-384-cert"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints matches Certificate and owning Certificate does not exist, treat as standalone and return NotDenied": {
+			request:     gen.CertificateRequest("", gen.SetCertificateRequestNamespace("sandbox"), gen.SetCertificateRequestCSR(rsaCSR), ownedBy(ecdsaCert)),
+			policy:      matchCertificatePolicy,
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints limits common name and request common name is within limits, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRCommonName("example.com"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					CommonName: &policyapi.CertificateRequestPolicyConstraintsCommonName{
+						MaxLength:         ptr.To(11),
+						AllowedCharacters: ptr.To("[ -~]"),
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints limits common name and request has no common name, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRDNSNames("example.com"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					CommonName: &policyapi.CertificateRequestPolicyConstraintsCommonName{
+						MaxLength:         ptr.To(1),
+						AllowedCharacters: ptr.To("[0-9]"),
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints limits common name length and request common name is too long, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRCommonName("example.com"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					CommonName: &policyapi.CertificateRequestPolicyConstraintsCommonName{
+						MaxLength: ptr.To(10),
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.commonName.maxLength"), "example.com", "must be no more than 10 characters"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints limits common name characters and request common name contains others, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRCommonName("exämple.com"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					CommonName: &policyapi.CertificateRequestPolicyConstraintsCommonName{
+						MaxLength:         ptr.To(11),
+						AllowedCharacters: ptr.To("[ -~]"),
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.commonName.allowedCharacters"), "exämple.com", `character 'ä' does not match "[ -~]"`),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints limits common name characters, return Denied with the first character which is not allowed": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRCommonName("exa€mple-Cöm"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					CommonName: &policyapi.CertificateRequestPolicyConstraintsCommonName{
+						AllowedCharacters: ptr.To("[a-z-]"),
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.commonName.allowedCharacters"), "exa€mple-Cöm", `character '€' does not match "[a-z-]"`),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints forbids public suffixes and request DNS names are internal, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRDNSNames("app.sandbox.svc.cluster.local", "*.sandbox.svc", "db.corp.internal", "localhost"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					DNSNames: &policyapi.CertificateRequestPolicyConstraintsDNSNames{ForbidPublicSuffix: ptr.To(true)},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints forbids public suffixes and request DNS names are public, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRDNSNames("app.svc.cluster.local", "app.example.com", "*.Example.CO.UK", "me.github.io"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					DNSNames: &policyapi.CertificateRequestPolicyConstraintsDNSNames{ForbidPublicSuffix: ptr.To(true)},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.dnsNames.forbidPublicSuffix"), "app.example.com", `must not end in the public suffix "com"`),
+					field.Invalid(field.NewPath("spec.constraints.dnsNames.forbidPublicSuffix"), "*.Example.CO.UK", `must not end in the public suffix "co.uk"`),
+					field.Invalid(field.NewPath("spec.constraints.dnsNames.forbidPublicSuffix"), "me.github.io", `must not end in the public suffix "github.io"`),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints forbids public suffixes and public request DNS names are allowed, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRDNSNames("app.svc.cluster.local", "app.internal.example.com", "internal.example.com"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					DNSNames: &policyapi.CertificateRequestPolicyConstraintsDNSNames{
+						ForbidPublicSuffix:   ptr.To(true),
+						AllowedPublicDomains: []string{"internal.example.com", "*.internal.example.com"},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints forbids IP addresses and request has none, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRDNSNames("example.com"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					IPAddresses: &policyapi.CertificateRequestPolicyConstraintsIPAddresses{Forbid: ptr.To(true)},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints forbids IP addresses and request has IP addresses, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRIPAddressesFromStrings("10.0.0.1", "1.1.1.1"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					IPAddresses: &policyapi.CertificateRequestPolicyConstraintsIPAddresses{
+						Forbid:       ptr.To(true),
+						AllowedCIDRs: []string{"10.0.0.0/8"},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.ipAddresses.forbid"), "10.0.0.1", "IP SANs must not be requested"),
+					field.Invalid(field.NewPath("spec.constraints.ipAddresses.forbid"), "1.1.1.1", "IP SANs must not be requested"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints allows CIDRs and request IP addresses are within them, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRIPAddressesFromStrings("10.1.2.3", "192.168.0.1", "fd00::1"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					IPAddresses: &policyapi.CertificateRequestPolicyConstraintsIPAddresses{
+						AllowedCIDRs: []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "fc00::/7"},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints allows CIDRs and request IP addresses are out of range, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRIPAddressesFromStrings("10.1.2.3", "8.8.8.8", "172.32.0.1"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					IPAddresses: &policyapi.CertificateRequestPolicyConstraintsIPAddresses{
+						AllowedCIDRs: []string{"10.0.0.0/8", "172.16.0.0/12"},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.ipAddresses.allowedCIDRs"), "8.8.8.8", "must be within one of: 10.0.0.0/8, 172.16.0.0/12"),
+					field.Invalid(field.NewPath("spec.constraints.ipAddresses.allowedCIDRs"), "172.32.0.1", "must be within one of: 10.0.0.0/8, 172.16.0.0/12"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints does not forbid public suffixes, return NotDenied for public DNS names": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRDNSNames("app.example.com"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					DNSNames: &policyapi.CertificateRequestPolicyConstraintsDNSNames{ForbidPublicSuffix: ptr.To(false)},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints enforces DNS name limits and request DNS names are within limits, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRDNSNames("example.com", "*.example.com", strings.Repeat("a", 63)+".example.com", strings.Repeat(strings.Repeat("a", 63)+".", 3)+strings.Repeat("a", 61)),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					EnforceDNSNameLimits: ptr.To(true),
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints enforces DNS name limits and request contains an over-long label, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRDNSNames("example.com", strings.Repeat("a", 64)+".example.com"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					EnforceDNSNameLimits: ptr.To(true),
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.enforceDNSNameLimits"), strings.Repeat("a", 64)+".example.com", `label "`+strings.Repeat("a", 64)+`" must be no more than 63 characters`),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints enforces DNS name limits and request contains an over-long name, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRDNSNames(strings.Repeat(strings.Repeat("a", 63)+".", 3)+strings.Repeat("a", 62)),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					EnforceDNSNameLimits: ptr.To(true),
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.enforceDNSNameLimits"), strings.Repeat(strings.Repeat("a", 63)+".", 3)+strings.Repeat("a", 62), "must be no more than 253 characters"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints does not enforce DNS name limits, return NotDenied for over-long names": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRDNSNames(strings.Repeat("a", 64)+".example.com"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					EnforceDNSNameLimits: ptr.To(false),
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints require isCA true and request is a CA, return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestIsCA(true)),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{IsCA: ptr.To(true)},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints require isCA false and request is not a CA, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA)),
+				gen.SetCertificateRequestIsCA(false),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{IsCA: ptr.To(false)},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints requires CA usages and CA request includes them, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA)),
+				gen.SetCertificateRequestIsCA(true),
+				gen.SetCertificateRequestKeyUsages(cmapi.UsageDigitalSignature, cmapi.UsageCertSign, cmapi.UsageCRLSign),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					CAMustIncludeUsages: &[]cmapi.KeyUsage{cmapi.UsageCertSign, cmapi.UsageCRLSign},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints requires CA usages and CA request is missing some, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA)),
+				gen.SetCertificateRequestIsCA(true),
+				gen.SetCertificateRequestKeyUsages(cmapi.UsageDigitalSignature, cmapi.UsageCertSign),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					CAMustIncludeUsages: &[]cmapi.KeyUsage{cmapi.UsageCertSign, cmapi.UsageCRLSign},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.caMustIncludeUsages"), []string{"digital signature", "cert sign"}, "CA requests are missing required usages: crl sign"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints requires CA usages and CSR requests a CA without them, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA, setCSRBasicConstraints(t, true, nil))),
+				gen.SetCertificateRequestIsCA(false),
+				gen.SetCertificateRequestKeyUsages(cmapi.UsageDigitalSignature),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					CAMustIncludeUsages: &[]cmapi.KeyUsage{cmapi.UsageCertSign, cmapi.UsageCRLSign},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.caMustIncludeUsages"), []string{"digital signature"}, "CA requests are missing required usages: cert sign, crl sign"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints requires CA usages and request is not for a CA, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA)),
+				gen.SetCertificateRequestIsCA(false),
+				gen.SetCertificateRequestKeyUsages(cmapi.UsageDigitalSignature, cmapi.UsageServerAuth),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					CAMustIncludeUsages: &[]cmapi.KeyUsage{cmapi.UsageCertSign, cmapi.UsageCRLSign},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints allow CAs for issuers and CA request references a matching issuer, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA)),
+				gen.SetCertificateRequestIsCA(true),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "ca-root", Kind: "ClusterIssuer"}),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					IsCAAllowedForIssuers: &[]policyapi.CertificateRequestPolicySelectorIssuerRef{
+						{Name: ptr.To("ca-*"), Kind: ptr.To("ClusterIssuer")},
+						{NameExpression: ptr.To("cr.namespace + '-ca'")},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints allow CAs for issuers and CA request references an issuer matching a name expression, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA)),
+				gen.SetCertificateRequestIsCA(true),
+				gen.SetCertificateRequestNamespace("team-a"),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "team-a-ca"}),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					IsCAAllowedForIssuers: &[]policyapi.CertificateRequestPolicySelectorIssuerRef{
+						{Name: ptr.To("ca-*"), Kind: ptr.To("ClusterIssuer")},
+						{NameExpression: ptr.To("cr.namespace + '-ca'")},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints allow CAs for issuers and CA request references another issuer, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA)),
+				gen.SetCertificateRequestIsCA(true),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "ca-root", Kind: "Issuer"}),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					IsCAAllowedForIssuers: &[]policyapi.CertificateRequestPolicySelectorIssuerRef{
+						{Name: ptr.To("ca-*"), Kind: ptr.To("ClusterIssuer")},
+						{NameExpression: ptr.To("cr.namespace + '-ca'")},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.isCAAllowedForIssuers"), "cert-manager.io/Issuer/ca-root", "CA requests must reference one of the allowed issuers"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints allow CAs for no issuers and CSR requests a CA, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA, setCSRBasicConstraints(t, true, nil))),
+				gen.SetCertificateRequestIsCA(false),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "ca-root", Kind: "ClusterIssuer"}),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					IsCAAllowedForIssuers: &[]policyapi.CertificateRequestPolicySelectorIssuerRef{},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.isCAAllowedForIssuers"), "cert-manager.io/ClusterIssuer/ca-root", "CA requests must reference one of the allowed issuers"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints allow CAs for issuers and request is not for a CA, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA)),
+				gen.SetCertificateRequestIsCA(false),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "leaf-issuer"}),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					IsCAAllowedForIssuers: &[]policyapi.CertificateRequestPolicySelectorIssuerRef{},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints require isCA false and CSR requests a non-CA basicConstraints, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA, setCSRBasicConstraints(t, false, nil))),
+				gen.SetCertificateRequestIsCA(false),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{IsCA: ptr.To(false)},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints require isCA false and CSR requests a CA in basicConstraints, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA, setCSRBasicConstraints(t, true, ptr.To(0)))),
+				gen.SetCertificateRequestIsCA(false),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{IsCA: ptr.To(false)},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.isCA"), true, "must be false, but the CSR requests a CA in its basicConstraints extension"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints require isCA true and request is not a CA, return Denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestIsCA(false)),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{IsCA: ptr.To(true)},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.isCA"), false, "must be true"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints require isCA false and request is a CA, return Denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestIsCA(true)),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{IsCA: ptr.To(false)},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.isCA"), true, "must be false"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints require single-valued organizations and request has one organization, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					setCSROrganizations("org-1"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					SingleValuedSubjectAttributes: &[]policyapi.CertificateRequestPolicySubjectAttribute{
+						policyapi.CertificateRequestPolicySubjectAttributeOrganizations,
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints require single-valued organizations and request has multiple organizations, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					setCSROrganizations("org-1", "org-2"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					SingleValuedSubjectAttributes: &[]policyapi.CertificateRequestPolicySubjectAttribute{
+						policyapi.CertificateRequestPolicySubjectAttributeCommonName,
+						policyapi.CertificateRequestPolicySubjectAttributeOrganizations,
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.singleValuedSubjectAttributes"), []string{"org-1", "org-2"}, "subject attribute organizations must not have more than one value"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints require single-valued common names and request has multiple common names, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					func(csr *x509.CertificateRequest) error {
+						// ExtraNames override the CommonName field, so both
+						// common names must be set as extra names.
+						csr.Subject.ExtraNames = []pkix.AttributeTypeAndValue{
+							{Type: asn1.ObjectIdentifier{2, 5, 4, 3}, Value: "cn-1"},
+							{Type: asn1.ObjectIdentifier{2, 5, 4, 3}, Value: "cn-2"},
+						}
+						return nil
+					},
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					SingleValuedSubjectAttributes: &[]policyapi.CertificateRequestPolicySubjectAttribute{
+						policyapi.CertificateRequestPolicySubjectAttributeCommonName,
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.singleValuedSubjectAttributes"), []string{"cn-1", "cn-2"}, "subject attribute commonName must not have more than one value"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints do not require single-valued organizations, return NotDenied for multiple organizations": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					setCSROrganizations("org-1", "org-2"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					SingleValuedSubjectAttributes: &[]policyapi.CertificateRequestPolicySubjectAttribute{
+						policyapi.CertificateRequestPolicySubjectAttributeCountries,
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints forbid organizations and request has no organization, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRCommonName("example.com"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					ForbiddenSubjectAttributes: &[]policyapi.CertificateRequestPolicySubjectAttribute{
+						policyapi.CertificateRequestPolicySubjectAttributeOrganizations,
+						policyapi.CertificateRequestPolicySubjectAttributeCountries,
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints forbid organizations and request has organizations, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRCommonName("example.com"),
+					setCSROrganizations("org-1", "org-2"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					ForbiddenSubjectAttributes: &[]policyapi.CertificateRequestPolicySubjectAttribute{
+						policyapi.CertificateRequestPolicySubjectAttributeOrganizations,
+						policyapi.CertificateRequestPolicySubjectAttributeCountries,
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.forbiddenSubjectAttributes"), []string{"org-1", "org-2"}, "subject attribute organizations must not be present"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints allow subject OIDs and request contains only modelled attributes, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRCommonName("example.com"),
+					setCSROrganizations("org-1"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					Subject: &policyapi.CertificateRequestPolicyConstraintsSubject{AllowedOIDs: &[]string{}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints allow subject OIDs and request contains a DC which is allowed, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRCommonName("example.com"),
+					setCSRExtraNames(pkix.AttributeTypeAndValue{Type: oidDomainComponent, Value: "example"}),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					Subject: &policyapi.CertificateRequestPolicyConstraintsSubject{AllowedOIDs: &[]string{"0.9.2342.19200300.100.1.25"}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints allow subject OIDs and request contains a DC and a custom OID which are not allowed, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRCommonName("example.com"),
+					setCSRExtraNames(
+						pkix.AttributeTypeAndValue{Type: oidDomainComponent, Value: "example"},
+						pkix.AttributeTypeAndValue{Type: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 99999, 1}, Value: "custom"},
+						pkix.AttributeTypeAndValue{Type: oidDomainComponent, Value: "com"},
+					),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					Subject: &policyapi.CertificateRequestPolicyConstraintsSubject{AllowedOIDs: &[]string{"1.3.6.1.4.1.99999.2"}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.subject.allowedOIDs"), "0.9.2342.19200300.100.1.25", "subject attribute must be one of [1.3.6.1.4.1.99999.2]"),
+					field.Invalid(field.NewPath("spec.constraints.subject.allowedOIDs"), "1.3.6.1.4.1.99999.1", "subject attribute must be one of [1.3.6.1.4.1.99999.2]"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints allow critical extensions and request only contains allowed critical extensions, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRDNSNames("example.com"),
+					setCSRBasicConstraints(t, true, nil),
+					setCSRExtraExtensions(pkix.Extension{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 99999, 1}, Critical: false, Value: []byte{0x05, 0x00}}),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{AllowedCriticalExtensionOIDs: &[]string{"2.5.29.19"}},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints allow no critical extensions and request contains critical extensions, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRDNSNames("example.com"),
+					setCSRBasicConstraints(t, true, nil),
+					setCSRExtraExtensions(pkix.Extension{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 99999, 1}, Critical: true, Value: []byte{0x05, 0x00}}),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{AllowedCriticalExtensionOIDs: &[]string{}},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.allowedCriticalExtensionOIDs"), "2.5.29.19", "critical extension must be one of []"),
+					field.Invalid(field.NewPath("spec.constraints.allowedCriticalExtensionOIDs"), "1.3.6.1.4.1.99999.1", "critical extension must be one of []"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints allow critical extensions and request contains a critical extension which is not allowed, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRDNSNames("example.com"),
+					setCSRBasicConstraints(t, true, nil),
+					setCSRExtraExtensions(pkix.Extension{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 99999, 1}, Critical: true, Value: []byte{0x05, 0x00}}),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{AllowedCriticalExtensionOIDs: &[]string{"2.5.29.15", "2.5.29.19"}},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.allowedCriticalExtensionOIDs"), "1.3.6.1.4.1.99999.1", "critical extension must be one of [2.5.29.15 2.5.29.19]"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints require organizations to equal the namespace and they do, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestNamespace("team-a"),
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRCommonName("team-a"),
+					setCSROrganizations("team-a"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					Subject: &policyapi.CertificateRequestPolicyConstraintsSubject{
+						OrganizationsMustEqualNamespace: ptr.To(true),
+						CommonNameMustEqualNamespace:    ptr.To(true),
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints require organizations to equal the namespace and the request has none, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestNamespace("team-a"),
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRDNSNames("example.com"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					Subject: &policyapi.CertificateRequestPolicyConstraintsSubject{OrganizationsMustEqualNamespace: ptr.To(true)},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints require subject attributes to equal the namespace and they don't, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestNamespace("team-a"),
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRCommonName("team-b"),
+					setCSROrganizations("team-a", "team-b"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					Subject: &policyapi.CertificateRequestPolicyConstraintsSubject{
+						OrganizationsMustEqualNamespace: ptr.To(true),
+						CommonNameMustEqualNamespace:    ptr.To(true),
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.subject.organizationsMustEqualNamespace"), "team-b", `subject attribute organizations must equal the request namespace "team-a"`),
+					field.Invalid(field.NewPath("spec.constraints.subject.commonNameMustEqualNamespace"), "team-b", `subject attribute commonName must equal the request namespace "team-a"`),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints forbid duplicate SANs and request SANs are unique, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRDNSNames("example.com", "www.example.com"),
+					gen.SetCSRIPAddressesFromStrings("10.0.0.1", "10.0.0.2"),
+					gen.SetCSRURIsFromStrings("spiffe://cluster.local/ns/sandbox/sa/app"),
+					gen.SetCSREmails([]string{"a@example.com", "b@example.com"}),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					ForbidDuplicateSANs: ptr.To(true),
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints forbid duplicate SANs and request contains duplicates, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRDNSNames("example.com", "www.example.com", "example.com", "example.com"),
+					gen.SetCSRIPAddressesFromStrings("10.0.0.1", "10.0.0.1"),
+					gen.SetCSRURIsFromStrings("spiffe://cluster.local/ns/sandbox/sa/app"),
+					gen.SetCSREmails([]string{"a@example.com", "a@example.com"}),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					ForbidDuplicateSANs: ptr.To(true),
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.forbidDuplicateSANs"), "example.com", "duplicate value in dnsNames"),
+					field.Invalid(field.NewPath("spec.constraints.forbidDuplicateSANs"), "10.0.0.1", "duplicate value in ipAddresses"),
+					field.Invalid(field.NewPath("spec.constraints.forbidDuplicateSANs"), "a@example.com", "duplicate value in emailAddresses"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints do not forbid duplicate SANs, return NotDenied for duplicates": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRDNSNames("example.com", "example.com"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					ForbidDuplicateSANs: ptr.To(false),
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints require commonName in DNS names and commonName is a DNS name, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRCommonName("example.com"),
+					gen.SetCSRDNSNames("www.example.com", "example.com"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					CommonNameMustBeInDNSNames: ptr.To(true),
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints require commonName in DNS names and commonName is only an IP address, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRCommonName("10.0.0.1"),
+					gen.SetCSRDNSNames("example.com"),
+					gen.SetCSRIPAddressesFromStrings("10.0.0.1"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					CommonNameMustBeInDNSNames: ptr.To(true),
+				},
+			},
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.commonNameMustBeInDNSNames"), "10.0.0.1", "commonName must be one of the requested DNS names [example.com]"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if constraints require commonName in DNS names and commonName differs from a DNS name only by case, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRCommonName("WWW.Example.com"),
+					gen.SetCSRDNSNames("www.example.com"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					CommonNameMustBeInDNSNames: ptr.To(true),
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints require commonName in DNS names and commonName is empty, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA,
+					gen.SetCSRDNSNames("example.com"),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					CommonNameMustBeInDNSNames: ptr.To(true),
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints requires an empty list of usages, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestKeyUsages(cmapi.UsageServerAuth),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					RequiredUsages: &[]cmapi.KeyUsage{},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fakeclient := fakeclient.NewClientBuilder().
+				WithScheme(policyapi.GlobalScheme).
+				WithRuntimeObjects(test.existingObjects...).
+				Build()
+			c := Approver().(*constraints)
+			c.reader, c.lister = fakeclient, fakeclient
+			response, err := c.Evaluate(context.TODO(), &policyapi.CertificateRequestPolicy{Spec: test.policy}, test.request)
+			assert.Equal(t, test.expErr, err != nil, "%v", err)
+			assert.Equal(t, test.expResponse, withoutReasons(t, response), "unexpected evaluation response")
+		})
+	}
+}
+
+func Test_fieldReasons(t *testing.T) {
+	tests := map[string]approver.Reason{
+		"spec.constraints.maxDuration":                                   approver.ReasonDurationTooLong,
+		"spec.constraints.minDuration":                                   approver.ReasonDurationTooShort,
+		"spec.constraints.allowedDurations":                              approver.ReasonDurationNotAllowed,
+		"spec.constraints.duration.max":                                  approver.ReasonDurationTooLong,
+		"spec.constraints.duration.min":                                  approver.ReasonDurationTooShort,
+		"spec.constraints.duration.values[0]":                            approver.ReasonDurationNotAllowed,
+		"spec.constraints.duration.granularity":                          approver.ReasonDurationNotAllowed,
+		"spec.constraints.privateKey.algorithm":                          approver.ReasonKeyAlgorithmNotAllowed,
+		"spec.constraints.privateKey.maxSize":                            approver.ReasonKeyTooLarge,
+		"spec.constraints.privateKey.minSize":                            approver.ReasonKeyTooSmall,
+		"spec.constraints.privateKey.allowedECDSACurves":                 approver.ReasonKeyCurveNotAllowed,
+		"spec.constraints.privateKey.allowedEd25519":                     approver.ReasonKeyAlgorithmNotAllowed,
+		"spec.constraints.privateKey.minRSAPublicExponent":               approver.ReasonKeyExponentTooSmall,
+		"spec.constraints.privateKey.allowedPublicKeys":                  approver.ReasonPublicKeyNotAllowed,
+		"spec.constraints.privateKey.matchCertificate":                   approver.ReasonKeyMismatch,
+		"spec.constraints.commonName.maxLength":                          approver.ReasonCommonNameTooLong,
+		"spec.constraints.commonName.allowedCharacters":                  approver.ReasonCommonNameInvalidCharacters,
+		"spec.constraints.forbidCommonNameWithSANs":                      approver.ReasonCommonNameWithSANs,
+		"spec.constraints.requireIdentity":                               approver.ReasonIdentityMissing,
+		"spec.constraints.commonNameMustBeInDNSNames":                    approver.ReasonCommonNameNotInDNSNames,
+		"spec.constraints.requireNamespacedSPIFFE":                       approver.ReasonURINotNamespaced,
+		"spec.constraints.enforceDNSNameLimits":                          approver.ReasonDNSNameInvalid,
+		"spec.constraints.dnsNames.forbidPublicSuffix":                   approver.ReasonDNSNamePublicSuffix,
+		"spec.constraints.ipAddresses.forbid":                            approver.ReasonIPAddressForbidden,
+		"spec.constraints.ipAddresses.allowedCIDRs":                      approver.ReasonIPAddressOutOfRange,
+		"spec.constraints.forbidDuplicateSANs":                           approver.ReasonDuplicateSANs,
+		"spec.constraints.requiredUsages":                                approver.ReasonUsageMissing,
+		"spec.constraints.mutuallyExclusiveUsages[1]":                    approver.ReasonUsagesMutuallyExclusive,
+		"spec.constraints.isCA":                                          approver.ReasonIsCAMismatch,
+		"spec.constraints.caMustIncludeUsages":                           approver.ReasonCAUsageMissing,
+		"spec.constraints.isCAAllowedForIssuers":                         approver.ReasonCAIssuerNotAllowed,
+		"spec.constraints.maxPathLen":                                    approver.ReasonPathLenTooLong,
+		"spec.constraints.singleValuedSubjectAttributes":                 approver.ReasonSubjectAttributeMultiValued,
+		"spec.constraints.forbiddenSubjectAttributes":                    approver.ReasonSubjectAttributeForbidden,
+		"spec.constraints.subject.allowedOIDs":                           approver.ReasonSubjectOIDNotAllowed,
+		"spec.constraints.allowedCriticalExtensionOIDs":                  approver.ReasonCriticalExtensionNotAllowed,
+		"spec.constraints.subject.organizationsMustEqualNamespace":       approver.ReasonSubjectNamespaceMismatch,
+		"spec.constraints.subject.organizationalUnitsMustEqualNamespace": approver.ReasonSubjectNamespaceMismatch,
+		"spec.constraints.subject.commonNameMustEqualNamespace":          approver.ReasonSubjectNamespaceMismatch,
+		"spec.constraints.privateKey.minSizeUnknown":                     approver.ReasonDenied,
+		"spec.constraints.privateKey":                                    approver.ReasonDenied,
+		"spec.constraints.unknownConstraint.isCA":                        approver.ReasonDenied,
+	}
+
+	for path, expReason := range tests {
+		t.Run(path, func(t *testing.T) {
+			el := field.ErrorList{field.Invalid(field.NewPath(path), "value", "detail")}
+			exp := []approver.DenialReason{{Reason: expReason, Field: path}}
+			assert.Equal(t, exp, approver.FieldReasons(el, fieldReasons, approver.ReasonDenied))
+		})
+	}
+}
+
+func Test_EvaluatePrivateKeyAlgorithmMessage(t *testing.T) {
+	ecdsaAlg := cmapi.ECDSAKeyAlgorithm
+	request := gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t, x509.RSA)))
+	policy := &policyapi.CertificateRequestPolicy{Spec: policyapi.CertificateRequestPolicySpec{
+		Constraints: &policyapi.CertificateRequestPolicyConstraints{
+			PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{Algorithm: &ecdsaAlg},
+		},
+	}}
+
+	response, err := new(constraints).Evaluate(context.TODO(), policy, request)
+	assert.NoError(t, err)
+	assert.Equal(t, approver.ResultDenied, response.Result)
+	assert.Contains(t, response.Message, "spec.constraints.privateKey.algorithm")
+	assert.Contains(t, response.Message, "found RSA, requires ECDSA")
+}
+
+func csrFrom(t *testing.T, keyAlgorithm x509.PublicKeyAlgorithm, mods ...gen.CSRModifier) []byte {
+	csr, _, err := gen.CSR(keyAlgorithm, mods...)
+	if err != nil {
+		t.Fatal(err)
 	}
 	return csr
 }
+
+// csrFromRSAExponent returns a CSR signed by a 2048 bit RSA private key with
+// the given public exponent, since rsa.GenerateKey always uses 65537.
+func csrFromRSAExponent(t *testing.T, exponent int, mods ...gen.CSRModifier) []byte {
+	e := big.NewInt(int64(exponent))
+	for {
+		p, err := rand.Prime(rand.Reader, 1024)
+		if err != nil {
+			t.Fatal(err)
+		}
+		q, err := rand.Prime(rand.Reader, 1024)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		one := big.NewInt(1)
+		phi := new(big.Int).Mul(new(big.Int).Sub(p, one), new(big.Int).Sub(q, one))
+		d := new(big.Int).ModInverse(e, phi)
+		if p.Cmp(q) == 0 || d == nil {
+			// The exponent must be coprime with phi.
+			continue
+		}
+
+		sk := &rsa.PrivateKey{
+			PublicKey: rsa.PublicKey{N: new(big.Int).Mul(p, q), E: exponent},
+			D:         d,
+			Primes:    []*big.Int{p, q},
+		}
+		sk.Precompute()
+
+		csr, err := gen.CSRWithSigner(sk, mods...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return csr
+	}
+}
+
+func csrFromCurve(t *testing.T, curve elliptic.Curve, mods ...gen.CSRModifier) []byte {
+	sk, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csr, err := gen.CSRWithSigner(sk, mods...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return csr
+}
+
+func setCSRBasicConstraints(t *testing.T, isCA bool, maxPathLen *int) gen.CSRModifier {
+	return func(csr *x509.CertificateRequest) error {
+		ext, err := utilpki.MarshalBasicConstraints(isCA, maxPathLen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		csr.ExtraExtensions = append(csr.ExtraExtensions, ext)
+		return nil
+	}
+}
+
+func setCSROrganizations(organizations ...string) gen.CSRModifier {
+	return func(csr *x509.CertificateRequest) error {
+		csr.Subject.Organization = organizations
+		return nil
+	}
+}
+
+// oidDomainComponent is the attribute type of a domainComponent (DC).
+var oidDomainComponent = asn1.ObjectIdentifier{0, 9, 2342, 19200300, 100, 1, 25}
+
+func setCSRExtraNames(names ...pkix.AttributeTypeAndValue) gen.CSRModifier {
+	return func(csr *x509.CertificateRequest) error {
+		csr.Subject.ExtraNames = names
+		return nil
+	}
+}
+
+// withoutReasons asserts that a response denying the request gives a specific
+// reason for each of its violations, and returns the response without its
+// reasons so that it can be compared with the expected response.
+func withoutReasons(t *testing.T, response approver.EvaluationResponse) approver.EvaluationResponse {
+	t.Helper()
+	if response.Result == approver.ResultDenied && len(response.Message) > 0 {
+		assert.NotEmpty(t, response.Reasons, "expected a denied response to give reasons")
+		for _, reason := range response.Reasons {
+			assert.NotEqual(t, approver.ReasonDenied, reason.Reason, "expected a specific reason for %q", reason.Field)
+		}
+	}
+	response.Reasons = nil
+	return response
+}
+
+func ownedBy(cert *cmapi.Certificate) gen.CertificateRequestModifier {
+	return func(cr *cmapi.CertificateRequest) {
+		cr.OwnerReferences = append(cr.OwnerReferences, *metav1.NewControllerRef(cert, cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateKind)))
+	}
+}
+
+// setCSRRawBasicConstraints adds a basicConstraints extension with the given
+// value marshalled as-is, to build extensions MarshalBasicConstraints won't.
+func setCSRRawBasicConstraints(t *testing.T, value any) gen.CSRModifier {
+	return func(csr *x509.CertificateRequest) error {
+		b, err := asn1.Marshal(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		csr.ExtraExtensions = append(csr.ExtraExtensions, pkix.Extension{Id: utilpki.OIDExtensionBasicConstraints, Critical: true, Value: b})
+		return nil
+	}
+}
+
+func setCSRExtraExtensions(extensions ...pkix.Extension) gen.CSRModifier {
+	return func(csr *x509.CertificateRequest) error {
+		csr.ExtraExtensions = append(csr.ExtraExtensions, extensions...)
+		return nil
+	}
+}
+
+func fingerprintFrom(t *testing.T, csrPEM []byte) string {
+	csr, err := utilpki.DecodeX509CertificateRequestBytes(csrPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(csr.RawSubjectPublicKeyInfo)
+	return hex.EncodeToString(sum[:])
+}
+
+func colonSeparated(fingerprint string) string {
+	var parts []string
+	for i := 0; i < len(fingerprint); i += 2 {
+		parts = append(parts, fingerprint[i:i+2])
+	}
+	return strings.Join(parts, ":")
+}
+
+func allowedPublicKeysConfigMap(ref *policyapi.CertificateRequestPolicyConfigMapReference, data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: ref.Name, Namespace: ref.Namespace},
+		Data:       data,
+	}
+}
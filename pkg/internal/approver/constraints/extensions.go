@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constraints
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+)
+
+// oidExtensionRequest is the object identifier of the PKCS #9
+// extensionRequest attribute, which holds the extensions requested by a CSR.
+var oidExtensionRequest = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 14}
+
+// requestedExtensions returns every extension requested by the CSR.
+// x509.CertificateRequest.Extensions only contains the first value of each
+// extensionRequest attribute, and skips attributes which fail to parse. Since
+// a signer may still honour those extensions, every value of every
+// extensionRequest attribute is returned here, and an error is returned if
+// any attribute fails to parse.
+func requestedExtensions(csr *x509.CertificateRequest) ([]pkix.Extension, error) {
+	// tbsCertificateRequest reflects the CertificationRequestInfo structure
+	// from RFC 2986, Section 4.1.
+	var tbs struct {
+		Version       int
+		Subject       asn1.RawValue
+		PublicKey     asn1.RawValue
+		RawAttributes []asn1.RawValue `asn1:"tag:0"`
+	}
+	if rest, err := asn1.Unmarshal(csr.RawTBSCertificateRequest, &tbs); err != nil {
+		return nil, fmt.Errorf("failed to parse CSR: %w", err)
+	} else if len(rest) > 0 {
+		return nil, errors.New("failed to parse CSR: trailing data")
+	}
+
+	var extensions []pkix.Extension
+	for _, rawAttribute := range tbs.RawAttributes {
+		var attribute struct {
+			Id     asn1.ObjectIdentifier
+			Values []asn1.RawValue `asn1:"set"`
+		}
+		if rest, err := asn1.Unmarshal(rawAttribute.FullBytes, &attribute); err != nil {
+			return nil, fmt.Errorf("failed to parse CSR attribute: %w", err)
+		} else if len(rest) > 0 {
+			return nil, errors.New("failed to parse CSR attribute: trailing data")
+		}
+
+		if !attribute.Id.Equal(oidExtensionRequest) {
+			continue
+		}
+
+		for _, value := range attribute.Values {
+			var requested []pkix.Extension
+			if rest, err := asn1.Unmarshal(value.FullBytes, &requested); err != nil {
+				return nil, fmt.Errorf("failed to parse requested extensions: %w", err)
+			} else if len(rest) > 0 {
+				return nil, errors.New("failed to parse requested extensions: trailing data")
+			}
+			extensions = append(extensions, requested...)
+		}
+	}
+
+	return extensions, nil
+}
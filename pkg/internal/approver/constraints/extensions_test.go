@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constraints
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_requestedExtensions(t *testing.T) {
+	marshal := func(v any) []byte {
+		b, err := asn1.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	type attribute struct {
+		Id     asn1.ObjectIdentifier
+		Values []asn1.RawValue `asn1:"set"`
+	}
+
+	// tbs returns a CertificationRequestInfo containing the given raw
+	// attributes.
+	tbs := func(attributes ...[]byte) []byte {
+		var rawAttributes []asn1.RawValue
+		for _, a := range attributes {
+			rawAttributes = append(rawAttributes, asn1.RawValue{FullBytes: a})
+		}
+		return marshal(struct {
+			Version       int
+			Subject       asn1.RawValue
+			PublicKey     asn1.RawValue
+			RawAttributes []asn1.RawValue `asn1:"tag:0"`
+		}{
+			Subject:       asn1.RawValue{FullBytes: marshal(pkix.RDNSequence{})},
+			PublicKey:     asn1.RawValue{FullBytes: marshal(struct{ Algorithm asn1.ObjectIdentifier }{asn1.ObjectIdentifier{1, 2, 3}})},
+			RawAttributes: rawAttributes,
+		})
+	}
+
+	// extensionRequest returns an extensionRequest attribute with a value for
+	// each of the given encoded extension lists.
+	extensionRequest := func(values ...[]byte) []byte {
+		var rawValues []asn1.RawValue
+		for _, v := range values {
+			rawValues = append(rawValues, asn1.RawValue{FullBytes: v})
+		}
+		return marshal(attribute{Id: oidExtensionRequest, Values: rawValues})
+	}
+
+	var (
+		basicConstraints = pkix.Extension{Id: asn1.ObjectIdentifier{2, 5, 29, 19}, Critical: true, Value: marshal(struct{}{})}
+		custom           = pkix.Extension{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 99999, 1}, Critical: true, Value: marshal(asn1.NullRawValue)}
+	)
+
+	tests := map[string]struct {
+		tbs    []byte
+		exp    []pkix.Extension
+		expErr bool
+	}{
+		"if no attributes, return no extensions": {
+			tbs: tbs(),
+			exp: nil,
+		},
+		"if unrelated attributes only, return no extensions": {
+			tbs: tbs(marshal(attribute{Id: asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 7}, Values: []asn1.RawValue{{FullBytes: marshal("password")}}})),
+			exp: nil,
+		},
+		"if a single extensionRequest value, return its extensions": {
+			tbs: tbs(extensionRequest(marshal([]pkix.Extension{basicConstraints, custom}))),
+			exp: []pkix.Extension{basicConstraints, custom},
+		},
+		"if multiple extensionRequest values, return the extensions of every value": {
+			tbs: tbs(extensionRequest(marshal([]pkix.Extension{basicConstraints}), marshal([]pkix.Extension{custom}))),
+			exp: []pkix.Extension{basicConstraints, custom},
+		},
+		"if multiple extensionRequest attributes, return the extensions of every attribute": {
+			tbs: tbs(extensionRequest(marshal([]pkix.Extension{basicConstraints})), extensionRequest(marshal([]pkix.Extension{custom}))),
+			exp: []pkix.Extension{basicConstraints, custom},
+		},
+		"if an extensionRequest value is malformed, return error": {
+			tbs:    tbs(extensionRequest(marshal([]pkix.Extension{basicConstraints}), marshal("not extensions"))),
+			expErr: true,
+		},
+		"if an attribute is malformed, return error": {
+			tbs:    tbs(marshal("not an attribute")),
+			expErr: true,
+		},
+		"if the request info has trailing data, return error": {
+			tbs:    append(tbs(), 0x00),
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			extensions, err := requestedExtensions(&x509.CertificateRequest{RawTBSCertificateRequest: test.tbs})
+			assert.Equal(t, test.expErr, err != nil, "%v", err)
+			assert.Equal(t, test.exp, extensions)
+		})
+	}
+}
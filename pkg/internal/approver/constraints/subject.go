@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constraints
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+)
+
+// subjectAttributeOIDs maps the supported subject attributes to the object
+// identifier of the attribute type.
+var subjectAttributeOIDs = map[policyapi.CertificateRequestPolicySubjectAttribute]asn1.ObjectIdentifier{
+	policyapi.CertificateRequestPolicySubjectAttributeCommonName:          {2, 5, 4, 3},
+	policyapi.CertificateRequestPolicySubjectAttributeSerialNumber:        {2, 5, 4, 5},
+	policyapi.CertificateRequestPolicySubjectAttributeCountries:           {2, 5, 4, 6},
+	policyapi.CertificateRequestPolicySubjectAttributeLocalities:          {2, 5, 4, 7},
+	policyapi.CertificateRequestPolicySubjectAttributeProvinces:           {2, 5, 4, 8},
+	policyapi.CertificateRequestPolicySubjectAttributeStreetAddresses:     {2, 5, 4, 9},
+	policyapi.CertificateRequestPolicySubjectAttributeOrganizations:       {2, 5, 4, 10},
+	policyapi.CertificateRequestPolicySubjectAttributeOrganizationalUnits: {2, 5, 4, 11},
+	policyapi.CertificateRequestPolicySubjectAttributePostalCodes:         {2, 5, 4, 17},
+}
+
+// subjectAttributeValues returns the values of the given attribute in the
+// subject of the CSR, counting every value of every RDN in the subject.
+func subjectAttributeValues(csr *x509.CertificateRequest, attribute policyapi.CertificateRequestPolicySubjectAttribute) []string {
+	oid, ok := subjectAttributeOIDs[attribute]
+	if !ok {
+		return nil
+	}
+
+	var values []string
+	for _, name := range csr.Subject.Names {
+		if !name.Type.Equal(oid) {
+			continue
+		}
+		if value, ok := name.Value.(string); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// supportedSubjectAttributes returns the sorted names of the supported subject
+// attributes.
+func supportedSubjectAttributes() []string {
+	supported := make([]string, 0, len(subjectAttributeOIDs))
+	for attribute := range subjectAttributeOIDs {
+		supported = append(supported, string(attribute))
+	}
+	sort.Strings(supported)
+	return supported
+}
+
+// unmodelledSubjectOIDs returns the object identifiers, in dotted form, of the
+// attribute types in the raw subject of the CSR which are not one of the
+// supported subject attributes, in the order they appear. Each identifier is
+// only returned once.
+func unmodelledSubjectOIDs(csr *x509.CertificateRequest) ([]string, error) {
+	var rdns pkix.RDNSequence
+	if rest, err := asn1.Unmarshal(csr.RawSubject, &rdns); err != nil {
+		return nil, fmt.Errorf("failed to parse subject of CSR: %w", err)
+	} else if len(rest) > 0 {
+		return nil, fmt.Errorf("failed to parse subject of CSR: trailing data")
+	}
+
+	var oids []string
+	for _, rdn := range rdns {
+		for _, atv := range rdn {
+			if isSupportedSubjectAttributeOID(atv.Type) {
+				continue
+			}
+			if oid := atv.Type.String(); !slices.Contains(oids, oid) {
+				oids = append(oids, oid)
+			}
+		}
+	}
+	return oids, nil
+}
+
+// isSupportedSubjectAttributeOID returns whether the given object identifier
+// is the attribute type of one of the supported subject attributes.
+func isSupportedSubjectAttributeOID(oid asn1.ObjectIdentifier) bool {
+	for _, supported := range subjectAttributeOIDs {
+		if supported.Equal(oid) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateOID returns an error if the given string is not an object
+// identifier in dotted form, i.e. `1.2.3`.
+func validateOID(oid string) error {
+	arcs := strings.Split(oid, ".")
+	if len(arcs) < 2 {
+		return fmt.Errorf("must have at least two arcs")
+	}
+	for _, arc := range arcs {
+		if _, err := strconv.ParseUint(arc, 10, 64); err != nil || (len(arc) > 1 && arc[0] == '0') {
+			return fmt.Errorf("arc %q is not a non-negative decimal number", arc)
+		}
+	}
+	return nil
+}
@@ -19,17 +19,26 @@ package constraints
 import (
 	"context"
 	"fmt"
+	"net"
+	"slices"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
 	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/internal/approver/validation"
+	"github.com/cert-manager/approver-policy/pkg/internal/util"
 )
 
+// nameExpressions caches the compiled `nameExpression` CEL expressions of the
+// issuerRefs of constraints.
+var nameExpressions = validation.NewExpressionCache()
+
 // Validate validates that the processed CertificateRequestPolicy has valid
 // constraint fields defined and there are no parsing errors in the values.
-func (c constraints) Validate(_ context.Context, policy *policyapi.CertificateRequestPolicy) (approver.WebhookValidationResponse, error) {
+func (c *constraints) Validate(_ context.Context, policy *policyapi.CertificateRequestPolicy) (approver.WebhookValidationResponse, error) {
 	// If no constraints are defined we can exit early
 	if policy.Spec.Constraints == nil {
 		return approver.WebhookValidationResponse{
@@ -78,16 +87,134 @@ func (c constraints) Validate(_ context.Context, policy *policyapi.CertificateRe
 		if maxSize != nil && minSize != nil && *maxSize < *minSize {
 			el = append(el, field.Invalid(fldPath.Child("maxSize"), *maxSize, "maxSize must be the same value as minSize or larger"))
 		}
+
+		if curves := consts.PrivateKey.AllowedECDSACurves; curves != nil {
+			supported := []string{"P-224", "P-256", "P-384", "P-521"}
+			for i, curve := range *curves {
+				if !slices.Contains(supported, curve) {
+					el = append(el, field.NotSupported(fldPath.Child("allowedECDSACurves").Index(i), curve, supported))
+				}
+			}
+		}
+
+		if minExponent := consts.PrivateKey.MinRSAPublicExponent; minExponent != nil && *minExponent < 3 {
+			el = append(el, field.Invalid(fldPath.Child("minRSAPublicExponent"), *minExponent, "must be 3 or larger"))
+		}
+
+		if ref := consts.PrivateKey.AllowedPublicKeysConfigMapRef; ref != nil {
+			fldPath := fldPath.Child("allowedPublicKeysConfigMapRef")
+			if len(ref.Name) == 0 {
+				el = append(el, field.Required(fldPath.Child("name"), "must define the name of the ConfigMap"))
+			}
+			if len(ref.Namespace) == 0 {
+				el = append(el, field.Required(fldPath.Child("namespace"), "must define the namespace of the ConfigMap"))
+			}
+		}
 	}
 
-	if consts.MaxDuration != nil && consts.MinDuration != nil && consts.MaxDuration.Duration < consts.MinDuration.Duration {
-		el = append(el, field.Invalid(fldPath.Child("maxDuration"), consts.MaxDuration.Duration.String(), "maxDuration must be the same value as minDuration or larger"))
+	if maxPathLen := consts.MaxPathLen; maxPathLen != nil && *maxPathLen < 0 {
+		el = append(el, field.Invalid(fldPath.Child("maxPathLen"), *maxPathLen, "must be 0 or greater"))
 	}
-	if consts.MaxDuration != nil && consts.MaxDuration.Duration < 0 {
-		el = append(el, field.Invalid(fldPath.Child("maxDuration"), consts.MaxDuration.Duration.String(), "maxDuration must be a value greater or equal to 0"))
+
+	if consts.CommonName != nil {
+		fldPath := fldPath.Child("commonName")
+
+		if maxLength := consts.CommonName.MaxLength; maxLength != nil && *maxLength < 1 {
+			el = append(el, field.Invalid(fldPath.Child("maxLength"), *maxLength, "must be greater than 0"))
+		}
+
+		if allowed := consts.CommonName.AllowedCharacters; allowed != nil {
+			if _, err := util.CompileRegexp(*allowed); err != nil {
+				el = append(el, field.Invalid(fldPath.Child("allowedCharacters"), *allowed, err.Error()))
+			}
+		}
+	}
+
+	if consts.SingleValuedSubjectAttributes != nil {
+		fldPath := fldPath.Child("singleValuedSubjectAttributes")
+		for i, attribute := range *consts.SingleValuedSubjectAttributes {
+			if _, ok := subjectAttributeOIDs[attribute]; !ok {
+				el = append(el, field.NotSupported(fldPath.Index(i), attribute, supportedSubjectAttributes()))
+			}
+		}
+	}
+
+	if consts.ForbiddenSubjectAttributes != nil {
+		fldPath := fldPath.Child("forbiddenSubjectAttributes")
+		for i, attribute := range *consts.ForbiddenSubjectAttributes {
+			if _, ok := subjectAttributeOIDs[attribute]; !ok {
+				el = append(el, field.NotSupported(fldPath.Index(i), attribute, supportedSubjectAttributes()))
+			}
+		}
+	}
+
+	if consts.Subject != nil && consts.Subject.AllowedOIDs != nil {
+		fldPath := fldPath.Child("subject", "allowedOIDs")
+		for i, oid := range *consts.Subject.AllowedOIDs {
+			if err := validateOID(oid); err != nil {
+				el = append(el, field.Invalid(fldPath.Index(i), oid, err.Error()))
+			}
+		}
+	}
+
+	if consts.AllowedCriticalExtensionOIDs != nil {
+		fldPath := fldPath.Child("allowedCriticalExtensionOIDs")
+		for i, oid := range *consts.AllowedCriticalExtensionOIDs {
+			if err := validateOID(oid); err != nil {
+				el = append(el, field.Invalid(fldPath.Index(i), oid, err.Error()))
+			}
+		}
+	}
+
+	if consts.IsCAAllowedForIssuers != nil {
+		fldPath := fldPath.Child("isCAAllowedForIssuers")
+		for i, issuerRef := range *consts.IsCAAllowedForIssuers {
+			if issuerRef.RequireReady != nil && *issuerRef.RequireReady {
+				el = append(el, field.Forbidden(fldPath.Index(i).Child("requireReady"), "issuer readiness can only be required by the selector"))
+			}
+			if issuerRef.NameExpression != nil {
+				if _, err := nameExpressions.Get(*issuerRef.NameExpression); err != nil {
+					el = append(el, field.Invalid(fldPath.Index(i).Child("nameExpression"), *issuerRef.NameExpression, err.Error()))
+				}
+			}
+		}
+	}
+
+	if consts.DNSNames != nil {
+		fldPath := fldPath.Child("dnsNames", "allowedPublicDomains")
+		for i, domain := range consts.DNSNames.AllowedPublicDomains {
+			if len(domain) == 0 {
+				el = append(el, field.Invalid(fldPath.Index(i), domain, "must not be empty"))
+			}
+		}
+		el = append(el, util.ValidateSet(fldPath, consts.DNSNames.AllowedPublicDomains)...)
+	}
+
+	if consts.IPAddresses != nil {
+		fldPath := fldPath.Child("ipAddresses", "allowedCIDRs")
+		for i, cidr := range consts.IPAddresses.AllowedCIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				el = append(el, field.Invalid(fldPath.Index(i), cidr, "must be a CIDR range, i.e. 10.0.0.0/8"))
+			}
+		}
+		el = append(el, util.ValidateSet(fldPath, consts.IPAddresses.AllowedCIDRs)...)
+	}
+
+	for i, group := range consts.MutuallyExclusiveUsages {
+		canonical := sets.New[string]()
+		for _, usage := range group {
+			canonical.Insert(util.CanonicalKeyUsage(usage))
+		}
+		if canonical.Len() < 2 {
+			el = append(el, field.Invalid(fldPath.Child("mutuallyExclusiveUsages").Index(i), group, "must contain at least two different usages"))
+		}
+	}
+
+	if consts.Duration != nil && (consts.MinDuration != nil || consts.MaxDuration != nil || consts.AllowedDurations != nil || consts.RequireDuration != nil) {
+		el = append(el, field.Forbidden(fldPath.Child("duration"), "duration cannot be combined with the deprecated minDuration, maxDuration, allowedDurations or requireDuration fields"))
 	}
-	if consts.MinDuration != nil && consts.MinDuration.Duration < 0 {
-		el = append(el, field.Invalid(fldPath.Child("minDuration"), consts.MinDuration.Duration.String(), "minDuration must be a value greater or equal to 0"))
+	for _, dc := range durationConstraints(consts, fldPath) {
+		el = append(el, dc.validate()...)
 	}
 
 	return approver.WebhookValidationResponse{
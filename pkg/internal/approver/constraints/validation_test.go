@@ -29,6 +29,7 @@ import (
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
 	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/internal/util"
 )
 
 func Test_Validate(t *testing.T) {
@@ -98,6 +99,386 @@ func Test_Validate(t *testing.T) {
 				},
 			},
 		},
+		"if policy references an allowed public keys ConfigMap without name or namespace, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+							AllowedPublicKeysConfigMapRef: &policyapi.CertificateRequestPolicyConfigMapReference{},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Required(field.NewPath("spec.constraints.privateKey.allowedPublicKeysConfigMapRef.name"), "must define the name of the ConfigMap"),
+					field.Required(field.NewPath("spec.constraints.privateKey.allowedPublicKeysConfigMapRef.namespace"), "must define the namespace of the ConfigMap"),
+				},
+			},
+		},
+		"if policy contains unsupported ECDSA curves, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+							AllowedECDSACurves: &[]string{"P-256", "secp256k1"},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.NotSupported(field.NewPath("spec.constraints.privateKey.allowedECDSACurves[1]"), "secp256k1", []string{"P-224", "P-256", "P-384", "P-521"}),
+				},
+			},
+		},
+		"if policy contains a min RSA public exponent less than 3, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{MinRSAPublicExponent: ptr.To(1)},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.privateKey.minRSAPublicExponent"), 1, "must be 3 or larger"),
+				},
+			},
+		},
+		"if policy contains a negative max path length, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						MaxPathLen: ptr.To(-1),
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.maxPathLen"), -1, "must be 0 or greater"),
+				},
+			},
+		},
+		"if policy contains invalid allowed public domains, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						DNSNames: &policyapi.CertificateRequestPolicyConstraintsDNSNames{
+							ForbidPublicSuffix:   ptr.To(true),
+							AllowedPublicDomains: []string{"*.example.com", "", "*.example.com"},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.dnsNames.allowedPublicDomains").Index(1), "", "must not be empty"),
+					field.Duplicate(field.NewPath("spec.constraints.dnsNames.allowedPublicDomains").Index(2), "*.example.com"),
+				},
+			},
+		},
+		"if policy contains invalid allowed durations, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						MinDuration: &metav1.Duration{Duration: time.Hour},
+						MaxDuration: &metav1.Duration{Duration: time.Hour * 168},
+						AllowedDurations: &[]metav1.Duration{
+							{Duration: time.Hour * 24},
+							{Duration: 0},
+							{Duration: time.Hour * 24},
+							{Duration: time.Minute},
+							{Duration: time.Hour * 2160},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.allowedDurations").Index(1), "0s", "must be greater than 0"),
+					field.Duplicate(field.NewPath("spec.constraints.allowedDurations").Index(2), "24h0m0s"),
+					field.Invalid(field.NewPath("spec.constraints.allowedDurations").Index(3), "1m0s", "must not be less than minDuration, since the request must satisfy both"),
+					field.Invalid(field.NewPath("spec.constraints.allowedDurations").Index(4), "2160h0m0s", "must not be greater than maxDuration, since the request must satisfy both"),
+				},
+			},
+		},
+		"if policy contains empty allowed durations, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						AllowedDurations: &[]metav1.Duration{},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Required(field.NewPath("spec.constraints.allowedDurations"), "must contain at least one duration if defined"),
+				},
+			},
+		},
+		"if policy contains invalid or duplicate allowed CIDRs, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						IPAddresses: &policyapi.CertificateRequestPolicyConstraintsIPAddresses{
+							AllowedCIDRs: []string{"10.0.0.0/8", "10.0.0.1", "fc00::/7", "10.0.0.0/8"},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.ipAddresses.allowedCIDRs").Index(1), "10.0.0.1", "must be a CIDR range, i.e. 10.0.0.0/8"),
+					field.Duplicate(field.NewPath("spec.constraints.ipAddresses.allowedCIDRs").Index(3), "10.0.0.0/8"),
+				},
+			},
+		},
+		"if policy contains a valid duration constraint, expect a Allowed=true response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						Duration: &policyapi.CertificateRequestPolicyConstraintsDuration{
+							Min:      &metav1.Duration{Duration: time.Hour},
+							Max:      &metav1.Duration{Duration: time.Hour * 168},
+							Values:   &[]metav1.Duration{{Duration: time.Hour * 24}, {Duration: time.Hour * 168}},
+							Required: ptr.To(false),
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{Allowed: true, Errors: nil},
+		},
+		"if policy contains an invalid duration constraint, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						Duration: &policyapi.CertificateRequestPolicyConstraintsDuration{
+							Min:    &metav1.Duration{Duration: time.Hour * 2},
+							Max:    &metav1.Duration{Duration: time.Hour},
+							Values: &[]metav1.Duration{{Duration: time.Minute}, {Duration: time.Minute}},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.duration.max"), "1h0m0s", "max must be the same value as min or larger"),
+					field.Invalid(field.NewPath("spec.constraints.duration.values").Index(0), "1m0s", "must not be less than min, since the request must satisfy both"),
+					field.Duplicate(field.NewPath("spec.constraints.duration.values").Index(1), "1m0s"),
+				},
+			},
+		},
+		"if policy contains an invalid duration granularity, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						Duration: &policyapi.CertificateRequestPolicyConstraintsDuration{
+							Values:      &[]metav1.Duration{{Duration: time.Hour * 24}, {Duration: time.Hour * 36}},
+							Granularity: &metav1.Duration{Duration: time.Hour * 24},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.duration.values[1]"), "36h0m0s", "must be a multiple of granularity, since the request must satisfy both"),
+				},
+			},
+		},
+		"if policy contains a zero duration granularity, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						Duration: &policyapi.CertificateRequestPolicyConstraintsDuration{
+							Granularity: &metav1.Duration{},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.duration.granularity"), "0s", "granularity must be greater than 0"),
+				},
+			},
+		},
+		"if policy combines a duration constraint with the deprecated duration fields, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						MaxDuration: &metav1.Duration{Duration: time.Hour},
+						Duration: &policyapi.CertificateRequestPolicyConstraintsDuration{
+							Max: &metav1.Duration{Duration: time.Hour},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Forbidden(field.NewPath("spec.constraints.duration"), "duration cannot be combined with the deprecated minDuration, maxDuration, allowedDurations or requireDuration fields"),
+				},
+			},
+		},
+		"if policy contains mutually exclusive usage groups with fewer than two usages, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						MutuallyExclusiveUsages: [][]cmapi.KeyUsage{
+							{cmapi.UsageServerAuth, cmapi.UsageClientAuth},
+							{cmapi.UsageServerAuth},
+							{cmapi.UsageSigning, cmapi.UsageDigitalSignature},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.mutuallyExclusiveUsages").Index(1), []cmapi.KeyUsage{cmapi.UsageServerAuth}, "must contain at least two different usages"),
+					field.Invalid(field.NewPath("spec.constraints.mutuallyExclusiveUsages").Index(2), []cmapi.KeyUsage{cmapi.UsageSigning, cmapi.UsageDigitalSignature}, "must contain at least two different usages"),
+				},
+			},
+		},
+		"if policy contains invalid common name constraints, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						CommonName: &policyapi.CertificateRequestPolicyConstraintsCommonName{
+							MaxLength:         ptr.To(0),
+							AllowedCharacters: ptr.To("[a-z"),
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.commonName.maxLength"), 0, "must be greater than 0"),
+					field.Invalid(field.NewPath("spec.constraints.commonName.allowedCharacters"), "[a-z", regexpErr(t, "[a-z")),
+				},
+			},
+		},
+		"if policy contains valid common name constraints, expect a Allowed=true response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						CommonName: &policyapi.CertificateRequestPolicyConstraintsCommonName{
+							MaxLength:         ptr.To(64),
+							AllowedCharacters: ptr.To("[ -~]"),
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: true,
+				Errors:  nil,
+			},
+		},
+		"if policy contains unsupported single-valued subject attributes, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						SingleValuedSubjectAttributes: &[]policyapi.CertificateRequestPolicySubjectAttribute{
+							policyapi.CertificateRequestPolicySubjectAttributeOrganizations,
+							"emailAddresses",
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.NotSupported(field.NewPath("spec.constraints.singleValuedSubjectAttributes[1]"), policyapi.CertificateRequestPolicySubjectAttribute("emailAddresses"), []string{
+						"commonName", "countries", "localities", "organizationalUnits", "organizations", "postalCodes", "provinces", "serialNumber", "streetAddresses",
+					}),
+				},
+			},
+		},
+		"if policy contains unsupported forbidden subject attributes, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						ForbiddenSubjectAttributes: &[]policyapi.CertificateRequestPolicySubjectAttribute{
+							"emailAddresses",
+							policyapi.CertificateRequestPolicySubjectAttributeOrganizations,
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.NotSupported(field.NewPath("spec.constraints.forbiddenSubjectAttributes[0]"), policyapi.CertificateRequestPolicySubjectAttribute("emailAddresses"), []string{
+						"commonName", "countries", "localities", "organizationalUnits", "organizations", "postalCodes", "provinces", "serialNumber", "streetAddresses",
+					}),
+				},
+			},
+		},
+		"if policy contains invalid allowed critical extension OIDs, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						AllowedCriticalExtensionOIDs: &[]string{"2.5.29.19", "keyUsage"},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.allowedCriticalExtensionOIDs[1]"), "keyUsage", "must have at least two arcs"),
+				},
+			},
+		},
+		"if policy contains invalid CA issuerRefs, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						IsCAAllowedForIssuers: &[]policyapi.CertificateRequestPolicySelectorIssuerRef{
+							{Name: ptr.To("ca-*")},
+							{RequireReady: ptr.To(true)},
+							{NameExpression: ptr.To("cr.namespace + 1")},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Forbidden(field.NewPath("spec.constraints.isCAAllowedForIssuers[1].requireReady"), "issuer readiness can only be required by the selector"),
+					field.Invalid(field.NewPath("spec.constraints.isCAAllowedForIssuers[2].nameExpression"), "cr.namespace + 1", "ERROR: <input>:1:14: found no matching overload for '_+_' applied to '(string, int)'\n | cr.namespace + 1\n | .............^"),
+				},
+			},
+		},
+		"if policy contains invalid allowed subject OIDs, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						Subject: &policyapi.CertificateRequestPolicyConstraintsSubject{
+							AllowedOIDs: &[]string{"0.9.2342.19200300.100.1.25", "domainComponent", "1.3.06"},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.subject.allowedOIDs[1]"), "domainComponent", "must have at least two arcs"),
+					field.Invalid(field.NewPath("spec.constraints.subject.allowedOIDs[2]"), "1.3.06", `arc "06" is not a non-negative decimal number`),
+				},
+			},
+		},
 		"if policy contains no validation errors, expect a Allowed=true response": {
 			policy: &policyapi.CertificateRequestPolicy{
 				Spec: policyapi.CertificateRequestPolicySpec{
@@ -127,3 +508,11 @@ func Test_Validate(t *testing.T) {
 		})
 	}
 }
+
+func regexpErr(t *testing.T, pattern string) string {
+	_, err := util.CompileRegexp(pattern)
+	if err == nil {
+		t.Fatalf("expected pattern %q to fail to compile", pattern)
+	}
+	return err.Error()
+}
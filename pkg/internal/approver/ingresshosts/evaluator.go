@@ -0,0 +1,145 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingresshosts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+// Evaluate denies requests containing DNS names which are not a host served
+// in the namespace of the request. Policies which do not configure the
+// ingress-hosts plugin are not evaluated.
+// An error signals that the policy couldn't be evaluated to completion.
+func (i *ingresshosts) Evaluate(ctx context.Context, policy *policyapi.CertificateRequestPolicy, request *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+	plugin, ok := policy.Spec.Plugins[Name]
+	if !ok {
+		return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
+	}
+
+	csr, err := utilpki.DecodeX509CertificateRequestBytes(request.Spec.Request)
+	if err != nil {
+		return approver.EvaluationResponse{}, err
+	}
+
+	// Nothing to check if no DNS names have been requested.
+	if len(csr.DNSNames) == 0 {
+		return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
+	}
+
+	hosts, err := i.hosts(ctx, request.Namespace)
+	if err != nil {
+		return approver.EvaluationResponse{}, err
+	}
+
+	matching := hostMatching(plugin.Values[valueHostMatching])
+	if len(matching) == 0 {
+		matching = hostMatchingWildcard
+	}
+
+	var (
+		el      field.ErrorList
+		fldPath = field.NewPath("spec", "plugins", Name)
+	)
+	for _, dnsName := range csr.DNSNames {
+		if !hostsContain(hosts, dnsName, matching) {
+			el = append(el, field.Invalid(fldPath, dnsName, fmt.Sprintf("DNS name is not a host served in namespace %q", request.Namespace)))
+		}
+	}
+
+	if len(el) > 0 {
+		return approver.EvaluationResponse{Result: approver.ResultDenied, Message: el.ToAggregate().Error()}, nil
+	}
+
+	return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
+}
+
+// hosts returns the set of lower case hosts served by Ingresses, and
+// HTTPRoutes if enabled, in the given namespace.
+func (i *ingresshosts) hosts(ctx context.Context, namespace string) (sets.Set[string], error) {
+	if i.lister == nil {
+		return nil, fmt.Errorf("%s %w", i.Name(), approver.ErrNotPrepared)
+	}
+
+	hosts := sets.New[string]()
+	insert := func(host string) {
+		if len(host) > 0 {
+			hosts.Insert(strings.ToLower(host))
+		}
+	}
+
+	var ingresses networkingv1.IngressList
+	if err := i.lister.List(ctx, &ingresses, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list Ingresses in namespace %q: %w", namespace, err)
+	}
+	for _, ingress := range ingresses.Items {
+		for _, rule := range ingress.Spec.Rules {
+			insert(rule.Host)
+		}
+		for _, tls := range ingress.Spec.TLS {
+			for _, host := range tls.Hosts {
+				insert(host)
+			}
+		}
+	}
+
+	if i.includeHTTPRoutes {
+		var routes gatewayv1.HTTPRouteList
+		if err := i.lister.List(ctx, &routes, client.InNamespace(namespace)); err != nil {
+			return nil, fmt.Errorf("failed to list HTTPRoutes in namespace %q: %w", namespace, err)
+		}
+		for _, route := range routes.Items {
+			for _, hostname := range route.Spec.Hostnames {
+				insert(string(hostname))
+			}
+		}
+	}
+
+	return hosts, nil
+}
+
+// hostsContain returns true if the DNS name is served by one of the given
+// hosts, according to the host matching mode.
+func hostsContain(hosts sets.Set[string], dnsName string, matching hostMatching) bool {
+	dnsName = strings.ToLower(dnsName)
+	if hosts.Has(dnsName) {
+		return true
+	}
+
+	if matching != hostMatchingWildcard {
+		return false
+	}
+
+	// A wildcard host matches a single DNS label in place of the wildcard.
+	if _, parent, ok := strings.Cut(dnsName, "."); ok && !strings.HasPrefix(dnsName, "*.") {
+		return hosts.Has("*." + parent)
+	}
+
+	return false
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingresshosts
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+func Test_Evaluate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := policyapi.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := networkingv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := gatewayv1.Install(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		objects = []runtime.Object{
+			&networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "sandbox", Name: "app"},
+				Spec: networkingv1.IngressSpec{
+					Rules: []networkingv1.IngressRule{{Host: "app.example.com"}, {Host: "*.apps.example.com"}},
+					TLS:   []networkingv1.IngressTLS{{Hosts: []string{"tls.example.com"}}},
+				},
+			},
+			&networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "app"},
+				Spec: networkingv1.IngressSpec{
+					Rules: []networkingv1.IngressRule{{Host: "other.example.com"}},
+				},
+			},
+			&gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "sandbox", Name: "route"},
+				Spec: gatewayv1.HTTPRouteSpec{
+					Hostnames: []gatewayv1.Hostname{"route.example.com"},
+				},
+			},
+		}
+
+		pluginPolicy = func(values map[string]string) policyapi.CertificateRequestPolicySpec {
+			return policyapi.CertificateRequestPolicySpec{
+				Plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+					Name: {Values: values},
+				},
+			}
+		}
+
+		requestWithDNSNames = func(namespace string, dnsNames ...string) *cmapi.CertificateRequest {
+			csr, _, err := gen.CSR(x509.ECDSA, gen.SetCSRDNSNames(dnsNames...))
+			if err != nil {
+				t.Fatal(err)
+			}
+			return gen.CertificateRequest("", gen.SetCertificateRequestNamespace(namespace), gen.SetCertificateRequestCSR(csr))
+		}
+
+		notServed = func(namespace string, dnsNames ...string) approver.EvaluationResponse {
+			var el field.ErrorList
+			for _, dnsName := range dnsNames {
+				el = append(el, field.Invalid(field.NewPath("spec.plugins.ingress-hosts"), dnsName, `DNS name is not a host served in namespace "`+namespace+`"`))
+			}
+			return approver.EvaluationResponse{Result: approver.ResultDenied, Message: el.ToAggregate().Error()}
+		}
+	)
+
+	tests := map[string]struct {
+		policy            policyapi.CertificateRequestPolicySpec
+		request           *cmapi.CertificateRequest
+		includeHTTPRoutes bool
+		expResponse       approver.EvaluationResponse
+		expErr            bool
+	}{
+		"if plugin not configured on policy, return NotDenied": {
+			policy:      policyapi.CertificateRequestPolicySpec{},
+			request:     requestWithDNSNames("sandbox", "unknown.example.com"),
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if request contains no DNS names, return NotDenied": {
+			policy:      pluginPolicy(nil),
+			request:     requestWithDNSNames("sandbox"),
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if request DNS names are all Ingress hosts in the request namespace, return NotDenied": {
+			policy:      pluginPolicy(nil),
+			request:     requestWithDNSNames("sandbox", "App.example.com", "tls.example.com"),
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if request DNS names include hosts of another namespace, return Denied": {
+			policy:      pluginPolicy(nil),
+			request:     requestWithDNSNames("sandbox", "app.example.com", "other.example.com"),
+			expResponse: notServed("sandbox", "other.example.com"),
+		},
+		"if request DNS name matches a wildcard host with Wildcard matching, return NotDenied": {
+			policy:      pluginPolicy(nil),
+			request:     requestWithDNSNames("sandbox", "foo.apps.example.com", "*.apps.example.com"),
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if request DNS name is more than one label below a wildcard host, return Denied": {
+			policy:      pluginPolicy(nil),
+			request:     requestWithDNSNames("sandbox", "foo.bar.apps.example.com"),
+			expResponse: notServed("sandbox", "foo.bar.apps.example.com"),
+		},
+		"if request DNS name matches a wildcard host with Exact matching, return Denied": {
+			policy:      pluginPolicy(map[string]string{"hostMatching": "Exact"}),
+			request:     requestWithDNSNames("sandbox", "foo.apps.example.com", "*.apps.example.com"),
+			expResponse: notServed("sandbox", "foo.apps.example.com"),
+		},
+		"if request DNS name is a HTTPRoute hostname and HTTPRoutes are not included, return Denied": {
+			policy:      pluginPolicy(nil),
+			request:     requestWithDNSNames("sandbox", "route.example.com"),
+			expResponse: notServed("sandbox", "route.example.com"),
+		},
+		"if request DNS name is a HTTPRoute hostname and HTTPRoutes are included, return NotDenied": {
+			policy:            pluginPolicy(nil),
+			request:           requestWithDNSNames("sandbox", "route.example.com", "app.example.com"),
+			includeHTTPRoutes: true,
+			expResponse:       approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if request fails to decode, return error": {
+			policy:      pluginPolicy(nil),
+			request:     gen.CertificateRequest("", gen.SetCertificateRequestCSR([]byte("bad-csr"))),
+			expResponse: approver.EvaluationResponse{},
+			expErr:      true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			i := &ingresshosts{
+				lister: fakeclient.NewClientBuilder().
+					WithScheme(scheme).
+					WithRuntimeObjects(objects...).
+					Build(),
+				includeHTTPRoutes: test.includeHTTPRoutes,
+			}
+			response, err := i.Evaluate(context.TODO(), &policyapi.CertificateRequestPolicy{Spec: test.policy}, test.request)
+			assert.Equal(t, test.expErr, err != nil, "%v", err)
+			assert.Equal(t, test.expResponse, response, "unexpected evaluation response")
+		})
+	}
+}
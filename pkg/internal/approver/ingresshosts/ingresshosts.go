@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingresshosts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/pflag"
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/registry"
+)
+
+const (
+	// Name is the name of the ingress-hosts plugin, used as its key in
+	// CertificateRequestPolicy `spec.plugins`.
+	Name = "ingress-hosts"
+
+	// valueHostMatching is the plugin value key which controls how requested
+	// DNS names are matched against hosts.
+	valueHostMatching = "hostMatching"
+)
+
+// hostMatching is how requested DNS names are matched against the hosts
+// served in the request namespace.
+type hostMatching string
+
+const (
+	// hostMatchingWildcard matches DNS names exactly, and additionally allows
+	// wildcard hosts (e.g. `*.example.com`) to match DNS names of a single
+	// label in place of the wildcard. This is the default.
+	hostMatchingWildcard hostMatching = "Wildcard"
+
+	// hostMatchingExact only matches DNS names which exactly equal a host.
+	hostMatchingExact hostMatching = "Exact"
+)
+
+// Load the ingress-hosts approver.
+func init() {
+	registry.Shared.Store(Approver())
+}
+
+// Approver returns an instance of the ingress-hosts approver.
+func Approver() approver.Interface {
+	return &ingresshosts{}
+}
+
+// ingresshosts is an approver-policy plugin which denies requests for DNS
+// names that are not served by an Ingress, or optionally a Gateway API
+// HTTPRoute, in the namespace of the request.
+type ingresshosts struct {
+	// lister is used to list Ingresses and HTTPRoutes from the informer
+	// cache.
+	lister client.Reader
+
+	// includeHTTPRoutes controls whether HTTPRoute hostnames are served
+	// hosts, in addition to Ingress hosts.
+	includeHTTPRoutes bool
+
+	// informers are the Ingress and optionally HTTPRoute informers, which
+	// must be synced before requests can be evaluated.
+	informers []cache.Informer
+}
+
+var _ approver.StartupChecker = &ingresshosts{}
+
+// Name of Approver is "ingress-hosts"
+func (i *ingresshosts) Name() string {
+	return Name
+}
+
+// RegisterFlags registers whether HTTPRoutes are considered. HTTPRoutes are
+// opt-in as the Gateway API CRDs may not be installed.
+func (i *ingresshosts) RegisterFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&i.includeHTTPRoutes, "ingress-hosts-include-httproutes", false,
+		"If true, the ingress-hosts plugin also allows DNS names which are hostnames of Gateway API HTTPRoutes in the request namespace. "+
+			"Requires the Gateway API CRDs to be installed.")
+}
+
+// Prepare sets up the lister, and starts the Ingress and optionally
+// HTTPRoute informers so that the cache is warm before the first evaluation.
+func (i *ingresshosts) Prepare(ctx context.Context, _ logr.Logger, mgr manager.Manager) error {
+	informer, err := mgr.GetCache().GetInformer(ctx, new(networkingv1.Ingress))
+	if err != nil {
+		return fmt.Errorf("failed to get Ingress informer: %w", err)
+	}
+	i.informers = append(i.informers, informer)
+
+	if i.includeHTTPRoutes {
+		if err := gatewayv1.Install(mgr.GetScheme()); err != nil {
+			return fmt.Errorf("failed to add Gateway API types to scheme: %w", err)
+		}
+		informer, err := mgr.GetCache().GetInformer(ctx, new(gatewayv1.HTTPRoute))
+		if err != nil {
+			return fmt.Errorf("failed to get HTTPRoute informer: %w", err)
+		}
+		i.informers = append(i.informers, informer)
+	}
+
+	i.lister = mgr.GetCache()
+	return nil
+}
+
+// StartupCheck returns an error until the Ingress and HTTPRoute informers have
+// synced, since hosts can't be listed from a cold cache.
+func (i *ingresshosts) StartupCheck(_ context.Context) error {
+	for _, informer := range i.informers {
+		if !informer.HasSynced() {
+			return errors.New("waiting for informers to sync")
+		}
+	}
+	return nil
+}
+
+// Ready always returns ready, ingress-hosts doesn't have any dependencies to
+// block readiness.
+func (i *ingresshosts) Ready(_ context.Context, _ *policyapi.CertificateRequestPolicy) (approver.ReconcilerReadyResponse, error) {
+	return approver.ReconcilerReadyResponse{Ready: true}, nil
+}
+
+// ingress-hosts never needs to manually enqueue policies.
+func (i *ingresshosts) EnqueueChan() <-chan string {
+	return nil
+}
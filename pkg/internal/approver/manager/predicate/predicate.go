@@ -21,8 +21,10 @@ import (
 	"fmt"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	authzv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -55,48 +57,106 @@ func Ready(_ context.Context, _ *cmapi.CertificateRequest, policies []policyapi.
 
 // SelectorIssuerRef is a Predicate that returns the subset of given policies
 // that have an `spec.selector.issuerRef` matching the `spec.issuerRef` in the
-// request. PredicateSelectorIssuerRef will match on strings using wilcards
-// "*". Empty selector is equivalent to "*" and will match on anything.
-func SelectorIssuerRef(_ context.Context, cr *cmapi.CertificateRequest, policies []policyapi.CertificateRequestPolicy) ([]policyapi.CertificateRequestPolicy, error) {
-	var matchingPolicies []policyapi.CertificateRequestPolicy
+// request, or any entry of `spec.selector.issuerRefs` matching.
+// PredicateSelectorIssuerRef will match on strings using wilcards "*". Empty
+// selector is equivalent to "*" and will match on anything. The issuer name
+// must additionally equal the result of the `nameExpression` CEL expression,
+// if set. If `requireReady` is set, the referenced issuer is fetched using the
+// lister and must be ready.
+func SelectorIssuerRef(lister client.Reader) Predicate {
+	return func(ctx context.Context, cr *cmapi.CertificateRequest, policies []policyapi.CertificateRequestPolicy) ([]policyapi.CertificateRequestPolicy, error) {
+		var matchingPolicies []policyapi.CertificateRequestPolicy
 
-	// cert-manager applies controller defaults for issuer Kind and Group,
-	// which means that default values are NOT materialized in resources
-	// if omitted.
-	// So in order to make policies addressing these default values effective,
-	// we must apply cert-manager defaults on request when matching policies.
-	issKind := nonEmptyOrDefault(cr.Spec.IssuerRef.Kind, cmapi.IssuerKind)
-	issGroup := nonEmptyOrDefault(cr.Spec.IssuerRef.Group, "cert-manager.io")
-	issName := cr.Spec.IssuerRef.Name
+		issKind, issGroup := util.IssuerRefKindGroup(cr)
 
-	for _, policy := range policies {
-		issRefSel := policy.Spec.Selector.IssuerRef
-		// If the issuerRef selector is nil, we match the policy and continue
-		// early.
-		if issRefSel == nil {
-			matchingPolicies = append(matchingPolicies, policy)
-			continue
+		// issuerReady is whether the issuer of the request is ready. We use a
+		// pointer here so we can lazily fetch the issuer as necessary.
+		var issuerReady *bool
+		getIssuerReady := func() (bool, error) {
+			if issuerReady == nil {
+				ready, err := isIssuerReady(ctx, lister, cr, issKind, issGroup)
+				if err != nil {
+					return false, err
+				}
+				issuerReady = &ready
+			}
+			return *issuerReady, nil
 		}
 
-		if issRefSel.Name != nil && !util.WildcardMatches(*issRefSel.Name, issName) {
-			continue
-		}
-		if issRefSel.Kind != nil && !util.WildcardMatches(*issRefSel.Kind, issKind) {
-			continue
-		}
-		if issRefSel.Group != nil && !util.WildcardMatches(*issRefSel.Group, issGroup) {
-			continue
+		for _, policy := range policies {
+			// If a list of issuerRef selectors is given, the policy matches if any
+			// of them match.
+			if issRefSels := policy.Spec.Selector.IssuerRefs; len(issRefSels) > 0 {
+				for _, issRefSel := range issRefSels {
+					matches, err := util.IssuerRefMatches(&issRefSel, cr, issKind, issGroup, getIssuerReady)
+					if err != nil {
+						return nil, fmt.Errorf("failed to match issuerRefs of CertificateRequestPolicy %q: %w", policy.Name, err)
+					}
+					if matches {
+						matchingPolicies = append(matchingPolicies, policy)
+						break
+					}
+				}
+				continue
+			}
+
+			// If the issuerRef selector is nil, we match the policy.
+			matches, err := util.IssuerRefMatches(policy.Spec.Selector.IssuerRef, cr, issKind, issGroup, getIssuerReady)
+			if err != nil {
+				return nil, fmt.Errorf("failed to match issuerRef of CertificateRequestPolicy %q: %w", policy.Name, err)
+			}
+			if matches {
+				matchingPolicies = append(matchingPolicies, policy)
+			}
 		}
-		matchingPolicies = append(matchingPolicies, policy)
+
+		return matchingPolicies, nil
 	}
+}
 
-	return matchingPolicies, nil
+// isIssuerReady returns true if the cert-manager.io Issuer or ClusterIssuer
+// referenced by the request exists and has a Ready condition set to True.
+// Issuers of any other group cannot be resolved, so are never ready.
+func isIssuerReady(ctx context.Context, lister client.Reader, cr *cmapi.CertificateRequest, kind, group string) (bool, error) {
+	if group != "cert-manager.io" {
+		return false, nil
+	}
+
+	var issuer cmapi.GenericIssuer
+	switch kind {
+	case cmapi.IssuerKind:
+		issuer = new(cmapi.Issuer)
+	case cmapi.ClusterIssuerKind:
+		issuer = new(cmapi.ClusterIssuer)
+	default:
+		return false, nil
+	}
+
+	key := client.ObjectKey{Name: cr.Spec.IssuerRef.Name}
+	if kind == cmapi.IssuerKind {
+		key.Namespace = cr.Namespace
+	}
+	if err := lister.Get(ctx, key, issuer); apierrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to get request's %s %q to determine readiness: %w", kind, cr.Spec.IssuerRef.Name, err)
+	}
+
+	for _, condition := range issuer.GetStatus().Conditions {
+		if condition.Type == cmapi.IssuerConditionReady {
+			return condition.Status == cmmeta.ConditionTrue, nil
+		}
+	}
+	return false, nil
 }
 
 // SelectorNamespace is a Predicate that returns the subset of given policies
 // that have an `spec.selector.namespace` matching the `metadata.namespace` of
 // the request. SelectorNamespace will match with `namespace.matchNames` on
-// namespaces using wilcards "*". Empty selector is equivalent to "*" and will
+// namespaces using wilcards "*", and `namespace.matchLabels` and
+// `namespace.matchExpressions` on namespace labels. Namespaces matching
+// `namespace.excludeNames` or `namespace.excludeLabels` are never matched,
+// regardless of the other fields. Empty selector is equivalent to "*" and will
 // match on any Namespace.
 func SelectorNamespace(lister client.Reader) Predicate {
 	return func(ctx context.Context, request *cmapi.CertificateRequest, policies []policyapi.CertificateRequestPolicy) ([]policyapi.CertificateRequestPolicy, error) {
@@ -105,6 +165,16 @@ func SelectorNamespace(lister client.Reader) Predicate {
 		// namespaceLabels are the labels of the namespace the request is in. We
 		// use a pointer here so we can lazily fetch the namespace as necessary.
 		var namespaceLabels *map[string]string
+		getNamespaceLabels := func() (labels.Set, error) {
+			if namespaceLabels == nil {
+				var namespace corev1.Namespace
+				if err := lister.Get(ctx, client.ObjectKey{Name: request.Namespace}, &namespace); err != nil {
+					return nil, fmt.Errorf("failed to get request's namespace to determine namespace selector: %w", err)
+				}
+				namespaceLabels = &namespace.Labels
+			}
+			return labels.Set(*namespaceLabels), nil
+		}
 
 		for _, policy := range policies {
 			nsSel := policy.Spec.Selector.Namespace
@@ -138,25 +208,37 @@ func SelectorNamespace(lister client.Reader) Predicate {
 				continue
 			}
 
-			// Match by Label Selector.
-			if nsSel.MatchLabels != nil {
+			// Exclusions take precedence over all matches, so a request in an
+			// excluded namespace is never selected.
+			if util.WildcardContains(nsSel.ExcludeNames, request.Namespace) {
+				continue
+			}
+			if len(nsSel.ExcludeLabels) > 0 {
+				nsLabels, err := getNamespaceLabels()
+				if err != nil {
+					return nil, err
+				}
+				if labels.SelectorFromSet(nsSel.ExcludeLabels).Matches(nsLabels) {
+					continue
+				}
+			}
 
-				if namespaceLabels == nil {
-					var namespace corev1.Namespace
-					if err := lister.Get(ctx, client.ObjectKey{Name: request.Namespace}, &namespace); err != nil {
-						return nil, fmt.Errorf("failed to get request's namespace to determine namespace selector: %w", err)
-					}
-					namespaceLabels = &namespace.Labels
+			// Match by Label Selector.
+			if nsSel.MatchLabels != nil || len(nsSel.MatchExpressions) > 0 {
+				nsLabels, err := getNamespaceLabels()
+				if err != nil {
+					return nil, err
 				}
 
 				selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
-					MatchLabels: nsSel.MatchLabels,
+					MatchLabels:      nsSel.MatchLabels,
+					MatchExpressions: nsSel.MatchExpressions,
 				})
 				if err != nil {
 					return nil, fmt.Errorf("failed to parse namespace label selector: %w", err)
 				}
 				// If the selector doesn't match, then we continue to the next policy.
-				if !selector.Matches(labels.Set(*namespaceLabels)) {
+				if !selector.Matches(nsLabels) {
 					continue
 				}
 			}
@@ -168,6 +250,165 @@ func SelectorNamespace(lister client.Reader) Predicate {
 	}
 }
 
+// ExplicitNamespaceSelector is a Predicate that returns the subset of given
+// policies that have a `spec.selector.namespace`. It is used to treat an
+// omitted namespace selector as matching no namespaces, rather than any
+// Namespace.
+func ExplicitNamespaceSelector(_ context.Context, _ *cmapi.CertificateRequest, policies []policyapi.CertificateRequestPolicy) ([]policyapi.CertificateRequestPolicy, error) {
+	var matchingPolicies []policyapi.CertificateRequestPolicy
+	for _, policy := range policies {
+		if policy.Spec.Selector.Namespace != nil {
+			matchingPolicies = append(matchingPolicies, policy)
+		}
+	}
+	return matchingPolicies, nil
+}
+
+// Fallback is a Predicate that returns the subset of given policies that are
+// not fallback policies. If every given policy is a fallback policy, they are
+// all returned, so that fallback policies are only selected for requests that
+// no other policy is selected for. Fallback must be run after every other
+// predicate.
+func Fallback(_ context.Context, _ *cmapi.CertificateRequest, policies []policyapi.CertificateRequestPolicy) ([]policyapi.CertificateRequestPolicy, error) {
+	var primaryPolicies []policyapi.CertificateRequestPolicy
+	for _, policy := range policies {
+		if policy.Spec.Fallback == nil || !*policy.Spec.Fallback {
+			primaryPolicies = append(primaryPolicies, policy)
+		}
+	}
+	if len(primaryPolicies) == 0 {
+		return policies, nil
+	}
+	return primaryPolicies, nil
+}
+
+// SelectorCertificateRequest is a Predicate that returns the subset of given
+// policies that have a `spec.selector.certificateRequest` matching the labels
+// and annotations of the request. An omitted selector will match on any
+// request.
+func SelectorCertificateRequest(_ context.Context, request *cmapi.CertificateRequest, policies []policyapi.CertificateRequestPolicy) ([]policyapi.CertificateRequestPolicy, error) {
+	var matchingPolicies []policyapi.CertificateRequestPolicy
+
+	for _, policy := range policies {
+		crSel := policy.Spec.Selector.CertificateRequest
+		if crSel == nil {
+			matchingPolicies = append(matchingPolicies, policy)
+			continue
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
+			MatchLabels:      crSel.MatchLabels,
+			MatchExpressions: crSel.MatchExpressions,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificateRequest label selector: %w", err)
+		}
+
+		if !selector.Matches(labels.Set(request.Labels)) {
+			continue
+		}
+
+		if !annotationsMatch(crSel.MatchAnnotations, request.Annotations) {
+			continue
+		}
+
+		annotationSelector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
+			MatchExpressions: crSel.MatchAnnotationExpressions,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificateRequest annotation selector: %w", err)
+		}
+
+		if annotationSelector.Matches(labels.Set(request.Annotations)) {
+			matchingPolicies = append(matchingPolicies, policy)
+		}
+	}
+
+	return matchingPolicies, nil
+}
+
+// SelectorCertificate is a Predicate that returns the subset of given policies
+// that have a `spec.selector.certificate` matching the labels of the
+// Certificate which owns the request. Requests which are not owned by a
+// Certificate, or whose Certificate doesn't exist, match no policies with the
+// selector. An omitted selector will match on any request.
+func SelectorCertificate(lister client.Reader) Predicate {
+	return func(ctx context.Context, request *cmapi.CertificateRequest, policies []policyapi.CertificateRequestPolicy) ([]policyapi.CertificateRequestPolicy, error) {
+		var matchingPolicies []policyapi.CertificateRequestPolicy
+
+		// certificateLabels are the labels of the Certificate which owns the
+		// request, or nil if there is none. We use a pointer here so we can
+		// lazily fetch the Certificate as necessary.
+		var (
+			certificateLabels *labels.Set
+			owned             bool
+		)
+		getCertificateLabels := func() (labels.Set, bool, error) {
+			if certificateLabels == nil {
+				certificateLabels = new(labels.Set)
+
+				var name string
+				if name, owned = util.OwningCertificateName(request); !owned {
+					return nil, false, nil
+				}
+
+				var certificate cmapi.Certificate
+				err := lister.Get(ctx, client.ObjectKey{Namespace: request.Namespace, Name: name}, &certificate)
+				if apierrors.IsNotFound(err) {
+					owned = false
+					return nil, false, nil
+				}
+				if err != nil {
+					return nil, false, fmt.Errorf("failed to get request's owning Certificate to determine certificate selector: %w", err)
+				}
+				*certificateLabels = certificate.Labels
+			}
+			return *certificateLabels, owned, nil
+		}
+
+		for _, policy := range policies {
+			certSel := policy.Spec.Selector.Certificate
+			if certSel == nil {
+				matchingPolicies = append(matchingPolicies, policy)
+				continue
+			}
+
+			certLabels, owned, err := getCertificateLabels()
+			if err != nil {
+				return nil, err
+			}
+			if !owned {
+				continue
+			}
+
+			selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
+				MatchLabels:      certSel.MatchLabels,
+				MatchExpressions: certSel.MatchExpressions,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse certificate label selector: %w", err)
+			}
+
+			if selector.Matches(certLabels) {
+				matchingPolicies = append(matchingPolicies, policy)
+			}
+		}
+
+		return matchingPolicies, nil
+	}
+}
+
+// annotationsMatch returns true if the given annotations contain every one of
+// the matchAnnotations with exactly the same value.
+func annotationsMatch(matchAnnotations, annotations map[string]string) bool {
+	for key, value := range matchAnnotations {
+		if actual, ok := annotations[key]; !ok || actual != value {
+			return false
+		}
+	}
+	return true
+}
+
 // RBACBoundPolicies is a Predicate that returns the subset of
 // CertificateRequestPolicies that have been RBAC bound to the user in the
 // CertificateRequest. Achieved using SubjectAccessReviews.
@@ -210,10 +451,3 @@ func RBACBound(client client.Client) Predicate {
 		return boundPolicies, nil
 	}
 }
-
-func nonEmptyOrDefault(s, d string) string {
-	if len(s) == 0 {
-		return d
-	}
-	return s
-}
@@ -523,6 +523,7 @@ func Test_SelectorIssuerRef(t *testing.T) {
 		request     *cmapi.CertificateRequest
 		policies    []policyapi.CertificateRequestPolicy
 		expPolicies []policyapi.CertificateRequestPolicy
+		expErr      bool
 	}{
 		"if no policies given, return no policies": {
 			policies:    nil,
@@ -547,6 +548,108 @@ func Test_SelectorIssuerRef(t *testing.T) {
 				}},
 			},
 		},
+		"if policy excludes the kind of the request, return no policies": {
+			request: &cmapi.CertificateRequest{Spec: cmapi.CertificateRequestSpec{IssuerRef: cmmeta.ObjectReference{
+				Name: "my-issuer", Kind: "ClusterIssuer",
+			}}},
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{
+						Kind: ptr.To("*"), ExcludeKinds: []string{"Cluster*"},
+					}},
+				}},
+			},
+			expPolicies: nil,
+		},
+		"if policy excludes a kind other than the defaulted kind of the request, return policy": {
+			request: &cmapi.CertificateRequest{Spec: cmapi.CertificateRequestSpec{IssuerRef: cmmeta.ObjectReference{
+				Name: "my-issuer",
+			}}},
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{
+						ExcludeKinds: []string{"ClusterIssuer"},
+					}},
+				}},
+			},
+			expPolicies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{
+						ExcludeKinds: []string{"ClusterIssuer"},
+					}},
+				}},
+			},
+		},
+		"if policy excludes the group of the request, return no policies": {
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{
+						ExcludeGroups: []string{"cert-manager.io", "test-group"},
+					}},
+				}},
+			},
+			expPolicies: nil,
+		},
+		"if policy excludes the defaulted group of the request, return no policies": {
+			request: &cmapi.CertificateRequest{Spec: cmapi.CertificateRequestSpec{IssuerRef: cmmeta.ObjectReference{
+				Name: "my-issuer",
+			}}},
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{
+						ExcludeGroups: []string{"cert-manager.io"},
+					}},
+				}},
+			},
+			expPolicies: nil,
+		},
+		"if policy selects namespaced issuers and request references an Issuer, return policy": {
+			request: &cmapi.CertificateRequest{Spec: cmapi.CertificateRequestSpec{IssuerRef: cmmeta.ObjectReference{
+				Name: "my-issuer",
+			}}},
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{Namespaced: ptr.To(true)}},
+				}},
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{Namespaced: ptr.To(false)}},
+				}},
+			},
+			expPolicies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{Namespaced: ptr.To(true)}},
+				}},
+			},
+		},
+		"if policy selects cluster scoped issuers and request references a ClusterIssuer, return policy": {
+			request: &cmapi.CertificateRequest{Spec: cmapi.CertificateRequestSpec{IssuerRef: cmmeta.ObjectReference{
+				Name: "my-issuer", Kind: "ClusterIssuer", Group: "cert-manager.io",
+			}}},
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{Namespaced: ptr.To(true)}},
+				}},
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{Namespaced: ptr.To(false)}},
+				}},
+			},
+			expPolicies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{Namespaced: ptr.To(false)}},
+				}},
+			},
+		},
+		"if policy selects namespaced or cluster scoped issuers and request references an issuer of another group, return no policies": {
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{Namespaced: ptr.To(true)}},
+				}},
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{Namespaced: ptr.To(false)}},
+				}},
+			},
+			expPolicies: nil,
+		},
 		"if policy given that doesn't match, return no policies": {
 			policies: []policyapi.CertificateRequestPolicy{
 				{Spec: policyapi.CertificateRequestPolicySpec{
@@ -716,6 +819,138 @@ func Test_SelectorIssuerRef(t *testing.T) {
 				}},
 			},
 		},
+		"if policy has issuerRefs with one matching entry, return policy": {
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{IssuerRefs: []policyapi.CertificateRequestPolicySelectorIssuerRef{
+						{Name: ptr.To("name"), Kind: ptr.To("kind"), Group: ptr.To("group")},
+						{Name: ptr.To("test-*"), Kind: ptr.To("test-kind")},
+					}},
+				}},
+			},
+			expPolicies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{IssuerRefs: []policyapi.CertificateRequestPolicySelectorIssuerRef{
+						{Name: ptr.To("name"), Kind: ptr.To("kind"), Group: ptr.To("group")},
+						{Name: ptr.To("test-*"), Kind: ptr.To("test-kind")},
+					}},
+				}},
+			},
+		},
+		"if policy has issuerRefs with no matching entries, return no policies": {
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{IssuerRefs: []policyapi.CertificateRequestPolicySelectorIssuerRef{
+						{Name: ptr.To("name"), Kind: ptr.To("kind"), Group: ptr.To("group")},
+						{Name: ptr.To("test-name"), Kind: ptr.To("other-kind")},
+					}},
+				}},
+			},
+			expPolicies: nil,
+		},
+		"if policy has multiple matching issuerRefs entries, return policy once": {
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{IssuerRefs: []policyapi.CertificateRequestPolicySelectorIssuerRef{
+						{Name: ptr.To("test-name")},
+						{Group: ptr.To("test-group")},
+					}},
+				}},
+			},
+			expPolicies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{IssuerRefs: []policyapi.CertificateRequestPolicySelectorIssuerRef{
+						{Name: ptr.To("test-name")},
+						{Group: ptr.To("test-group")},
+					}},
+				}},
+			},
+		},
+		"if policy name expression matches the issuer name derived from the request namespace, return policy": {
+			request: &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "team-a"},
+				Spec:       cmapi.CertificateRequestSpec{IssuerRef: cmmeta.ObjectReference{Name: "team-a-issuer"}},
+			},
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{NameExpression: ptr.To("cr.namespace + '-issuer'")}},
+				}},
+			},
+			expPolicies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{NameExpression: ptr.To("cr.namespace + '-issuer'")}},
+				}},
+			},
+		},
+		"if policy name expression doesn't match the issuer name, return no policies": {
+			request: &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "team-a"},
+				Spec:       cmapi.CertificateRequestSpec{IssuerRef: cmmeta.ObjectReference{Name: "team-b-issuer"}},
+			},
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{NameExpression: ptr.To("cr.namespace + '-issuer'")}},
+				}},
+			},
+			expPolicies: nil,
+		},
+		"if policy name and name expression both match, return policy": {
+			request: &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "team-a"},
+				Spec:       cmapi.CertificateRequestSpec{IssuerRef: cmmeta.ObjectReference{Name: "team-a-issuer"}},
+			},
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("*-issuer"), NameExpression: ptr.To("cr.namespace + '-issuer'")}},
+				}},
+			},
+			expPolicies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("*-issuer"), NameExpression: ptr.To("cr.namespace + '-issuer'")}},
+				}},
+			},
+		},
+		"if policy name expression matches but name doesn't, return no policies": {
+			request: &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "team-a"},
+				Spec:       cmapi.CertificateRequestSpec{IssuerRef: cmmeta.ObjectReference{Name: "team-a-issuer"}},
+			},
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("prod-*"), NameExpression: ptr.To("cr.namespace + '-issuer'")}},
+				}},
+			},
+			expPolicies: nil,
+		},
+		"if any issuerRefs entry name expression matches, return policy": {
+			request: &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "team-a"},
+				Spec:       cmapi.CertificateRequestSpec{IssuerRef: cmmeta.ObjectReference{Name: "team-a"}},
+			},
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{IssuerRefs: []policyapi.CertificateRequestPolicySelectorIssuerRef{{NameExpression: ptr.To("cr.namespace + '-issuer'")}, {NameExpression: ptr.To("cr.namespace")}}},
+				}},
+			},
+			expPolicies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{IssuerRefs: []policyapi.CertificateRequestPolicySelectorIssuerRef{{NameExpression: ptr.To("cr.namespace + '-issuer'")}, {NameExpression: ptr.To("cr.namespace")}}},
+				}},
+			},
+		},
+		"if policy name expression doesn't output a string, return error": {
+			request: &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "team-a"},
+				Spec:       cmapi.CertificateRequestSpec{IssuerRef: cmmeta.ObjectReference{Name: "team-a-issuer"}},
+			},
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{NameExpression: ptr.To("size(cr.namespace)")}},
+				}},
+			},
+			expPolicies: nil,
+			expErr:      true,
+		},
 	}
 
 	for name, test := range tests {
@@ -723,8 +958,9 @@ func Test_SelectorIssuerRef(t *testing.T) {
 			if test.request == nil {
 				test.request = baseRequest
 			}
-			policies, err := SelectorIssuerRef(context.TODO(), test.request, test.policies)
-			assert.NoError(t, err)
+			lister := fakeclient.NewClientBuilder().WithScheme(policyapi.GlobalScheme).Build()
+			policies, err := SelectorIssuerRef(lister)(context.TODO(), test.request, test.policies)
+			assert.Equalf(t, test.expErr, err != nil, "%v", err)
 			if !apiequality.Semantic.DeepEqual(test.expPolicies, policies) {
 				t.Errorf("unexpected policies returned:\nexp=%#+v\ngot=%#+v", test.expPolicies, policies)
 			}
@@ -732,6 +968,78 @@ func Test_SelectorIssuerRef(t *testing.T) {
 	}
 }
 
+func Test_SelectorIssuerRefRequireReady(t *testing.T) {
+	issuerWithReady := func(kind, namespace, name string, status cmmeta.ConditionStatus) client.Object {
+		issuerStatus := cmapi.IssuerStatus{Conditions: []cmapi.IssuerCondition{{Type: cmapi.IssuerConditionReady, Status: status}}}
+		if kind == cmapi.ClusterIssuerKind {
+			return &cmapi.ClusterIssuer{ObjectMeta: metav1.ObjectMeta{Name: name}, Status: issuerStatus}
+		}
+		return &cmapi.Issuer{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}, Status: issuerStatus}
+	}
+	requestFor := func(name, kind, group string) *cmapi.CertificateRequest {
+		return &cmapi.CertificateRequest{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns"},
+			Spec:       cmapi.CertificateRequestSpec{IssuerRef: cmmeta.ObjectReference{Name: name, Kind: kind, Group: group}},
+		}
+	}
+
+	requireReadyPolicy := policyapi.CertificateRequestPolicy{Spec: policyapi.CertificateRequestPolicySpec{
+		Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{RequireReady: ptr.To(true)}},
+	}}
+	anyIssuerPolicy := policyapi.CertificateRequestPolicy{Spec: policyapi.CertificateRequestPolicySpec{
+		Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{RequireReady: ptr.To(false)}},
+	}}
+	policies := []policyapi.CertificateRequestPolicy{requireReadyPolicy, anyIssuerPolicy}
+
+	tests := map[string]struct {
+		existingIssuers []client.Object
+		request         *cmapi.CertificateRequest
+		expPolicies     []policyapi.CertificateRequestPolicy
+	}{
+		"if the Issuer is ready, return all policies": {
+			existingIssuers: []client.Object{issuerWithReady(cmapi.IssuerKind, "test-ns", "my-issuer", cmmeta.ConditionTrue)},
+			request:         requestFor("my-issuer", "", ""),
+			expPolicies:     policies,
+		},
+		"if the Issuer is not ready, return only policies which don't require a ready issuer": {
+			existingIssuers: []client.Object{issuerWithReady(cmapi.IssuerKind, "test-ns", "my-issuer", cmmeta.ConditionFalse)},
+			request:         requestFor("my-issuer", "", ""),
+			expPolicies:     []policyapi.CertificateRequestPolicy{anyIssuerPolicy},
+		},
+		"if a ready Issuer exists in another namespace, return only policies which don't require a ready issuer": {
+			existingIssuers: []client.Object{issuerWithReady(cmapi.IssuerKind, "other-ns", "my-issuer", cmmeta.ConditionTrue)},
+			request:         requestFor("my-issuer", "", ""),
+			expPolicies:     []policyapi.CertificateRequestPolicy{anyIssuerPolicy},
+		},
+		"if the ClusterIssuer is ready, return all policies": {
+			existingIssuers: []client.Object{issuerWithReady(cmapi.ClusterIssuerKind, "", "my-issuer", cmmeta.ConditionTrue)},
+			request:         requestFor("my-issuer", cmapi.ClusterIssuerKind, "cert-manager.io"),
+			expPolicies:     policies,
+		},
+		"if the ClusterIssuer doesn't exist, return only policies which don't require a ready issuer": {
+			request:     requestFor("my-issuer", cmapi.ClusterIssuerKind, "cert-manager.io"),
+			expPolicies: []policyapi.CertificateRequestPolicy{anyIssuerPolicy},
+		},
+		"if the issuer is of another group, return only policies which don't require a ready issuer": {
+			request:     requestFor("my-issuer", "AWSPCAClusterIssuer", "awspca.cert-manager.io"),
+			expPolicies: []policyapi.CertificateRequestPolicy{anyIssuerPolicy},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			lister := fakeclient.NewClientBuilder().
+				WithScheme(policyapi.GlobalScheme).
+				WithObjects(test.existingIssuers...).
+				Build()
+
+			got, err := SelectorIssuerRef(lister)(context.TODO(), test.request, policies)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expPolicies, got)
+		})
+	}
+}
+
 func Test_SelectorNamespace(t *testing.T) {
 	var (
 		baseRequest = &cmapi.CertificateRequest{
@@ -1039,6 +1347,191 @@ func Test_SelectorNamespace(t *testing.T) {
 			existingNamespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-namespace", Labels: map[string]string{"foo": "bar"}}},
 			expErr:            false,
 		},
+		"if policy uses match expressions Exists and namespace has the label, return policy": {
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "foo", Operator: metav1.LabelSelectorOpExists},
+						},
+					}},
+				}},
+			},
+			existingNamespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-namespace", Labels: map[string]string{"foo": "bar"}}},
+			expPolicies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "foo", Operator: metav1.LabelSelectorOpExists},
+						},
+					}},
+				}},
+			},
+			expErr: false,
+		},
+		"if policy uses match expressions Exists and namespace doesn't have the label, return no policies": {
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "foo", Operator: metav1.LabelSelectorOpExists},
+						},
+					}},
+				}},
+			},
+			existingNamespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-namespace", Labels: map[string]string{"bar": "foo"}}},
+			expPolicies:       nil,
+			expErr:            false,
+		},
+		"if policy uses match expressions NotIn and namespace label is in the values, return no policies": {
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "env", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"prod", "staging"}},
+						},
+					}},
+				}},
+			},
+			existingNamespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-namespace", Labels: map[string]string{"env": "prod"}}},
+			expPolicies:       nil,
+			expErr:            false,
+		},
+		"if policy uses match expressions NotIn and namespace label is not in the values, return policy": {
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "env", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"prod", "staging"}},
+						},
+					}},
+				}},
+			},
+			existingNamespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-namespace", Labels: map[string]string{"env": "dev"}}},
+			expPolicies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "env", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"prod", "staging"}},
+						},
+					}},
+				}},
+			},
+			expErr: false,
+		},
+		"if policy uses match labels and match expressions but only match expressions matches, return no policies": {
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{
+						MatchLabels: map[string]string{"foo": "bar"},
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "env", Operator: metav1.LabelSelectorOpExists},
+						},
+					}},
+				}},
+			},
+			existingNamespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-namespace", Labels: map[string]string{"env": "dev"}}},
+			expPolicies:       nil,
+			expErr:            false,
+		},
+		"if policy excludes the namespace by name, return no policies": {
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{
+						ExcludeNames: []string{"test-namespace"},
+					}},
+				}},
+			},
+			existingNamespace: testns,
+			expPolicies:       nil,
+			expErr:            false,
+		},
+		"if policy excludes the namespace by wildcard name, return no policies": {
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{
+						ExcludeNames: []string{"kube-system", "test-*"},
+					}},
+				}},
+			},
+			existingNamespace: testns,
+			expPolicies:       nil,
+			expErr:            false,
+		},
+		"if policy excludes a different namespace by name, return policy": {
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{
+						ExcludeNames: []string{"kube-system", "cert-manager"},
+					}},
+				}},
+			},
+			existingNamespace: testns,
+			expPolicies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{
+						ExcludeNames: []string{"kube-system", "cert-manager"},
+					}},
+				}},
+			},
+			expErr: false,
+		},
+		"if policy matches and excludes the namespace by name, exclude wins and return no policies": {
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{
+						MatchNames:   []string{"*"},
+						ExcludeNames: []string{"test-namespace"},
+					}},
+				}},
+			},
+			existingNamespace: testns,
+			expPolicies:       nil,
+			expErr:            false,
+		},
+		"if policy matches by label and excludes the namespace by label, exclude wins and return no policies": {
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{
+						MatchLabels:   map[string]string{"foo": "bar"},
+						ExcludeLabels: map[string]string{"env": "system"},
+					}},
+				}},
+			},
+			existingNamespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-namespace", Labels: map[string]string{"foo": "bar", "env": "system"}}},
+			expPolicies:       nil,
+			expErr:            false,
+		},
+		"if policy excludes by labels which the namespace only partly has, return policy": {
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{
+						ExcludeLabels: map[string]string{"env": "system", "team": "platform"},
+					}},
+				}},
+			},
+			existingNamespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-namespace", Labels: map[string]string{"env": "system"}}},
+			expPolicies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{
+						ExcludeLabels: map[string]string{"env": "system", "team": "platform"},
+					}},
+				}},
+			},
+			expErr: false,
+		},
+		"if namespace for request doesn't exist and using exclude labels, expect error": {
+			policies: []policyapi.CertificateRequestPolicy{
+				{Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{
+						ExcludeLabels: map[string]string{"env": "system"},
+					}},
+				}},
+			},
+			existingNamespace: nil,
+			expPolicies:       nil,
+			expErr:            true,
+		},
 	}
 
 	for name, test := range tests {
@@ -1058,3 +1551,333 @@ func Test_SelectorNamespace(t *testing.T) {
 		})
 	}
 }
+
+func Test_ExplicitNamespaceSelector(t *testing.T) {
+	namespacePolicy := policyapi.CertificateRequestPolicy{Spec: policyapi.CertificateRequestPolicySpec{
+		Selector: policyapi.CertificateRequestPolicySelector{Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{
+			MatchNames: []string{"*"},
+		}},
+	}}
+	emptyNamespacePolicy := policyapi.CertificateRequestPolicy{Spec: policyapi.CertificateRequestPolicySpec{
+		Selector: policyapi.CertificateRequestPolicySelector{Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{}},
+	}}
+	noNamespacePolicy := policyapi.CertificateRequestPolicy{Spec: policyapi.CertificateRequestPolicySpec{
+		Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{}},
+	}}
+
+	tests := map[string]struct {
+		policies    []policyapi.CertificateRequestPolicy
+		expPolicies []policyapi.CertificateRequestPolicy
+	}{
+		"if no policies given, return no policies": {
+			policies:    nil,
+			expPolicies: nil,
+		},
+		"if policy has no namespace selector, return no policies": {
+			policies:    []policyapi.CertificateRequestPolicy{noNamespacePolicy},
+			expPolicies: nil,
+		},
+		"if policies have a namespace selector, including an empty one, return them": {
+			policies:    []policyapi.CertificateRequestPolicy{namespacePolicy, noNamespacePolicy, emptyNamespacePolicy},
+			expPolicies: []policyapi.CertificateRequestPolicy{namespacePolicy, emptyNamespacePolicy},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			policies, err := ExplicitNamespaceSelector(context.TODO(), &cmapi.CertificateRequest{}, test.policies)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expPolicies, policies)
+		})
+	}
+}
+
+func Test_Fallback(t *testing.T) {
+	primaryPolicy := policyapi.CertificateRequestPolicy{ObjectMeta: metav1.ObjectMeta{Name: "primary"}}
+	notFallbackPolicy := policyapi.CertificateRequestPolicy{ObjectMeta: metav1.ObjectMeta{Name: "not-fallback"}, Spec: policyapi.CertificateRequestPolicySpec{
+		Fallback: ptr.To(false),
+	}}
+	fallbackPolicy := policyapi.CertificateRequestPolicy{ObjectMeta: metav1.ObjectMeta{Name: "fallback"}, Spec: policyapi.CertificateRequestPolicySpec{
+		Fallback: ptr.To(true),
+	}}
+
+	tests := map[string]struct {
+		policies    []policyapi.CertificateRequestPolicy
+		expPolicies []policyapi.CertificateRequestPolicy
+	}{
+		"if no policies given, return no policies": {
+			policies:    nil,
+			expPolicies: nil,
+		},
+		"if non-fallback policies are given, return only them": {
+			policies:    []policyapi.CertificateRequestPolicy{fallbackPolicy, primaryPolicy, notFallbackPolicy},
+			expPolicies: []policyapi.CertificateRequestPolicy{primaryPolicy, notFallbackPolicy},
+		},
+		"if only fallback policies are given, return them": {
+			policies:    []policyapi.CertificateRequestPolicy{fallbackPolicy},
+			expPolicies: []policyapi.CertificateRequestPolicy{fallbackPolicy},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			policies, err := Fallback(context.TODO(), &cmapi.CertificateRequest{}, test.policies)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expPolicies, policies)
+		})
+	}
+}
+
+func Test_SelectorCertificateRequest(t *testing.T) {
+	matchLabelsPolicy := policyapi.CertificateRequestPolicy{Spec: policyapi.CertificateRequestPolicySpec{
+		Selector: policyapi.CertificateRequestPolicySelector{CertificateRequest: &policyapi.CertificateRequestPolicySelectorCertificateRequest{
+			MatchLabels: map[string]string{"team": "a"},
+		}},
+	}}
+	matchExpressionsPolicy := policyapi.CertificateRequestPolicy{Spec: policyapi.CertificateRequestPolicySpec{
+		Selector: policyapi.CertificateRequestPolicySelector{CertificateRequest: &policyapi.CertificateRequestPolicySelectorCertificateRequest{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "env", Operator: metav1.LabelSelectorOpIn, Values: []string{"prod", "staging"}},
+			},
+		}},
+	}}
+	emptySelectorPolicy := policyapi.CertificateRequestPolicy{Spec: policyapi.CertificateRequestPolicySpec{
+		Selector: policyapi.CertificateRequestPolicySelector{CertificateRequest: &policyapi.CertificateRequestPolicySelectorCertificateRequest{}},
+	}}
+	noSelectorPolicy := policyapi.CertificateRequestPolicy{Spec: policyapi.CertificateRequestPolicySpec{
+		Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{}},
+	}}
+
+	tests := map[string]struct {
+		labels      map[string]string
+		policies    []policyapi.CertificateRequestPolicy
+		expPolicies []policyapi.CertificateRequestPolicy
+		expErr      bool
+	}{
+		"if no policies given, return no policies": {
+			labels:      map[string]string{"team": "a"},
+			policies:    nil,
+			expPolicies: nil,
+		},
+		"if policy has no certificateRequest selector, return policy": {
+			labels:      nil,
+			policies:    []policyapi.CertificateRequestPolicy{noSelectorPolicy},
+			expPolicies: []policyapi.CertificateRequestPolicy{noSelectorPolicy},
+		},
+		"if policy has empty certificateRequest selector, return policy": {
+			labels:      nil,
+			policies:    []policyapi.CertificateRequestPolicy{emptySelectorPolicy},
+			expPolicies: []policyapi.CertificateRequestPolicy{emptySelectorPolicy},
+		},
+		"if request labels match policy match labels, return policy": {
+			labels:      map[string]string{"team": "a", "env": "dev"},
+			policies:    []policyapi.CertificateRequestPolicy{matchLabelsPolicy},
+			expPolicies: []policyapi.CertificateRequestPolicy{matchLabelsPolicy},
+		},
+		"if request labels don't match policy match labels, return no policies": {
+			labels:      map[string]string{"team": "b"},
+			policies:    []policyapi.CertificateRequestPolicy{matchLabelsPolicy},
+			expPolicies: nil,
+		},
+		"if request has no labels and policy has match labels, return no policies": {
+			labels:      nil,
+			policies:    []policyapi.CertificateRequestPolicy{matchLabelsPolicy},
+			expPolicies: nil,
+		},
+		"if request labels match policy match expressions, return policy": {
+			labels:      map[string]string{"env": "prod"},
+			policies:    []policyapi.CertificateRequestPolicy{matchExpressionsPolicy},
+			expPolicies: []policyapi.CertificateRequestPolicy{matchExpressionsPolicy},
+		},
+		"if request labels don't match policy match expressions, return no policies": {
+			labels:      map[string]string{"env": "dev"},
+			policies:    []policyapi.CertificateRequestPolicy{matchExpressionsPolicy},
+			expPolicies: nil,
+		},
+		"if some policies match, return only matching policies": {
+			labels:      map[string]string{"team": "a", "env": "dev"},
+			policies:    []policyapi.CertificateRequestPolicy{matchLabelsPolicy, matchExpressionsPolicy, noSelectorPolicy},
+			expPolicies: []policyapi.CertificateRequestPolicy{matchLabelsPolicy, noSelectorPolicy},
+		},
+		"if policy has invalid match expression, return error": {
+			labels: map[string]string{"env": "prod"},
+			policies: []policyapi.CertificateRequestPolicy{{Spec: policyapi.CertificateRequestPolicySpec{
+				Selector: policyapi.CertificateRequestPolicySelector{CertificateRequest: &policyapi.CertificateRequestPolicySelectorCertificateRequest{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "env", Operator: metav1.LabelSelectorOpIn},
+					},
+				}},
+			}}},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			request := &cmapi.CertificateRequest{ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Labels: test.labels}}
+			policies, err := SelectorCertificateRequest(context.TODO(), request, test.policies)
+			assert.Equal(t, err != nil, test.expErr, "%v", err)
+			if !test.expErr && !apiequality.Semantic.DeepEqual(test.expPolicies, policies) {
+				t.Errorf("unexpected policies returned:\nexp=%#+v\ngot=%#+v", test.expPolicies, policies)
+			}
+		})
+	}
+}
+
+func Test_SelectorCertificateRequest_Annotations(t *testing.T) {
+	const revisionAnnotation = "cert-manager.io/certificate-revision"
+
+	firstIssuancePolicy := policyapi.CertificateRequestPolicy{Spec: policyapi.CertificateRequestPolicySpec{
+		Selector: policyapi.CertificateRequestPolicySelector{CertificateRequest: &policyapi.CertificateRequestPolicySelectorCertificateRequest{
+			MatchAnnotations: map[string]string{revisionAnnotation: "1"},
+		}},
+	}}
+	renewalPolicy := policyapi.CertificateRequestPolicy{Spec: policyapi.CertificateRequestPolicySpec{
+		Selector: policyapi.CertificateRequestPolicySelector{CertificateRequest: &policyapi.CertificateRequestPolicySelectorCertificateRequest{
+			MatchAnnotationExpressions: []metav1.LabelSelectorRequirement{
+				{Key: revisionAnnotation, Operator: metav1.LabelSelectorOpExists},
+				{Key: revisionAnnotation, Operator: metav1.LabelSelectorOpNotIn, Values: []string{"1"}},
+			},
+		}},
+	}}
+	labelsAndAnnotationsPolicy := policyapi.CertificateRequestPolicy{Spec: policyapi.CertificateRequestPolicySpec{
+		Selector: policyapi.CertificateRequestPolicySelector{CertificateRequest: &policyapi.CertificateRequestPolicySelectorCertificateRequest{
+			MatchLabels:      map[string]string{"team": "a"},
+			MatchAnnotations: map[string]string{revisionAnnotation: "1"},
+		}},
+	}}
+	allPolicies := []policyapi.CertificateRequestPolicy{firstIssuancePolicy, renewalPolicy, labelsAndAnnotationsPolicy}
+
+	tests := map[string]struct {
+		labels      map[string]string
+		annotations map[string]string
+		policies    []policyapi.CertificateRequestPolicy
+		expPolicies []policyapi.CertificateRequestPolicy
+		expErr      bool
+	}{
+		"if request is the first revision, return the first issuance policy": {
+			annotations: map[string]string{revisionAnnotation: "1"},
+			policies:    allPolicies,
+			expPolicies: []policyapi.CertificateRequestPolicy{firstIssuancePolicy},
+		},
+		"if request is a later revision, return the renewal policy": {
+			annotations: map[string]string{revisionAnnotation: "2"},
+			policies:    allPolicies,
+			expPolicies: []policyapi.CertificateRequestPolicy{renewalPolicy},
+		},
+		"if request has no revision annotation, return no policies": {
+			annotations: map[string]string{"other": "1"},
+			policies:    allPolicies,
+			expPolicies: nil,
+		},
+		"if request labels and annotations both match, return policies": {
+			labels:      map[string]string{"team": "a"},
+			annotations: map[string]string{revisionAnnotation: "1"},
+			policies:    allPolicies,
+			expPolicies: []policyapi.CertificateRequestPolicy{firstIssuancePolicy, labelsAndAnnotationsPolicy},
+		},
+		"if request annotations match but labels don't, return no policies": {
+			labels:      map[string]string{"team": "b"},
+			annotations: map[string]string{revisionAnnotation: "1"},
+			policies:    []policyapi.CertificateRequestPolicy{labelsAndAnnotationsPolicy},
+			expPolicies: nil,
+		},
+		"if policy has invalid match annotation expression, return error": {
+			annotations: map[string]string{revisionAnnotation: "1"},
+			policies: []policyapi.CertificateRequestPolicy{{Spec: policyapi.CertificateRequestPolicySpec{
+				Selector: policyapi.CertificateRequestPolicySelector{CertificateRequest: &policyapi.CertificateRequestPolicySelectorCertificateRequest{
+					MatchAnnotationExpressions: []metav1.LabelSelectorRequirement{
+						{Key: revisionAnnotation, Operator: metav1.LabelSelectorOpIn},
+					},
+				}},
+			}}},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			request := &cmapi.CertificateRequest{ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Labels: test.labels, Annotations: test.annotations}}
+			policies, err := SelectorCertificateRequest(context.TODO(), request, test.policies)
+			assert.Equal(t, err != nil, test.expErr, "%v", err)
+			if !test.expErr && !apiequality.Semantic.DeepEqual(test.expPolicies, policies) {
+				t.Errorf("unexpected policies returned:\nexp=%#+v\ngot=%#+v", test.expPolicies, policies)
+			}
+		})
+	}
+}
+
+func Test_SelectorCertificate(t *testing.T) {
+	certificate := &cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "test-namespace",
+		Name:      "test-certificate",
+		Labels:    map[string]string{"team": "a"},
+	}}
+	ownedBy := func(apiVersion, kind, name string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{APIVersion: apiVersion, Kind: kind, Name: name, Controller: ptr.To(true)}}
+	}
+
+	noSelectorPolicy := policyapi.CertificateRequestPolicy{}
+	teamAPolicy := policyapi.CertificateRequestPolicy{Spec: policyapi.CertificateRequestPolicySpec{
+		Selector: policyapi.CertificateRequestPolicySelector{Certificate: &policyapi.CertificateRequestPolicySelectorCertificate{
+			MatchLabels: map[string]string{"team": "a"},
+		}},
+	}}
+	teamBPolicy := policyapi.CertificateRequestPolicy{Spec: policyapi.CertificateRequestPolicySpec{
+		Selector: policyapi.CertificateRequestPolicySelector{Certificate: &policyapi.CertificateRequestPolicySelectorCertificate{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: metav1.LabelSelectorOpIn, Values: []string{"b"}}},
+		}},
+	}}
+	anyCertificatePolicy := policyapi.CertificateRequestPolicy{Spec: policyapi.CertificateRequestPolicySpec{
+		Selector: policyapi.CertificateRequestPolicySelector{Certificate: &policyapi.CertificateRequestPolicySelectorCertificate{}},
+	}}
+	allPolicies := []policyapi.CertificateRequestPolicy{noSelectorPolicy, teamAPolicy, teamBPolicy, anyCertificatePolicy}
+
+	tests := map[string]struct {
+		ownerReferences []metav1.OwnerReference
+		policies        []policyapi.CertificateRequestPolicy
+		expPolicies     []policyapi.CertificateRequestPolicy
+	}{
+		"if no policies given, return no policies": {
+			ownerReferences: ownedBy("cert-manager.io/v1", "Certificate", certificate.Name),
+			policies:        nil,
+			expPolicies:     nil,
+		},
+		"if the owning Certificate's labels match, return the matching policies": {
+			ownerReferences: ownedBy("cert-manager.io/v1", "Certificate", certificate.Name),
+			policies:        allPolicies,
+			expPolicies:     []policyapi.CertificateRequestPolicy{noSelectorPolicy, teamAPolicy, anyCertificatePolicy},
+		},
+		"if the request has no owner, return only policies without a certificate selector": {
+			ownerReferences: nil,
+			policies:        allPolicies,
+			expPolicies:     []policyapi.CertificateRequestPolicy{noSelectorPolicy},
+		},
+		"if the request is owned by a resource other than a Certificate, return only policies without a certificate selector": {
+			ownerReferences: ownedBy("example.com/v1", "Certificate", certificate.Name),
+			policies:        allPolicies,
+			expPolicies:     []policyapi.CertificateRequestPolicy{noSelectorPolicy},
+		},
+		"if the owning Certificate doesn't exist, return only policies without a certificate selector": {
+			ownerReferences: ownedBy("cert-manager.io/v1", "Certificate", "deleted-certificate"),
+			policies:        allPolicies,
+			expPolicies:     []policyapi.CertificateRequestPolicy{noSelectorPolicy},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			lister := fakeclient.NewClientBuilder().
+				WithScheme(policyapi.GlobalScheme).
+				WithRuntimeObjects(certificate).
+				Build()
+
+			request := &cmapi.CertificateRequest{ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", OwnerReferences: test.ownerReferences}}
+			policies, err := SelectorCertificate(lister)(context.TODO(), request, test.policies)
+			assert.NoError(t, err)
+			if !apiequality.Semantic.DeepEqual(test.expPolicies, policies) {
+				t.Errorf("unexpected policies returned:\nexp=%#+v\ngot=%#+v", test.expPolicies, policies)
+			}
+		})
+	}
+}
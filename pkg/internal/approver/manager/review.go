@@ -19,16 +19,21 @@ package manager
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/go-logr/logr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
 	"github.com/cert-manager/approver-policy/pkg/approver"
 	"github.com/cert-manager/approver-policy/pkg/approver/manager"
 	"github.com/cert-manager/approver-policy/pkg/internal/approver/manager/predicate"
+	"github.com/cert-manager/approver-policy/pkg/internal/util"
 )
 
 var _ manager.Interface = &mngr{}
@@ -38,8 +43,72 @@ var _ manager.Interface = &mngr{}
 // CertificateRequests using the registered evaluators.
 type mngr struct {
 	lister     client.Reader
-	predicates []predicate.Predicate
+	predicates []namedPredicate
 	evaluators []approver.Evaluator
+
+	// baseline is the name of the CertificateRequestPolicy which every
+	// request must pass in addition to being approved by a selected policy.
+	// Empty if no baseline policy is configured.
+	baseline string
+
+	// maxPolicies is the maximum number of policies evaluated per request.
+	// Zero means no limit.
+	maxPolicies int
+
+	// denyOnNoApplicablePolicy denies requests which no policy is applicable
+	// to, rather than leaving them unprocessed.
+	denyOnNoApplicablePolicy bool
+
+	// concurrency is the maximum number of policies evaluated concurrently
+	// per request.
+	concurrency int
+}
+
+// Options are optional configuration of the approver Manager.
+type Options struct {
+	// BaselinePolicy names a CertificateRequestPolicy which is never selected
+	// for approval, but which every approved request must additionally pass.
+	BaselinePolicy string
+
+	// MaxPolicies is the maximum number of selected policies which are
+	// evaluated for a single request. If more policies are selected, only the
+	// first MaxPolicies sorted by name are evaluated. Zero means no limit.
+	MaxPolicies int
+
+	// RequireExplicitSelectors causes policies which omit a namespace selector
+	// to match no requests, rather than requests in any namespace.
+	RequireExplicitSelectors bool
+
+	// DenyOnNoApplicablePolicy causes requests which no ready policy is bound
+	// or applicable to be denied, rather than left unprocessed. Requests are
+	// still left unprocessed if no policies exist.
+	DenyOnNoApplicablePolicy bool
+
+	// EvaluatorTimeout is the maximum duration of a single evaluator call.
+	// Evaluators which time out deny the request for that policy. Zero means
+	// no timeout.
+	EvaluatorTimeout time.Duration
+
+	// CircuitBreaker records evaluator timeouts, marking the policies of
+	// repeatedly timing out plugins as not ready. May be nil. Only used if
+	// EvaluatorTimeout is set.
+	CircuitBreaker *CircuitBreaker
+
+	// Concurrency is the maximum number of policies which are evaluated
+	// concurrently for a single request. Values less than two evaluate
+	// policies sequentially.
+	Concurrency int
+}
+
+// rbacBoundPredicate is the name of the predicate which filters policies to
+// those the requester is bound to use.
+const rbacBoundPredicate = "RBACBound"
+
+// namedPredicate is a Predicate paired with a name which is used when
+// recording review traces.
+type namedPredicate struct {
+	name string
+	predicate.Predicate
 }
 
 // policyMessage holds the name of the CertificateRequestPolicy and aggregated
@@ -49,9 +118,12 @@ type policyMessage struct {
 	// response by the evaluators.
 	name string
 
-	// message is the aggregated messages returned from the evaluators for this
+	// messages are the messages returned from the evaluators for this policy.
+	messages []string
+
+	// reasons are the denial reasons returned from the evaluators for this
 	// policy.
-	message string
+	reasons []approver.DenialReason
 }
 
 // New constructs a new approver Manager that evaluates whether
@@ -64,16 +136,42 @@ type policyMessage struct {
 // IssuerRef
 //   - CertificateRequestPolicy is bound to the user that appears in the
 //     CertificateRequest
-func New(lister client.Reader, client client.Client, evaluators []approver.Evaluator) manager.Interface {
+//   - CertificateRequestPolicy is not a fallback policy, unless every
+//     remaining policy is a fallback policy
+//
+// If RequireExplicitSelectors is set, policies must also have a namespace
+// selector.
+func New(lister client.Reader, client client.Client, evaluators []approver.Evaluator, opts Options) manager.Interface {
+	if opts.EvaluatorTimeout > 0 {
+		evaluators = withTimeout(evaluators, opts.EvaluatorTimeout, opts.CircuitBreaker)
+	}
+
+	predicates := []namedPredicate{
+		{"Ready", predicate.Ready},
+		{"SelectorIssuerRef", predicate.SelectorIssuerRef(lister)},
+	}
+	if opts.RequireExplicitSelectors {
+		predicates = append(predicates, namedPredicate{"ExplicitNamespaceSelector", predicate.ExplicitNamespaceSelector})
+	}
+	predicates = append(predicates,
+		namedPredicate{"SelectorNamespace", predicate.SelectorNamespace(lister)},
+		namedPredicate{"SelectorCertificateRequest", predicate.SelectorCertificateRequest},
+		namedPredicate{"SelectorCertificate", predicate.SelectorCertificate(lister)},
+		namedPredicate{rbacBoundPredicate, predicate.RBACBound(client)},
+		// Fallback policies are only selected if no other policy is, so must
+		// be filtered last.
+		namedPredicate{"Fallback", predicate.Fallback},
+	)
+
 	return &mngr{
-		lister: lister,
-		predicates: []predicate.Predicate{
-			predicate.Ready,
-			predicate.SelectorIssuerRef,
-			predicate.SelectorNamespace(lister),
-			predicate.RBACBound(client),
-		},
-		evaluators: evaluators,
+		lister:      lister,
+		predicates:  predicates,
+		evaluators:  evaluators,
+		baseline:    opts.BaselinePolicy,
+		maxPolicies: opts.MaxPolicies,
+
+		denyOnNoApplicablePolicy: opts.DenyOnNoApplicablePolicy,
+		concurrency:              opts.Concurrency,
 	}
 }
 
@@ -81,6 +179,14 @@ func New(lister client.Reader, client client.Client, evaluators []approver.Evalu
 // approved. All evaluators will be called with CertificateRequestPolicys that
 // have passed all of the predicates.
 func (m *mngr) Review(ctx context.Context, cr *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
+	trace := manager.ReviewTraceFromContext(ctx)
+	if trace != nil {
+		start := time.Now()
+		defer func() {
+			trace.Duration = time.Since(start)
+		}()
+	}
+
 	policyList := new(policyapi.CertificateRequestPolicyList)
 	if err := m.lister.List(ctx, policyList); err != nil {
 		return manager.ReviewResponse{}, err
@@ -90,86 +196,564 @@ func (m *mngr) Review(ctx context.Context, cr *cmapi.CertificateRequest) (manage
 	// ResultUnprocessed. A CertificateRequest may be re-evaluated at a later
 	// time if a CertificateRequestPolicy is created.
 	if len(policyList.Items) == 0 {
-		return manager.ReviewResponse{Result: manager.ResultUnprocessed, Message: "No CertificateRequestPolicies exist"}, nil
+		return manager.ReviewResponse{Result: manager.ResultUnprocessed, Message: manager.MessageNoPolicies}, nil
 	}
 
 	var (
 		policies = policyList.Items
 		err      error
 	)
+	if len(m.baseline) > 0 {
+		// The baseline policy is only ever evaluated in addition to the
+		// selected policies, so can never approve a request on its own.
+		policies = slices.DeleteFunc(policies, func(policy policyapi.CertificateRequestPolicy) bool {
+			return policy.Name == m.baseline
+		})
+	}
+	if trace != nil {
+		trace.Policies = policyNames(policies)
+	}
 	for _, predicate := range m.predicates {
-		policies, err = predicate(ctx, cr, policies)
+		start := time.Now()
+		policies, err = predicate.Predicate(ctx, cr, policies)
 		if err != nil {
 			return manager.ReviewResponse{}, fmt.Errorf("failed to perform predicate on policies: %w", err)
 		}
+		if trace != nil {
+			trace.Predicates = append(trace.Predicates, manager.PredicateTrace{
+				Name:     predicate.name,
+				Policies: policyNames(policies),
+				Duration: time.Since(start),
+			})
+			if predicate.name == rbacBoundPredicate {
+				trace.BoundPolicies = policyNames(policies)
+			}
+		}
 	}
 
-	// If no policies are appropriate, return ResultUnprocessed.
+	// If no policies are appropriate, return ResultUnprocessed, or
+	// ResultDenied if configured to deny by default.
 	if len(policies) == 0 {
+		if m.denyOnNoApplicablePolicy {
+			return manager.ReviewResponse{
+				Result:  manager.ResultDenied,
+				Message: manager.MessageNoApplicablePoliciesDenied,
+			}, nil
+		}
 		return manager.ReviewResponse{
 			Result:  manager.ResultUnprocessed,
-			Message: "No CertificateRequestPolicies bound or applicable",
+			Message: manager.MessageNoApplicablePolicies,
+		}, nil
+	}
+
+	policies, err = m.resolvePolicies(ctx, policies)
+	if err != nil {
+		return manager.ReviewResponse{}, err
+	}
+
+	var exceeded int
+	if m.maxPolicies > 0 && len(policies) > m.maxPolicies {
+		// Sort so that the evaluated policies are deterministic. Strict
+		// policies are sorted first so that they are always evaluated within
+		// the maximum where possible.
+		exceeded = len(policies)
+		policies = slices.Clone(policies)
+		slices.SortFunc(policies, func(a, b policyapi.CertificateRequestPolicy) int {
+			if aStrict, bStrict := isStrict(&a), isStrict(&b); aStrict != bStrict {
+				if aStrict {
+					return -1
+				}
+				return 1
+			}
+			return strings.Compare(a.Name, b.Name)
+		})
+		policies = policies[:m.maxPolicies]
+		logr.FromContextOrDiscard(ctx).Info("request selected more CertificateRequestPolicies than the maximum evaluated per request, policy selectors are likely misconfigured",
+			"selected", exceeded, "max", m.maxPolicies)
+	}
+
+	response, err := evaluate(ctx, m.evaluators, policies, cr, m.concurrency)
+	if err != nil {
+		return response, err
+	}
+
+	if exceeded > 0 && response.Result == manager.ResultDenied {
+		response.Message = fmt.Sprintf("%s (only the first %d of %d applicable CertificateRequestPolicies were evaluated, exceeding the maximum per request)",
+			response.Message, m.maxPolicies, exceeded)
+	}
+
+	if response.Result != manager.ResultApproved || len(m.baseline) == 0 {
+		return response, nil
+	}
+
+	return m.reviewBaseline(ctx, cr, response)
+}
+
+// resolvePolicies returns the given policies with the policies they inherit
+// from and the CertificateRequestPolicyProfiles they reference applied, so
+// that evaluators are given the merged allowed and constraints fields.
+func (m *mngr) resolvePolicies(ctx context.Context, policies []policyapi.CertificateRequestPolicy) ([]policyapi.CertificateRequestPolicy, error) {
+	resolved := make([]policyapi.CertificateRequestPolicy, len(policies))
+	for i := range policies {
+		policy, err := util.ResolvePolicy(ctx, m.lister, &policies[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve CertificateRequestPolicy %q: %w", policies[i].Name, err)
+		}
+		resolved[i] = *policy
+	}
+	return resolved, nil
+}
+
+// reviewBaseline evaluates the request against the baseline policy, returning
+// a denied response if any evaluator denies the request, or the given approved
+// response otherwise. An error is returned if the baseline policy doesn't
+// exist or is not ready, so that requests are never approved without the
+// baseline being enforced.
+func (m *mngr) reviewBaseline(ctx context.Context, cr *cmapi.CertificateRequest, approved manager.ReviewResponse) (manager.ReviewResponse, error) {
+	var baseline policyapi.CertificateRequestPolicy
+	if err := m.lister.Get(ctx, client.ObjectKey{Name: m.baseline}, &baseline); err != nil {
+		return manager.ReviewResponse{}, fmt.Errorf("failed to get baseline CertificateRequestPolicy %q: %w", m.baseline, err)
+	}
+
+	ready, err := predicate.Ready(ctx, cr, []policyapi.CertificateRequestPolicy{baseline})
+	if err != nil {
+		return manager.ReviewResponse{}, err
+	}
+	if len(ready) == 0 {
+		return manager.ReviewResponse{}, fmt.Errorf("baseline CertificateRequestPolicy %q is not ready", m.baseline)
+	}
+
+	resolved, err := util.ResolvePolicy(ctx, m.lister, &baseline)
+	if err != nil {
+		return manager.ReviewResponse{}, fmt.Errorf("failed to resolve baseline CertificateRequestPolicy %q: %w", m.baseline, err)
+	}
+
+	evaluation, err := evaluatePolicy(ctx, m.evaluators, resolved, cr)
+	if err != nil {
+		return manager.ReviewResponse{}, err
+	}
+	if evaluation.denied {
+		return manager.ReviewResponse{
+			Result:   manager.ResultDenied,
+			Message:  "Denied by baseline CertificateRequestPolicy: " + manager.FormatPolicyMessages([]manager.PolicyMessages{{Policy: baseline.Name, Messages: evaluation.messages}}),
+			Policies: []string{baseline.Name},
+			Denials:  []manager.Denial{{Policy: baseline.Name, Errors: evaluation.messages, Reasons: evaluation.reasons}},
+		}, nil
+	}
+	if evaluation.pending {
+		return manager.ReviewResponse{
+			Result:   manager.ResultPending,
+			Message:  "Awaiting external approval by baseline CertificateRequestPolicy: " + manager.FormatPolicyMessages([]manager.PolicyMessages{{Policy: baseline.Name, Messages: evaluation.messages}}),
+			Policies: []string{baseline.Name},
+		}, nil
+	}
+
+	return approved, nil
+}
+
+// Evaluate runs every evaluator against the given policies, and returns the
+// combined result. Policies take precedence in the following order:
+//
+//  1. If any Strict policy denies the request, a denied response listing the
+//     denying Strict policies is returned, regardless of any other policy.
+//  2. If any Strict policy is pending, a pending response listing the pending
+//     Strict policies is returned, since the request can't be approved until
+//     it is known that no Strict policy denies it.
+//  3. If any Strict policy approves the request, an approved response for the
+//     first approving Strict policy is returned.
+//  4. Otherwise, the Permissive policies are evaluated in turn, and an
+//     approved response is returned for the first policy which no evaluator
+//     denied and no evaluator is pending. If none approve the request, but
+//     any policy which didn't deny it is pending, a pending response listing
+//     those policies is returned, so that a request is never denied whilst
+//     awaiting an external decision. If every policy is denied, a denied
+//     response listing all policies is returned.
+//
+// No predicates are run, so the caller is responsible for passing only the
+// policies which are appropriate for the request.
+// Evaluations are recorded to the review trace in the context, if any.
+func Evaluate(ctx context.Context, evaluators []approver.Evaluator, policies []policyapi.CertificateRequestPolicy, cr *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
+	return evaluate(ctx, evaluators, policies, cr, 1)
+}
+
+// evaluate is Evaluate, evaluating up to concurrency policies at once. The
+// result is the same regardless of concurrency.
+func evaluate(ctx context.Context, evaluators []approver.Evaluator, policies []policyapi.CertificateRequestPolicy, cr *cmapi.CertificateRequest, concurrency int) (manager.ReviewResponse, error) {
+	var strict, permissive []policyapi.CertificateRequestPolicy
+	for _, policy := range policies {
+		if isStrict(&policy) {
+			strict = append(strict, policy)
+		} else {
+			permissive = append(permissive, policy)
+		}
+	}
+
+	// Every Strict policy is evaluated, since any one of them denying the
+	// request denies it regardless of the other policies.
+	var (
+		deniedMessages, pendingMessages []policyMessage
+		approved                        *manager.ReviewResponse
+	)
+	evaluations, err := evaluatePolicies(ctx, evaluators, strict, cr, concurrency)
+	if err != nil {
+		return manager.ReviewResponse{}, err
+	}
+	for i, policy := range strict {
+		evaluation := evaluations[i]
+		message := policyMessage{name: policy.Name, messages: evaluation.messages, reasons: evaluation.reasons}
+
+		switch {
+		case evaluation.denied:
+			deniedMessages = append(deniedMessages, message)
+
+		case evaluation.pending:
+			pendingMessages = append(pendingMessages, message)
+
+		case approved == nil:
+			approved = &manager.ReviewResponse{
+				Result:      manager.ResultApproved,
+				Message:     manager.FormatApprovedMessage(policy.Name),
+				Policies:    []string{policy.Name},
+				Annotations: evaluation.annotations,
+			}
+		}
+	}
+
+	if len(deniedMessages) > 0 {
+		messages, names := sortPolicyMessages(deniedMessages)
+		return manager.ReviewResponse{
+			Result:   manager.ResultDenied,
+			Message:  manager.FormatStrictDeniedMessage(messages),
+			Policies: names,
+			Denials:  policyDenials(deniedMessages),
+		}, nil
+	}
+
+	if len(pendingMessages) > 0 {
+		messages, names := sortPolicyMessages(pendingMessages)
+		return manager.ReviewResponse{
+			Result:   manager.ResultPending,
+			Message:  manager.FormatPendingMessage(messages),
+			Policies: names,
 		}, nil
 	}
 
+	if approved != nil {
+		return *approved, nil
+	}
+
+	return evaluatePermissive(ctx, evaluators, permissive, cr, concurrency)
+}
+
+// evaluatePermissive runs every evaluator against each of the given policies
+// in turn, returning an approved response for the first policy which approves
+// the request, or otherwise a pending or denied response listing the policies.
+// If policies are evaluated concurrently, every policy is evaluated up front
+// and the first approving policy is still chosen in order.
+func evaluatePermissive(ctx context.Context, evaluators []approver.Evaluator, policies []policyapi.CertificateRequestPolicy, cr *cmapi.CertificateRequest, concurrency int) (manager.ReviewResponse, error) {
+	var evaluations []policyEvaluation
+	if concurrency > 1 {
+		var err error
+		evaluations, err = evaluatePolicies(ctx, evaluators, policies, cr, concurrency)
+		if err != nil {
+			return manager.ReviewResponse{}, err
+		}
+	}
+
 	// policyMessages hold the aggregated messages of each evaluator response,
-	// keyed by the policy name that was executed.
-	var policyMessages []policyMessage
+	// keyed by the policy name that was executed. pendingMessages hold those
+	// of policies which are awaiting an external decision.
+	var policyMessages, pendingMessages []policyMessage
 
 	// Run every evaluators against ever policy which is bound to the requesting
 	// user.
-	for _, policy := range policies {
-		var (
-			evaluatorDenied   bool
-			evaluatorMessages []string
-		)
-
-		for _, evaluator := range m.evaluators {
+	for i, policy := range policies {
+		var evaluation policyEvaluation
+		if evaluations != nil {
+			evaluation = evaluations[i]
+		} else {
+			var err error
 			// #nosec G601 -- False positive. The function does not keep this pointer past its scope.
-			response, err := evaluator.Evaluate(ctx, &policy, cr)
+			evaluation, err = evaluatePolicy(ctx, evaluators, &policy, cr)
 			if err != nil {
-				// if a single evaluator errors, then return early without trying
-				// others.
 				return manager.ReviewResponse{}, err
 			}
+		}
 
-			if len(response.Message) > 0 {
-				evaluatorMessages = append(evaluatorMessages, response.Message)
-			}
+		message := policyMessage{name: policy.Name, messages: evaluation.messages, reasons: evaluation.reasons}
 
-			// evaluatorDenied will be set to true if any evaluator denies. We don't
-			// break early so that we can capture the responses from _all_
-			// evaluators.
-			if response.Result == approver.ResultDenied {
-				evaluatorDenied = true
-			}
-		}
+		switch {
+		case evaluation.denied:
+			// Collect evaluator messages that were executed for this policy.
+			policyMessages = append(policyMessages, message)
+
+		case evaluation.pending:
+			// Keep evaluating, since another policy may approve the request
+			// without waiting.
+			pendingMessages = append(pendingMessages, message)
 
-		// If no evaluator denied the request, return with approved response.
-		if !evaluatorDenied {
+		default:
+			// If no evaluator denied the request, return with approved response.
 			return manager.ReviewResponse{
-				Result:  manager.ResultApproved,
-				Message: fmt.Sprintf("Approved by CertificateRequestPolicy: %q", policy.Name),
+				Result:      manager.ResultApproved,
+				Message:     manager.FormatApprovedMessage(policy.Name),
+				Policies:    []string{policy.Name},
+				Annotations: evaluation.annotations,
 			}, nil
 		}
+	}
 
-		// Collect evaluator messages that were executed for this policy.
-		policyMessages = append(policyMessages, policyMessage{name: policy.Name, message: strings.Join(evaluatorMessages, ", ")})
+	if len(pendingMessages) > 0 {
+		messages, names := sortPolicyMessages(pendingMessages)
+		return manager.ReviewResponse{
+			Result:   manager.ResultPending,
+			Message:  manager.FormatPendingMessage(messages),
+			Policies: names,
+		}, nil
 	}
 
-	// Sort messages by policy name and build message string.
+	// Return with all policies that we consulted, and their errors to why the
+	// request was denied.
+	messages, names := sortPolicyMessages(policyMessages)
+	return manager.ReviewResponse{
+		Result:   manager.ResultDenied,
+		Message:  manager.FormatDeniedMessage(messages),
+		Policies: names,
+		Denials:  policyDenials(policyMessages),
+	}, nil
+}
+
+// isStrict returns true if the given policy has Strict enforcement.
+func isStrict(policy *policyapi.CertificateRequestPolicy) bool {
+	return policy.Spec.Enforcement != nil && *policy.Spec.Enforcement == policyapi.CertificateRequestPolicyEnforcementStrict
+}
+
+// sortPolicyMessages sorts the given messages by policy name, and returns
+// them as the messages of each policy along with the policy names.
+func sortPolicyMessages(policyMessages []policyMessage) ([]manager.PolicyMessages, []string) {
 	sort.SliceStable(policyMessages, func(i, j int) bool {
 		return policyMessages[i].name < policyMessages[j].name
 	})
-	var messages []string
+	var (
+		messages []manager.PolicyMessages
+		names    []string
+	)
 	for _, policyMessage := range policyMessages {
-		messages = append(messages, fmt.Sprintf("[%s: %s]", policyMessage.name, policyMessage.message))
+		messages = append(messages, manager.PolicyMessages{Policy: policyMessage.name, Messages: policyMessage.messages})
+		names = append(names, policyMessage.name)
 	}
+	return messages, names
+}
 
-	// Return with all policies that we consulted, and their errors to why the
-	// request was denied.
-	return manager.ReviewResponse{
-		Result:  manager.ResultDenied,
-		Message: fmt.Sprintf("No policy approved this request: %s", strings.Join(messages, " ")),
-	}, nil
+// policyDenials returns the given messages of denying policies as denials,
+// in the same order.
+func policyDenials(policyMessages []policyMessage) []manager.Denial {
+	denials := make([]manager.Denial, 0, len(policyMessages))
+	for _, policyMessage := range policyMessages {
+		denials = append(denials, manager.Denial{Policy: policyMessage.name, Errors: policyMessage.messages, Reasons: policyMessage.reasons})
+	}
+	return denials
+}
+
+// policyEvaluation is the aggregated result of running every evaluator
+// against a single policy.
+type policyEvaluation struct {
+	// denied is true if any evaluator denied the request.
+	denied bool
+
+	// pending is true if any evaluator is awaiting an external decision.
+	pending bool
+
+	// messages are the messages returned by all evaluators.
+	messages []string
+
+	// reasons are the denial reasons returned by all evaluators which denied
+	// the request.
+	reasons []approver.DenialReason
+
+	// annotations are the merged annotations returned by all evaluators.
+	annotations map[string]string
+}
+
+// evaluatePolicies runs every evaluator against each of the given policies,
+// evaluating up to concurrency policies at once, and returns the evaluations
+// in the same order as the policies. Each policy records its evaluations to
+// its own review trace, which are appended to the review trace in the
+// context in policy order once every policy has been evaluated, so that the
+// trace doesn't depend on which policy finished first. If any policy errors,
+// the error of the first such policy is returned.
+func evaluatePolicies(ctx context.Context, evaluators []approver.Evaluator, policies []policyapi.CertificateRequestPolicy, cr *cmapi.CertificateRequest, concurrency int) ([]policyEvaluation, error) {
+	evaluations := make([]policyEvaluation, len(policies))
+	if concurrency < 2 {
+		for i := range policies {
+			evaluation, err := evaluatePolicy(ctx, evaluators, &policies[i], cr)
+			if err != nil {
+				return nil, err
+			}
+			evaluations[i] = evaluation
+		}
+		return evaluations, nil
+	}
+
+	var (
+		trace  = manager.ReviewTraceFromContext(ctx)
+		traces = make([]*manager.ReviewTrace, len(policies))
+		errs   = make([]error, len(policies))
+		sem    = make(chan struct{}, concurrency)
+		wg     sync.WaitGroup
+	)
+	for i := range policies {
+		policyCtx := ctx
+		if trace != nil {
+			traces[i] = new(manager.ReviewTrace)
+			policyCtx = manager.WithReviewTrace(ctx, traces[i])
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			evaluations[i], errs[i] = evaluatePolicy(policyCtx, evaluators, &policies[i], cr)
+		}()
+	}
+	wg.Wait()
+
+	if trace != nil {
+		for _, policyTrace := range traces {
+			trace.Evaluations = append(trace.Evaluations, policyTrace.Evaluations...)
+		}
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return evaluations, nil
+}
+
+// evaluatePolicy runs every evaluator against the given policy, returning
+// whether any evaluator denied the request or is pending, along with the
+// messages and merged annotations of all evaluators. Once an evaluator has
+// denied the request, evaluators of later stages are skipped since they can't
+// change the result. Evaluations are recorded to the review trace in the
+// context, if any.
+func evaluatePolicy(ctx context.Context, evaluators []approver.Evaluator, policy *policyapi.CertificateRequestPolicy, cr *cmapi.CertificateRequest) (policyEvaluation, error) {
+	trace := manager.ReviewTraceFromContext(ctx)
+
+	var (
+		evaluation  policyEvaluation
+		deniedStage approver.EvaluationStage
+	)
+
+	for _, evaluator := range evaluators {
+		if evaluation.denied && approver.StageOf(evaluator) > deniedStage {
+			logr.FromContextOrDiscard(ctx).V(5).Info("skipping evaluator of later stage for denied policy", "policy", policy.Name, "evaluator", evaluatorName(evaluator))
+			continue
+		}
+
+		start := time.Now()
+		response, err := evaluator.Evaluate(ctx, policy, cr)
+		if err != nil {
+			// If the policy has already been denied by a previous evaluator,
+			// the result can't change so the error is ignored. This ensures
+			// requests which are never valid, such as those with an
+			// unparsable CSR, are denied rather than retried forever.
+			if evaluation.denied {
+				logr.FromContextOrDiscard(ctx).V(5).Info("ignoring evaluator error for denied policy", "policy", policy.Name, "evaluator", evaluatorName(evaluator), "error", err.Error())
+				break
+			}
+			// if a single evaluator errors, then return early without trying
+			// others.
+			return policyEvaluation{}, err
+		}
+
+		if trace != nil {
+			trace.Evaluations = append(trace.Evaluations, manager.EvaluationTrace{
+				Policy:    policy.Name,
+				Evaluator: evaluatorName(evaluator),
+				Result:    response.Result,
+				Pending:   response.Pending,
+				Message:   response.Message,
+				Duration:  time.Since(start),
+			})
+		}
+
+		if len(response.Message) > 0 {
+			evaluation.messages = append(evaluation.messages, response.Message)
+		}
+
+		// Annotations are merged in evaluator order, so later evaluators
+		// take precedence for the same key. Reserved annotations, which
+		// cert-manager and approver-policy act on, are dropped.
+		var reserved []string
+		for k, v := range response.Annotations {
+			if isReservedAnnotation(k) {
+				reserved = append(reserved, k)
+				continue
+			}
+			if evaluation.annotations == nil {
+				evaluation.annotations = make(map[string]string)
+			}
+			evaluation.annotations[k] = v
+		}
+		if len(reserved) > 0 {
+			slices.Sort(reserved)
+			logr.FromContextOrDiscard(ctx).Info("dropping reserved annotations returned by evaluator", "policy", policy.Name, "evaluator", evaluatorName(evaluator), "annotations", reserved)
+		}
+
+		// denied will be set to true if any evaluator denies. We don't break
+		// early so that we can capture the responses from all evaluators of
+		// the same stage. A denial always takes precedence over a pending
+		// evaluator.
+		if response.Result == approver.ResultDenied {
+			if !evaluation.denied {
+				deniedStage = approver.StageOf(evaluator)
+			}
+			evaluation.denied = true
+			evaluation.reasons = append(evaluation.reasons, response.Reasons...)
+		} else if response.Pending {
+			evaluation.pending = true
+		}
+	}
+
+	logr.FromContextOrDiscard(ctx).V(5).Info("evaluated policy", "policy", policy.Name, "denied", evaluation.denied, "pending", evaluation.pending)
+
+	return evaluation, nil
+}
+
+// isReservedAnnotation returns true if the given annotation key is in the
+// cert-manager.io domain, or any of its subdomains such as
+// policy.cert-manager.io. These annotations are read by cert-manager during
+// issuance, or written by approver-policy to record its decision, so must
+// not be set by evaluators.
+func isReservedAnnotation(key string) bool {
+	prefix, _, ok := strings.Cut(key, "/")
+	if !ok {
+		return false
+	}
+	prefix = strings.ToLower(prefix)
+	return prefix == "cert-manager.io" || strings.HasSuffix(prefix, ".cert-manager.io")
+}
+
+// policyNames returns the names of the given policies.
+func policyNames(policies []policyapi.CertificateRequestPolicy) []string {
+	names := make([]string, 0, len(policies))
+	for _, policy := range policies {
+		names = append(names, policy.Name)
+	}
+	return names
+}
+
+// evaluatorName returns the name of the given evaluator if it has one,
+// otherwise its type.
+func evaluatorName(evaluator approver.Evaluator) string {
+	if named, ok := evaluator.(interface{ Name() string }); ok {
+		return named.Name()
+	}
+	return fmt.Sprintf("%T", evaluator)
 }
@@ -19,13 +19,21 @@ package manager
 import (
 	"context"
 	"errors"
+	"fmt"
 	"path"
+	"slices"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
 	"github.com/cert-manager/approver-policy/pkg/approver"
@@ -118,7 +126,7 @@ func Test_Review(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{Name: "test-policy-a"},
 				Spec:       policyapi.CertificateRequestPolicySpec{Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{}}},
 			}},
-			expResponse: manager.ReviewResponse{Result: manager.ResultDenied, Message: "No policy approved this request: [test-policy-a: this is a denied response]"},
+			expResponse: manager.ReviewResponse{Result: manager.ResultDenied, Message: "No policy approved this request: [test-policy-a: this is a denied response]", Policies: []string{"test-policy-a"}, Denials: []manager.Denial{{Policy: "test-policy-a", Errors: []string{"this is a denied response"}}}},
 			expErr:      false,
 		},
 		"if single policy returns and evaluator returns not-denied, return ResultApproved": {
@@ -139,7 +147,59 @@ func Test_Review(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{Name: "test-policy-a"},
 				Spec:       policyapi.CertificateRequestPolicySpec{Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{}}},
 			}},
-			expResponse: manager.ReviewResponse{Result: manager.ResultApproved, Message: `Approved by CertificateRequestPolicy: "test-policy-a"`},
+			expResponse: manager.ReviewResponse{Result: manager.ResultApproved, Message: `Approved by CertificateRequestPolicy: "test-policy-a"`, Policies: []string{"test-policy-a"}},
+			expErr:      false,
+		},
+		"if evaluators of the approving policy return annotations, return them merged with ResultApproved": {
+			evaluator: func(t *testing.T) approver.Evaluator {
+				return fake.NewFakeEvaluator().WithEvaluate(func(_ context.Context, policy *policyapi.CertificateRequestPolicy, _ *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+					if policy.Name == "test-policy-b" {
+						return approver.EvaluationResponse{Result: approver.ResultNotDenied, Annotations: map[string]string{"example.com/ticket": "#123"}}, nil
+					}
+					return approver.EvaluationResponse{Result: approver.ResultDenied, Message: "this is a denied response", Annotations: map[string]string{"example.com/denied": "true"}}, nil
+				})
+			},
+			predicate: func(t *testing.T) predicate.Predicate {
+				return func(_ context.Context, _ *cmapi.CertificateRequest, policies []policyapi.CertificateRequestPolicy) ([]policyapi.CertificateRequestPolicy, error) {
+					return policies, nil
+				}
+			},
+			policies: []policyapi.CertificateRequestPolicy{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-policy-a"},
+					Spec:       policyapi.CertificateRequestPolicySpec{Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{}}},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-policy-b"},
+					Spec:       policyapi.CertificateRequestPolicySpec{Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{}}},
+				},
+			},
+			expResponse: manager.ReviewResponse{
+				Result:      manager.ResultApproved,
+				Message:     `Approved by CertificateRequestPolicy: "test-policy-b"`,
+				Policies:    []string{"test-policy-b"},
+				Annotations: map[string]string{"example.com/ticket": "#123"},
+			},
+			expErr: false,
+		},
+		"if evaluators of a denying policy return annotations, don't return them": {
+			evaluator: func(t *testing.T) approver.Evaluator {
+				return fake.NewFakeEvaluator().WithResponse(approver.EvaluationResponse{
+					Result:      approver.ResultDenied,
+					Message:     "this is a denied response",
+					Annotations: map[string]string{"example.com/ticket": "#123"},
+				})
+			},
+			predicate: func(t *testing.T) predicate.Predicate {
+				return func(_ context.Context, _ *cmapi.CertificateRequest, policies []policyapi.CertificateRequestPolicy) ([]policyapi.CertificateRequestPolicy, error) {
+					return policies, nil
+				}
+			},
+			policies: []policyapi.CertificateRequestPolicy{{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-policy-a"},
+				Spec:       policyapi.CertificateRequestPolicySpec{Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{}}},
+			}},
+			expResponse: manager.ReviewResponse{Result: manager.ResultDenied, Message: "No policy approved this request: [test-policy-a: this is a denied response]", Policies: []string{"test-policy-a"}, Denials: []manager.Denial{{Policy: "test-policy-a", Errors: []string{"this is a denied response"}}}},
 			expErr:      false,
 		},
 		"if two policies returned and evaluator returns one not-denied, return ResultApproved": {
@@ -175,7 +235,7 @@ func Test_Review(t *testing.T) {
 					Spec:       policyapi.CertificateRequestPolicySpec{Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{}}},
 				},
 			},
-			expResponse: manager.ReviewResponse{Result: manager.ResultApproved, Message: `Approved by CertificateRequestPolicy: "test-policy-b"`},
+			expResponse: manager.ReviewResponse{Result: manager.ResultApproved, Message: `Approved by CertificateRequestPolicy: "test-policy-b"`, Policies: []string{"test-policy-b"}},
 			expErr:      false,
 		},
 		"if two policies returned and both return denied, return ResultDenied": {
@@ -208,7 +268,7 @@ func Test_Review(t *testing.T) {
 					Spec:       policyapi.CertificateRequestPolicySpec{Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{}}},
 				},
 			},
-			expResponse: manager.ReviewResponse{Result: manager.ResultDenied, Message: "No policy approved this request: [test-policy-a: this is a denied response] [test-policy-b: this is a denied response]"},
+			expResponse: manager.ReviewResponse{Result: manager.ResultDenied, Message: "No policy approved this request: [test-policy-a: this is a denied response] [test-policy-b: this is a denied response]", Policies: []string{"test-policy-a", "test-policy-b"}, Denials: []manager.Denial{{Policy: "test-policy-a", Errors: []string{"this is a denied response"}}, {Policy: "test-policy-b", Errors: []string{"this is a denied response"}}}},
 			expErr:      false,
 		},
 	}
@@ -233,7 +293,7 @@ func Test_Review(t *testing.T) {
 
 			mngr := &mngr{
 				lister:     env.AdminClient,
-				predicates: []predicate.Predicate{test.predicate(t)},
+				predicates: []namedPredicate{{"test", test.predicate(t)}},
 				evaluators: []approver.Evaluator{test.evaluator(t)},
 			}
 
@@ -254,3 +314,1021 @@ func Test_Review(t *testing.T) {
 		})
 	}
 }
+
+func Test_NewRequireExplicitSelectors(t *testing.T) {
+	predicateNames := func(opts Options) []string {
+		var names []string
+		for _, predicate := range New(nil, nil, nil, opts).(*mngr).predicates {
+			names = append(names, predicate.name)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"Ready", "SelectorIssuerRef", "SelectorNamespace", "SelectorCertificateRequest", "SelectorCertificate", "RBACBound", "Fallback"},
+		predicateNames(Options{}))
+	assert.Equal(t, []string{"Ready", "SelectorIssuerRef", "ExplicitNamespaceSelector", "SelectorNamespace", "SelectorCertificateRequest", "SelectorCertificate", "RBACBound", "Fallback"},
+		predicateNames(Options{RequireExplicitSelectors: true}))
+}
+
+func Test_ReviewBaseline(t *testing.T) {
+	readyPolicy := func(name string) *policyapi.CertificateRequestPolicy {
+		return &policyapi.CertificateRequestPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: policyapi.CertificateRequestPolicyStatus{
+				Conditions: []policyapi.CertificateRequestPolicyCondition{
+					{Type: policyapi.CertificateRequestPolicyConditionReady, Status: corev1.ConditionTrue},
+				},
+			},
+		}
+	}
+
+	// denyBaseline denies the baseline policy and approves any other policy.
+	denyBaseline := fake.NewFakeEvaluator().WithEvaluate(func(_ context.Context, policy *policyapi.CertificateRequestPolicy, _ *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+		if policy.Name == "baseline" {
+			return approver.EvaluationResponse{Result: approver.ResultDenied, Message: "SHA-1 is forbidden"}, nil
+		}
+		return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
+	})
+
+	tests := map[string]struct {
+		baseline    string
+		evaluator   approver.Evaluator
+		objects     []runtime.Object
+		expResponse manager.ReviewResponse
+		expErr      bool
+	}{
+		"if no baseline is configured, approve with the normal policy": {
+			baseline:    "",
+			evaluator:   denyBaseline,
+			objects:     []runtime.Object{readyPolicy("baseline"), readyPolicy("normal")},
+			expResponse: manager.ReviewResponse{Result: manager.ResultApproved, Message: `Approved by CertificateRequestPolicy: "normal"`, Policies: []string{"normal"}},
+		},
+		"if the baseline denies even though a normal policy approves, return ResultDenied": {
+			baseline:  "baseline",
+			evaluator: denyBaseline,
+			objects:   []runtime.Object{readyPolicy("baseline"), readyPolicy("normal")},
+			expResponse: manager.ReviewResponse{
+				Result:   manager.ResultDenied,
+				Message:  "Denied by baseline CertificateRequestPolicy: [baseline: SHA-1 is forbidden]",
+				Policies: []string{"baseline"},
+				Denials:  []manager.Denial{{Policy: "baseline", Errors: []string{"SHA-1 is forbidden"}}},
+			},
+		},
+		"if the baseline and a normal policy approve, return ResultApproved": {
+			baseline:    "baseline",
+			evaluator:   fake.NewFakeEvaluator().WithResponse(approver.EvaluationResponse{Result: approver.ResultNotDenied}),
+			objects:     []runtime.Object{readyPolicy("baseline"), readyPolicy("normal")},
+			expResponse: manager.ReviewResponse{Result: manager.ResultApproved, Message: `Approved by CertificateRequestPolicy: "normal"`, Policies: []string{"normal"}},
+		},
+		"if only the baseline policy exists, return ResultUnprocessed as the baseline never approves": {
+			baseline:    "baseline",
+			evaluator:   fake.NewFakeEvaluator().WithResponse(approver.EvaluationResponse{Result: approver.ResultNotDenied}),
+			objects:     []runtime.Object{readyPolicy("baseline")},
+			expResponse: manager.ReviewResponse{Result: manager.ResultUnprocessed, Message: "No CertificateRequestPolicies bound or applicable"},
+		},
+		"if the baseline policy doesn't exist, return an error": {
+			baseline:    "baseline",
+			evaluator:   fake.NewFakeEvaluator().WithResponse(approver.EvaluationResponse{Result: approver.ResultNotDenied}),
+			objects:     []runtime.Object{readyPolicy("normal")},
+			expResponse: manager.ReviewResponse{},
+			expErr:      true,
+		},
+		"if the baseline policy is not ready, return an error": {
+			baseline:    "baseline",
+			evaluator:   fake.NewFakeEvaluator().WithResponse(approver.EvaluationResponse{Result: approver.ResultNotDenied}),
+			objects:     []runtime.Object{&policyapi.CertificateRequestPolicy{ObjectMeta: metav1.ObjectMeta{Name: "baseline"}}, readyPolicy("normal")},
+			expResponse: manager.ReviewResponse{},
+			expErr:      true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fakeclient := fakeclient.NewClientBuilder().
+				WithScheme(policyapi.GlobalScheme).
+				WithRuntimeObjects(test.objects...).
+				Build()
+
+			mngr := &mngr{
+				lister:     fakeclient,
+				predicates: []namedPredicate{{"Ready", predicate.Ready}},
+				evaluators: []approver.Evaluator{test.evaluator},
+				baseline:   test.baseline,
+			}
+
+			response, err := mngr.Review(context.TODO(), &cmapi.CertificateRequest{ObjectMeta: metav1.ObjectMeta{Name: "test-req"}})
+			assert.Equalf(t, test.expErr, err != nil, "%v", err)
+			assert.Equal(t, test.expResponse, response)
+		})
+	}
+}
+
+func Test_ReviewFallback(t *testing.T) {
+	readyPolicy := func(name string, fallback bool, team string) *policyapi.CertificateRequestPolicy {
+		return &policyapi.CertificateRequestPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: policyapi.CertificateRequestPolicySpec{
+				Selector: policyapi.CertificateRequestPolicySelector{
+					CertificateRequest: &policyapi.CertificateRequestPolicySelectorCertificateRequest{MatchLabels: map[string]string{"team": team}},
+				},
+				Fallback: ptr.To(fallback),
+			},
+			Status: policyapi.CertificateRequestPolicyStatus{
+				Conditions: []policyapi.CertificateRequestPolicyCondition{
+					{Type: policyapi.CertificateRequestPolicyConditionReady, Status: corev1.ConditionTrue},
+				},
+			},
+		}
+	}
+
+	// denyPrimary denies the primary policy and approves any other policy.
+	denyPrimary := fake.NewFakeEvaluator().WithEvaluate(func(_ context.Context, policy *policyapi.CertificateRequestPolicy, _ *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+		if policy.Name == "primary" {
+			return approver.EvaluationResponse{Result: approver.ResultDenied, Message: "denied by primary"}, nil
+		}
+		return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
+	})
+
+	tests := map[string]struct {
+		team        string
+		expResponse manager.ReviewResponse
+	}{
+		"if a primary policy is selected, the fallback policy is not evaluated, even though the primary denies": {
+			team: "a",
+			expResponse: manager.ReviewResponse{
+				Result:   manager.ResultDenied,
+				Message:  "No policy approved this request: [primary: denied by primary]",
+				Policies: []string{"primary"},
+				Denials:  []manager.Denial{{Policy: "primary", Errors: []string{"denied by primary"}}},
+			},
+		},
+		"if no primary policy is selected, the fallback policy is evaluated": {
+			team:        "b",
+			expResponse: manager.ReviewResponse{Result: manager.ResultApproved, Message: `Approved by CertificateRequestPolicy: "other-fallback"`, Policies: []string{"other-fallback"}},
+		},
+		"if neither a primary nor fallback policy is selected, return ResultUnprocessed": {
+			team:        "c",
+			expResponse: manager.ReviewResponse{Result: manager.ResultUnprocessed, Message: manager.MessageNoApplicablePolicies},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fakeclient := fakeclient.NewClientBuilder().
+				WithScheme(policyapi.GlobalScheme).
+				WithRuntimeObjects(readyPolicy("primary", false, "a"), readyPolicy("fallback", true, "a"), readyPolicy("other-fallback", true, "b")).
+				Build()
+
+			mngr := &mngr{
+				lister: fakeclient,
+				predicates: []namedPredicate{
+					{"Ready", predicate.Ready},
+					{"SelectorCertificateRequest", predicate.SelectorCertificateRequest},
+					{"Fallback", predicate.Fallback},
+				},
+				evaluators: []approver.Evaluator{denyPrimary},
+			}
+
+			response, err := mngr.Review(context.TODO(), &cmapi.CertificateRequest{ObjectMeta: metav1.ObjectMeta{Name: "test-req", Labels: map[string]string{"team": test.team}}})
+			assert.NoError(t, err)
+			assert.Equal(t, test.expResponse, response)
+		})
+	}
+}
+
+func Test_ReviewMaxPolicies(t *testing.T) {
+	readyPolicy := func(name string) *policyapi.CertificateRequestPolicy {
+		return &policyapi.CertificateRequestPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: policyapi.CertificateRequestPolicyStatus{
+				Conditions: []policyapi.CertificateRequestPolicyCondition{
+					{Type: policyapi.CertificateRequestPolicyConditionReady, Status: corev1.ConditionTrue},
+				},
+			},
+		}
+	}
+
+	// Only policy-d approves, so is never reached when capped below 4.
+	approveD := func(evaluated *[]string) approver.Evaluator {
+		return fake.NewFakeEvaluator().WithEvaluate(func(_ context.Context, policy *policyapi.CertificateRequestPolicy, _ *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+			*evaluated = append(*evaluated, policy.Name)
+			if policy.Name == "policy-d" {
+				return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
+			}
+			return approver.EvaluationResponse{Result: approver.ResultDenied, Message: "denied"}, nil
+		})
+	}
+
+	policies := []runtime.Object{readyPolicy("policy-c"), readyPolicy("policy-a"), readyPolicy("policy-d"), readyPolicy("policy-b")}
+
+	tests := map[string]struct {
+		maxPolicies  int
+		expEvaluated []string
+		expResponse  manager.ReviewResponse
+	}{
+		"if no maximum is configured, evaluate all policies": {
+			maxPolicies:  0,
+			expEvaluated: []string{"policy-a", "policy-b", "policy-c", "policy-d"},
+			expResponse:  manager.ReviewResponse{Result: manager.ResultApproved, Message: `Approved by CertificateRequestPolicy: "policy-d"`, Policies: []string{"policy-d"}},
+		},
+		"if the number of policies equals the maximum, evaluate all policies": {
+			maxPolicies:  4,
+			expEvaluated: []string{"policy-a", "policy-b", "policy-c", "policy-d"},
+			expResponse:  manager.ReviewResponse{Result: manager.ResultApproved, Message: `Approved by CertificateRequestPolicy: "policy-d"`, Policies: []string{"policy-d"}},
+		},
+		"if more policies than the maximum are selected, only evaluate the first sorted by name and deny with a clear message": {
+			maxPolicies:  2,
+			expEvaluated: []string{"policy-a", "policy-b"},
+			expResponse: manager.ReviewResponse{
+				Result:   manager.ResultDenied,
+				Message:  "No policy approved this request: [policy-a: denied] [policy-b: denied] (only the first 2 of 4 applicable CertificateRequestPolicies were evaluated, exceeding the maximum per request)",
+				Policies: []string{"policy-a", "policy-b"},
+				Denials:  []manager.Denial{{Policy: "policy-a", Errors: []string{"denied"}}, {Policy: "policy-b", Errors: []string{"denied"}}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fakeclient := fakeclient.NewClientBuilder().
+				WithScheme(policyapi.GlobalScheme).
+				WithRuntimeObjects(policies...).
+				Build()
+
+			var evaluated []string
+			mngr := &mngr{
+				lister:      fakeclient,
+				predicates:  []namedPredicate{{"Ready", predicate.Ready}},
+				evaluators:  []approver.Evaluator{approveD(&evaluated)},
+				maxPolicies: test.maxPolicies,
+			}
+
+			response, err := mngr.Review(context.TODO(), &cmapi.CertificateRequest{ObjectMeta: metav1.ObjectMeta{Name: "test-req"}})
+			assert.NoError(t, err)
+			assert.Equal(t, test.expResponse, response)
+			assert.Equal(t, test.expEvaluated, evaluated)
+		})
+	}
+}
+
+func Test_ReviewDenyOnNoApplicablePolicy(t *testing.T) {
+	notReadyPolicy := &policyapi.CertificateRequestPolicy{ObjectMeta: metav1.ObjectMeta{Name: "not-ready"}}
+
+	tests := map[string]struct {
+		policies                 []runtime.Object
+		denyOnNoApplicablePolicy bool
+		expResponse              manager.ReviewResponse
+	}{
+		"if no policies exist, return unprocessed when disabled": {
+			policies:                 nil,
+			denyOnNoApplicablePolicy: false,
+			expResponse:              manager.ReviewResponse{Result: manager.ResultUnprocessed, Message: manager.MessageNoPolicies},
+		},
+		"if no policies exist, return unprocessed when enabled": {
+			policies:                 nil,
+			denyOnNoApplicablePolicy: true,
+			expResponse:              manager.ReviewResponse{Result: manager.ResultUnprocessed, Message: manager.MessageNoPolicies},
+		},
+		"if no policy is applicable, return unprocessed when disabled": {
+			policies:                 []runtime.Object{notReadyPolicy},
+			denyOnNoApplicablePolicy: false,
+			expResponse:              manager.ReviewResponse{Result: manager.ResultUnprocessed, Message: manager.MessageNoApplicablePolicies},
+		},
+		"if no policy is applicable, return denied when enabled": {
+			policies:                 []runtime.Object{notReadyPolicy},
+			denyOnNoApplicablePolicy: true,
+			expResponse:              manager.ReviewResponse{Result: manager.ResultDenied, Message: manager.MessageNoApplicablePoliciesDenied},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fakeclient := fakeclient.NewClientBuilder().
+				WithScheme(policyapi.GlobalScheme).
+				WithRuntimeObjects(test.policies...).
+				Build()
+
+			mngr := &mngr{
+				lister:     fakeclient,
+				predicates: []namedPredicate{{"Ready", predicate.Ready}},
+				evaluators: []approver.Evaluator{fake.NewFakeEvaluator()},
+
+				denyOnNoApplicablePolicy: test.denyOnNoApplicablePolicy,
+			}
+
+			response, err := mngr.Review(context.TODO(), &cmapi.CertificateRequest{ObjectMeta: metav1.ObjectMeta{Name: "test-req"}})
+			assert.NoError(t, err)
+			assert.Equal(t, test.expResponse, response)
+		})
+	}
+}
+
+func Test_ReviewPending(t *testing.T) {
+	readyPolicy := func(name string) runtime.Object {
+		return &policyapi.CertificateRequestPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: policyapi.CertificateRequestPolicyStatus{
+				Conditions: []policyapi.CertificateRequestPolicyCondition{
+					{Type: policyapi.CertificateRequestPolicyConditionReady, Status: corev1.ConditionTrue},
+				},
+			},
+		}
+	}
+
+	var (
+		approve = approver.EvaluationResponse{Result: approver.ResultNotDenied}
+		deny    = approver.EvaluationResponse{Result: approver.ResultDenied, Message: "denied"}
+		pending = approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: "awaiting approval", Pending: true}
+	)
+
+	// evaluator returns the response of the given evaluator for each policy.
+	evaluator := func(responses map[string]approver.EvaluationResponse) approver.Evaluator {
+		return fake.NewFakeEvaluator().WithEvaluate(func(_ context.Context, policy *policyapi.CertificateRequestPolicy, _ *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+			return responses[policy.Name], nil
+		})
+	}
+
+	tests := map[string]struct {
+		policies    []runtime.Object
+		baseline    string
+		evaluators  []approver.Evaluator
+		expResponse manager.ReviewResponse
+	}{
+		"if the only policy is pending, return pending": {
+			policies:   []runtime.Object{readyPolicy("policy-a")},
+			evaluators: []approver.Evaluator{evaluator(map[string]approver.EvaluationResponse{"policy-a": pending})},
+			expResponse: manager.ReviewResponse{
+				Result:   manager.ResultPending,
+				Message:  "Awaiting external approval: [policy-a: awaiting approval]",
+				Policies: []string{"policy-a"},
+			},
+		},
+		"if one policy is pending and another approves, return approved": {
+			policies:   []runtime.Object{readyPolicy("policy-a"), readyPolicy("policy-b")},
+			evaluators: []approver.Evaluator{evaluator(map[string]approver.EvaluationResponse{"policy-a": pending, "policy-b": approve})},
+			expResponse: manager.ReviewResponse{
+				Result:   manager.ResultApproved,
+				Message:  `Approved by CertificateRequestPolicy: "policy-b"`,
+				Policies: []string{"policy-b"},
+			},
+		},
+		"if one policy is pending and another denies, return pending for only the pending policy": {
+			policies:   []runtime.Object{readyPolicy("policy-a"), readyPolicy("policy-b")},
+			evaluators: []approver.Evaluator{evaluator(map[string]approver.EvaluationResponse{"policy-a": deny, "policy-b": pending})},
+			expResponse: manager.ReviewResponse{
+				Result:   manager.ResultPending,
+				Message:  "Awaiting external approval: [policy-b: awaiting approval]",
+				Policies: []string{"policy-b"},
+			},
+		},
+		"if a policy has a pending and a denying evaluator, return denied": {
+			policies: []runtime.Object{readyPolicy("policy-a")},
+			evaluators: []approver.Evaluator{
+				evaluator(map[string]approver.EvaluationResponse{"policy-a": pending}),
+				evaluator(map[string]approver.EvaluationResponse{"policy-a": deny}),
+			},
+			expResponse: manager.ReviewResponse{
+				Result:   manager.ResultDenied,
+				Message:  "No policy approved this request: [policy-a: awaiting approval, denied]",
+				Policies: []string{"policy-a"},
+				Denials:  []manager.Denial{{Policy: "policy-a", Errors: []string{"awaiting approval", "denied"}}},
+			},
+		},
+		"if the baseline policy is pending, return pending": {
+			policies:   []runtime.Object{readyPolicy("policy-a"), readyPolicy("baseline")},
+			baseline:   "baseline",
+			evaluators: []approver.Evaluator{evaluator(map[string]approver.EvaluationResponse{"policy-a": approve, "baseline": pending})},
+			expResponse: manager.ReviewResponse{
+				Result:   manager.ResultPending,
+				Message:  "Awaiting external approval by baseline CertificateRequestPolicy: [baseline: awaiting approval]",
+				Policies: []string{"baseline"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fakeclient := fakeclient.NewClientBuilder().
+				WithScheme(policyapi.GlobalScheme).
+				WithRuntimeObjects(test.policies...).
+				Build()
+
+			mngr := &mngr{
+				lister:     fakeclient,
+				predicates: []namedPredicate{{"Ready", predicate.Ready}},
+				evaluators: test.evaluators,
+				baseline:   test.baseline,
+			}
+
+			response, err := mngr.Review(context.TODO(), &cmapi.CertificateRequest{ObjectMeta: metav1.ObjectMeta{Name: "test-req"}})
+			assert.NoError(t, err)
+			assert.Equal(t, test.expResponse, response)
+		})
+	}
+}
+
+func Test_ReviewEvaluationStages(t *testing.T) {
+	policy := &policyapi.CertificateRequestPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy-a"},
+		Status: policyapi.CertificateRequestPolicyStatus{
+			Conditions: []policyapi.CertificateRequestPolicyCondition{
+				{Type: policyapi.CertificateRequestPolicyConditionReady, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+
+	var evaluated []string
+	evaluator := func(name string, stage approver.EvaluationStage, result approver.EvaluationResult) approver.Evaluator {
+		return fake.NewFakeEvaluator().WithEvaluationStage(stage).WithEvaluate(func(context.Context, *policyapi.CertificateRequestPolicy, *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+			evaluated = append(evaluated, name)
+			if result == approver.ResultDenied {
+				return approver.EvaluationResponse{Result: approver.ResultDenied, Message: name + " denied"}, nil
+			}
+			return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
+		})
+	}
+
+	tests := map[string]struct {
+		evaluators   []approver.Evaluator
+		expEvaluated []string
+		expResult    manager.ReviewResult
+		expMessage   string
+	}{
+		"if no evaluator denies, run evaluators of every stage": {
+			evaluators: []approver.Evaluator{
+				evaluator("allowed", approver.EvaluationStageBuiltin, approver.ResultNotDenied),
+				evaluator("cel", approver.EvaluationStageCEL, approver.ResultNotDenied),
+				evaluator("plugin", approver.EvaluationStagePlugin, approver.ResultNotDenied),
+			},
+			expEvaluated: []string{"allowed", "cel", "plugin"},
+			expResult:    manager.ResultApproved,
+			expMessage:   `Approved by CertificateRequestPolicy: "policy-a"`,
+		},
+		"if a builtin evaluator denies, skip CEL and plugin evaluators but run other builtin evaluators": {
+			evaluators: []approver.Evaluator{
+				evaluator("allowed", approver.EvaluationStageBuiltin, approver.ResultDenied),
+				evaluator("constraints", approver.EvaluationStageBuiltin, approver.ResultDenied),
+				evaluator("cel", approver.EvaluationStageCEL, approver.ResultNotDenied),
+				evaluator("plugin", approver.EvaluationStagePlugin, approver.ResultNotDenied),
+			},
+			expEvaluated: []string{"allowed", "constraints"},
+			expResult:    manager.ResultDenied,
+			expMessage:   "No policy approved this request: [policy-a: allowed denied, constraints denied]",
+		},
+		"if a CEL evaluator denies, skip plugin evaluators": {
+			evaluators: []approver.Evaluator{
+				evaluator("allowed", approver.EvaluationStageBuiltin, approver.ResultNotDenied),
+				evaluator("cel", approver.EvaluationStageCEL, approver.ResultDenied),
+				evaluator("plugin", approver.EvaluationStagePlugin, approver.ResultNotDenied),
+			},
+			expEvaluated: []string{"allowed", "cel"},
+			expResult:    manager.ResultDenied,
+			expMessage:   "No policy approved this request: [policy-a: cel denied]",
+		},
+		"if a plugin evaluator denies, run every other plugin evaluator": {
+			evaluators: []approver.Evaluator{
+				evaluator("plugin-a", approver.EvaluationStagePlugin, approver.ResultDenied),
+				evaluator("plugin-b", approver.EvaluationStagePlugin, approver.ResultNotDenied),
+			},
+			expEvaluated: []string{"plugin-a", "plugin-b"},
+			expResult:    manager.ResultDenied,
+			expMessage:   "No policy approved this request: [policy-a: plugin-a denied]",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			evaluated = nil
+
+			fakeclient := fakeclient.NewClientBuilder().
+				WithScheme(policyapi.GlobalScheme).
+				WithRuntimeObjects(policy).
+				Build()
+
+			mngr := &mngr{
+				lister:     fakeclient,
+				predicates: []namedPredicate{{"Ready", predicate.Ready}},
+				evaluators: test.evaluators,
+			}
+
+			response, err := mngr.Review(context.TODO(), &cmapi.CertificateRequest{ObjectMeta: metav1.ObjectMeta{Name: "test-req"}})
+			assert.NoError(t, err)
+			assert.Equal(t, test.expResult, response.Result)
+			assert.Equal(t, test.expMessage, response.Message)
+			assert.Equal(t, test.expEvaluated, evaluated)
+		})
+	}
+}
+
+func Test_ReviewConcurrency(t *testing.T) {
+	readyPolicy := func(name string, enforcement policyapi.CertificateRequestPolicyEnforcement) runtime.Object {
+		return &policyapi.CertificateRequestPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       policyapi.CertificateRequestPolicySpec{Enforcement: ptr.To(enforcement)},
+			Status: policyapi.CertificateRequestPolicyStatus{
+				Conditions: []policyapi.CertificateRequestPolicyCondition{
+					{Type: policyapi.CertificateRequestPolicyConditionReady, Status: corev1.ConditionTrue},
+				},
+			},
+		}
+	}
+
+	// evaluator denies every policy not in approve, sleeping to force
+	// evaluations of different policies to overlap, and records the maximum
+	// number of concurrent evaluations.
+	var inflight, maxInflight atomic.Int32
+	evaluator := func(approve ...string) approver.Evaluator {
+		return fake.NewFakeEvaluator().WithEvaluate(func(_ context.Context, policy *policyapi.CertificateRequestPolicy, _ *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+			n := inflight.Add(1)
+			defer inflight.Add(-1)
+			for {
+				if m := maxInflight.Load(); n <= m || maxInflight.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+
+			for _, name := range approve {
+				if policy.Name == name {
+					return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
+				}
+			}
+			return approver.EvaluationResponse{Result: approver.ResultDenied, Message: policy.Name + " denied"}, nil
+		})
+	}
+
+	var permissive, strict []runtime.Object
+	for i := range 20 {
+		permissive = append(permissive, readyPolicy(fmt.Sprintf("policy-%02d", i), policyapi.CertificateRequestPolicyEnforcementPermissive))
+		strict = append(strict, readyPolicy(fmt.Sprintf("strict-%02d", i), policyapi.CertificateRequestPolicyEnforcementStrict))
+	}
+
+	tests := map[string]struct {
+		policies  []runtime.Object
+		evaluator approver.Evaluator
+	}{
+		"if every permissive policy denies, return denied with every policy": {
+			policies:  permissive,
+			evaluator: evaluator(),
+		},
+		"if some permissive policies approve, return approved by the first": {
+			policies:  permissive,
+			evaluator: evaluator("policy-17", "policy-09", "policy-12"),
+		},
+		"if some strict policies deny, return denied with the denying policies": {
+			policies:  append(slices.Clone(permissive), strict...),
+			evaluator: evaluator("strict-00", "strict-03", "strict-04", "strict-11"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fakeclient := fakeclient.NewClientBuilder().
+				WithScheme(policyapi.GlobalScheme).
+				WithRuntimeObjects(test.policies...).
+				Build()
+
+			review := func(concurrency int) (manager.ReviewResponse, *manager.ReviewTrace) {
+				mngr := &mngr{
+					lister:      fakeclient,
+					predicates:  []namedPredicate{{"Ready", predicate.Ready}},
+					evaluators:  []approver.Evaluator{test.evaluator},
+					concurrency: concurrency,
+				}
+
+				trace := new(manager.ReviewTrace)
+				response, err := mngr.Review(manager.WithReviewTrace(context.TODO(), trace), &cmapi.CertificateRequest{ObjectMeta: metav1.ObjectMeta{Name: "test-req"}})
+				assert.NoError(t, err)
+				return response, trace
+			}
+
+			// Concurrent reviews evaluate every policy, so the trace may
+			// differ from a sequential review but must be ordered by policy.
+			expResponse, _ := review(1)
+			_, expTrace := review(4)
+			assert.True(t, slices.IsSorted(evaluationPolicies(expTrace)))
+
+			for range 10 {
+				maxInflight.Store(0)
+				response, trace := review(4)
+				assert.Equal(t, expResponse, response)
+				assert.Equal(t, evaluationPolicies(expTrace), evaluationPolicies(trace))
+				assert.LessOrEqual(t, maxInflight.Load(), int32(4))
+			}
+		})
+	}
+}
+
+// evaluationPolicies returns the policy names of the evaluations recorded in
+// the given trace, in order.
+func evaluationPolicies(trace *manager.ReviewTrace) []string {
+	var names []string
+	for _, evaluation := range trace.Evaluations {
+		names = append(names, evaluation.Policy)
+	}
+	return names
+}
+
+func Test_ReviewEvaluatorError(t *testing.T) {
+	policy := &policyapi.CertificateRequestPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy-a"},
+		Status: policyapi.CertificateRequestPolicyStatus{
+			Conditions: []policyapi.CertificateRequestPolicyCondition{
+				{Type: policyapi.CertificateRequestPolicyConditionReady, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+
+	evaluator := func(response approver.EvaluationResponse, err error) approver.Evaluator {
+		return fake.NewFakeEvaluator().WithEvaluate(func(context.Context, *policyapi.CertificateRequestPolicy, *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+			return response, err
+		})
+	}
+
+	var (
+		deny     = evaluator(approver.EvaluationResponse{Result: approver.ResultDenied, Message: "could not parse CSR: bad"}, nil)
+		notDeny  = evaluator(approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil)
+		erroring = evaluator(approver.EvaluationResponse{}, errors.New("failed to decode CSR"))
+	)
+
+	tests := map[string]struct {
+		evaluators  []approver.Evaluator
+		expResponse manager.ReviewResponse
+		expErr      bool
+	}{
+		"if an evaluator errors before the policy is denied, return error": {
+			evaluators:  []approver.Evaluator{notDeny, erroring, deny},
+			expResponse: manager.ReviewResponse{},
+			expErr:      true,
+		},
+		"if an evaluator errors after the policy is denied, return denied": {
+			evaluators: []approver.Evaluator{deny, erroring},
+			expResponse: manager.ReviewResponse{
+				Result:   manager.ResultDenied,
+				Message:  "No policy approved this request: [policy-a: could not parse CSR: bad]",
+				Policies: []string{"policy-a"},
+				Denials:  []manager.Denial{{Policy: "policy-a", Errors: []string{"could not parse CSR: bad"}}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fakeclient := fakeclient.NewClientBuilder().
+				WithScheme(policyapi.GlobalScheme).
+				WithRuntimeObjects(policy.DeepCopy()).
+				Build()
+
+			mngr := &mngr{
+				lister:     fakeclient,
+				predicates: []namedPredicate{{"Ready", predicate.Ready}},
+				evaluators: test.evaluators,
+			}
+
+			response, err := mngr.Review(context.TODO(), &cmapi.CertificateRequest{ObjectMeta: metav1.ObjectMeta{Name: "test-req"}})
+			assert.Equal(t, test.expErr, err != nil, "%v", err)
+			assert.Equal(t, test.expResponse, response)
+		})
+	}
+}
+
+func Test_ReviewReasons(t *testing.T) {
+	policy := &policyapi.CertificateRequestPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy-a"},
+		Status: policyapi.CertificateRequestPolicyStatus{
+			Conditions: []policyapi.CertificateRequestPolicyCondition{
+				{Type: policyapi.CertificateRequestPolicyConditionReady, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+
+	evaluator := func(response approver.EvaluationResponse) approver.Evaluator {
+		return fake.NewFakeEvaluator().WithEvaluate(func(context.Context, *policyapi.CertificateRequestPolicy, *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+			return response, nil
+		})
+	}
+
+	var (
+		dnsNameReason = approver.DenialReason{Reason: approver.ReasonDNSNameNotAllowed, Field: "spec.allowed.dnsNames.values"}
+		keyReason     = approver.DenialReason{Reason: approver.ReasonKeyTooSmall, Field: "spec.constraints.privateKey.minSize"}
+
+		denyDNSName = evaluator(approver.EvaluationResponse{Result: approver.ResultDenied, Message: "dns", Reasons: []approver.DenialReason{dnsNameReason}})
+		denyKey     = evaluator(approver.EvaluationResponse{Result: approver.ResultDenied, Message: "key", Reasons: []approver.DenialReason{keyReason}})
+		notDeny     = evaluator(approver.EvaluationResponse{Result: approver.ResultNotDenied, Reasons: []approver.DenialReason{{Reason: approver.ReasonDenied}}})
+	)
+
+	tests := map[string]struct {
+		evaluators  []approver.Evaluator
+		expResponse manager.ReviewResponse
+	}{
+		"if evaluators deny the request, return denials with the reasons of each evaluator": {
+			evaluators: []approver.Evaluator{denyDNSName, notDeny, denyKey},
+			expResponse: manager.ReviewResponse{
+				Result:   manager.ResultDenied,
+				Message:  "No policy approved this request: [policy-a: dns, key]",
+				Policies: []string{"policy-a"},
+				Denials:  []manager.Denial{{Policy: "policy-a", Errors: []string{"dns", "key"}, Reasons: []approver.DenialReason{dnsNameReason, keyReason}}},
+			},
+		},
+		"if no evaluator denies the request, return approved ignoring reasons": {
+			evaluators: []approver.Evaluator{notDeny},
+			expResponse: manager.ReviewResponse{
+				Result:   manager.ResultApproved,
+				Message:  `Approved by CertificateRequestPolicy: "policy-a"`,
+				Policies: []string{"policy-a"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fakeclient := fakeclient.NewClientBuilder().
+				WithScheme(policyapi.GlobalScheme).
+				WithRuntimeObjects(policy.DeepCopy()).
+				Build()
+
+			mngr := &mngr{
+				lister:     fakeclient,
+				predicates: []namedPredicate{{"Ready", predicate.Ready}},
+				evaluators: test.evaluators,
+			}
+
+			response, err := mngr.Review(context.TODO(), &cmapi.CertificateRequest{ObjectMeta: metav1.ObjectMeta{Name: "test-req"}})
+			assert.NoError(t, err)
+			assert.Equal(t, test.expResponse, response)
+		})
+	}
+}
+
+func Test_ReviewEnforcement(t *testing.T) {
+	policy := func(name string, enforcement *policyapi.CertificateRequestPolicyEnforcement) runtime.Object {
+		return &policyapi.CertificateRequestPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       policyapi.CertificateRequestPolicySpec{Enforcement: enforcement},
+			Status: policyapi.CertificateRequestPolicyStatus{
+				Conditions: []policyapi.CertificateRequestPolicyCondition{
+					{Type: policyapi.CertificateRequestPolicyConditionReady, Status: corev1.ConditionTrue},
+				},
+			},
+		}
+	}
+	var (
+		strict     = ptr.To(policyapi.CertificateRequestPolicyEnforcementStrict)
+		permissive = ptr.To(policyapi.CertificateRequestPolicyEnforcementPermissive)
+
+		approve = approver.EvaluationResponse{Result: approver.ResultNotDenied}
+		deny    = approver.EvaluationResponse{Result: approver.ResultDenied, Message: "denied"}
+		pending = approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: "awaiting approval", Pending: true}
+	)
+
+	tests := map[string]struct {
+		policies    []runtime.Object
+		responses   map[string]approver.EvaluationResponse
+		expResponse manager.ReviewResponse
+	}{
+		"if a Strict policy denies and a Permissive policy approves, return denied": {
+			policies:  []runtime.Object{policy("policy-a", permissive), policy("policy-b", strict)},
+			responses: map[string]approver.EvaluationResponse{"policy-a": approve, "policy-b": deny},
+			expResponse: manager.ReviewResponse{
+				Result:   manager.ResultDenied,
+				Message:  "Denied by Strict CertificateRequestPolicy: [policy-b: denied]",
+				Policies: []string{"policy-b"},
+				Denials:  []manager.Denial{{Policy: "policy-b", Errors: []string{"denied"}}},
+			},
+		},
+		"if a Strict policy denies and an omitted enforcement policy approves, return denied": {
+			policies:  []runtime.Object{policy("policy-a", nil), policy("policy-b", strict)},
+			responses: map[string]approver.EvaluationResponse{"policy-a": approve, "policy-b": deny},
+			expResponse: manager.ReviewResponse{
+				Result:   manager.ResultDenied,
+				Message:  "Denied by Strict CertificateRequestPolicy: [policy-b: denied]",
+				Policies: []string{"policy-b"},
+				Denials:  []manager.Denial{{Policy: "policy-b", Errors: []string{"denied"}}},
+			},
+		},
+		"if a Strict policy denies and another Strict policy approves, return denied": {
+			policies:  []runtime.Object{policy("policy-a", strict), policy("policy-b", strict), policy("policy-c", strict)},
+			responses: map[string]approver.EvaluationResponse{"policy-a": approve, "policy-b": deny, "policy-c": deny},
+			expResponse: manager.ReviewResponse{
+				Result:   manager.ResultDenied,
+				Message:  "Denied by Strict CertificateRequestPolicy: [policy-b: denied] [policy-c: denied]",
+				Policies: []string{"policy-b", "policy-c"},
+				Denials:  []manager.Denial{{Policy: "policy-b", Errors: []string{"denied"}}, {Policy: "policy-c", Errors: []string{"denied"}}},
+			},
+		},
+		"if a Strict policy approves and a Permissive policy denies, return approved": {
+			policies:  []runtime.Object{policy("policy-a", permissive), policy("policy-b", strict)},
+			responses: map[string]approver.EvaluationResponse{"policy-a": deny, "policy-b": approve},
+			expResponse: manager.ReviewResponse{
+				Result:   manager.ResultApproved,
+				Message:  `Approved by CertificateRequestPolicy: "policy-b"`,
+				Policies: []string{"policy-b"},
+			},
+		},
+		"if a Strict policy is pending and a Permissive policy approves, return pending": {
+			policies:  []runtime.Object{policy("policy-a", permissive), policy("policy-b", strict)},
+			responses: map[string]approver.EvaluationResponse{"policy-a": approve, "policy-b": pending},
+			expResponse: manager.ReviewResponse{
+				Result:   manager.ResultPending,
+				Message:  "Awaiting external approval: [policy-b: awaiting approval]",
+				Policies: []string{"policy-b"},
+			},
+		},
+		"if only Permissive policies are selected, any approving policy should approve": {
+			policies:  []runtime.Object{policy("policy-a", permissive), policy("policy-b", nil)},
+			responses: map[string]approver.EvaluationResponse{"policy-a": deny, "policy-b": approve},
+			expResponse: manager.ReviewResponse{
+				Result:   manager.ResultApproved,
+				Message:  `Approved by CertificateRequestPolicy: "policy-b"`,
+				Policies: []string{"policy-b"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fakeclient := fakeclient.NewClientBuilder().
+				WithScheme(policyapi.GlobalScheme).
+				WithRuntimeObjects(test.policies...).
+				Build()
+
+			mngr := &mngr{
+				lister:     fakeclient,
+				predicates: []namedPredicate{{"Ready", predicate.Ready}},
+				evaluators: []approver.Evaluator{fake.NewFakeEvaluator().WithEvaluate(func(_ context.Context, policy *policyapi.CertificateRequestPolicy, _ *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+					return test.responses[policy.Name], nil
+				})},
+			}
+
+			response, err := mngr.Review(context.TODO(), &cmapi.CertificateRequest{ObjectMeta: metav1.ObjectMeta{Name: "test-req"}})
+			assert.NoError(t, err)
+			assert.Equal(t, test.expResponse, response)
+		})
+	}
+}
+
+func Test_ReviewProfile(t *testing.T) {
+	readyPolicy := func(name, profileName string) *policyapi.CertificateRequestPolicy {
+		policy := &policyapi.CertificateRequestPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{IsCA: ptr.To(false)},
+			},
+			Status: policyapi.CertificateRequestPolicyStatus{
+				Conditions: []policyapi.CertificateRequestPolicyCondition{
+					{Type: policyapi.CertificateRequestPolicyConditionReady, Status: corev1.ConditionTrue},
+				},
+			},
+		}
+		if len(profileName) > 0 {
+			policy.Spec.ProfileRef = &policyapi.CertificateRequestPolicyProfileReference{Name: profileName}
+		}
+		return policy
+	}
+
+	profile := &policyapi.CertificateRequestPolicyProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-profile"},
+		Spec: policyapi.CertificateRequestPolicyProfileSpec{
+			Allowed: &policyapi.CertificateRequestPolicyAllowed{
+				IsCA:     ptr.To(true),
+				DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*.example.com"}},
+			},
+		},
+	}
+
+	// requireProfile only approves policies which have the DNS names of the
+	// profile applied, and which keep their own isCA field.
+	requireProfile := fake.NewFakeEvaluator().WithEvaluate(func(_ context.Context, policy *policyapi.CertificateRequestPolicy, _ *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+		if allowed := policy.Spec.Allowed; allowed != nil && allowed.DNSNames != nil && !ptr.Deref(allowed.IsCA, true) {
+			return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
+		}
+		return approver.EvaluationResponse{Result: approver.ResultDenied, Message: "profile not applied"}, nil
+	})
+
+	tests := map[string]struct {
+		baseline    string
+		objects     []runtime.Object
+		expResponse manager.ReviewResponse
+		expErr      bool
+	}{
+		"if a policy doesn't reference a profile, only its own fields are evaluated": {
+			objects: []runtime.Object{readyPolicy("normal", ""), profile},
+			expResponse: manager.ReviewResponse{
+				Result:   manager.ResultDenied,
+				Message:  "No policy approved this request: [normal: profile not applied]",
+				Policies: []string{"normal"},
+				Denials:  []manager.Denial{{Policy: "normal", Errors: []string{"profile not applied"}}},
+			},
+		},
+		"if a policy references a profile, the policy is evaluated with the profile applied": {
+			objects:     []runtime.Object{readyPolicy("normal", "test-profile"), profile},
+			expResponse: manager.ReviewResponse{Result: manager.ResultApproved, Message: `Approved by CertificateRequestPolicy: "normal"`, Policies: []string{"normal"}},
+		},
+		"if the baseline policy references a profile, the baseline is evaluated with the profile applied": {
+			baseline:    "baseline",
+			objects:     []runtime.Object{readyPolicy("baseline", "test-profile"), readyPolicy("normal", "test-profile"), profile},
+			expResponse: manager.ReviewResponse{Result: manager.ResultApproved, Message: `Approved by CertificateRequestPolicy: "normal"`, Policies: []string{"normal"}},
+		},
+		"if a referenced profile doesn't exist, return an error": {
+			objects:     []runtime.Object{readyPolicy("normal", "test-profile")},
+			expResponse: manager.ReviewResponse{},
+			expErr:      true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fakeclient := fakeclient.NewClientBuilder().
+				WithScheme(policyapi.GlobalScheme).
+				WithRuntimeObjects(test.objects...).
+				Build()
+
+			mngr := &mngr{
+				lister:     fakeclient,
+				predicates: []namedPredicate{{"Ready", predicate.Ready}},
+				evaluators: []approver.Evaluator{requireProfile},
+				baseline:   test.baseline,
+			}
+
+			response, err := mngr.Review(context.TODO(), &cmapi.CertificateRequest{ObjectMeta: metav1.ObjectMeta{Name: "test-req"}})
+			assert.Equalf(t, test.expErr, err != nil, "%v", err)
+			assert.Equal(t, test.expResponse, response)
+		})
+	}
+}
+
+func Test_ReviewReservedAnnotations(t *testing.T) {
+	policy := &policyapi.CertificateRequestPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy-a"},
+		Status: policyapi.CertificateRequestPolicyStatus{
+			Conditions: []policyapi.CertificateRequestPolicyCondition{
+				{Type: policyapi.CertificateRequestPolicyConditionReady, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+
+	evaluator := fake.NewFakeEvaluator().WithEvaluate(func(context.Context, *policyapi.CertificateRequestPolicy, *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+		return approver.EvaluationResponse{Result: approver.ResultNotDenied, Annotations: map[string]string{
+			"example.com/ticket":                           "#123",
+			"cert-manager.io/issuer-name":                  "other-issuer",
+			"policy.cert-manager.io/denied-by":             "policy-a",
+			"acme.cert-manager.io/http01-override-ingress": "evil",
+			"not-cert-manager.io/foo":                      "bar",
+		}}, nil
+	})
+
+	fakeclient := fakeclient.NewClientBuilder().
+		WithScheme(policyapi.GlobalScheme).
+		WithRuntimeObjects(policy.DeepCopy()).
+		Build()
+
+	mngr := &mngr{
+		lister:     fakeclient,
+		predicates: []namedPredicate{{"Ready", predicate.Ready}},
+		evaluators: []approver.Evaluator{evaluator},
+	}
+
+	response, err := mngr.Review(context.TODO(), &cmapi.CertificateRequest{ObjectMeta: metav1.ObjectMeta{Name: "test-req"}})
+	assert.NoError(t, err)
+	assert.Equal(t, manager.ResultApproved, response.Result)
+	assert.Equal(t, map[string]string{
+		"example.com/ticket":      "#123",
+		"not-cert-manager.io/foo": "bar",
+	}, response.Annotations)
+}
+
+func Test_ReviewTraceBoundPolicies(t *testing.T) {
+	var policies []runtime.Object
+	for _, name := range []string{"policy-a", "policy-b", "policy-c"} {
+		policies = append(policies, &policyapi.CertificateRequestPolicy{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+
+	// without returns a predicate which filters out the given policy.
+	without := func(name string) predicate.Predicate {
+		return func(_ context.Context, _ *cmapi.CertificateRequest, policies []policyapi.CertificateRequestPolicy) ([]policyapi.CertificateRequestPolicy, error) {
+			return slices.DeleteFunc(slices.Clone(policies), func(policy policyapi.CertificateRequestPolicy) bool {
+				return policy.Name == name
+			}), nil
+		}
+	}
+
+	mngr := &mngr{
+		lister: fakeclient.NewClientBuilder().
+			WithScheme(policyapi.GlobalScheme).
+			WithRuntimeObjects(policies...).
+			Build(),
+		predicates: []namedPredicate{
+			{"SelectorNamespace", without("policy-c")},
+			{rbacBoundPredicate, without("policy-b")},
+		},
+		evaluators: []approver.Evaluator{fake.NewFakeEvaluator().WithEvaluate(func(context.Context, *policyapi.CertificateRequestPolicy, *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+			return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
+		})},
+	}
+
+	trace := new(manager.ReviewTrace)
+	_, err := mngr.Review(manager.WithReviewTrace(context.TODO(), trace), &cmapi.CertificateRequest{ObjectMeta: metav1.ObjectMeta{Name: "test-req"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"policy-a", "policy-b", "policy-c"}, trace.Policies)
+	assert.Equal(t, []string{"policy-a"}, trace.BoundPolicies)
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+var _ approver.Reconciler = &CircuitBreaker{}
+
+// CircuitBreaker tracks evaluators which repeatedly time out. Once an
+// evaluator has timed out threshold consecutive times, the breaker opens and
+// CertificateRequestPolicies which configure that evaluator as a plugin are
+// marked as not ready until the cooldown has passed.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	clock     clock.Clock
+
+	lock       sync.Mutex
+	evaluators map[string]*breakerState
+	enqueue    chan string
+}
+
+// breakerState is the circuit breaker state of a single evaluator.
+type breakerState struct {
+	// timeouts is the number of consecutive timeouts of the evaluator.
+	timeouts int
+
+	// openUntil is the time until which the breaker is open. Zero if the
+	// breaker is closed.
+	openUntil time.Time
+
+	// policies are the names of the policies which were being evaluated when
+	// the evaluator timed out.
+	policies []string
+}
+
+// NewCircuitBreaker returns a CircuitBreaker which opens for the cooldown
+// once an evaluator has timed out threshold consecutive times. A threshold of
+// zero or less never opens the breaker.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold:  threshold,
+		cooldown:   cooldown,
+		clock:      clock.RealClock{},
+		evaluators: make(map[string]*breakerState),
+		enqueue:    make(chan string),
+	}
+}
+
+// recordTimeout records that the named evaluator timed out evaluating the
+// given policy, opening the breaker if the threshold has been reached.
+func (b *CircuitBreaker) recordTimeout(evaluator, policy string) {
+	if b == nil || b.threshold <= 0 {
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	state, ok := b.evaluators[evaluator]
+	if !ok {
+		state = new(breakerState)
+		b.evaluators[evaluator] = state
+	}
+
+	state.timeouts++
+	if !slices.Contains(state.policies, policy) {
+		state.policies = append(state.policies, policy)
+	}
+
+	if state.timeouts < b.threshold {
+		return
+	}
+
+	state.openUntil = b.clock.Now().Add(b.cooldown)
+	state.timeouts = 0
+
+	// Re-sync the affected policies so they are marked as not ready. Sent
+	// asynchronously so that reviews are never blocked on the policy
+	// controller.
+	policies := state.policies
+	state.policies = nil
+	go func() {
+		for _, policy := range policies {
+			b.enqueue <- policy
+		}
+	}()
+}
+
+// recordSuccess records that the named evaluator completed within the
+// timeout, resetting its consecutive timeouts.
+func (b *CircuitBreaker) recordSuccess(evaluator string) {
+	if b == nil {
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if state, ok := b.evaluators[evaluator]; ok {
+		state.timeouts = 0
+		state.policies = nil
+	}
+}
+
+// Ready returns not ready if the policy configures a plugin whose breaker is
+// open, requeueing the policy once the cooldown has passed.
+func (b *CircuitBreaker) Ready(_ context.Context, policy *policyapi.CertificateRequestPolicy) (approver.ReconcilerReadyResponse, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	var (
+		el           field.ErrorList
+		requeueAfter time.Duration
+		now          = b.clock.Now()
+	)
+	for name := range policy.Spec.Plugins {
+		state, ok := b.evaluators[name]
+		if !ok || !now.Before(state.openUntil) {
+			continue
+		}
+
+		el = append(el, field.Forbidden(field.NewPath("spec", "plugins").Key(name),
+			fmt.Sprintf("plugin repeatedly timed out evaluating requests, circuit breaker is open until %s", state.openUntil.UTC().Format(time.RFC3339))))
+		if remaining := state.openUntil.Sub(now); remaining > requeueAfter {
+			requeueAfter = remaining
+		}
+	}
+
+	if len(el) > 0 {
+		// Sort errors as plugins are iterated in random order.
+		slices.SortFunc(el, func(a, b *field.Error) int {
+			return cmp.Compare(a.Field, b.Field)
+		})
+		return approver.ReconcilerReadyResponse{Ready: false, Errors: el, Result: ctrl.Result{Requeue: true, RequeueAfter: requeueAfter}}, nil
+	}
+
+	return approver.ReconcilerReadyResponse{Ready: true}, nil
+}
+
+// EnqueueChan returns a channel which receives the names of policies whose
+// plugin breaker has opened.
+func (b *CircuitBreaker) EnqueueChan() <-chan string {
+	return b.enqueue
+}
+
+// timeoutEvaluator wraps an evaluator, denying requests which it fails to
+// evaluate within the timeout rather than blocking the review.
+type timeoutEvaluator struct {
+	approver.Evaluator
+
+	name    string
+	timeout time.Duration
+	breaker *CircuitBreaker
+}
+
+// evaluationResult is the result of calling an evaluator.
+type evaluationResult struct {
+	response approver.EvaluationResponse
+	err      error
+}
+
+// withTimeout wraps the given evaluators so that each evaluation is bounded
+// by the timeout. Timeouts are recorded to the breaker, which may be nil.
+func withTimeout(evaluators []approver.Evaluator, timeout time.Duration, breaker *CircuitBreaker) []approver.Evaluator {
+	wrapped := make([]approver.Evaluator, 0, len(evaluators))
+	for _, evaluator := range evaluators {
+		wrapped = append(wrapped, &timeoutEvaluator{
+			Evaluator: evaluator,
+			name:      evaluatorName(evaluator),
+			timeout:   timeout,
+			breaker:   breaker,
+		})
+	}
+	return wrapped
+}
+
+// Name returns the name of the wrapped evaluator.
+func (t *timeoutEvaluator) Name() string {
+	return t.name
+}
+
+// EvaluationStage returns the stage of the wrapped evaluator.
+func (t *timeoutEvaluator) EvaluationStage() approver.EvaluationStage {
+	return approver.StageOf(t.Evaluator)
+}
+
+// Evaluate calls the wrapped evaluator with a context bounded by the timeout.
+// The evaluator is run in its own goroutine so that evaluators which ignore
+// the context can't block the review.
+func (t *timeoutEvaluator) Evaluate(ctx context.Context, policy *policyapi.CertificateRequestPolicy, cr *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+	tctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	resultCh := make(chan evaluationResult, 1)
+	go func() {
+		response, err := t.Evaluator.Evaluate(tctx, policy, cr)
+		resultCh <- evaluationResult{response, err}
+	}()
+
+	select {
+	case result := <-resultCh:
+		// An evaluator respecting the context may return the deadline error
+		// itself, which is also a timeout.
+		if result.err == nil || tctx.Err() == nil || ctx.Err() != nil {
+			t.breaker.recordSuccess(t.name)
+			return result.response, result.err
+		}
+	case <-tctx.Done():
+		if ctx.Err() != nil {
+			return approver.EvaluationResponse{}, ctx.Err()
+		}
+	}
+
+	t.breaker.recordTimeout(t.name, policy.Name)
+	return approver.EvaluationResponse{
+		Result:  approver.ResultDenied,
+		Message: fmt.Sprintf("evaluator %q timed out after %s", t.name, t.timeout),
+	}, nil
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	fakeclock "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/approver/fake"
+	"github.com/cert-manager/approver-policy/pkg/approver/manager"
+	"github.com/cert-manager/approver-policy/pkg/internal/approver/manager/predicate"
+)
+
+// namedEvaluator is a fake evaluator with a name, as plugins have.
+type namedEvaluator struct {
+	*fake.FakeEvaluator
+	name string
+}
+
+func (n namedEvaluator) Name() string {
+	return n.name
+}
+
+func Test_timeoutEvaluator(t *testing.T) {
+	// block is only closed once the test has finished, so that slow evaluators
+	// never return within the timeout.
+	block := make(chan struct{})
+	t.Cleanup(func() { close(block) })
+
+	var (
+		approve = approver.EvaluationResponse{Result: approver.ResultNotDenied}
+		timeout = approver.EvaluationResponse{Result: approver.ResultDenied, Message: `evaluator "slow" timed out after 10ms`}
+	)
+
+	tests := map[string]struct {
+		evaluate    func(context.Context, *policyapi.CertificateRequestPolicy, *cmapi.CertificateRequest) (approver.EvaluationResponse, error)
+		cancel      bool
+		expResponse approver.EvaluationResponse
+		expErr      bool
+	}{
+		"if the evaluator returns within the timeout, return its response": {
+			evaluate: func(context.Context, *policyapi.CertificateRequestPolicy, *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+				return approve, nil
+			},
+			expResponse: approve,
+		},
+		"if the evaluator returns an error within the timeout, return the error": {
+			evaluate: func(context.Context, *policyapi.CertificateRequestPolicy, *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+				return approver.EvaluationResponse{}, errors.New("this is an error")
+			},
+			expErr: true,
+		},
+		"if the evaluator ignores the context and never returns, return denied": {
+			evaluate: func(context.Context, *policyapi.CertificateRequestPolicy, *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+				<-block
+				return approve, nil
+			},
+			expResponse: timeout,
+		},
+		"if the evaluator returns the context error once timed out, return denied": {
+			evaluate: func(ctx context.Context, _ *policyapi.CertificateRequestPolicy, _ *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+				<-ctx.Done()
+				return approver.EvaluationResponse{}, ctx.Err()
+			},
+			expResponse: timeout,
+		},
+		"if the review context is cancelled, return an error rather than denying": {
+			evaluate: func(context.Context, *policyapi.CertificateRequestPolicy, *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+				<-block
+				return approve, nil
+			},
+			cancel: true,
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.TODO())
+			defer cancel()
+			if test.cancel {
+				cancel()
+			}
+
+			evaluators := withTimeout([]approver.Evaluator{
+				namedEvaluator{fake.NewFakeEvaluator().WithEvaluate(test.evaluate), "slow"},
+			}, 10*time.Millisecond, nil)
+
+			response, err := evaluators[0].Evaluate(ctx, &policyapi.CertificateRequestPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy-a"}}, &cmapi.CertificateRequest{})
+			assert.Equal(t, test.expErr, err != nil, "%v", err)
+			assert.Equal(t, test.expResponse, response)
+		})
+	}
+}
+
+func Test_CircuitBreaker(t *testing.T) {
+	fixedTime := time.Date(2026, 01, 01, 01, 0, 0, 0, time.UTC)
+	fixedclock := fakeclock.NewFakeClock(fixedTime)
+
+	breaker := NewCircuitBreaker(2, time.Minute)
+	breaker.clock = fixedclock
+
+	withPlugin := &policyapi.CertificateRequestPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy-a"},
+		Spec: policyapi.CertificateRequestPolicySpec{
+			Plugins: map[string]policyapi.CertificateRequestPolicyPluginData{"slow": {}},
+		},
+	}
+	withoutPlugin := &policyapi.CertificateRequestPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy-b"},
+	}
+
+	assertReady := func(t *testing.T, policy *policyapi.CertificateRequestPolicy, exp approver.ReconcilerReadyResponse) {
+		t.Helper()
+		response, err := breaker.Ready(context.TODO(), policy)
+		assert.NoError(t, err)
+		assert.Equal(t, exp, response)
+	}
+	ready := approver.ReconcilerReadyResponse{Ready: true}
+
+	// A success resets the consecutive timeouts, so the breaker stays closed.
+	breaker.recordTimeout("slow", "policy-a")
+	breaker.recordSuccess("slow")
+	breaker.recordTimeout("slow", "policy-a")
+	assertReady(t, withPlugin, ready)
+
+	// The second consecutive timeout opens the breaker.
+	breaker.recordTimeout("slow", "policy-a")
+	select {
+	case name := <-breaker.EnqueueChan():
+		assert.Equal(t, "policy-a", name)
+	case <-time.After(time.Second):
+		t.Fatal("expected policy to be enqueued when the breaker opened")
+	}
+
+	assertReady(t, withPlugin, approver.ReconcilerReadyResponse{
+		Ready: false,
+		Errors: field.ErrorList{
+			field.Forbidden(field.NewPath("spec", "plugins").Key("slow"), "plugin repeatedly timed out evaluating requests, circuit breaker is open until 2026-01-01T01:01:00Z"),
+		},
+		Result: ctrl.Result{Requeue: true, RequeueAfter: time.Minute},
+	})
+	assertReady(t, withoutPlugin, ready)
+
+	// Once the cooldown has passed the policy is ready again.
+	fixedclock.Step(time.Minute)
+	assertReady(t, withPlugin, ready)
+}
+
+func Test_ReviewEvaluatorTimeout(t *testing.T) {
+	block := make(chan struct{})
+	t.Cleanup(func() { close(block) })
+
+	fakeclient := fakeclient.NewClientBuilder().
+		WithScheme(policyapi.GlobalScheme).
+		WithRuntimeObjects(&policyapi.CertificateRequestPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy-a"},
+			Status: policyapi.CertificateRequestPolicyStatus{
+				Conditions: []policyapi.CertificateRequestPolicyCondition{
+					{Type: policyapi.CertificateRequestPolicyConditionReady, Status: corev1.ConditionTrue},
+				},
+			},
+		}).
+		Build()
+
+	slow := namedEvaluator{fake.NewFakeEvaluator().WithEvaluate(func(context.Context, *policyapi.CertificateRequestPolicy, *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+		<-block
+		return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
+	}), "slow"}
+
+	mngr := &mngr{
+		lister:     fakeclient,
+		predicates: []namedPredicate{{"Ready", predicate.Ready}},
+		evaluators: withTimeout([]approver.Evaluator{slow}, 10*time.Millisecond, nil),
+	}
+
+	response, err := mngr.Review(context.TODO(), &cmapi.CertificateRequest{ObjectMeta: metav1.ObjectMeta{Name: "test-req"}})
+	assert.NoError(t, err)
+	assert.Equal(t, manager.ReviewResponse{
+		Result:   manager.ResultDenied,
+		Message:  `No policy approved this request: [policy-a: evaluator "slow" timed out after 10ms]`,
+		Policies: []string{"policy-a"},
+		Denials:  []manager.Denial{{Policy: "policy-a", Errors: []string{`evaluator "slow" timed out after 10ms`}}},
+	}, response)
+}
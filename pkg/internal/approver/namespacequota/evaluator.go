@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacequota
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+// Evaluate denies requests when the namespace of the request already contains
+// its quota of CertificateRequests. Policies which do not configure the
+// namespace-quota plugin, or which define no quota for the namespace, are not
+// evaluated.
+//
+// Every other CertificateRequest in the namespace which has not been denied
+// counts towards the quota, including those still pending a decision. This
+// means that concurrent requests close to the quota may all be denied, but
+// the quota can never be exceeded.
+// An error signals that the policy couldn't be evaluated to completion.
+func (n *namespacequota) Evaluate(ctx context.Context, policy *policyapi.CertificateRequestPolicy, request *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+	plugin, ok := policy.Spec.Plugins[Name]
+	if !ok {
+		return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
+	}
+
+	if n.lister == nil || n.reader == nil {
+		return approver.EvaluationResponse{}, fmt.Errorf("%s %w", n.Name(), approver.ErrNotPrepared)
+	}
+
+	quota, ok, err := n.quota(ctx, plugin.Values, request.Namespace)
+	if err != nil {
+		return approver.EvaluationResponse{}, err
+	}
+	if !ok {
+		return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
+	}
+
+	var crList cmapi.CertificateRequestList
+	if err := n.lister.List(ctx, &crList, client.InNamespace(request.Namespace)); err != nil {
+		return approver.EvaluationResponse{}, fmt.Errorf("failed to list CertificateRequests in namespace %q: %w", request.Namespace, err)
+	}
+
+	var count int
+	for i := range crList.Items {
+		cr := &crList.Items[i]
+		if cr.Name == request.Name || apiutil.CertificateRequestIsDenied(cr) {
+			continue
+		}
+		count++
+	}
+
+	if count >= quota {
+		el := field.ErrorList{field.Forbidden(field.NewPath("spec", "plugins", Name),
+			fmt.Sprintf("namespace %q already has %d CertificateRequests which meets its quota of %d", request.Namespace, count, quota))}
+		return approver.EvaluationResponse{Result: approver.ResultDenied, Message: el.ToAggregate().Error()}, nil
+	}
+
+	return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
+}
+
+// quota returns the maximum number of CertificateRequests that may exist in
+// the given namespace. A quota in the quota ConfigMap takes precedence over
+// maxCertificateRequests. Returns false if no quota applies to the namespace.
+func (n *namespacequota) quota(ctx context.Context, values map[string]string, namespace string) (int, bool, error) {
+	if ref, ok := values[valueQuotaConfigMap]; ok {
+		cmNamespace, cmName, ok := parseConfigMapRef(ref)
+		if !ok {
+			return 0, false, fmt.Errorf("invalid quota ConfigMap reference %q", ref)
+		}
+
+		quotas, err := n.quotaConfigMap(ctx, client.ObjectKey{Namespace: cmNamespace, Name: cmName}, false)
+		if err != nil {
+			return 0, false, err
+		}
+
+		if value, ok := quotas[namespace]; ok {
+			quota, err := parseQuota(value)
+			if err != nil {
+				return 0, false, fmt.Errorf("invalid quota for namespace %q in ConfigMap %s/%s: %w", namespace, cmNamespace, cmName, err)
+			}
+			return quota, true, nil
+		}
+	}
+
+	if value, ok := values[valueMaxCertificateRequests]; ok {
+		quota, err := parseQuota(value)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid %s: %w", valueMaxCertificateRequests, err)
+		}
+		return quota, true, nil
+	}
+
+	return 0, false, nil
+}
+
+// quotaConfigMap returns the data of the quota ConfigMap with the given key.
+// The ConfigMap is read from the cache unless refresh is true, or it hasn't
+// been read before.
+func (n *namespacequota) quotaConfigMap(ctx context.Context, key client.ObjectKey, refresh bool) (map[string]string, error) {
+	if n.reader == nil {
+		return nil, fmt.Errorf("%s %w", n.Name(), approver.ErrNotPrepared)
+	}
+
+	var (
+		quotas map[string]string
+		err    error
+	)
+	if refresh {
+		quotas, err = n.quotas.Refresh(ctx, n.reader, key)
+	} else {
+		quotas, err = n.quotas.Get(ctx, n.reader, key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quota ConfigMap %s/%s: %w", key.Namespace, key.Name, err)
+	}
+	return quotas, nil
+}
+
+// parseQuotas returns the data of the given quota ConfigMap, which maps
+// namespace names to quotas.
+func parseQuotas(cm *corev1.ConfigMap) map[string]string {
+	return cm.Data
+}
+
+// parseQuota parses the given quota, which must be a non-negative integer.
+func parseQuota(value string) (int, error) {
+	quota, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+	if quota < 0 {
+		return 0, fmt.Errorf("must be greater than or equal to 0, got %d", quota)
+	}
+	return quota, nil
+}
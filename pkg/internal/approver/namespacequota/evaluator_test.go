@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacequota
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+func Test_Evaluate(t *testing.T) {
+	var (
+		request = gen.CertificateRequest("new", gen.SetCertificateRequestNamespace("sandbox"))
+
+		existingRequests = func(namespace string, n int) []runtime.Object {
+			var objects []runtime.Object
+			for i := 0; i < n; i++ {
+				objects = append(objects, gen.CertificateRequest(fmt.Sprintf("existing-%d", i), gen.SetCertificateRequestNamespace(namespace)))
+			}
+			return objects
+		}
+
+		deniedRequest = gen.CertificateRequest("denied",
+			gen.SetCertificateRequestNamespace("sandbox"),
+			gen.AddCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+				Type:   cmapi.CertificateRequestConditionDenied,
+				Status: cmmeta.ConditionTrue,
+			}),
+		)
+
+		quotaConfigMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "cert-manager", Name: "quotas"},
+			Data:       map[string]string{"sandbox": "1"},
+		}
+
+		pluginPolicy = func(values map[string]string) policyapi.CertificateRequestPolicySpec {
+			return policyapi.CertificateRequestPolicySpec{
+				Plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+					Name: {Values: values},
+				},
+			}
+		}
+
+		overQuota = func(count, quota int) approver.EvaluationResponse {
+			el := field.ErrorList{field.Forbidden(field.NewPath("spec.plugins.namespace-quota"),
+				fmt.Sprintf(`namespace "sandbox" already has %d CertificateRequests which meets its quota of %d`, count, quota))}
+			return approver.EvaluationResponse{Result: approver.ResultDenied, Message: el.ToAggregate().Error()}
+		}
+	)
+
+	tests := map[string]struct {
+		policy          policyapi.CertificateRequestPolicySpec
+		existingObjects []runtime.Object
+		expResponse     approver.EvaluationResponse
+		expErr          bool
+	}{
+		"if plugin not configured on policy, return NotDenied": {
+			policy:          policyapi.CertificateRequestPolicySpec{},
+			existingObjects: existingRequests("sandbox", 5),
+			expResponse:     approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if namespace is under quota, return NotDenied": {
+			policy:          pluginPolicy(map[string]string{"maxCertificateRequests": "3"}),
+			existingObjects: append(existingRequests("sandbox", 2), request),
+			expResponse:     approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if namespace is at quota, return Denied": {
+			policy:          pluginPolicy(map[string]string{"maxCertificateRequests": "3"}),
+			existingObjects: append(existingRequests("sandbox", 3), request),
+			expResponse:     overQuota(3, 3),
+		},
+		"if namespace is over quota, return Denied": {
+			policy:          pluginPolicy(map[string]string{"maxCertificateRequests": "3"}),
+			existingObjects: append(existingRequests("sandbox", 5), request),
+			expResponse:     overQuota(5, 3),
+		},
+		"if quota is zero, return Denied": {
+			policy:          pluginPolicy(map[string]string{"maxCertificateRequests": "0"}),
+			existingObjects: []runtime.Object{request},
+			expResponse:     overQuota(0, 0),
+		},
+		"if other namespaces and denied requests would exceed quota, return NotDenied": {
+			policy:          pluginPolicy(map[string]string{"maxCertificateRequests": "3"}),
+			existingObjects: append(append(existingRequests("sandbox", 2), existingRequests("other", 5)...), deniedRequest, request),
+			expResponse:     approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if quota ConfigMap defines a quota for the namespace, it should override maxCertificateRequests": {
+			policy:          pluginPolicy(map[string]string{"maxCertificateRequests": "3", "quotaConfigMap": "cert-manager/quotas"}),
+			existingObjects: append(existingRequests("sandbox", 2), quotaConfigMap, request),
+			expResponse:     overQuota(2, 1),
+		},
+		"if quota ConfigMap does not define a quota for the namespace and no default, return NotDenied": {
+			policy: pluginPolicy(map[string]string{"quotaConfigMap": "cert-manager/quotas"}),
+			existingObjects: append(existingRequests("sandbox", 2), &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "cert-manager", Name: "quotas"},
+				Data:       map[string]string{"other": "1"},
+			}, request),
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if quota ConfigMap does not exist, return error": {
+			policy:          pluginPolicy(map[string]string{"quotaConfigMap": "cert-manager/quotas"}),
+			existingObjects: []runtime.Object{request},
+			expErr:          true,
+		},
+		"if quota ConfigMap contains an invalid quota for the namespace, return error": {
+			policy: pluginPolicy(map[string]string{"quotaConfigMap": "cert-manager/quotas"}),
+			existingObjects: []runtime.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "cert-manager", Name: "quotas"},
+				Data:       map[string]string{"sandbox": "lots"},
+			}, request},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := fakeclient.NewClientBuilder().
+				WithScheme(policyapi.GlobalScheme).
+				WithRuntimeObjects(test.existingObjects...).
+				Build()
+			n := Approver().(*namespacequota)
+			n.lister, n.reader = client, client
+			response, err := n.Evaluate(context.TODO(), &policyapi.CertificateRequestPolicy{Spec: test.policy}, request)
+			assert.Equal(t, test.expErr, err != nil, "%v", err)
+			assert.Equal(t, test.expResponse, response, "unexpected evaluation response")
+		})
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacequota
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/pflag"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/internal/util"
+	"github.com/cert-manager/approver-policy/pkg/registry"
+)
+
+const (
+	// Name is the name of the namespace-quota plugin, used as its key in
+	// CertificateRequestPolicy `spec.plugins`.
+	Name = "namespace-quota"
+
+	// valueMaxCertificateRequests is the plugin value key of the maximum
+	// number of CertificateRequests that may exist in a namespace.
+	valueMaxCertificateRequests = "maxCertificateRequests"
+
+	// valueQuotaConfigMap is the plugin value key of a ConfigMap, in the form
+	// `<namespace>/<name>`, whose data maps namespace names to the maximum
+	// number of CertificateRequests that may exist in that namespace. Quotas
+	// in the ConfigMap override maxCertificateRequests.
+	valueQuotaConfigMap = "quotaConfigMap"
+)
+
+// Load the namespace-quota approver.
+func init() {
+	registry.Shared.Store(Approver())
+}
+
+var _ approver.DependencyReconciler = &namespacequota{}
+
+// Approver returns an instance of the namespace-quota approver.
+func Approver() approver.Interface {
+	return &namespacequota{
+		quotas: util.NewConfigMapCache(parseQuotas),
+	}
+}
+
+// namespacequota is an approver-policy plugin which denies requests once the
+// namespace of the request already contains the configured maximum number of
+// CertificateRequests.
+type namespacequota struct {
+	// lister is used to list CertificateRequests from the informer cache.
+	lister client.Reader
+
+	// reader is used for fetching quota ConfigMaps referenced by policies.
+	// Reads are made directly against the API server so that approver-policy
+	// is not required to cache all ConfigMaps in the cluster.
+	reader client.Reader
+
+	// quotas caches the data of quota ConfigMaps, so that they are not read
+	// on every evaluation. They are re-read when the readiness of a policy
+	// referencing them is re-synced.
+	quotas *util.ConfigMapCache[map[string]string]
+}
+
+// Name of Approver is "namespace-quota"
+func (n *namespacequota) Name() string {
+	return Name
+}
+
+// RegisterFlags is a no-op, namespace-quota doesn't need any flags.
+func (n *namespacequota) RegisterFlags(_ *pflag.FlagSet) {}
+
+// Prepare sets up the CertificateRequest lister and the ConfigMap reader.
+// CertificateRequests are already cached by the approver-policy controller.
+func (n *namespacequota) Prepare(_ context.Context, _ logr.Logger, mgr manager.Manager) error {
+	n.lister = mgr.GetCache()
+	n.reader = mgr.GetAPIReader()
+	return nil
+}
+
+// Ready always returns ready, namespace-quota has no external readiness
+// requirements beyond the dependencies it declares. The quota ConfigMap
+// referenced by the policy, if any, is re-read so that evaluations observe
+// changes to it once the policy has been re-synced.
+func (n *namespacequota) Ready(ctx context.Context, policy *policyapi.CertificateRequestPolicy) (approver.ReconcilerReadyResponse, error) {
+	if plugin, ok := policy.Spec.Plugins[Name]; ok {
+		if namespace, name, ok := parseConfigMapRef(plugin.Values[valueQuotaConfigMap]); ok {
+			// A ConfigMap which doesn't exist is reported as a missing
+			// dependency.
+			if _, err := n.quotaConfigMap(ctx, client.ObjectKey{Namespace: namespace, Name: name}, true); err != nil && !apierrors.IsNotFound(err) {
+				return approver.ReconcilerReadyResponse{}, err
+			}
+		}
+	}
+	return approver.ReconcilerReadyResponse{Ready: true}, nil
+}
+
+// Dependencies returns the quota ConfigMap referenced by the policy, if any.
+// The policy is not ready while the ConfigMap does not exist.
+func (n *namespacequota) Dependencies(policy *policyapi.CertificateRequestPolicy) []approver.Dependency {
+	plugin, ok := policy.Spec.Plugins[Name]
+	if !ok {
+		return nil
+	}
+
+	namespace, name, ok := parseConfigMapRef(plugin.Values[valueQuotaConfigMap])
+	if !ok {
+		return nil
+	}
+
+	return []approver.Dependency{{
+		Kind:      approver.DependencyKindConfigMap,
+		Namespace: namespace,
+		Name:      name,
+		FieldPath: field.NewPath("spec", "plugins", Name, "values").Key(valueQuotaConfigMap),
+	}}
+}
+
+// namespace-quota never needs to manually enqueue policies.
+func (n *namespacequota) EnqueueChan() <-chan string {
+	return nil
+}
+
+// parseConfigMapRef parses a ConfigMap reference in the form
+// `<namespace>/<name>`. Returns false if the reference is malformed.
+func parseConfigMapRef(ref string) (string, string, bool) {
+	namespace, name, ok := strings.Cut(ref, "/")
+	if !ok || len(namespace) == 0 || len(name) == 0 || strings.Contains(name, "/") {
+		return "", "", false
+	}
+	return namespace, name, true
+}
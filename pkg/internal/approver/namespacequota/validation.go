@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacequota
+
+import (
+	"context"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+// Validate validates that the namespace-quota plugin values of the processed
+// CertificateRequestPolicy are known and valid.
+func (n *namespacequota) Validate(_ context.Context, policy *policyapi.CertificateRequestPolicy) (approver.WebhookValidationResponse, error) {
+	plugin, ok := policy.Spec.Plugins[Name]
+	if !ok {
+		return approver.WebhookValidationResponse{
+			Allowed: true,
+			Errors:  nil,
+		}, nil
+	}
+
+	var (
+		el      field.ErrorList
+		fldPath = field.NewPath("spec", "plugins", Name, "values")
+	)
+
+	// Sort keys so that errors are deterministic.
+	keys := make([]string, 0, len(plugin.Values))
+	for key := range plugin.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := plugin.Values[key]
+		switch key {
+		case valueMaxCertificateRequests:
+			if _, err := parseQuota(value); err != nil {
+				el = append(el, field.Invalid(fldPath.Key(key), value, err.Error()))
+			}
+		case valueQuotaConfigMap:
+			if _, _, ok := parseConfigMapRef(value); !ok {
+				el = append(el, field.Invalid(fldPath.Key(key), value, "must be a ConfigMap reference in the form <namespace>/<name>"))
+			}
+		default:
+			el = append(el, field.NotSupported(fldPath, key, []string{valueMaxCertificateRequests, valueQuotaConfigMap}))
+		}
+	}
+
+	if _, ok := plugin.Values[valueMaxCertificateRequests]; !ok {
+		if _, ok := plugin.Values[valueQuotaConfigMap]; !ok {
+			el = append(el, field.Required(fldPath, "one of maxCertificateRequests or quotaConfigMap must be defined"))
+		}
+	}
+
+	return approver.WebhookValidationResponse{
+		Allowed: len(el) == 0,
+		Errors:  el,
+	}, nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacequota
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+func Test_Validate(t *testing.T) {
+	_, atoiErr := strconv.Atoi("lots")
+
+	tests := map[string]struct {
+		policy      *policyapi.CertificateRequestPolicy
+		expResponse approver.WebhookValidationResponse
+	}{
+		"if policy doesn't configure plugin, expect a Allowed=true response": {
+			policy: &policyapi.CertificateRequestPolicy{},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: true,
+				Errors:  nil,
+			},
+		},
+		"if policy configures plugin with valid values, expect a Allowed=true response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+						Name: {Values: map[string]string{"maxCertificateRequests": "10", "quotaConfigMap": "cert-manager/quotas"}},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: true,
+				Errors:  nil,
+			},
+		},
+		"if policy configures plugin without a quota, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+						Name: {},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Required(field.NewPath("spec.plugins.namespace-quota.values"), "one of maxCertificateRequests or quotaConfigMap must be defined"),
+				},
+			},
+		},
+		"if policy configures plugin with invalid values, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+						Name: {Values: map[string]string{"maxCertificateRequests": "lots", "quotaConfigMap": "quotas", "foo": "bar"}},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.NotSupported(field.NewPath("spec.plugins.namespace-quota.values"), "foo", []string{"maxCertificateRequests", "quotaConfigMap"}),
+					field.Invalid(field.NewPath("spec.plugins.namespace-quota.values").Key("maxCertificateRequests"), "lots", atoiErr.Error()),
+					field.Invalid(field.NewPath("spec.plugins.namespace-quota.values").Key("quotaConfigMap"), "quotas", "must be a ConfigMap reference in the form <namespace>/<name>"),
+				},
+			},
+		},
+		"if policy configures plugin with a negative quota, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+						Name: {Values: map[string]string{"maxCertificateRequests": "-1"}},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.plugins.namespace-quota.values").Key("maxCertificateRequests"), "-1", "must be greater than or equal to 0, got -1"),
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			response, err := Approver().Validate(context.TODO(), test.policy)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expResponse, response)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reversedns
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+var _ approver.FileReloader = &reversedns{}
+
+// Reload re-reads the configuration file, if one is configured. The default
+// plugin values are only replaced if the whole file is valid, so that a
+// broken file leaves the previous configuration in place.
+func (r *reversedns) Reload(_ context.Context) error {
+	if len(r.configFile) == 0 {
+		return nil
+	}
+
+	defaults, err := loadConfig(r.configFile)
+	if err != nil {
+		return err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.defaults = defaults
+
+	return nil
+}
+
+// ReloadFiles returns the configuration file, if one is configured.
+func (r *reversedns) ReloadFiles() []string {
+	if len(r.configFile) == 0 {
+		return nil
+	}
+	return []string{r.configFile}
+}
+
+// values returns the plugin values of a policy, with any value the policy
+// doesn't define taken from the configuration file.
+func (r *reversedns) values(policyValues map[string]string) map[string]string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	values := maps.Clone(r.defaults)
+	if values == nil {
+		values = make(map[string]string, len(policyValues))
+	}
+	maps.Copy(values, policyValues)
+	return values
+}
+
+// loadConfig reads and validates the default plugin values from the YAML
+// configuration file at the given path.
+func loadConfig(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s config file: %w", Name, err)
+	}
+
+	var defaults map[string]string
+	if err := yaml.UnmarshalStrict(data, &defaults); err != nil {
+		return nil, fmt.Errorf("failed to parse %s config file %q: %w", Name, path, err)
+	}
+
+	if el := validateValues(field.NewPath("config"), defaults); len(el) > 0 {
+		return nil, fmt.Errorf("invalid %s config file %q: %w", Name, path, el.ToAggregate())
+	}
+
+	return defaults, nil
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reversedns
+
+import (
+	"context"
+	"crypto/x509"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/testr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/internal/reloader"
+)
+
+func Test_loadConfig(t *testing.T) {
+	tests := map[string]struct {
+		config      string
+		expDefaults map[string]string
+		expErr      bool
+	}{
+		"an empty file should return no defaults": {
+			config:      "",
+			expDefaults: nil,
+		},
+		"valid values should be returned": {
+			config:      "nameserver: 10.96.0.10:53\ntimeout: 2s\n",
+			expDefaults: map[string]string{"nameserver": "10.96.0.10:53", "timeout": "2s"},
+		},
+		"an unknown value should error": {
+			config: "foo: bar\n",
+			expErr: true,
+		},
+		"an invalid value should error": {
+			config: "timeout: -1s\n",
+			expErr: true,
+		},
+		"a file which isn't a map of strings should error": {
+			config: "- nameserver\n",
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(test.config), 0600))
+
+			defaults, err := loadConfig(path)
+			assert.Equal(t, test.expErr, err != nil, "%v", err)
+			assert.Equal(t, test.expDefaults, defaults)
+		})
+	}
+}
+
+func Test_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("nameserver: 10.96.0.10:53\n"), 0600))
+
+	var nameserver string
+	r := &reversedns{
+		configFile: path,
+		newResolver: func(ns string) resolver {
+			nameserver = ns
+			return fakeResolver{records: map[string][]string{"10.0.0.1": {"app.example.com."}}}
+		},
+	}
+	require.NoError(t, r.Prepare(context.TODO(), logr.Discard(), nil))
+	assert.Equal(t, []string{path}, r.ReloadFiles())
+
+	csr, _, err := gen.CSR(x509.ECDSA, gen.SetCSRDNSNames("app.example.com"), gen.SetCSRIPAddressesFromStrings("10.0.0.1"))
+	require.NoError(t, err)
+	request := gen.CertificateRequest("", gen.SetCertificateRequestCSR(csr))
+
+	evaluate := func(values map[string]string) {
+		t.Helper()
+		policy := &policyapi.CertificateRequestPolicy{Spec: policyapi.CertificateRequestPolicySpec{
+			Plugins: map[string]policyapi.CertificateRequestPolicyPluginData{Name: {Values: values}},
+		}}
+		response, err := r.Evaluate(context.TODO(), policy, request)
+		require.NoError(t, err)
+		assert.Equal(t, approver.ResultNotDenied, response.Result)
+	}
+
+	evaluate(nil)
+	assert.Equal(t, "10.96.0.10:53", nameserver, "expected the nameserver of the config file")
+
+	evaluate(map[string]string{"nameserver": "192.168.0.10:53"})
+	assert.Equal(t, "192.168.0.10:53", nameserver, "expected the nameserver of the policy to take precedence")
+
+	require.NoError(t, os.WriteFile(path, []byte("nameserver: 10.96.0.11:53\n"), 0600))
+	require.NoError(t, r.Reload(context.TODO()))
+	evaluate(nil)
+	assert.Equal(t, "10.96.0.11:53", nameserver, "expected the nameserver of the changed config file")
+
+	require.NoError(t, os.WriteFile(path, []byte("nameserver: not-a-nameserver\n"), 0600))
+	assert.Error(t, r.Reload(context.TODO()))
+	evaluate(nil)
+	assert.Equal(t, "10.96.0.11:53", nameserver, "expected an invalid config file to keep the previous configuration")
+}
+
+func Test_Reload_NoConfigFile(t *testing.T) {
+	r := &reversedns{}
+	assert.NoError(t, r.Reload(context.TODO()))
+	assert.Empty(t, r.ReloadFiles())
+	assert.Equal(t, map[string]string{"timeout": "1s"}, r.values(map[string]string{"timeout": "1s"}))
+}
+
+// Test_Reload_SIGHUP ensures that the configuration file of the plugin is
+// reloaded by approver-policy receiving a SIGHUP.
+func Test_Reload_SIGHUP(t *testing.T) {
+	// Keep SIGHUP from terminating the test process should it be sent before
+	// the reloader has started listening for it.
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	t.Cleanup(func() { signal.Stop(sighup) })
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("nameserver: 10.96.0.10:53\n"), 0600))
+
+	var nameserver string
+	r := &reversedns{
+		configFile: path,
+		newResolver: func(ns string) resolver {
+			nameserver = ns
+			return fakeResolver{records: map[string][]string{"10.0.0.1": {"app.example.com."}}}
+		},
+	}
+	require.NoError(t, r.Prepare(context.TODO(), logr.Discard(), nil))
+
+	lister := fakeclient.NewClientBuilder().
+		WithScheme(policyapi.GlobalScheme).
+		WithRuntimeObjects(&policyapi.CertificateRequestPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy-a"}}).
+		Build()
+
+	// Checking for file changes is disabled, so only a SIGHUP reloads.
+	configReloader := reloader.New(testr.New(t), lister, []approver.Reloader{r}, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() { _ = configReloader.Start(ctx) }()
+
+	require.NoError(t, os.WriteFile(path, []byte("nameserver: 10.96.0.11:53\n"), 0600))
+
+	// The reloader may not yet be listening, so SIGHUP is sent until the
+	// policies are re-synced.
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	timeout := time.After(5 * time.Second)
+	for resynced := false; !resynced; {
+		select {
+		case <-ticker.C:
+			require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+		case <-configReloader.EnqueueChan():
+			resynced = true
+		case <-timeout:
+			t.Fatal("timed out waiting for policies to be re-synced")
+		}
+	}
+
+	csr, _, err := gen.CSR(x509.ECDSA, gen.SetCSRDNSNames("app.example.com"), gen.SetCSRIPAddressesFromStrings("10.0.0.1"))
+	require.NoError(t, err)
+	policy := &policyapi.CertificateRequestPolicy{Spec: policyapi.CertificateRequestPolicySpec{
+		Plugins: map[string]policyapi.CertificateRequestPolicyPluginData{Name: {}},
+	}}
+	response, err := r.Evaluate(context.TODO(), policy, gen.CertificateRequest("", gen.SetCertificateRequestCSR(csr)))
+	require.NoError(t, err)
+	assert.Equal(t, approver.ResultNotDenied, response.Result)
+	assert.Equal(t, "10.96.0.11:53", nameserver, "expected the nameserver of the reloaded config file")
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reversedns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+// Evaluate denies requests containing IP SANs which don't have a PTR record
+// matching one of the requested DNS SANs. Policies which do not configure the
+// reverse-dns plugin are not evaluated. Values which the policy doesn't define
+// are taken from the configuration file.
+// An error signals that the policy couldn't be evaluated to completion, for
+// example if the resolver could not be reached.
+func (r *reversedns) Evaluate(ctx context.Context, policy *policyapi.CertificateRequestPolicy, request *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+	plugin, ok := policy.Spec.Plugins[Name]
+	if !ok {
+		return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
+	}
+
+	csr, err := utilpki.DecodeX509CertificateRequestBytes(request.Spec.Request)
+	if err != nil {
+		return approver.EvaluationResponse{}, err
+	}
+
+	// Nothing to check if no IP SANs have been requested.
+	if len(csr.IPAddresses) == 0 {
+		return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
+	}
+
+	values := r.values(plugin.Values)
+
+	timeout := defaultTimeout
+	if value, ok := values[valueTimeout]; ok {
+		if timeout, err = time.ParseDuration(value); err != nil {
+			return approver.EvaluationResponse{}, fmt.Errorf("failed to parse %s plugin %s: %w", Name, valueTimeout, err)
+		}
+	}
+
+	dnsNames := sets.New[string]()
+	for _, dnsName := range csr.DNSNames {
+		dnsNames.Insert(normaliseName(dnsName))
+	}
+
+	var (
+		el      field.ErrorList
+		fldPath = field.NewPath("spec", "plugins", Name)
+		dns     = r.newResolver(values[valueNameserver])
+	)
+	for _, ip := range csr.IPAddresses {
+		names, err := lookupAddr(ctx, dns, ip, timeout)
+		if err != nil {
+			return approver.EvaluationResponse{}, err
+		}
+
+		if len(names) == 0 {
+			el = append(el, field.Invalid(fldPath, ip.String(), "IP address has no PTR record"))
+			continue
+		}
+
+		if !dnsNames.HasAny(names...) {
+			el = append(el, field.Invalid(fldPath, ip.String(), fmt.Sprintf("PTR records [%s] do not match any requested DNS name", strings.Join(names, ", "))))
+		}
+	}
+
+	if len(el) > 0 {
+		return approver.EvaluationResponse{Result: approver.ResultDenied, Message: el.ToAggregate().Error()}, nil
+	}
+
+	return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
+}
+
+// lookupAddr returns the normalised names of the PTR records of the given IP.
+// An IP without PTR records returns no names and no error.
+func lookupAddr(ctx context.Context, dns resolver, ip net.IP, timeout time.Duration) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	names, err := dns.LookupAddr(ctx, ip.String())
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to lookup PTR records of %s: %w", ip, err)
+	}
+
+	for i := range names {
+		names[i] = normaliseName(names[i])
+	}
+	return names, nil
+}
+
+// normaliseName returns the DNS name in lower case without a trailing dot, so
+// that PTR record names can be compared with requested DNS names.
+func normaliseName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
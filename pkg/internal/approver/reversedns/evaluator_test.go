@@ -0,0 +1,167 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reversedns
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"net"
+	"testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+// fakeResolver resolves PTR records from a static set of records. IPs
+// without records are not found, and IPs in errs return the given error.
+type fakeResolver struct {
+	records map[string][]string
+	errs    map[string]error
+}
+
+func (f fakeResolver) LookupAddr(_ context.Context, addr string) ([]string, error) {
+	if err, ok := f.errs[addr]; ok {
+		return nil, err
+	}
+	names, ok := f.records[addr]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: addr, IsNotFound: true}
+	}
+	return append([]string(nil), names...), nil
+}
+
+func Test_Evaluate(t *testing.T) {
+	var (
+		ptrRecords = fakeResolver{
+			records: map[string][]string{
+				"10.0.0.1":  {"app.example.com."},
+				"10.0.0.2":  {"Other.Example.com.", "db.example.com."},
+				"fd00::1":   {"app.example.com."},
+				"10.0.0.99": {"unrelated.example.org."},
+			},
+			errs: map[string]error{
+				"10.0.0.50": errors.New("i/o timeout"),
+			},
+		}
+
+		pluginPolicy = func(values map[string]string) policyapi.CertificateRequestPolicySpec {
+			return policyapi.CertificateRequestPolicySpec{
+				Plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+					Name: {Values: values},
+				},
+			}
+		}
+
+		request = func(dnsNames []string, ips ...string) *cmapi.CertificateRequest {
+			csr, _, err := gen.CSR(x509.ECDSA, gen.SetCSRDNSNames(dnsNames...), gen.SetCSRIPAddressesFromStrings(ips...))
+			if err != nil {
+				t.Fatal(err)
+			}
+			return gen.CertificateRequest("", gen.SetCertificateRequestCSR(csr))
+		}
+	)
+
+	tests := map[string]struct {
+		policy        policyapi.CertificateRequestPolicySpec
+		request       *cmapi.CertificateRequest
+		expNameserver string
+		expResponse   approver.EvaluationResponse
+		expErr        bool
+	}{
+		"if plugin not configured on policy, return NotDenied": {
+			policy:      policyapi.CertificateRequestPolicySpec{},
+			request:     request(nil, "10.0.0.99"),
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if request contains no IP SANs, return NotDenied": {
+			policy:      pluginPolicy(nil),
+			request:     request([]string{"app.example.com"}),
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if every IP SAN has a PTR record matching a requested DNS SAN, return NotDenied": {
+			policy:      pluginPolicy(map[string]string{"nameserver": "10.96.0.10:53"}),
+			request:     request([]string{"app.example.com", "other.example.com"}, "10.0.0.1", "10.0.0.2", "fd00::1"),
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+			// The configured nameserver must be passed to the resolver.
+			expNameserver: "10.96.0.10:53",
+		},
+		"if an IP SAN has PTR records not matching any requested DNS SAN, return Denied": {
+			policy:  pluginPolicy(nil),
+			request: request([]string{"app.example.com"}, "10.0.0.1", "10.0.0.99"),
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.plugins.reverse-dns"), "10.0.0.99", "PTR records [unrelated.example.org] do not match any requested DNS name"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if an IP SAN has no PTR record, return Denied": {
+			policy:  pluginPolicy(nil),
+			request: request([]string{"app.example.com"}, "10.0.0.3"),
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.plugins.reverse-dns"), "10.0.0.3", "IP address has no PTR record"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if request has IP SANs but no DNS SANs, return Denied": {
+			policy:  pluginPolicy(nil),
+			request: request(nil, "10.0.0.1"),
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.plugins.reverse-dns"), "10.0.0.1", "PTR records [app.example.com] do not match any requested DNS name"),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if the resolver fails, return error": {
+			policy:      pluginPolicy(nil),
+			request:     request([]string{"app.example.com"}, "10.0.0.50"),
+			expResponse: approver.EvaluationResponse{},
+			expErr:      true,
+		},
+		"if request fails to decode, return error": {
+			policy:      pluginPolicy(nil),
+			request:     gen.CertificateRequest("", gen.SetCertificateRequestCSR([]byte("bad-csr"))),
+			expResponse: approver.EvaluationResponse{},
+			expErr:      true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var nameserver string
+			r := &reversedns{
+				newResolver: func(ns string) resolver {
+					nameserver = ns
+					return ptrRecords
+				},
+			}
+			response, err := r.Evaluate(context.TODO(), &policyapi.CertificateRequestPolicy{Spec: test.policy}, test.request)
+			assert.Equal(t, test.expErr, err != nil, "%v", err)
+			assert.Equal(t, test.expResponse, response, "unexpected evaluation response")
+			assert.Equal(t, test.expNameserver, nameserver, "unexpected nameserver")
+		})
+	}
+}
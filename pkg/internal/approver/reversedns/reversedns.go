@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reversedns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/registry"
+)
+
+const (
+	// Name is the name of the reverse-dns plugin, used as its key in
+	// CertificateRequestPolicy `spec.plugins`.
+	Name = "reverse-dns"
+
+	// valueNameserver is the plugin value key of the DNS resolver, in the
+	// form `<host>:<port>`, which PTR records are looked up against. Defaults
+	// to the system resolver.
+	valueNameserver = "nameserver"
+
+	// valueTimeout is the plugin value key of the timeout of looking up the
+	// PTR records of a single IP address, as a Go duration.
+	valueTimeout = "timeout"
+
+	// defaultTimeout is the timeout of a single lookup if none is configured.
+	defaultTimeout = 5 * time.Second
+)
+
+// Load the reverse-dns approver.
+func init() {
+	registry.Shared.Store(Approver())
+}
+
+// Approver returns an instance of the reverse-dns approver.
+func Approver() approver.Interface {
+	return &reversedns{
+		newResolver: newNetResolver,
+	}
+}
+
+// resolver looks up the names of an IP address using its PTR records.
+type resolver interface {
+	LookupAddr(ctx context.Context, addr string) ([]string, error)
+}
+
+// reversedns is an approver-policy plugin which denies requests for IP SANs
+// whose PTR records don't match any of the requested DNS SANs.
+type reversedns struct {
+	// newResolver returns the resolver for the given nameserver. An empty
+	// nameserver returns the system resolver. Overridden in tests.
+	newResolver func(nameserver string) resolver
+
+	// configFile is the path of the YAML file holding the default plugin
+	// values, used for the values a policy doesn't define. Optional.
+	configFile string
+
+	// lock protects defaults, which are replaced on reload.
+	lock     sync.RWMutex
+	defaults map[string]string
+}
+
+// Name of Approver is "reverse-dns"
+func (r *reversedns) Name() string {
+	return Name
+}
+
+// RegisterFlags registers the flag for the optional configuration file.
+func (r *reversedns) RegisterFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&r.configFile, "reverse-dns-config-file", "",
+		`Path to a YAML file of default reverse-dns plugin values, e.g. "nameserver: 10.96.0.10:53", which are used
+	 for any value a CertificateRequestPolicy doesn't define. The file is reloaded when it changes.`)
+}
+
+// Prepare loads the configuration file, if one is configured. reverse-dns
+// doesn't need any Kubernetes resources.
+func (r *reversedns) Prepare(ctx context.Context, _ logr.Logger, _ manager.Manager) error {
+	if err := r.Reload(ctx); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	return nil
+}
+
+// Ready always returns ready, reverse-dns doesn't have any dependencies to
+// block readiness.
+func (r *reversedns) Ready(_ context.Context, _ *policyapi.CertificateRequestPolicy) (approver.ReconcilerReadyResponse, error) {
+	return approver.ReconcilerReadyResponse{Ready: true}, nil
+}
+
+// reverse-dns never needs to manually enqueue policies.
+func (r *reversedns) EnqueueChan() <-chan string {
+	return nil
+}
+
+// newNetResolver returns a resolver which sends queries to the given
+// nameserver, or the system resolver if nameserver is empty.
+func newNetResolver(nameserver string) resolver {
+	if len(nameserver) == 0 {
+		return net.DefaultResolver
+	}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, nameserver)
+		},
+	}
+}
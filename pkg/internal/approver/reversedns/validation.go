@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reversedns
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+// Validate validates that the reverse-dns plugin values of the processed
+// CertificateRequestPolicy are known and valid.
+func (r *reversedns) Validate(_ context.Context, policy *policyapi.CertificateRequestPolicy) (approver.WebhookValidationResponse, error) {
+	plugin, ok := policy.Spec.Plugins[Name]
+	if !ok {
+		return approver.WebhookValidationResponse{
+			Allowed: true,
+			Errors:  nil,
+		}, nil
+	}
+
+	el := validateValues(field.NewPath("spec", "plugins", Name, "values"), plugin.Values)
+
+	return approver.WebhookValidationResponse{
+		Allowed: len(el) == 0,
+		Errors:  el,
+	}, nil
+}
+
+// validateValues validates that the given reverse-dns plugin values are known
+// and valid. Used for both the values of policies and the values of the
+// configuration file.
+func validateValues(fldPath *field.Path, values map[string]string) field.ErrorList {
+	var el field.ErrorList
+
+	// Sort keys so that errors are deterministic.
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := values[key]
+		switch key {
+		case valueNameserver:
+			host, port, err := net.SplitHostPort(value)
+			if err != nil {
+				el = append(el, field.Invalid(fldPath.Key(key), value, "must be of the form <host>:<port>"))
+				break
+			}
+			if len(host) == 0 {
+				el = append(el, field.Invalid(fldPath.Key(key), value, "must define a host"))
+			}
+			if p, err := strconv.ParseUint(port, 10, 16); err != nil || p == 0 {
+				el = append(el, field.Invalid(fldPath.Key(key), value, "must define a port between 1 and 65535"))
+			}
+		case valueTimeout:
+			if d, err := time.ParseDuration(value); err != nil {
+				el = append(el, field.Invalid(fldPath.Key(key), value, err.Error()))
+			} else if d <= 0 {
+				el = append(el, field.Invalid(fldPath.Key(key), value, "must be greater than 0"))
+			}
+		default:
+			el = append(el, field.NotSupported(fldPath, key, []string{valueNameserver, valueTimeout}))
+		}
+	}
+
+	return el
+}
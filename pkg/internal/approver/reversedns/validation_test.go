@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reversedns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+func Test_Validate(t *testing.T) {
+	tests := map[string]struct {
+		policy      *policyapi.CertificateRequestPolicy
+		expResponse approver.WebhookValidationResponse
+	}{
+		"if policy doesn't configure plugin, expect a Allowed=true response": {
+			policy: &policyapi.CertificateRequestPolicy{},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: true,
+				Errors:  nil,
+			},
+		},
+		"if policy configures plugin with valid values, expect a Allowed=true response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+						Name: {Values: map[string]string{"nameserver": "kube-dns.kube-system.svc:53", "timeout": "2s"}},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: true,
+				Errors:  nil,
+			},
+		},
+		"if policy configures plugin with invalid values, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+						Name: {Values: map[string]string{"nameserver": "10.96.0.10", "timeout": "-1s", "foo": "bar"}},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.NotSupported(field.NewPath("spec.plugins.reverse-dns.values"), "foo", []string{"nameserver", "timeout"}),
+					field.Invalid(field.NewPath("spec.plugins.reverse-dns.values").Key("nameserver"), "10.96.0.10", "must be of the form <host>:<port>"),
+					field.Invalid(field.NewPath("spec.plugins.reverse-dns.values").Key("timeout"), "-1s", "must be greater than 0"),
+				},
+			},
+		},
+		"if policy configures plugin with an invalid nameserver port, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+						Name: {Values: map[string]string{"nameserver": ":dns"}},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.plugins.reverse-dns.values").Key("nameserver"), ":dns", "must define a host"),
+					field.Invalid(field.NewPath("spec.plugins.reverse-dns.values").Key("nameserver"), ":dns", "must define a port between 1 and 65535"),
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			response, err := Approver().Validate(context.TODO(), test.policy)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expResponse, response)
+		})
+	}
+}
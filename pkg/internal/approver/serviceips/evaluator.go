@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceips
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+// Evaluate denies requests containing IP SANs which are not an IP of a
+// Service in the namespace of the request. Policies which do not configure
+// the service-ips plugin are not evaluated.
+// An error signals that the policy couldn't be evaluated to completion.
+func (s *serviceips) Evaluate(ctx context.Context, policy *policyapi.CertificateRequestPolicy, request *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+	plugin, ok := policy.Spec.Plugins[Name]
+	if !ok {
+		return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
+	}
+
+	csr, err := utilpki.DecodeX509CertificateRequestBytes(request.Spec.Request)
+	if err != nil {
+		return approver.EvaluationResponse{}, err
+	}
+
+	// Nothing to check if no IP SANs have been requested.
+	if len(csr.IPAddresses) == 0 {
+		return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
+	}
+
+	serviceIPs, err := s.serviceIPs(ctx, request.Namespace, plugin.Values[valueAllowExternalIPs] == "true")
+	if err != nil {
+		return approver.EvaluationResponse{}, err
+	}
+
+	if serviceIPs.Len() == 0 && onNoServices(plugin.Values[valueOnNoServices]) == onNoServicesAllow {
+		return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
+	}
+
+	var (
+		el      field.ErrorList
+		fldPath = field.NewPath("spec", "plugins", Name)
+	)
+	for _, ip := range csr.IPAddresses {
+		if !serviceIPs.Has(ip.String()) {
+			el = append(el, field.Invalid(fldPath, ip.String(), fmt.Sprintf("IP address is not an IP of a Service in namespace %q", request.Namespace)))
+		}
+	}
+
+	if len(el) > 0 {
+		return approver.EvaluationResponse{Result: approver.ResultDenied, Message: el.ToAggregate().Error()}, nil
+	}
+
+	return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
+}
+
+// serviceIPs returns the set of normalised IPs belonging to Services in the
+// given namespace. ExternalIPs are only included if externalIPs is true,
+// since any user who can create a Service may set them to any IP.
+func (s *serviceips) serviceIPs(ctx context.Context, namespace string, externalIPs bool) (sets.Set[string], error) {
+	if s.lister == nil {
+		return nil, fmt.Errorf("%s %w", s.Name(), approver.ErrNotPrepared)
+	}
+
+	var services corev1.ServiceList
+	if err := s.lister.List(ctx, &services, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list Services in namespace %q: %w", namespace, err)
+	}
+
+	ips := sets.New[string]()
+	insert := func(ip string) {
+		if parsed := net.ParseIP(ip); parsed != nil {
+			ips.Insert(parsed.String())
+		}
+	}
+
+	for _, svc := range services.Items {
+		insert(svc.Spec.ClusterIP)
+		for _, ip := range svc.Spec.ClusterIPs {
+			insert(ip)
+		}
+		if externalIPs {
+			for _, ip := range svc.Spec.ExternalIPs {
+				insert(ip)
+			}
+		}
+		for _, ingress := range svc.Status.LoadBalancer.Ingress {
+			insert(ingress.IP)
+		}
+	}
+
+	return ips, nil
+}
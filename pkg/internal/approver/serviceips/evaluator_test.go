@@ -0,0 +1,193 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceips
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+func Test_Evaluate(t *testing.T) {
+	var (
+		services = []runtime.Object{
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "sandbox", Name: "app"},
+				Spec: corev1.ServiceSpec{
+					ClusterIP:  "10.96.0.10",
+					ClusterIPs: []string{"10.96.0.10", "fd00::a"},
+				},
+			},
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "sandbox", Name: "app-lb"},
+				Spec: corev1.ServiceSpec{
+					ExternalIPs: []string{"192.0.2.10"},
+				},
+				Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{
+					Ingress: []corev1.LoadBalancerIngress{{IP: "203.0.113.10"}, {Hostname: "lb.example.com"}},
+				}},
+			},
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "app"},
+				Spec:       corev1.ServiceSpec{ClusterIP: "10.96.0.20"},
+			},
+		}
+
+		pluginPolicy = func(values map[string]string) policyapi.CertificateRequestPolicySpec {
+			return policyapi.CertificateRequestPolicySpec{
+				Plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+					Name: {Values: values},
+				},
+			}
+		}
+
+		requestWithIPs = func(namespace string, ips ...string) *cmapi.CertificateRequest {
+			csr, _, err := gen.CSR(x509.ECDSA, gen.SetCSRIPAddressesFromStrings(ips...))
+			if err != nil {
+				t.Fatal(err)
+			}
+			return gen.CertificateRequest("", gen.SetCertificateRequestNamespace(namespace), gen.SetCertificateRequestCSR(csr))
+		}
+	)
+
+	tests := map[string]struct {
+		policy          policyapi.CertificateRequestPolicySpec
+		request         *cmapi.CertificateRequest
+		existingObjects []runtime.Object
+		expResponse     approver.EvaluationResponse
+		expErr          bool
+	}{
+		"if plugin not configured on policy, return NotDenied": {
+			policy:          policyapi.CertificateRequestPolicySpec{},
+			request:         requestWithIPs("sandbox", "1.1.1.1"),
+			existingObjects: services,
+			expResponse:     approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if request contains no IP SANs, return NotDenied": {
+			policy:          pluginPolicy(nil),
+			request:         requestWithIPs("sandbox"),
+			existingObjects: services,
+			expResponse:     approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if request IP SANs are all Service IPs in the request namespace, return NotDenied": {
+			policy:          pluginPolicy(nil),
+			request:         requestWithIPs("sandbox", "10.96.0.10", "fd00:0::a", "203.0.113.10"),
+			existingObjects: services,
+			expResponse:     approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if request IP SAN is only an ExternalIP of a Service and allowExternalIPs is unset, return Denied": {
+			policy:          pluginPolicy(nil),
+			request:         requestWithIPs("sandbox", "192.0.2.10"),
+			existingObjects: services,
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.plugins.service-ips"), "192.0.2.10", `IP address is not an IP of a Service in namespace "sandbox"`),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if request IP SAN is only an ExternalIP of a Service and allowExternalIPs is false, return Denied": {
+			policy:          pluginPolicy(map[string]string{"allowExternalIPs": "false"}),
+			request:         requestWithIPs("sandbox", "192.0.2.10"),
+			existingObjects: services,
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.plugins.service-ips"), "192.0.2.10", `IP address is not an IP of a Service in namespace "sandbox"`),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if request IP SAN is an ExternalIP of a Service and allowExternalIPs is true, return NotDenied": {
+			policy:          pluginPolicy(map[string]string{"allowExternalIPs": "true"}),
+			request:         requestWithIPs("sandbox", "10.96.0.10", "192.0.2.10"),
+			existingObjects: services,
+			expResponse:     approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if request IP SANs include IPs outside of the request namespace Services, return Denied": {
+			policy:          pluginPolicy(nil),
+			request:         requestWithIPs("sandbox", "10.96.0.10", "10.96.0.20", "1.1.1.1"),
+			existingObjects: services,
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.plugins.service-ips"), "10.96.0.20", `IP address is not an IP of a Service in namespace "sandbox"`),
+					field.Invalid(field.NewPath("spec.plugins.service-ips"), "1.1.1.1", `IP address is not an IP of a Service in namespace "sandbox"`),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if request namespace has no Services and onNoServices is unset, return Denied": {
+			policy:          pluginPolicy(nil),
+			request:         requestWithIPs("empty", "10.96.0.10"),
+			existingObjects: services,
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.plugins.service-ips"), "10.96.0.10", `IP address is not an IP of a Service in namespace "empty"`),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if request namespace has no Services and onNoServices is Allow, return NotDenied": {
+			policy:          pluginPolicy(map[string]string{"onNoServices": "Allow"}),
+			request:         requestWithIPs("empty", "10.96.0.10"),
+			existingObjects: services,
+			expResponse:     approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if request namespace has Services and onNoServices is Allow, return Denied for non Service IPs": {
+			policy:          pluginPolicy(map[string]string{"onNoServices": "Allow"}),
+			request:         requestWithIPs("other", "1.1.1.1"),
+			existingObjects: services,
+			expResponse: approver.EvaluationResponse{
+				Result: approver.ResultDenied,
+				Message: field.ErrorList{
+					field.Invalid(field.NewPath("spec.plugins.service-ips"), "1.1.1.1", `IP address is not an IP of a Service in namespace "other"`),
+				}.ToAggregate().Error(),
+			},
+		},
+		"if request fails to decode, return error": {
+			policy:      pluginPolicy(nil),
+			request:     gen.CertificateRequest("", gen.SetCertificateRequestCSR([]byte("bad-csr"))),
+			expResponse: approver.EvaluationResponse{},
+			expErr:      true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := &serviceips{
+				lister: fakeclient.NewClientBuilder().
+					WithScheme(policyapi.GlobalScheme).
+					WithRuntimeObjects(test.existingObjects...).
+					Build(),
+			}
+			response, err := s.Evaluate(context.TODO(), &policyapi.CertificateRequestPolicy{Spec: test.policy}, test.request)
+			assert.Equal(t, test.expErr, err != nil, "%v", err)
+			assert.Equal(t, test.expResponse, response, "unexpected evaluation response")
+		})
+	}
+}
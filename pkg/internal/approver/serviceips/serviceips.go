@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceips
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/registry"
+)
+
+const (
+	// Name is the name of the service-ips plugin, used as its key in
+	// CertificateRequestPolicy `spec.plugins`.
+	Name = "service-ips"
+
+	// valueOnNoServices is the plugin value key which controls how requests
+	// are evaluated when the request namespace has no Service IPs.
+	valueOnNoServices = "onNoServices"
+
+	// valueAllowExternalIPs is the plugin value key which, if "true", also
+	// considers the ExternalIPs of Services. ExternalIPs are set by whoever
+	// creates the Service, and so are not considered by default.
+	valueAllowExternalIPs = "allowExternalIPs"
+)
+
+// onNoServices is how IP SANs are evaluated when the request namespace has no
+// Service IPs.
+type onNoServices string
+
+const (
+	// onNoServicesDeny denies any requested IP SAN. This is the default.
+	onNoServicesDeny onNoServices = "Deny"
+
+	// onNoServicesAllow does not deny requested IP SANs.
+	onNoServicesAllow onNoServices = "Allow"
+)
+
+// Load the service-ips approver.
+func init() {
+	registry.Shared.Store(Approver())
+}
+
+// Approver returns an instance of the service-ips approver.
+func Approver() approver.Interface {
+	return &serviceips{}
+}
+
+// serviceips is an approver-policy plugin which denies requests for IP SANs
+// that do not belong to a Service in the namespace of the request. The
+// ClusterIPs and LoadBalancer ingress IPs of Services are considered, which
+// are allocated by the cluster rather than written by the Service creator.
+// ExternalIPs are only considered if the policy opts in with the
+// allowExternalIPs value.
+type serviceips struct {
+	// lister is used to list Services from the informer cache.
+	lister client.Reader
+
+	// informer is the Service informer, which must be synced before requests
+	// can be evaluated.
+	informer cache.Informer
+}
+
+var _ approver.StartupChecker = &serviceips{}
+
+// Name of Approver is "service-ips"
+func (s *serviceips) Name() string {
+	return Name
+}
+
+// RegisterFlags is a no-op, service-ips doesn't need any flags.
+func (s *serviceips) RegisterFlags(_ *pflag.FlagSet) {}
+
+// Prepare sets up the Service lister, and starts the Service informer so that
+// the cache is warm before the first evaluation.
+func (s *serviceips) Prepare(ctx context.Context, _ logr.Logger, mgr manager.Manager) error {
+	informer, err := mgr.GetCache().GetInformer(ctx, new(corev1.Service))
+	if err != nil {
+		return fmt.Errorf("failed to get Service informer: %w", err)
+	}
+	s.informer = informer
+	s.lister = mgr.GetCache()
+	return nil
+}
+
+// StartupCheck returns an error until the Service informer has synced, since
+// Service IPs can't be listed from a cold cache.
+func (s *serviceips) StartupCheck(_ context.Context) error {
+	if s.informer == nil || !s.informer.HasSynced() {
+		return errors.New("waiting for Service informer to sync")
+	}
+	return nil
+}
+
+// Ready always returns ready, service-ips doesn't have any dependencies to
+// block readiness.
+func (s *serviceips) Ready(_ context.Context, _ *policyapi.CertificateRequestPolicy) (approver.ReconcilerReadyResponse, error) {
+	return approver.ReconcilerReadyResponse{Ready: true}, nil
+}
+
+// service-ips never needs to manually enqueue policies.
+func (s *serviceips) EnqueueChan() <-chan string {
+	return nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceips
+
+import (
+	"context"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+// Validate validates that the service-ips plugin values of the processed
+// CertificateRequestPolicy are known and valid.
+func (s *serviceips) Validate(_ context.Context, policy *policyapi.CertificateRequestPolicy) (approver.WebhookValidationResponse, error) {
+	plugin, ok := policy.Spec.Plugins[Name]
+	if !ok {
+		return approver.WebhookValidationResponse{
+			Allowed: true,
+			Errors:  nil,
+		}, nil
+	}
+
+	var (
+		el      field.ErrorList
+		fldPath = field.NewPath("spec", "plugins", Name, "values")
+	)
+
+	// Sort keys so that errors are deterministic.
+	keys := make([]string, 0, len(plugin.Values))
+	for key := range plugin.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := plugin.Values[key]
+		switch key {
+		case valueOnNoServices:
+			switch onNoServices(value) {
+			case onNoServicesDeny, onNoServicesAllow:
+			default:
+				el = append(el, field.NotSupported(fldPath.Key(key), value, []string{string(onNoServicesDeny), string(onNoServicesAllow)}))
+			}
+		case valueAllowExternalIPs:
+			if value != "true" && value != "false" {
+				el = append(el, field.NotSupported(fldPath.Key(key), value, []string{"true", "false"}))
+			}
+		default:
+			el = append(el, field.NotSupported(fldPath, key, []string{valueAllowExternalIPs, valueOnNoServices}))
+		}
+	}
+
+	return approver.WebhookValidationResponse{
+		Allowed: len(el) == 0,
+		Errors:  el,
+	}, nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+)
+
+// Expression knows how to evaluate a CEL expression which outputs a string
+// in the context of a CertificateRequest, i.e. to derive the name of an
+// issuer from the namespace of a request.
+// Expression is stateless, thread-safe, and cacheable.
+type Expression interface {
+	// Evaluate returns the string value of the CEL expression in the context
+	// of the request.
+	// Returned errors should be considered as internal/technical errors,
+	// and should NOT be returned unprocessed to end-users of the API.
+	Evaluate(request cmapi.CertificateRequest) (string, error)
+}
+
+type expression struct {
+	expression string
+	program    cel.Program
+}
+
+func (e *expression) compile() error {
+	if e.program != nil {
+		// Already compiled
+		return nil
+	}
+
+	env, err := cel.NewEnv(
+		cel.Types(&CertificateRequest{}),
+		cel.Variable(varRequest, cel.ObjectType("cm.io.policy.pkg.internal.approver.validation.CertificateRequest")),
+		ext.Strings(),
+		ServiceAccountLib(),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	ast, iss := env.Compile(e.expression)
+	if iss.Err() != nil {
+		return compileError(env, e.expression, iss, requestVariables...)
+	}
+	if !reflect.DeepEqual(ast.OutputType(), cel.StringType) {
+		return fmt.Errorf(
+			"got %v, wanted %v result type", ast.OutputType(), cel.StringType)
+	}
+
+	e.program, err = env.Program(ast)
+	return err
+}
+
+func (e *expression) Evaluate(request cmapi.CertificateRequest) (string, error) {
+	if e.program == nil {
+		return "", errors.New("must compile first")
+	}
+
+	vars := map[string]interface{}{
+		varRequest: NewCELRequest(request),
+	}
+
+	out, _, err := e.program.Eval(vars)
+	if err != nil {
+		return "", err
+	}
+
+	return out.Value().(string), nil
+}
+
+// ExpressionCache maintains a cache of compiled expressions, in the same way
+// as Cache does for validators.
+type ExpressionCache interface {
+	// Get returns a compiled expression for the supplied CEL expression.
+	// Any compilation errors will be returned to the caller.
+	//
+	// The supplied CEL expression must output a string.
+	Get(expr string) (Expression, error)
+}
+
+type expressionCache struct {
+	m sync.Map
+}
+
+type expressionCacheEntry struct {
+	expression *expression
+	err        error
+}
+
+func (c *expressionCache) Get(expr string) (Expression, error) {
+	if o, ok := c.m.Load(expr); ok {
+		ce := o.(*expressionCacheEntry)
+		return ce.expression, ce.err
+	}
+
+	e := &expression{expression: expr}
+	err := e.compile()
+	if err != nil {
+		e = nil
+	}
+	o, _ := c.m.LoadOrStore(expr, &expressionCacheEntry{expression: e, err: err})
+	ce := o.(*expressionCacheEntry)
+	return ce.expression, ce.err
+}
+
+// NewExpressionCache is a constructor for cache of compiled CEL expressions
+// which output a string.
+func NewExpressionCache() ExpressionCache {
+	return &expressionCache{}
+}
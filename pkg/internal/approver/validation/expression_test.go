@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Expression_Compile(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr bool
+	}{
+		{name: "namespace-suffix", expr: "cr.namespace + '-issuer'"},
+		{name: "extended-string-function-library", expr: "'issuer-%s'.format([cr.namespace])"},
+		{name: "serviceaccount-namespace", expr: "serviceAccount(cr.username).getNamespace()"},
+		{name: "err-no-expression", wantErr: true},
+		{name: "err-self-undeclared", expr: "self", wantErr: true},
+		{name: "err-must-return-string", expr: "size(cr.namespace) < 24", wantErr: true},
+		{name: "err-invalid-property", expr: "cr.foo", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &expression{expression: tt.expr}
+			err := e.compile()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_Expression_Evaluate(t *testing.T) {
+	e := &expression{expression: "cr.namespace + '-issuer'"}
+	assert.NoError(t, e.compile())
+
+	tests := []struct {
+		name string
+		cr   cmapi.CertificateRequest
+		want string
+	}{
+		{name: "namespace", cr: newCertificateRequest("foo-ns"), want: "foo-ns-issuer"},
+		{name: "empty-namespace", cr: newCertificateRequest(""), want: "-issuer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := e.Evaluate(tt.cr)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ExpressionCache_Get(t *testing.T) {
+	c := NewExpressionCache()
+
+	got, err := c.Get("cr.namespace")
+	assert.NoError(t, err)
+	// Cache should return same expression for same expression
+	same, _ := c.Get("cr.namespace")
+	assert.Same(t, got, same)
+
+	_, err = c.Get("foo")
+	assert.Error(t, err)
+	// Cache should return same error for same expression
+	_, sameErr := c.Get("foo")
+	assert.Same(t, err, sameErr)
+}
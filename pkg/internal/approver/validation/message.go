@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+)
+
+// MessageExpression knows how to evaluate a CEL expression which outputs the
+// message of a failed validation, in the context of the validated value and
+// the CertificateRequest.
+// MessageExpression is stateless, thread-safe, and cacheable.
+type MessageExpression interface {
+	// Evaluate returns the message for the supplied value in the context of
+	// the request, as returned by NewCELRequest.
+	// An error is returned if the expression fails to evaluate, or evaluates
+	// to an empty message or one containing line breaks.
+	Evaluate(value string, request *CertificateRequest) (string, error)
+}
+
+type messageExpression struct {
+	expression string
+	program    cel.Program
+}
+
+func (m *messageExpression) compile() error {
+	if m.program != nil {
+		// Already compiled
+		return nil
+	}
+
+	env, err := cel.NewEnv(
+		cel.Types(&CertificateRequest{}),
+		cel.Variable(varSelf, cel.StringType),
+		cel.Variable(varRequest, cel.ObjectType("cm.io.policy.pkg.internal.approver.validation.CertificateRequest")),
+		ext.Strings(),
+		ServiceAccountLib(),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	ast, iss := env.Compile(m.expression)
+	if iss.Err() != nil {
+		return compileError(env, m.expression, iss, append([]string{varSelf}, requestVariables...)...)
+	}
+	if !reflect.DeepEqual(ast.OutputType(), cel.StringType) {
+		return fmt.Errorf(
+			"got %v, wanted %v result type", ast.OutputType(), cel.StringType)
+	}
+
+	m.program, err = env.Program(ast)
+	return err
+}
+
+func (m *messageExpression) Evaluate(value string, request *CertificateRequest) (string, error) {
+	if m.program == nil {
+		return "", errors.New("must compile first")
+	}
+
+	vars := map[string]interface{}{
+		varSelf:    value,
+		varRequest: request,
+	}
+
+	out, _, err := m.program.Eval(vars)
+	if err != nil {
+		return "", err
+	}
+
+	// Mirror CRD validation's messageExpression: the message must be a
+	// single, non-empty line.
+	message := strings.TrimSpace(out.Value().(string))
+	if len(message) == 0 {
+		return "", errors.New("message expression evaluated to an empty string")
+	}
+	if strings.ContainsAny(message, "\r\n") {
+		return "", errors.New("message expression evaluated to a string containing line breaks")
+	}
+
+	return message, nil
+}
+
+// MessageCache maintains a cache of compiled message expressions, in the same
+// way as Cache does for validators.
+type MessageCache interface {
+	// Get returns a compiled message expression for the supplied CEL
+	// expression.
+	// Any compilation errors will be returned to the caller.
+	//
+	// The supplied CEL expression must output a string.
+	Get(expr string) (MessageExpression, error)
+}
+
+type messageCache struct {
+	m sync.Map
+}
+
+type messageCacheEntry struct {
+	expression *messageExpression
+	err        error
+}
+
+func (c *messageCache) Get(expr string) (MessageExpression, error) {
+	if o, ok := c.m.Load(expr); ok {
+		ce := o.(*messageCacheEntry)
+		return ce.expression, ce.err
+	}
+
+	m := &messageExpression{expression: expr}
+	err := m.compile()
+	if err != nil {
+		m = nil
+	}
+	o, _ := c.m.LoadOrStore(expr, &messageCacheEntry{expression: m, err: err})
+	ce := o.(*messageCacheEntry)
+	return ce.expression, ce.err
+}
+
+// NewMessageCache is a constructor for cache of compiled CEL expressions
+// which output the message of a failed validation.
+func NewMessageCache() MessageCache {
+	return &messageCache{}
+}
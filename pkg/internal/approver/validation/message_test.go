@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MessageExpression_Compile(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr bool
+	}{
+		{name: "self-and-namespace", expr: "'DNS name ' + self + ' must end in ' + cr.namespace + '.svc'"},
+		{name: "extended-string-function-library", expr: "'%s must end in %s.svc'.format([self, cr.namespace])"},
+		{name: "err-no-expression", wantErr: true},
+		{name: "err-must-return-string", expr: "self.endsWith(cr.namespace)", wantErr: true},
+		{name: "err-invalid-property", expr: "cr.foo", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &messageExpression{expression: tt.expr}
+			err := m.compile()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_MessageExpression_Evaluate(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "self-and-namespace",
+			expr:  "'DNS name ' + self + ' must end in ' + cr.namespace + '.svc'",
+			value: "foo.example.com",
+			want:  "DNS name foo.example.com must end in foo-ns.svc",
+		},
+		{
+			name:  "surrounding-whitespace-trimmed",
+			expr:  "' ' + self + ' '",
+			value: "foo",
+			want:  "foo",
+		},
+		{
+			name:    "err-empty-message",
+			expr:    "self",
+			value:   "",
+			wantErr: true,
+		},
+		{
+			name:    "err-line-breaks",
+			expr:    "'foo\\nbar'",
+			wantErr: true,
+		},
+		{
+			name:    "err-evaluation",
+			expr:    "self.substring(10)",
+			value:   "foo",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &messageExpression{expression: tt.expr}
+			assert.NoError(t, m.compile())
+
+			got, err := m.Evaluate(tt.value, NewCELRequest(newCertificateRequest("foo-ns")))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_MessageCache_Get(t *testing.T) {
+	c := NewMessageCache()
+
+	got, err := c.Get("self")
+	assert.NoError(t, err)
+	// Cache should return same expression for same expression
+	same, _ := c.Get("self")
+	assert.Same(t, got, same)
+
+	_, err = c.Get("foo")
+	assert.Error(t, err)
+	// Cache should return same error for same expression
+	_, sameErr := c.Get("foo")
+	assert.Same(t, err, sameErr)
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// NewCELRequest returns the value of the `cr` variable of CEL
+// expressions for the given request. It should be built once per evaluation
+// of a request, and passed to each of the expressions evaluated against it.
+// The duration is in seconds, and zero if the request doesn't specify one.
+// The key algorithm and size are derived from the public key of the CSR, and
+// are empty if the CSR cannot be parsed. The size of Ed25519 keys, which is
+// fixed, is zero.
+func NewCELRequest(request cmapi.CertificateRequest) *CertificateRequest {
+	cr := &CertificateRequest{
+		Name:      request.GetName(),
+		Namespace: request.GetNamespace(),
+		Username:  request.Spec.Username,
+		IsCA:      request.Spec.IsCA,
+	}
+
+	if request.Spec.Duration != nil {
+		cr.Duration = int64(request.Spec.Duration.Duration.Seconds())
+	}
+
+	csr, err := utilpki.DecodeX509CertificateRequestBytes(request.Spec.Request)
+	if err != nil {
+		return cr
+	}
+
+	switch pub := csr.PublicKey.(type) {
+	case *rsa.PublicKey:
+		cr.KeyAlgorithm, cr.KeySize = string(cmapi.RSAKeyAlgorithm), int64(pub.N.BitLen())
+	case *ecdsa.PublicKey:
+		cr.KeyAlgorithm, cr.KeySize = string(cmapi.ECDSAKeyAlgorithm), int64(pub.Curve.Params().BitSize)
+	case ed25519.PublicKey:
+		cr.KeyAlgorithm = string(cmapi.Ed25519KeyAlgorithm)
+	}
+
+	return cr
+}
@@ -20,10 +20,12 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 
-	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/google/cel-go/cel"
+	celast "github.com/google/cel-go/common/ast"
 	"github.com/google/cel-go/ext"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 const (
@@ -31,18 +33,56 @@ const (
 	varRequest = "cr"
 )
 
+// requestVariables are the fields of the CertificateRequest which may be
+// referenced from CEL expressions.
+var requestVariables = []string{
+	varRequest + ".name", varRequest + ".namespace", varRequest + ".username",
+	varRequest + ".duration", varRequest + ".keyAlgorithm", varRequest + ".keySize", varRequest + ".isCA",
+}
+
+// compileError returns the error of the given compilation issues of the
+// expression. If any issue is a reference to an undeclared variable, the
+// variables available to the expression are listed after the issues.
+// References to undeclared functions are left as reported by CEL.
+func compileError(env *cel.Env, expression string, iss *cel.Issues, vars ...string) error {
+	idents := identExprIDs(env, expression)
+	for _, e := range iss.Errors() {
+		if strings.HasPrefix(e.Message, "undeclared reference") && idents.Has(e.ExprID) {
+			return fmt.Errorf("%w\nonly the variables %s may be referenced", iss.Err(), strings.Join(vars, ", "))
+		}
+	}
+	return iss.Err()
+}
+
+// identExprIDs returns the IDs of the identifiers in the parsed expression,
+// i.e. references to variables rather than to functions.
+func identExprIDs(env *cel.Env, expression string) sets.Set[int64] {
+	ids := sets.New[int64]()
+	ast, iss := env.Parse(expression)
+	if iss.Err() != nil {
+		return ids
+	}
+	celast.PreOrderVisit(ast.NativeRep().Expr(), celast.NewExprVisitor(func(e celast.Expr) {
+		if e.Kind() == celast.IdentKind {
+			ids.Insert(e.ID())
+		}
+	}))
+	return ids
+}
+
 // Validator knows how to validate CSR attribute values in CertificateRequests
 // against CEL expressions declared in CertificateRequestPolicy.
 // Validator is stateless, thread-safe, and cacheable.
 type Validator interface {
 	// Validate validates the supplied value against the Validator CEL
-	// expression in the context of the request.
+	// expression in the context of the request, as returned by
+	// NewCELRequest.
 	// Returns 'true' if the value is valid (passes validation).
 	// Returned errors should be considered as internal/technical errors,
 	// and should NOT be returned unprocessed to end-users of the API.
 	// CEL program errors are usually not very human-readable and require
 	// knowledge of how CEL works and is used.
-	Validate(value string, request cmapi.CertificateRequest) (bool, error)
+	Validate(value string, request *CertificateRequest) (bool, error)
 }
 
 type validator struct {
@@ -70,7 +110,7 @@ func (v *validator) compile() error {
 
 	ast, iss := env.Compile(v.expression)
 	if iss.Err() != nil {
-		return iss.Err()
+		return compileError(env, v.expression, iss, append([]string{varSelf}, requestVariables...)...)
 	}
 	if !reflect.DeepEqual(ast.OutputType(), cel.BoolType) {
 		return fmt.Errorf(
@@ -81,18 +121,14 @@ func (v *validator) compile() error {
 	return err
 }
 
-func (v *validator) Validate(value string, request cmapi.CertificateRequest) (bool, error) {
+func (v *validator) Validate(value string, request *CertificateRequest) (bool, error) {
 	if v.program == nil {
 		return false, errors.New("must compile first")
 	}
 
 	vars := map[string]interface{}{
-		varSelf: value,
-		varRequest: &CertificateRequest{
-			Name:      request.GetName(),
-			Namespace: request.GetNamespace(),
-			Username:  request.Spec.Username,
-		},
+		varSelf:    value,
+		varRequest: request,
 	}
 
 	out, _, err := v.program.Eval(vars)
@@ -17,10 +17,14 @@ limitations under the License.
 package validation
 
 import (
+	"crypto/x509"
 	"testing"
+	"time"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func Test_Validator_Compile(t *testing.T) {
@@ -42,6 +46,9 @@ func Test_Validator_Compile(t *testing.T) {
 		{name: "check-serviceaccount-getname", expr: "self.startsWith(serviceAccount(cr.username).getName())", wantErr: false},
 		{name: "check-serviceaccount-getnamespace", expr: "self.startsWith(serviceAccount(cr.username).getNamespace())", wantErr: false},
 		{name: "check-serviceaccount-isSA", expr: "isServiceAccount(cr.username)", wantErr: false},
+		{name: "check-key-and-duration-properties", expr: "cr.keyAlgorithm == 'RSA' && cr.keySize >= 4096 || cr.duration <= 7776000", wantErr: false},
+		{name: "check-isca-property", expr: "!cr.isCA", wantErr: false},
+		{name: "err-duration-is-int", expr: "cr.duration == '90d'", wantErr: true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -78,7 +85,7 @@ func Test_Validator_Validate(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := v.Validate(tt.args.val, tt.args.cr)
+			got, err := v.Validate(tt.args.val, NewCELRequest(tt.args.cr))
 			if tt.wantErr {
 				assert.Error(t, err)
 				return
@@ -90,6 +97,41 @@ func Test_Validator_Validate(t *testing.T) {
 	}
 }
 
+func Test_Validator_Validate_Request(t *testing.T) {
+	v := &validator{expression: "'%s/%d/%d/%s'.format([cr.keyAlgorithm, cr.keySize, cr.duration, cr.isCA]) == self"}
+	assert.NoError(t, v.compile())
+
+	tests := []struct {
+		name string
+		val  string
+		cr   cmapi.CertificateRequest
+	}{
+		{name: "rsa", val: "RSA/2048/3600/true", cr: newCertificateRequestWithKey(t, x509.RSA, &metav1.Duration{Duration: time.Hour}, true)},
+		{name: "ecdsa", val: "ECDSA/256/86400/false", cr: newCertificateRequestWithKey(t, x509.ECDSA, &metav1.Duration{Duration: time.Hour * 24}, false)},
+		{name: "ed25519-no-duration", val: "Ed25519/0/0/false", cr: newCertificateRequestWithKey(t, x509.Ed25519, nil, false)},
+		{name: "invalid-csr", val: "/0/3600/false", cr: cmapi.CertificateRequest{Spec: cmapi.CertificateRequestSpec{
+			Request:  []byte("not a csr"),
+			Duration: &metav1.Duration{Duration: time.Hour},
+		}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := v.Validate(tt.val, NewCELRequest(tt.cr))
+			assert.NoError(t, err)
+			assert.True(t, got, "expected cr to format as %q", tt.val)
+		})
+	}
+}
+
+func newCertificateRequestWithKey(t *testing.T, alg x509.PublicKeyAlgorithm, duration *metav1.Duration, isCA bool) cmapi.CertificateRequest {
+	t.Helper()
+	csr, _, err := gen.CSR(alg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cmapi.CertificateRequest{Spec: cmapi.CertificateRequestSpec{Request: csr, Duration: duration, IsCA: isCA}}
+}
+
 func newCertificateRequest(namespace string) cmapi.CertificateRequest {
 	request := cmapi.CertificateRequest{}
 	request.SetNamespace(namespace)
@@ -120,7 +162,7 @@ func Test_Validator_Validate_ServiceAccount(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := v.Validate(tt.args.val, tt.args.cr)
+			got, err := v.Validate(tt.args.val, NewCELRequest(tt.args.cr))
 			if tt.wantErr {
 				assert.Error(t, err)
 				return
@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+
+	"github.com/cert-manager/approver-policy/pkg/approver/manager"
+)
+
+// StdoutPath is the audit log path which writes the audit log to stdout,
+// rather than to a file.
+const StdoutPath = "-"
+
+// Logger writes a JSON line for every approve or deny decision made by
+// approver-policy. Unlike Kubernetes Events, which expire, the audit log is a
+// lasting record of every decision along with the context of the request.
+type Logger struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+// Record is a single line of the audit log.
+type Record struct {
+	// Timestamp is the time at which the decision was made.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Request identifies the CertificateRequest which was reviewed.
+	Request Request `json:"request"`
+
+	// Result is the decision, either "approved" or "denied".
+	Result string `json:"result"`
+
+	// Message is the message of the decision, as written to the Approved or
+	// Denied condition of the request.
+	Message string `json:"message"`
+
+	// Policies are the names of the CertificateRequestPolicies which resulted
+	// in the decision.
+	Policies []string `json:"policies"`
+
+	// Denials are the policies which denied the request, along with the
+	// messages and reasons of their evaluators. Only set for denied requests.
+	Denials []manager.Denial `json:"denials,omitempty"`
+}
+
+// Request identifies a reviewed CertificateRequest and its requester.
+type Request struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	UID       string `json:"uid"`
+	Username  string `json:"username"`
+}
+
+// New returns a Logger which writes to w.
+func New(w io.Writer) *Logger {
+	return &Logger{w: w}
+}
+
+// Open returns a Logger which appends to the file at path, creating it if it
+// doesn't exist. The path StdoutPath writes to stdout instead.
+func Open(path string) (*Logger, error) {
+	if path == StdoutPath {
+		return New(os.Stdout), nil
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	return &Logger{w: f, closer: f}, nil
+}
+
+// Record writes a line for the given decision on the request. A nil Logger
+// records nothing.
+func (l *Logger) Record(now time.Time, cr *cmapi.CertificateRequest, result string, response manager.ReviewResponse) error {
+	if l == nil {
+		return nil
+	}
+
+	line, err := json.Marshal(Record{
+		Timestamp: now.UTC(),
+		Request: Request{
+			Name:      cr.Name,
+			Namespace: cr.Namespace,
+			UID:       string(cr.UID),
+			Username:  cr.Spec.Username,
+		},
+		Result:   result,
+		Message:  response.Message,
+		Policies: response.Policies,
+		Denials:  response.Denials,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+
+	// Lines must be written whole, since requests are reconciled
+	// concurrently.
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.w.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes the audit log file. Closing a Logger which writes to stdout,
+// or a nil Logger, does nothing.
+func (l *Logger) Close() error {
+	if l == nil || l.closer == nil {
+		return nil
+	}
+	return l.closer.Close()
+}
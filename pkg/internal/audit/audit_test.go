@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/approver/manager"
+)
+
+func Test_Logger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	// Existing lines must be kept.
+	require.NoError(t, os.WriteFile(path, []byte("{}\n"), 0o600))
+
+	l, err := Open(path)
+	require.NoError(t, err)
+
+	cr := &cmapi.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cr", Namespace: "test-ns", UID: "test-uid"},
+		Spec:       cmapi.CertificateRequestSpec{Username: "test-user"},
+	}
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	require.NoError(t, l.Record(now, cr, "approved", manager.ReviewResponse{
+		Result:   manager.ResultApproved,
+		Message:  `Approved by CertificateRequestPolicy: "allow"`,
+		Policies: []string{"allow"},
+	}))
+	require.NoError(t, l.Record(now, cr, "denied", manager.ReviewResponse{
+		Result:   manager.ResultDenied,
+		Message:  "No policy approved this request: [deny: spec.allowed.commonName.value: Invalid value: \"foo\": bar]",
+		Policies: []string{"deny"},
+		Denials: []manager.Denial{{
+			Policy:  "deny",
+			Errors:  []string{`spec.allowed.commonName.value: Invalid value: "foo": bar`},
+			Reasons: []approver.DenialReason{{Field: "spec.allowed.commonName.value", Reason: approver.ReasonCommonNameNotAllowed}},
+		}},
+	}))
+	require.NoError(t, l.Close())
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `{}
+{"timestamp":"2024-01-02T03:04:05Z","request":{"name":"test-cr","namespace":"test-ns","uid":"test-uid","username":"test-user"},"result":"approved","message":"Approved by CertificateRequestPolicy: \"allow\"","policies":["allow"]}
+{"timestamp":"2024-01-02T03:04:05Z","request":{"name":"test-cr","namespace":"test-ns","uid":"test-uid","username":"test-user"},"result":"denied","message":"No policy approved this request: [deny: spec.allowed.commonName.value: Invalid value: \"foo\": bar]","policies":["deny"],"denials":[{"policy":"deny","errors":["spec.allowed.commonName.value: Invalid value: \"foo\": bar"],"reasons":[{"reason":"CommonNameNotAllowed","field":"spec.allowed.commonName.value"}]}]}
+`, string(b))
+}
+
+func Test_LoggerNil(t *testing.T) {
+	var l *Logger
+	assert.NoError(t, l.Record(time.Now(), &cmapi.CertificateRequest{}, "approved", manager.ReviewResponse{}))
+	assert.NoError(t, l.Close())
+}
@@ -19,20 +19,30 @@ package cmd
 import (
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"net/http"
+	"slices"
 
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	servertls "github.com/cert-manager/cert-manager/pkg/server/tls"
 	"github.com/cert-manager/cert-manager/pkg/server/tls/authority"
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver/remote"
+	"github.com/cert-manager/approver-policy/pkg/internal/audit"
 	"github.com/cert-manager/approver-policy/pkg/internal/cmd/options"
 	"github.com/cert-manager/approver-policy/pkg/internal/controllers"
 	"github.com/cert-manager/approver-policy/pkg/internal/metrics"
+	"github.com/cert-manager/approver-policy/pkg/internal/readiness"
+	"github.com/cert-manager/approver-policy/pkg/internal/reloader"
+	"github.com/cert-manager/approver-policy/pkg/internal/tracing"
 	"github.com/cert-manager/approver-policy/pkg/internal/webhook"
 	"github.com/cert-manager/approver-policy/pkg/registry"
 )
@@ -58,8 +68,6 @@ func NewCommand(ctx context.Context) *cobra.Command {
 
 			mlog := opts.Logr.WithName("controller-manager")
 
-			ctrl.SetLogger(mlog)
-
 			certificateSource := &servertls.DynamicSource{
 				DNSNames: []string{fmt.Sprintf("%s.%s.svc", opts.Webhook.ServiceName, opts.Webhook.CASecretNamespace)},
 				Authority: &authority.DynamicAuthority{
@@ -71,6 +79,19 @@ func NewCommand(ctx context.Context) *cobra.Command {
 				},
 			}
 
+			metricsOptions := server.Options{
+				BindAddress: opts.MetricsAddress,
+			}
+			if opts.MetricsExemplars {
+				// Exemplars are only exposed in the OpenMetrics format.
+				metricsOptions.FilterProvider = metrics.OpenMetricsFilterProvider
+			}
+			if opts.PluginsEndpoint {
+				metricsOptions.ExtraHandlers = map[string]http.Handler{
+					"/plugins": pluginsHandler(registry.Shared),
+				}
+			}
+
 			mgr, err := ctrl.NewManager(opts.RestConfig, ctrl.Options{
 				Scheme:                        policyapi.GlobalScheme,
 				LeaderElection:                true,
@@ -80,9 +101,7 @@ func NewCommand(ctx context.Context) *cobra.Command {
 				LeaderElectionNamespace:       opts.LeaderElectionNamespace,
 				ReadinessEndpointName:         "/readyz",
 				HealthProbeBindAddress:        opts.ReadyzAddress,
-				Metrics: server.Options{
-					BindAddress: opts.MetricsAddress,
-				},
+				Metrics:                       metricsOptions,
 				WebhookServer: ctrlwebhook.NewServer(ctrlwebhook.Options{
 					Port: opts.Webhook.Port,
 					Host: opts.Webhook.Host,
@@ -102,12 +121,25 @@ func NewCommand(ctx context.Context) *cobra.Command {
 				return err
 			}
 
+			// Remote plugins must be registered before anything consumes the
+			// registry.
+			for _, name := range slices.Sorted(maps.Keys(opts.RemotePlugins)) {
+				log.Info("registering remote plugin...", "plugin", name, "address", opts.RemotePlugins[name])
+				if err := remote.Register(registry.Shared, name, opts.RemotePlugins[name]); err != nil {
+					return fmt.Errorf("failed to register remote plugin: %w", err)
+				}
+			}
+			log.Info("registered approvers", "approvers", registry.Shared.List())
+
 			metrics.RegisterMetrics(ctx, opts.Logr.WithName("metrics"), mgr.GetCache())
+			reviewMetrics := metrics.RegisterReviewMetrics(opts.MetricsExemplars)
+			policyMetrics := metrics.RegisterPolicyMetrics()
 
 			if err := webhook.Register(ctx, webhook.Options{
-				Log:      opts.Logr,
-				Webhooks: registry.Shared.Webhooks(),
-				Manager:  mgr,
+				Log:                    opts.Logr,
+				Webhooks:               registry.Shared.Webhooks(),
+				Manager:                mgr,
+				DenySolePolicyDeletion: opts.Webhook.DenySolePolicyDeletion,
 			}); err != nil {
 				return fmt.Errorf("failed to register webhook: %w", err)
 			}
@@ -121,11 +153,62 @@ func NewCommand(ctx context.Context) *cobra.Command {
 			}
 			log.Info("all approvers ready...")
 
+			// Report ready only once every approver is able to evaluate
+			// requests, in addition to the webhook serving.
+			if err := mgr.AddReadyzCheck("approvers", readiness.New(registry.Shared.StartupCheckers()).Check); err != nil {
+				return fmt.Errorf("failed to add approvers readyz check: %w", err)
+			}
+
+			configReloader := reloader.New(opts.Logr.WithName("reloader"), mgr.GetCache(), registry.Shared.Reloaders(), opts.ConfigReloadInterval)
+			if err := mgr.Add(configReloader); err != nil {
+				return fmt.Errorf("failed to add configuration reloader: %w", err)
+			}
+
+			// Reviews are only traced if a collector has been configured.
+			var tracerProvider trace.TracerProvider
+			if len(opts.TracingEndpoint) > 0 {
+				sdkTracerProvider, err := tracing.New(ctx, opts.TracingEndpoint)
+				if err != nil {
+					return err
+				}
+				defer func() {
+					// Use a fresh context since ctx is cancelled on shutdown.
+					if err := sdkTracerProvider.Shutdown(context.Background()); err != nil {
+						log.Error(err, "failed to flush trace spans")
+					}
+				}()
+				tracerProvider = sdkTracerProvider
+			}
+
+			auditLog, err := openAuditLog(opts.AuditLogPath)
+			if err != nil {
+				return err
+			}
+			defer auditLog.Close()
+
 			if err := controllers.AddControllers(ctx, controllers.Options{
-				Log:         opts.Logr.WithName("controller"),
-				Manager:     mgr,
-				Evaluators:  registry.Shared.Evaluators(),
-				Reconcilers: registry.Shared.Reconcilers(),
+				Log:                         opts.Logr.WithName("controller"),
+				Manager:                     mgr,
+				Evaluators:                  registry.Shared.Evaluators(),
+				Reconcilers:                 registry.Shared.Reconcilers(),
+				EnqueueChans:                []<-chan string{configReloader.EnqueueChan()},
+				ReviewMetrics:               reviewMetrics,
+				PolicyMetrics:               policyMetrics,
+				AuditLog:                    auditLog,
+				TracerProvider:              tracerProvider,
+				BaselinePolicy:              opts.BaselinePolicy,
+				MaxPoliciesPerRequest:       opts.MaxPoliciesPerRequest,
+				PolicyEvaluationConcurrency: opts.PolicyEvaluationConcurrency,
+				RequireExplicitSelectors:    opts.RequireExplicitSelectors,
+				DenyOnNoApplicablePolicy:    opts.DenyOnNoApplicablePolicy,
+				DependencyResyncPeriod:      opts.DependencyResyncPeriod,
+				PendingRequeueInterval:      opts.PendingRequeueInterval,
+				PendingTimeout:              opts.PendingTimeout,
+				DenialsAnnotation:           opts.DenialsAnnotation,
+				DebugReviews:                opts.DebugReviews,
+				EvaluatorTimeout:            opts.EvaluatorTimeout,
+				CircuitBreakerThreshold:     opts.CircuitBreakerThreshold,
+				CircuitBreakerCooldown:      opts.CircuitBreakerCooldown,
 			}); err != nil {
 				return fmt.Errorf("failed to add controllers: %w", err)
 			}
@@ -139,3 +222,23 @@ func NewCommand(ctx context.Context) *cobra.Command {
 
 	return cmd
 }
+
+// openAuditLog opens the audit log at the given path. Returns a nil Logger,
+// which records nothing, if path is empty.
+func openAuditLog(path string) (*audit.Logger, error) {
+	if len(path) == 0 {
+		return nil, nil
+	}
+	return audit.Open(path)
+}
+
+// pluginsHandler returns an HTTP handler which responds with the names of the
+// approvers registered to the given registry as a JSON list.
+func pluginsHandler(r *registry.Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(r.List()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
@@ -30,6 +30,7 @@ import (
 	"k8s.io/client-go/rest"
 	cliflag "k8s.io/component-base/cli/flag"
 	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/cert-manager/approver-policy/pkg/approver"
 
@@ -50,6 +51,90 @@ type Options struct {
 	// disable exposing metrics.
 	MetricsAddress string
 
+	// MetricsExemplars enables attaching the trace ID of a review to its
+	// latency observation as an OpenMetrics exemplar. Requires tracing to be
+	// enabled.
+	MetricsExemplars bool
+
+	// TracingEndpoint is the address of an OTLP gRPC collector which spans of
+	// CertificateRequest reviews are exported to. Empty disables tracing.
+	TracingEndpoint string
+
+	// PluginsEndpoint enables serving the names of the registered approvers
+	// on the metrics server.
+	PluginsEndpoint bool
+
+	// BaselinePolicy is the name of a CertificateRequestPolicy which every
+	// request must pass in addition to being approved by a selected policy.
+	BaselinePolicy string
+
+	// MaxPoliciesPerRequest is the maximum number of selected
+	// CertificateRequestPolicies evaluated for a single request. Zero means
+	// no limit.
+	MaxPoliciesPerRequest int
+
+	// PolicyEvaluationConcurrency is the maximum number of
+	// CertificateRequestPolicies evaluated concurrently for a single request.
+	PolicyEvaluationConcurrency int
+
+	// RequireExplicitSelectors causes CertificateRequestPolicies which omit a
+	// namespace selector to match no requests, rather than requests in any
+	// namespace.
+	RequireExplicitSelectors bool
+
+	// DenyOnNoApplicablePolicy causes requests which no ready
+	// CertificateRequestPolicy is bound or applicable to be denied, rather
+	// than left unprocessed.
+	DenyOnNoApplicablePolicy bool
+
+	// DependencyResyncPeriod is the period at which CertificateRequestPolicies
+	// which depend on ConfigMaps or Secrets are re-synced, so that their
+	// readiness reflects those objects being created or deleted.
+	DependencyResyncPeriod time.Duration
+
+	// PendingRequeueInterval is the interval at which requests awaiting an
+	// external decision are reviewed again.
+	PendingRequeueInterval time.Duration
+
+	// PendingTimeout is the duration after which requests still awaiting an
+	// external decision are denied. Zero means requests are never denied for
+	// being pending.
+	PendingTimeout time.Duration
+
+	// DenialsAnnotation enables writing the policies which denied a request,
+	// and their reasons, as a JSON annotation on denied requests.
+	DenialsAnnotation bool
+
+	// DebugReviews enables recording CertificateRequestReviews for requests
+	// which request one with the debug-review annotation.
+	DebugReviews bool
+
+	// AuditLogPath is the path of the file which a JSON line is appended to
+	// for every approve and deny decision. "-" writes to stdout, and empty
+	// disables the audit log.
+	AuditLogPath string
+
+	// EvaluatorTimeout is the maximum duration of a single evaluator call
+	// when reviewing a request. Zero means no timeout.
+	EvaluatorTimeout time.Duration
+
+	// CircuitBreakerThreshold is the number of consecutive timeouts of a
+	// plugin after which the policies using it are marked as not ready.
+	CircuitBreakerThreshold int
+
+	// CircuitBreakerCooldown is the duration for which policies are marked as
+	// not ready once the circuit breaker of a plugin opens.
+	CircuitBreakerCooldown time.Duration
+
+	// ConfigReloadInterval is the interval at which the configuration files
+	// of plugins are checked for changes, and reloaded if changed. Zero means
+	// configuration is only reloaded on SIGHUP.
+	ConfigReloadInterval time.Duration
+
+	// RemotePlugins maps the names of plugins served out-of-tree to the gRPC
+	// addresses they are served on.
+	RemotePlugins map[string]string
+
 	// LeaderElectionNamespace is the Namespace to lease the controller replica
 	// leadership election.
 	LeaderElectionNamespace string
@@ -136,6 +221,11 @@ type Webhook struct {
 	// LeafDuration for webhook server TLS certificates.
 	// Defaults to 7 days.
 	LeafDuration time.Duration
+
+	// DenySolePolicyDeletion causes the deletion of a
+	// CertificateRequestPolicy which is the only policy selecting some issuers
+	// to be denied, rather than only warned about.
+	DenySolePolicyDeletion bool
 }
 
 func New() *Options {
@@ -162,8 +252,53 @@ func (o *Options) Complete() error {
 
 	log := logr.FromSlogHandler(handler)
 	klog.SetLogger(log)
+	// Set the controller-runtime logger as early as possible, since
+	// controller-runtime complains if anything logs before it is set.
+	ctrl.SetLogger(log.WithName("controller-manager"))
 	o.Logr = log
 
+	if o.MetricsExemplars && len(o.TracingEndpoint) == 0 {
+		return errors.New("--metrics-exemplars requires --tracing-endpoint to be set")
+	}
+
+	if o.MaxPoliciesPerRequest < 0 {
+		return fmt.Errorf("--max-policies-per-request must not be negative: %d", o.MaxPoliciesPerRequest)
+	}
+
+	if o.PolicyEvaluationConcurrency < 1 {
+		return fmt.Errorf("--policy-evaluation-concurrency must be positive: %d", o.PolicyEvaluationConcurrency)
+	}
+
+	if o.DependencyResyncPeriod <= 0 {
+		return fmt.Errorf("--policy-dependency-resync-period must be positive: %s", o.DependencyResyncPeriod)
+	}
+
+	if o.PendingRequeueInterval <= 0 {
+		return fmt.Errorf("--pending-requeue-interval must be positive: %s", o.PendingRequeueInterval)
+	}
+
+	if o.PendingTimeout < 0 {
+		return fmt.Errorf("--pending-timeout must not be negative: %s", o.PendingTimeout)
+	}
+
+	if o.EvaluatorTimeout < 0 {
+		return fmt.Errorf("--evaluator-timeout must not be negative: %s", o.EvaluatorTimeout)
+	}
+
+	if o.CircuitBreakerCooldown <= 0 {
+		return fmt.Errorf("--circuit-breaker-cooldown must be positive: %s", o.CircuitBreakerCooldown)
+	}
+
+	if o.ConfigReloadInterval < 0 {
+		return fmt.Errorf("--config-reload-interval must not be negative: %s", o.ConfigReloadInterval)
+	}
+
+	for name, address := range o.RemotePlugins {
+		if len(name) == 0 || len(address) == 0 {
+			return fmt.Errorf("--remote-plugins must be of the form <name>=<address>: %q=%q", name, address)
+		}
+	}
+
 	var err error
 	o.RestConfig, err = o.kubeConfigFlags.ToRESTConfig()
 	if err != nil {
@@ -212,6 +347,94 @@ func (o *Options) addAppFlags(fs *pflag.FlagSet) {
 		`TCP address for exposing HTTP Prometheus metrics which will be served on the HTTP path '/metrics'. The value "0" will
 	 disable exposing metrics.`)
 
+	fs.BoolVar(&o.MetricsExemplars, "metrics-exemplars", false,
+		`Attach the trace ID of a review to the review latency metrics as an OpenMetrics exemplar. Requires
+	 --tracing-endpoint. Exemplars are only attached to reviews that are part of a sampled trace, and are only exposed
+	 when metrics are scraped in the OpenMetrics format.`)
+
+	fs.StringVar(&o.TracingEndpoint, "tracing-endpoint", "",
+		`Address of an OTLP gRPC collector to export a trace span of each CertificateRequest review to. The connection
+	 may be further configured with the standard OTEL_EXPORTER_OTLP_* environment variables. Empty disables tracing.`)
+
+	fs.BoolVar(&o.PluginsEndpoint, "plugins-endpoint", false,
+		`Serve the names of the registered approvers, including plugins, as a JSON list on the HTTP path '/plugins' of the
+	 metrics server.`)
+
+	fs.StringVar(&o.BaselinePolicy, "baseline-policy", "",
+		`Name of a CertificateRequestPolicy which every request must pass, in addition to being approved by one of the
+	 policies selected for it. The baseline policy is never selected to approve requests itself, and requests are not
+	 approved while it doesn't exist or isn't ready. Empty disables the baseline policy.`)
+
+	fs.IntVar(&o.MaxPoliciesPerRequest, "max-policies-per-request", 0,
+		`Maximum number of selected CertificateRequestPolicies which are evaluated for a single request. If a request
+	 selects more policies, only the first policies sorted by name are evaluated, with Strict policies sorted first.
+	 The value 0 disables the limit.`)
+
+	fs.IntVar(&o.PolicyEvaluationConcurrency, "policy-evaluation-concurrency", 1,
+		`Maximum number of CertificateRequestPolicies which are evaluated concurrently for a single request. Higher values
+	 reduce the latency of reviews which select many policies using slow plugins. The result of a review is the
+	 same regardless of concurrency. The value 1 evaluates policies sequentially.`)
+
+	fs.BoolVar(&o.RequireExplicitSelectors, "require-explicit-selectors", false,
+		`If true, CertificateRequestPolicies which omit spec.selector.namespace match no requests, rather than
+	 requests in any namespace. Use matchNames: ["*"] to explicitly select every namespace.`)
+
+	fs.BoolVar(&o.DenyOnNoApplicablePolicy, "deny-on-no-applicable-policy", false,
+		`If true, requests which no ready CertificateRequestPolicy is bound or applicable to are denied, rather than
+	 left unprocessed. Requests are still left unprocessed while no CertificateRequestPolicies exist.`)
+
+	fs.DurationVar(&o.DependencyResyncPeriod, "policy-dependency-resync-period", time.Minute,
+		`Period at which CertificateRequestPolicies which depend on ConfigMaps or Secrets, e.g. through
+	 spec.allowed.valuesFrom, are re-synced. Dependencies are not watched, so a dependency being created or deleted
+	 is reflected in the Ready condition of a policy, and changes to the contents of a ConfigMap are used for
+	 evaluations, after at most this period.`)
+
+	fs.DurationVar(&o.PendingRequeueInterval, "pending-requeue-interval", 30*time.Second,
+		`Interval at which requests that a policy is awaiting an external decision for, e.g. a human approval, are
+	 reviewed again until the decision has been made.`)
+
+	fs.DurationVar(&o.PendingTimeout, "pending-timeout", 0,
+		`Duration since creation after which requests still awaiting an external decision are denied. The value 0
+	 disables the timeout, so requests are never denied for being pending.`)
+
+	fs.BoolVar(&o.DenialsAnnotation, "denials-annotation", false,
+		`Write the CertificateRequestPolicies which denied a request, along with the reasons each denied it, as JSON
+	 to the "policy.cert-manager.io/denials" annotation of denied requests.`)
+
+	fs.BoolVar(&o.DebugReviews, "debug-reviews", false,
+		`Record a CertificateRequestReview of the review of requests which set the
+	 "policy.cert-manager.io/debug-review" annotation to "true". Reviews only include the policies which the
+	 requester is bound to use, but reveal their predicate results and evaluator messages.`)
+
+	fs.StringVar(&o.AuditLogPath, "audit-log-path", "",
+		`Path of a file which a JSON line is appended to for every request approved or denied, recording the request,
+	 the policies which resulted in the decision, the reasons for denials, and the time of the decision. The value
+	 "-" writes the audit log to stdout. Empty disables the audit log.`)
+
+	fs.DurationVar(&o.EvaluatorTimeout, "evaluator-timeout", 0,
+		`Maximum duration of a single evaluator or plugin call when reviewing a request. Evaluators which time out
+	 deny the request for that policy, rather than blocking the review. The value 0 disables the timeout.`)
+
+	fs.IntVar(&o.CircuitBreakerThreshold, "circuit-breaker-threshold", 3,
+		`Number of consecutive timeouts of a plugin after which the CertificateRequestPolicies using that plugin are
+	 marked as not ready for --circuit-breaker-cooldown. Only used if --evaluator-timeout is set. The value 0
+	 disables the circuit breaker.`)
+
+	fs.DurationVar(&o.CircuitBreakerCooldown, "circuit-breaker-cooldown", 5*time.Minute,
+		`Duration for which the CertificateRequestPolicies using a plugin are marked as not ready once the circuit
+	 breaker of that plugin opens.`)
+
+	fs.DurationVar(&o.ConfigReloadInterval, "config-reload-interval", 10*time.Second,
+		`Interval at which the configuration files of plugins are checked for changes, and reloaded if they have
+	 changed. Configuration is always reloaded on SIGHUP. The value 0 disables checking for changes. Of the in-tree
+	 plugins, only the reverse-dns config file (--reverse-dns-config-file) is reloaded; other in-tree
+	 configuration is read from flags and requires a restart.`)
+
+	fs.StringToStringVar(&o.RemotePlugins, "remote-plugins", nil,
+		`Plugins served out-of-tree over gRPC, in the form <name>=<address>, e.g.
+	 "my-plugin=unix:///var/run/my-plugin.sock". Policies use the name in spec.plugins. Connections are not
+	 encrypted, so plugins must be served on a unix socket or loopback address, e.g. as a sidecar.`)
+
 	fs.StringVar(&o.ReadyzAddress, "readiness-probe-bind-address", ":6060",
 		"TCP address for exposing the HTTP readiness probe which will be served on the HTTP path '/readyz'.")
 }
@@ -255,6 +478,11 @@ func (o *Options) addWebhookFlags(fs *pflag.FlagSet) {
 		"webhook-leaf-cert-duration", time.Hour*24*7,
 		"Duration for webhook server TLS certificates. Defaults to 7 days.")
 
+	fs.BoolVar(&o.Webhook.DenySolePolicyDeletion,
+		"webhook-deny-sole-policy-deletion", false,
+		`If true, deny deleting a CertificateRequestPolicy which is the only policy selecting some issuers, since
+	 their requests would be neither approved nor denied. If false, such deletions are allowed with a warning.`)
+
 	var deprecatedCertDir string
 	fs.StringVar(&deprecatedCertDir,
 		"webhook-certificate-dir", "/tmp",
@@ -18,11 +18,16 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -41,6 +46,8 @@ import (
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
 	"github.com/cert-manager/approver-policy/pkg/approver"
 	"github.com/cert-manager/approver-policy/pkg/internal/controllers/ssa_client"
+	"github.com/cert-manager/approver-policy/pkg/internal/metrics"
+	"github.com/cert-manager/approver-policy/pkg/internal/util"
 )
 
 // certificaterequestpolicies is a controller-runtime Reconciler which handles
@@ -69,6 +76,25 @@ type certificaterequestpolicies struct {
 	// CertificateRequestPolicies that are not in a Ready state will not be used
 	// to evaluate.
 	reconcilers []approver.Reconciler
+
+	// evaluators are used to evaluate ready CertificateRequestPolicies against
+	// their test cases.
+	evaluators []approver.Evaluator
+
+	// dependencyReader is used for checking the existence of objects that
+	// CertificateRequestPolicies depend on, as declared by approver
+	// DependencyReconcilers. Reads are made directly against the API server so
+	// that approver-policy is not required to list or watch every Secret and
+	// ConfigMap in the cluster.
+	dependencyReader client.Reader
+
+	// dependencyResyncPeriod is the period at which CertificateRequestPolicies
+	// which declare dependencies are re-synced, so that their readiness
+	// reflects the existence of those dependencies.
+	dependencyResyncPeriod time.Duration
+
+	// policyMetrics records the readiness of CertificateRequestPolicies.
+	policyMetrics *metrics.PolicyRecorder
 }
 
 // addCertificateRequestPolicyController will register the
@@ -85,9 +111,12 @@ func addCertificateRequestPolicyController(_ context.Context, opts Options) erro
 			enqueueListSelect = append(enqueueListSelect, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(enqueueChan)})
 		}
 	}
+	for _, enqueueChan := range opts.EnqueueChans {
+		enqueueListSelect = append(enqueueListSelect, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(enqueueChan)})
+	}
 
 	// Only setup generic event triggers if at least one Reconciler gave an
-	// enqueue channel.
+	// enqueue channel, or additional enqueue channels were given.
 	if len(enqueueListSelect) > 0 {
 		if err := opts.Manager.Add(manager.RunnableFunc(func(ctx context.Context) error {
 			enqueueListSelect = append(enqueueListSelect, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())})
@@ -120,6 +149,8 @@ func addCertificateRequestPolicyController(_ context.Context, opts Options) erro
 
 	return ctrl.NewControllerManagedBy(opts.Manager).
 		For(new(policyapi.CertificateRequestPolicy)).
+		Watches(new(policyapi.CertificateRequestPolicyProfile), handler.EnqueueRequestsFromMapFunc(enqueueProfileReferences(log, opts.Manager.GetCache()))).
+		Watches(new(policyapi.CertificateRequestPolicy), handler.EnqueueRequestsFromMapFunc(enqueueInheritingPolicies(log, opts.Manager.GetCache()))).
 		WatchesRawSource(source.Channel(genericChan, handler.EnqueueRequestsFromMapFunc(
 			func(_ context.Context, obj client.Object) []reconcile.Request {
 				log.Info("reconciling certificaterequestpolicy after receiving event message", "name", obj.GetName())
@@ -127,12 +158,16 @@ func addCertificateRequestPolicyController(_ context.Context, opts Options) erro
 			},
 		))).
 		Complete(&certificaterequestpolicies{
-			log:         log,
-			clock:       clock.RealClock{},
-			recorder:    opts.Manager.GetEventRecorderFor("policy.cert-manager.io"),
-			client:      opts.Manager.GetClient(),
-			lister:      opts.Manager.GetCache(),
-			reconcilers: opts.Reconcilers,
+			log:                    log,
+			clock:                  clock.RealClock{},
+			recorder:               opts.Manager.GetEventRecorderFor("policy.cert-manager.io"),
+			client:                 opts.Manager.GetClient(),
+			lister:                 opts.Manager.GetCache(),
+			dependencyReader:       opts.Manager.GetAPIReader(),
+			dependencyResyncPeriod: opts.DependencyResyncPeriod,
+			reconcilers:            opts.Reconcilers,
+			evaluators:             opts.Evaluators,
+			policyMetrics:          opts.PolicyMetrics,
 		})
 }
 
@@ -170,6 +205,9 @@ func (c *certificaterequestpolicies) reconcileStatusPatch(ctx context.Context, r
 
 	policy := new(policyapi.CertificateRequestPolicy)
 	if err := c.lister.Get(ctx, req.NamespacedName, policy); err != nil {
+		if apierrors.IsNotFound(err) {
+			c.policyMetrics.Delete(req.NamespacedName.Name)
+		}
 		return reconcile.Result{}, nil, client.IgnoreNotFound(err)
 	}
 
@@ -181,9 +219,29 @@ func (c *certificaterequestpolicies) reconcileStatusPatch(ctx context.Context, r
 		el    field.ErrorList
 	)
 
+	// Readiness is determined using the policy with its parents and referenced
+	// profile applied, so that the fields given by them are also checked.
+	resolved, err := util.ResolveInheritance(ctx, c.lister, policy)
+	if apierrors.IsNotFound(err) || errors.Is(err, util.ErrInheritanceCycle) {
+		ready = false
+		el = append(el, field.Invalid(field.NewPath("spec", "inheritFrom"), policy.Spec.InheritFrom, err.Error()))
+		resolved = policy
+	} else if err != nil {
+		return reconcile.Result{}, nil, fmt.Errorf("failed to resolve parents of CertificateRequestPolicy %q: %w", req.NamespacedName.Name, err)
+	}
+
+	resolved, err = util.ResolveProfile(ctx, c.lister, resolved)
+	if apierrors.IsNotFound(err) {
+		ready = false
+		el = append(el, field.NotFound(field.NewPath("spec", "profileRef", "name"), policy.Spec.ProfileRef.Name))
+		resolved = policy
+	} else if err != nil {
+		return reconcile.Result{}, nil, fmt.Errorf("failed to resolve profile of CertificateRequestPolicy %q: %w", req.NamespacedName.Name, err)
+	}
+
 	// Capture the ready response from each Reconciler.
 	for _, reconciler := range c.reconcilers {
-		response, err := reconciler.Ready(ctx, policy)
+		response, err := reconciler.Ready(ctx, resolved)
 		if err != nil {
 			return reconcile.Result{}, nil, fmt.Errorf("failed to evaluate ready state of CertificateRequestPolicy %q: %w", req.NamespacedName.Name, err)
 		}
@@ -205,15 +263,52 @@ func (c *certificaterequestpolicies) reconcileStatusPatch(ctx context.Context, r
 		el = append(el, response.Errors...)
 	}
 
+	// Check that all objects the policy depends on exist. Dependencies are not
+	// watched, so policies which have any are periodically re-synced to pick
+	// up dependencies being created or deleted.
+	hasDependencies, dependencyErrs, err := c.checkDependencies(ctx, resolved)
+	if err != nil {
+		return reconcile.Result{}, nil, fmt.Errorf("failed to check dependencies of CertificateRequestPolicy %q: %w", req.NamespacedName.Name, err)
+	}
+	if len(dependencyErrs) > 0 {
+		ready = false
+	}
+	el = append(el, dependencyErrs...)
+	if hasDependencies && (!result.Requeue || result.RequeueAfter > c.dependencyResyncPeriod) {
+		result.Requeue = true
+		result.RequeueAfter = c.dependencyResyncPeriod
+	}
+
 	log = log.WithValues("ready", ready)
 
 	policyPatch := &policyapi.CertificateRequestPolicyStatus{}
 
+	// Surface suspicious selector configurations without affecting readiness.
+	// The Warning condition is omitted from the patch, and so removed, once
+	// there is nothing to warn about.
+	if warnings := util.SelectorWarnings(policy.Spec.Selector, field.NewPath("spec", "selector")); len(warnings) > 0 {
+		message := fmt.Sprintf("CertificateRequestPolicy selector may never match a request: %s", warnings.ToAggregate())
+		log.V(2).Info("suspicious selector", "warnings", warnings.ToAggregate())
+
+		c.setCertificateRequestPolicyCondition(
+			policy.Status.Conditions,
+			&policyPatch.Conditions,
+			policy.Generation,
+			policyapi.CertificateRequestPolicyCondition{
+				Type:    policyapi.CertificateRequestPolicyConditionWarning,
+				Status:  corev1.ConditionTrue,
+				Reason:  "SuspiciousSelector",
+				Message: message,
+			},
+		)
+	}
+
 	if !ready {
 		log.V(2).Info("NOT ready for approval evaluation", "errors", el.ToAggregate())
 
 		message := fmt.Sprintf("CertificateRequestPolicy is not ready for approval evaluation: %s", el.ToAggregate())
 		c.recorder.Event(policy, corev1.EventTypeWarning, "NotReady", message)
+		c.policyMetrics.SetNotReady(policy.Name, el.ToAggregate().Error())
 
 		c.setCertificateRequestPolicyCondition(
 			policy.Status.Conditions,
@@ -234,6 +329,7 @@ func (c *certificaterequestpolicies) reconcileStatusPatch(ctx context.Context, r
 
 	message := "CertificateRequestPolicy is ready for approval evaluation"
 	c.recorder.Event(policy, corev1.EventTypeNormal, "Ready", message)
+	c.policyMetrics.SetReady(policy.Name)
 
 	c.setCertificateRequestPolicyCondition(
 		policy.Status.Conditions,
@@ -247,9 +343,123 @@ func (c *certificaterequestpolicies) reconcileStatusPatch(ctx context.Context, r
 		},
 	)
 
+	// Test cases are only run against ready policies, since evaluating a
+	// policy which isn't ready may fail. The TestsPassed condition is omitted
+	// from the patch, and so removed, if the policy defines no test cases.
+	if len(resolved.Spec.TestCases) > 0 {
+		failures, err := runTestCases(ctx, c.evaluators, resolved)
+		if err != nil {
+			return result, policyPatch, fmt.Errorf("failed to run test cases of CertificateRequestPolicy %q: %w", req.NamespacedName.Name, err)
+		}
+
+		condition := policyapi.CertificateRequestPolicyCondition{
+			Type:    policyapi.CertificateRequestPolicyConditionTestsPassed,
+			Status:  corev1.ConditionTrue,
+			Reason:  "TestsPassed",
+			Message: fmt.Sprintf("All %d test cases gave the expected result", len(resolved.Spec.TestCases)),
+		}
+		if len(failures) > 0 {
+			log.V(2).Info("test cases failed", "failures", failures)
+			condition.Status = corev1.ConditionFalse
+			condition.Reason = "TestsFailed"
+			condition.Message = fmt.Sprintf("%d of %d test cases failed: %s", len(failures), len(resolved.Spec.TestCases), strings.Join(failures, "; "))
+			c.recorder.Event(policy, corev1.EventTypeWarning, "TestsFailed", condition.Message)
+		}
+
+		c.setCertificateRequestPolicyCondition(
+			policy.Status.Conditions,
+			&policyPatch.Conditions,
+			policy.Generation,
+			condition,
+		)
+	}
+
 	return result, policyPatch, nil
 }
 
+// enqueueProfileReferences returns a map function which enqueues the
+// CertificateRequestPolicies that reference the given
+// CertificateRequestPolicyProfile, so that their readiness is updated when the
+// profile changes.
+func enqueueProfileReferences(log logr.Logger, lister client.Reader) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		policyList := new(policyapi.CertificateRequestPolicyList)
+		if err := lister.List(ctx, policyList); err != nil {
+			log.Error(err, "failed to list CertificateRequestPolicies referencing profile", "profile", obj.GetName())
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, policy := range policyList.Items {
+			if policy.Spec.ProfileRef != nil && policy.Spec.ProfileRef.Name == obj.GetName() {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: policy.Name}})
+			}
+		}
+		return requests
+	}
+}
+
+// enqueueInheritingPolicies returns a map function which enqueues the
+// CertificateRequestPolicies that inherit from the given
+// CertificateRequestPolicy, either directly or through other policies, so
+// that their readiness is updated when the parent changes.
+func enqueueInheritingPolicies(log logr.Logger, lister client.Reader) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		policyList := new(policyapi.CertificateRequestPolicyList)
+		if err := lister.List(ctx, policyList); err != nil {
+			log.Error(err, "failed to list CertificateRequestPolicies inheriting from policy", "policy", obj.GetName())
+			return nil
+		}
+
+		var (
+			requests []reconcile.Request
+			parents  = []string{obj.GetName()}
+			seen     = map[string]bool{obj.GetName(): true}
+		)
+		for len(parents) > 0 {
+			parent := parents[0]
+			parents = parents[1:]
+			for _, policy := range policyList.Items {
+				if seen[policy.Name] || !slices.Contains(policy.Spec.InheritFrom, parent) {
+					continue
+				}
+				seen[policy.Name] = true
+				parents = append(parents, policy.Name)
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: policy.Name}})
+			}
+		}
+		return requests
+	}
+}
+
+// checkDependencies gets each of the dependencies declared for the policy by
+// DependencyReconcilers. Returns whether the policy has any dependencies,
+// along with the reason for each of them which does not exist.
+func (c *certificaterequestpolicies) checkDependencies(ctx context.Context, policy *policyapi.CertificateRequestPolicy) (bool, field.ErrorList, error) {
+	var dependencies []approver.Dependency
+	for _, reconciler := range c.reconcilers {
+		if dependencyReconciler, ok := reconciler.(approver.DependencyReconciler); ok {
+			dependencies = append(dependencies, dependencyReconciler.Dependencies(policy)...)
+		}
+	}
+
+	var el field.ErrorList
+	for _, dependency := range dependencies {
+		obj := new(metav1.PartialObjectMetadata)
+		obj.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind(string(dependency.Kind)))
+		err := c.dependencyReader.Get(ctx, client.ObjectKey{Namespace: dependency.Namespace, Name: dependency.Name}, obj)
+		if apierrors.IsNotFound(err) {
+			el = append(el, field.NotFound(dependency.FieldPath, fmt.Sprintf("%s %s/%s", dependency.Kind, dependency.Namespace, dependency.Name)))
+			continue
+		}
+		if err != nil {
+			return false, nil, fmt.Errorf("failed to get %s %s/%s: %w", dependency.Kind, dependency.Namespace, dependency.Name, err)
+		}
+	}
+
+	return len(dependencies) > 0, el, nil
+}
+
 // setCertificateRequestPolicyCondition updates the CertificateRequestPolicy
 // object with the given condition.
 // Will overwrite any existing condition of the same type.
@@ -19,9 +19,12 @@ package controllers
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,12 +34,14 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2/ktesting"
 	fakeclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
 	"github.com/cert-manager/approver-policy/pkg/approver"
 	fakeapprover "github.com/cert-manager/approver-policy/pkg/approver/fake"
+	"github.com/cert-manager/approver-policy/pkg/internal/metrics"
 )
 
 func Test_certificaterequestpolicies_Reconcile(t *testing.T) {
@@ -108,6 +113,40 @@ func Test_certificaterequestpolicies_Reconcile(t *testing.T) {
 			},
 			expEvent: "Normal Ready CertificateRequestPolicy is ready for approval evaluation",
 		},
+		"if policy selector is suspicious, add warning condition and update to ready": {
+			existingObjects: []runtime.Object{&policyapi.CertificateRequestPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-policy", Generation: policyGeneration, ResourceVersion: "3"},
+				TypeMeta:   metav1.TypeMeta{Kind: "CertificateRequestPolicy", APIVersion: "policy.cert-manager.io/v1alpha1"},
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{
+						IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{
+							Name:  ptr.To("prod"),
+							Kind:  ptr.To("Issuer"),
+							Group: ptr.To(""),
+						},
+					},
+				},
+			}},
+			expResult: ctrl.Result{},
+			expError:  false,
+			expStatusPatch: &policyapi.CertificateRequestPolicyStatus{
+				Conditions: []policyapi.CertificateRequestPolicyCondition{
+					{Type: policyapi.CertificateRequestPolicyConditionWarning,
+						Status:             corev1.ConditionTrue,
+						LastTransitionTime: fixedmetatime,
+						Reason:             "SuspiciousSelector",
+						Message:            "CertificateRequestPolicy selector may never match a request: spec.selector.issuerRef.group: Invalid value: \"\": an empty group never matches an issuer, since requests which omit the issuer group are matched as \"cert-manager.io\", hint: omit the field or use `*` to match any group",
+						ObservedGeneration: policyGeneration},
+					{Type: policyapi.CertificateRequestPolicyConditionReady,
+						Status:             corev1.ConditionTrue,
+						LastTransitionTime: fixedmetatime,
+						Reason:             "Ready",
+						Message:            "CertificateRequestPolicy is ready for approval evaluation",
+						ObservedGeneration: policyGeneration},
+				},
+			},
+			expEvent: "Normal Ready CertificateRequestPolicy is ready for approval evaluation",
+		},
 		"if reconciler returns not ready response, update to ready": {
 			existingObjects: []runtime.Object{&policyapi.CertificateRequestPolicy{
 				ObjectMeta: metav1.ObjectMeta{Name: "test-policy", Generation: policyGeneration, ResourceVersion: "3"},
@@ -439,12 +478,13 @@ func Test_certificaterequestpolicies_Reconcile(t *testing.T) {
 			fakerecorder := record.NewFakeRecorder(1)
 
 			c := &certificaterequestpolicies{
-				log:         ktesting.NewLogger(t, ktesting.DefaultConfig),
-				clock:       fixedclock,
-				client:      fakeclient,
-				lister:      fakeclient,
-				recorder:    fakerecorder,
-				reconcilers: test.reconcilers,
+				log:              ktesting.NewLogger(t, ktesting.DefaultConfig),
+				clock:            fixedclock,
+				client:           fakeclient,
+				lister:           fakeclient,
+				recorder:         fakerecorder,
+				reconcilers:      test.reconcilers,
+				dependencyReader: fakeclient,
 			}
 
 			resp, statusPatch, err := c.reconcileStatusPatch(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Name: policyName}})
@@ -472,6 +512,262 @@ func Test_certificaterequestpolicies_Reconcile(t *testing.T) {
 	}
 }
 
+func Test_certificaterequestpolicies_ReconcileDependencies(t *testing.T) {
+	const policyName = "test-policy"
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "cert-manager", Name: "plugin-credentials"}}
+
+	fakeclient := fakeclient.NewClientBuilder().
+		WithScheme(policyapi.GlobalScheme).
+		WithRuntimeObjects(
+			&policyapi.CertificateRequestPolicy{ObjectMeta: metav1.ObjectMeta{Name: policyName}},
+			secret,
+		).
+		Build()
+
+	reconciler := fakeapprover.NewFakeReconciler().
+		WithReady(func(context.Context, *policyapi.CertificateRequestPolicy) (approver.ReconcilerReadyResponse, error) {
+			return approver.ReconcilerReadyResponse{Ready: true}, nil
+		}).
+		WithDependencies(func(*policyapi.CertificateRequestPolicy) []approver.Dependency {
+			return []approver.Dependency{{
+				Kind:      approver.DependencyKindSecret,
+				Namespace: secret.Namespace,
+				Name:      secret.Name,
+				FieldPath: field.NewPath("spec", "plugins", "test", "secretRef"),
+			}}
+		})
+
+	c := &certificaterequestpolicies{
+		log:                    ktesting.NewLogger(t, ktesting.DefaultConfig),
+		clock:                  fakeclock.NewFakeClock(time.Now()),
+		client:                 fakeclient,
+		lister:                 fakeclient,
+		recorder:               record.NewFakeRecorder(2),
+		reconcilers:            []approver.Reconciler{reconciler},
+		dependencyReader:       fakeclient,
+		dependencyResyncPeriod: 30 * time.Second,
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: policyName}}
+
+	readyStatus := func(t *testing.T) *policyapi.CertificateRequestPolicyCondition {
+		result, statusPatch, err := c.reconcileStatusPatch(context.TODO(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Dependencies are not watched, so the policy must be re-synced to
+		// observe changes to them.
+		if exp := (ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}); result != exp {
+			t.Errorf("unexpected result, exp=%v got=%v", exp, result)
+		}
+		if statusPatch == nil || len(statusPatch.Conditions) != 1 {
+			t.Fatalf("expected a single status condition, got=%v", statusPatch)
+		}
+		return &statusPatch.Conditions[0]
+	}
+
+	if cond := readyStatus(t); cond.Status != corev1.ConditionTrue {
+		t.Fatalf("expected policy to be ready whilst Secret exists, got=%v", cond)
+	}
+
+	if err := fakeclient.Delete(context.TODO(), secret); err != nil {
+		t.Fatal(err)
+	}
+
+	cond := readyStatus(t)
+	if cond.Status != corev1.ConditionFalse {
+		t.Fatalf("expected policy to not be ready after Secret was deleted, got=%v", cond)
+	}
+	expMessage := "CertificateRequestPolicy is not ready for approval evaluation: spec.plugins.test.secretRef: Not found: \"Secret cert-manager/plugin-credentials\""
+	if cond.Message != expMessage {
+		t.Errorf("unexpected condition message, exp=%q got=%q", expMessage, cond.Message)
+	}
+}
+
+func Test_certificaterequestpolicies_ReconcileProfile(t *testing.T) {
+	const policyName = "test-policy"
+
+	profile := &policyapi.CertificateRequestPolicyProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-profile"},
+		Spec: policyapi.CertificateRequestPolicyProfileSpec{
+			Allowed: &policyapi.CertificateRequestPolicyAllowed{IsCA: ptr.To(true)},
+		},
+	}
+
+	fakeclient := fakeclient.NewClientBuilder().
+		WithScheme(policyapi.GlobalScheme).
+		WithRuntimeObjects(
+			&policyapi.CertificateRequestPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: policyName},
+				Spec: policyapi.CertificateRequestPolicySpec{
+					ProfileRef: &policyapi.CertificateRequestPolicyProfileReference{Name: profile.Name},
+				},
+			},
+			&policyapi.CertificateRequestPolicy{ObjectMeta: metav1.ObjectMeta{Name: "other-policy"}},
+			profile,
+		).
+		Build()
+
+	// The reconcilers are given the policy with the profile applied.
+	reconciler := fakeapprover.NewFakeReconciler().
+		WithReady(func(_ context.Context, policy *policyapi.CertificateRequestPolicy) (approver.ReconcilerReadyResponse, error) {
+			if policy.Spec.Allowed == nil || !ptr.Deref(policy.Spec.Allowed.IsCA, false) {
+				return approver.ReconcilerReadyResponse{
+					Ready:  false,
+					Errors: field.ErrorList{field.Required(field.NewPath("spec", "allowed", "isCA"), "expected profile to be applied")},
+				}, nil
+			}
+			return approver.ReconcilerReadyResponse{Ready: true}, nil
+		})
+
+	c := &certificaterequestpolicies{
+		log:                    ktesting.NewLogger(t, ktesting.DefaultConfig),
+		clock:                  fakeclock.NewFakeClock(time.Now()),
+		client:                 fakeclient,
+		lister:                 fakeclient,
+		recorder:               record.NewFakeRecorder(2),
+		reconcilers:            []approver.Reconciler{reconciler},
+		dependencyReader:       fakeclient,
+		dependencyResyncPeriod: 30 * time.Second,
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: policyName}}
+
+	readyStatus := func(t *testing.T) *policyapi.CertificateRequestPolicyCondition {
+		_, statusPatch, err := c.reconcileStatusPatch(context.TODO(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if statusPatch == nil || len(statusPatch.Conditions) != 1 {
+			t.Fatalf("expected a single status condition, got=%v", statusPatch)
+		}
+		return &statusPatch.Conditions[0]
+	}
+
+	if cond := readyStatus(t); cond.Status != corev1.ConditionTrue {
+		t.Fatalf("expected policy to be ready whilst profile exists, got=%v", cond)
+	}
+
+	// Changes to the profile should only enqueue the policies which reference
+	// it.
+	requests := enqueueProfileReferences(c.log, fakeclient)(context.TODO(), profile)
+	if exp := []ctrl.Request{req}; !apiequality.Semantic.DeepEqual(requests, exp) {
+		t.Errorf("unexpected requests for profile event, exp=%v got=%v", exp, requests)
+	}
+
+	if err := fakeclient.Delete(context.TODO(), profile); err != nil {
+		t.Fatal(err)
+	}
+
+	cond := readyStatus(t)
+	if cond.Status != corev1.ConditionFalse {
+		t.Fatalf("expected policy to not be ready after profile was deleted, got=%v", cond)
+	}
+	expMessage := "CertificateRequestPolicy is not ready for approval evaluation: [spec.profileRef.name: Not found: \"test-profile\", spec.allowed.isCA: Required value: expected profile to be applied]"
+	if cond.Message != expMessage {
+		t.Errorf("unexpected condition message, exp=%q got=%q", expMessage, cond.Message)
+	}
+}
+
+func Test_certificaterequestpolicies_ReconcileInheritance(t *testing.T) {
+	const policyName = "test-policy"
+
+	policy := func(name string, inheritFrom ...string) *policyapi.CertificateRequestPolicy {
+		return &policyapi.CertificateRequestPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       policyapi.CertificateRequestPolicySpec{InheritFrom: inheritFrom},
+		}
+	}
+	parent := policy("parent")
+	parent.Spec.Allowed = &policyapi.CertificateRequestPolicyAllowed{IsCA: ptr.To(true)}
+
+	fakeclient := fakeclient.NewClientBuilder().
+		WithScheme(policyapi.GlobalScheme).
+		WithRuntimeObjects(
+			policy(policyName, "parent"),
+			policy("grandchild", policyName),
+			policy("other-policy"),
+			parent,
+		).
+		Build()
+
+	// The reconcilers are given the policy with its parents applied.
+	reconciler := fakeapprover.NewFakeReconciler().
+		WithReady(func(_ context.Context, policy *policyapi.CertificateRequestPolicy) (approver.ReconcilerReadyResponse, error) {
+			if policy.Spec.Allowed == nil || !ptr.Deref(policy.Spec.Allowed.IsCA, false) {
+				return approver.ReconcilerReadyResponse{
+					Ready:  false,
+					Errors: field.ErrorList{field.Required(field.NewPath("spec", "allowed", "isCA"), "expected parent to be applied")},
+				}, nil
+			}
+			return approver.ReconcilerReadyResponse{Ready: true}, nil
+		})
+
+	c := &certificaterequestpolicies{
+		log:                    ktesting.NewLogger(t, ktesting.DefaultConfig),
+		clock:                  fakeclock.NewFakeClock(time.Now()),
+		client:                 fakeclient,
+		lister:                 fakeclient,
+		recorder:               record.NewFakeRecorder(3),
+		reconcilers:            []approver.Reconciler{reconciler},
+		dependencyReader:       fakeclient,
+		dependencyResyncPeriod: 30 * time.Second,
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: policyName}}
+
+	readyStatus := func(t *testing.T) *policyapi.CertificateRequestPolicyCondition {
+		_, statusPatch, err := c.reconcileStatusPatch(context.TODO(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if statusPatch == nil || len(statusPatch.Conditions) != 1 {
+			t.Fatalf("expected a single status condition, got=%v", statusPatch)
+		}
+		return &statusPatch.Conditions[0]
+	}
+
+	if cond := readyStatus(t); cond.Status != corev1.ConditionTrue {
+		t.Fatalf("expected policy to be ready whilst parent exists, got=%v", cond)
+	}
+
+	// Changes to the parent should enqueue the policies which inherit from
+	// it, including through other policies.
+	requests := enqueueInheritingPolicies(c.log, fakeclient)(context.TODO(), parent)
+	if exp := []ctrl.Request{req, {NamespacedName: types.NamespacedName{Name: "grandchild"}}}; !apiequality.Semantic.DeepEqual(requests, exp) {
+		t.Errorf("unexpected requests for parent event, exp=%v got=%v", exp, requests)
+	}
+
+	// A parent which inherits from the policy is a cycle.
+	parent.Spec.InheritFrom = []string{"grandchild"}
+	if err := fakeclient.Update(context.TODO(), parent); err != nil {
+		t.Fatal(err)
+	}
+
+	cond := readyStatus(t)
+	if cond.Status != corev1.ConditionFalse {
+		t.Fatalf("expected policy to not be ready with an inheritance cycle, got=%v", cond)
+	}
+	expMessage := "CertificateRequestPolicy is not ready for approval evaluation: [spec.inheritFrom: Invalid value: []string{\"parent\"}: CertificateRequestPolicy inheritance cycle: test-policy -> parent -> grandchild -> test-policy, spec.allowed.isCA: Required value: expected parent to be applied]"
+	if cond.Message != expMessage {
+		t.Errorf("unexpected condition message, exp=%q got=%q", expMessage, cond.Message)
+	}
+
+	if err := fakeclient.Delete(context.TODO(), parent); err != nil {
+		t.Fatal(err)
+	}
+
+	cond = readyStatus(t)
+	if cond.Status != corev1.ConditionFalse {
+		t.Fatalf("expected policy to not be ready after parent was deleted, got=%v", cond)
+	}
+	expMessage = "CertificateRequestPolicy is not ready for approval evaluation: [spec.inheritFrom: Invalid value: []string{\"parent\"}: failed to get parent CertificateRequestPolicy \"parent\": certificaterequestpolicies.policy.cert-manager.io \"parent\" not found, spec.allowed.isCA: Required value: expected parent to be applied]"
+	if cond.Message != expMessage {
+		t.Errorf("unexpected condition message, exp=%q got=%q", expMessage, cond.Message)
+	}
+}
+
 func Test_certificaterequestpolicies_setCertificateRequestPolicyCondition(t *testing.T) {
 	const policyGeneration int64 = 2
 
@@ -602,3 +898,66 @@ func Test_certificaterequestpolicies_setCertificateRequestPolicyCondition(t *tes
 		})
 	}
 }
+
+func Test_certificaterequestpolicies_ReconcilePolicyMetrics(t *testing.T) {
+	const policyName = "test-policy"
+
+	fakeclient := fakeclient.NewClientBuilder().
+		WithScheme(policyapi.GlobalScheme).
+		WithRuntimeObjects(&policyapi.CertificateRequestPolicy{ObjectMeta: metav1.ObjectMeta{Name: policyName}}).
+		Build()
+
+	var ready bool
+	reconciler := fakeapprover.NewFakeReconciler().
+		WithReady(func(context.Context, *policyapi.CertificateRequestPolicy) (approver.ReconcilerReadyResponse, error) {
+			if ready {
+				return approver.ReconcilerReadyResponse{Ready: true}, nil
+			}
+			return approver.ReconcilerReadyResponse{
+				Ready:  false,
+				Errors: field.ErrorList{field.Invalid(field.NewPath("spec", "plugins", "test"), "foo", "bar")},
+			}, nil
+		})
+
+	policyMetrics := metrics.NewPolicyRecorder()
+	c := &certificaterequestpolicies{
+		log:              ktesting.NewLogger(t, ktesting.DefaultConfig),
+		clock:            fakeclock.NewFakeClock(time.Now()),
+		client:           fakeclient,
+		lister:           fakeclient,
+		recorder:         record.NewFakeRecorder(3),
+		reconcilers:      []approver.Reconciler{reconciler},
+		dependencyReader: fakeclient,
+		policyMetrics:    policyMetrics,
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: policyName}}
+	reconcile := func(t *testing.T) {
+		t.Helper()
+		if _, _, err := c.reconcileStatusPatch(context.TODO(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ready = true
+	reconcile(t)
+	assert.Equal(t, float64(1), testutil.ToFloat64(policyMetrics), "expected ready gauge to be 1")
+
+	ready = false
+	reconcile(t)
+	assert.NoError(t, testutil.CollectAndCompare(policyMetrics, strings.NewReader(`
+# HELP approverpolicy_policy_not_ready_info Set to 1 for every CertificateRequestPolicy which is not ready, with the reason it is not ready as a label.
+# TYPE approverpolicy_policy_not_ready_info gauge
+approverpolicy_policy_not_ready_info{policy="test-policy",reason="spec.plugins.test: Invalid value: \"foo\": bar"} 1
+# HELP approverpolicy_policy_ready Whether a CertificateRequestPolicy is ready for approval evaluation (1) or not (0).
+# TYPE approverpolicy_policy_ready gauge
+approverpolicy_policy_ready{policy="test-policy"} 0
+`)))
+
+	// Once the policy no longer exists, its metrics are removed.
+	if err := fakeclient.Delete(context.TODO(), &policyapi.CertificateRequestPolicy{ObjectMeta: metav1.ObjectMeta{Name: policyName}}); err != nil {
+		t.Fatal(err)
+	}
+	reconcile(t)
+	assert.Equal(t, 0, testutil.CollectAndCount(policyMetrics))
+}
@@ -18,34 +18,45 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
 	"github.com/cert-manager/approver-policy/pkg/approver/manager"
 	internalmanager "github.com/cert-manager/approver-policy/pkg/internal/approver/manager"
+	"github.com/cert-manager/approver-policy/pkg/internal/audit"
 	"github.com/cert-manager/approver-policy/pkg/internal/controllers/ssa_client"
+	"github.com/cert-manager/approver-policy/pkg/internal/metrics"
 )
 
 // certificaterequests is a controller-runtime Reconciler which evaluates
@@ -74,18 +85,65 @@ type certificaterequests struct {
 	// to manage all approvers which have been registered and active for this
 	// controller.
 	manager manager.Interface
+
+	// reviewMetrics records the latency of reviews. May be nil.
+	reviewMetrics *metrics.ReviewRecorder
+
+	// auditLog records every approve and deny decision. May be nil.
+	auditLog *audit.Logger
+
+	// tracer starts a span around each review. May be nil, in which case
+	// reviews are not traced.
+	tracer oteltrace.Tracer
+
+	// pendingRequeueInterval is the interval at which requests awaiting an
+	// external decision are reviewed again.
+	pendingRequeueInterval time.Duration
+
+	// pendingTimeout is the duration since creation after which requests
+	// still awaiting an external decision are denied. Zero means never.
+	pendingTimeout time.Duration
+
+	// denialsAnnotation enables writing the denials of a denied request as a
+	// JSON annotation.
+	denialsAnnotation bool
+
+	// debugReviews enables recording CertificateRequestReviews for requests
+	// with the debug-review annotation.
+	debugReviews bool
 }
 
 // addCertificateRequestController will register the certificaterequests
 // controller with the controller-runtime Manager.
 func addCertificateRequestController(ctx context.Context, opts Options) error {
+	reviewManager := internalmanager.New(opts.Manager.GetCache(), opts.Manager.GetClient(), opts.Evaluators, internalmanager.Options{
+		BaselinePolicy:           opts.BaselinePolicy,
+		MaxPolicies:              opts.MaxPoliciesPerRequest,
+		RequireExplicitSelectors: opts.RequireExplicitSelectors,
+		DenyOnNoApplicablePolicy: opts.DenyOnNoApplicablePolicy,
+		EvaluatorTimeout:         opts.EvaluatorTimeout,
+		CircuitBreaker:           opts.circuitBreaker,
+		Concurrency:              opts.PolicyEvaluationConcurrency,
+	})
+
 	c := &certificaterequests{
-		log:      opts.Log.WithName("certificaterequests"),
-		clock:    clock.RealClock{},
-		recorder: opts.Manager.GetEventRecorderFor("policy.cert-manager.io"),
-		client:   opts.Manager.GetClient(),
-		lister:   opts.Manager.GetCache(),
-		manager:  internalmanager.New(opts.Manager.GetCache(), opts.Manager.GetClient(), opts.Evaluators),
+		log:           opts.Log.WithName("certificaterequests"),
+		clock:         clock.RealClock{},
+		recorder:      opts.Manager.GetEventRecorderFor("policy.cert-manager.io"),
+		client:        opts.Manager.GetClient(),
+		lister:        opts.Manager.GetCache(),
+		manager:       reviewManager,
+		reviewMetrics: opts.ReviewMetrics,
+		auditLog:      opts.AuditLog,
+
+		pendingRequeueInterval: opts.PendingRequeueInterval,
+		pendingTimeout:         opts.PendingTimeout,
+		denialsAnnotation:      opts.DenialsAnnotation,
+		debugReviews:           opts.DebugReviews,
+	}
+
+	if opts.TracerProvider != nil {
+		c.tracer = opts.TracerProvider.Tracer("github.com/cert-manager/approver-policy")
 	}
 
 	enqueueRequestFromMapFunc := func(_ context.Context, _ client.Object) []reconcile.Request {
@@ -152,7 +210,26 @@ func addCertificateRequestController(ctx context.Context, opts Options) error {
 // function will call the approver manager to evaluate whether a
 // CertificateRequest should be approved, denied, or left alone.
 func (c *certificaterequests) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	result, patch, resultErr := c.reconcileStatusPatch(ctx, req)
+	result, patch, annotations, decision, resultErr := c.reconcileStatusPatch(ctx, req)
+
+	// Annotations must be applied before the status, since a request that has
+	// been approved or denied will never be reconciled again.
+	if annotations != nil {
+		cr, patch, err := ssa_client.GenerateCertificateRequestAnnotationsPatch(req.Name, req.Namespace, annotations)
+		if err != nil {
+			err = fmt.Errorf("failed to generate CertificateRequest annotations patch: %w", err)
+			return ctrl.Result{}, utilerrors.NewAggregate([]error{resultErr, err})
+		}
+
+		if err := c.client.Patch(ctx, cr, patch, &client.PatchOptions{
+			FieldManager: "approver-policy",
+			Force:        ptr.To(true),
+		}); err != nil {
+			err = fmt.Errorf("failed to apply CertificateRequest annotations patch: %w", err)
+			return ctrl.Result{}, utilerrors.NewAggregate([]error{resultErr, err})
+		}
+	}
+
 	if patch != nil {
 		cr, patch, err := ssa_client.GenerateCertificateRequestStatusPatch(req.Name, req.Namespace, patch)
 		if err != nil {
@@ -171,25 +248,77 @@ func (c *certificaterequests) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 	}
 
+	// The decision is only recorded once the Approved or Denied condition has
+	// been applied, so that a failed or retried patch doesn't result in a
+	// duplicate record, or a record of a decision that was never persisted.
+	// The audit log must not block the request from being approved or
+	// denied, since the decision is still recorded by the condition.
+	if decision != nil {
+		if err := c.auditLog.Record(decision.time, decision.cr, reviewResultLabel(decision.response.Result, nil), decision.response); err != nil {
+			c.log.Error(err, "failed to write audit log", "namespace", req.Namespace, "name", req.Name)
+		}
+	}
+
 	return result, resultErr
 }
 
-func (c *certificaterequests) reconcileStatusPatch(ctx context.Context, req ctrl.Request) (ctrl.Result, *cmapi.CertificateRequestStatus, error) {
+// decision is an approve or deny decision on a CertificateRequest, which is
+// written to the audit log once it has been applied to the request.
+type decision struct {
+	// time is the time at which the decision was made.
+	time time.Time
+
+	// cr is the CertificateRequest which was reviewed.
+	cr *cmapi.CertificateRequest
+
+	// response is the review response giving the decision.
+	response manager.ReviewResponse
+}
+
+func (c *certificaterequests) reconcileStatusPatch(ctx context.Context, req ctrl.Request) (ctrl.Result, *cmapi.CertificateRequestStatus, map[string]string, *decision, error) {
 	log := c.log.WithValues("namespace", req.NamespacedName.Namespace, "name", req.NamespacedName.Name)
 	log.V(2).Info("syncing certificaterequest")
 
 	cr := new(cmapi.CertificateRequest)
 	if err := c.lister.Get(ctx, req.NamespacedName, cr); err != nil {
-		return ctrl.Result{}, nil, client.IgnoreNotFound(err)
+		return ctrl.Result{}, nil, nil, nil, client.IgnoreNotFound(err)
 	}
 
 	if apiutil.CertificateRequestIsApproved(cr) || apiutil.CertificateRequestIsDenied(cr) {
 		// Return early if already approved/denied as this is decision is final for requests.
-		return ctrl.Result{}, nil, nil
+		return ctrl.Result{}, nil, nil, nil, nil
+	}
+
+	// The UID correlates the log lines of a single request across reconciles,
+	// and is passed to the review so that approvers log with the same values.
+	log = log.WithValues("uid", cr.UID)
+	ctx = logr.NewContext(ctx, log)
+
+	// Only record the full decision context of the review when debug logging
+	// is enabled, or a debug review record has been requested and debug
+	// reviews are enabled.
+	var (
+		trace        *manager.ReviewTrace
+		debugLog     = log.V(4)
+		recordReview = c.debugReviews && cr.Annotations[policyapi.CertificateRequestAnnotationDebugReview] == "true"
+	)
+	if debugLog.Enabled() || recordReview {
+		trace = new(manager.ReviewTrace)
+		ctx = manager.WithReviewTrace(ctx, trace)
+	}
+	if debugLog.Enabled() {
+		defer func() {
+			debugLog.Info("review decision context",
+				"policies", trace.Policies,
+				"predicates", trace.Predicates,
+				"evaluations", trace.Evaluations,
+				"duration", trace.Duration,
+			)
+		}()
 	}
 
 	// Query review on the approver manager.
-	response, err := c.manager.Review(ctx, cr)
+	response, err := c.review(ctx, cr)
 	if err != nil {
 		// If an error occurs when evaluating, we fire an event on the
 		// CertificateRequest and return err to try again.
@@ -197,7 +326,26 @@ func (c *certificaterequests) reconcileStatusPatch(ctx context.Context, req ctrl
 		// information about the approver configuration being exposed to the
 		// client.
 		c.recorder.Eventf(cr, corev1.EventTypeWarning, "EvaluationError", "approver-policy failed to review the request and will retry")
-		return ctrl.Result{}, nil, err
+		return ctrl.Result{}, nil, nil, nil, err
+	}
+
+	if recordReview {
+		// The review record is a debugging aid, so failing to write it must
+		// not block the request from being approved or denied.
+		if err := c.recordReview(ctx, cr, response, trace); err != nil {
+			log.Error(err, "failed to record CertificateRequestReview")
+		}
+	}
+
+	// Requests are only ever denied for awaiting an external decision if a
+	// timeout has been configured.
+	pendingFor := c.clock.Since(cr.CreationTimestamp.Time)
+	if response.Result == manager.ResultPending && c.pendingTimeout > 0 && pendingFor >= c.pendingTimeout {
+		response = manager.ReviewResponse{
+			Result:   manager.ResultDenied,
+			Message:  fmt.Sprintf("%s (timed out after %s)", response.Message, c.pendingTimeout),
+			Policies: response.Policies,
+		}
 	}
 
 	crPatch := &cmapi.CertificateRequestStatus{}
@@ -217,7 +365,15 @@ func (c *certificaterequests) reconcileStatusPatch(ctx context.Context, req ctrl
 			response.Message,
 		)
 
-		return ctrl.Result{}, crPatch, nil
+		// Annotations contributed by evaluators must not override the
+		// approved-by annotation.
+		annotations := make(map[string]string, len(response.Annotations)+1)
+		for k, v := range response.Annotations {
+			annotations[k] = v
+		}
+		annotations[policyapi.CertificateRequestAnnotationApprovedBy] = strings.Join(response.Policies, ",")
+
+		return ctrl.Result{}, crPatch, annotations, &decision{time: c.clock.Now(), cr: cr, response: response}, nil
 
 	case manager.ResultDenied:
 		log.V(2).Info("denying request")
@@ -233,21 +389,170 @@ func (c *certificaterequests) reconcileStatusPatch(ctx context.Context, req ctrl
 			response.Message,
 		)
 
-		return ctrl.Result{}, crPatch, nil
+		annotations := map[string]string{
+			policyapi.CertificateRequestAnnotationDeniedBy: strings.Join(response.Policies, ","),
+		}
+
+		if c.denialsAnnotation && len(response.Denials) > 0 {
+			denials, err := json.Marshal(response.Denials)
+			if err != nil {
+				return ctrl.Result{}, nil, nil, nil, fmt.Errorf("failed to encode denials: %w", err)
+			}
+			annotations[policyapi.CertificateRequestAnnotationDenials] = string(denials)
+		}
+
+		return ctrl.Result{}, crPatch, annotations, &decision{time: c.clock.Now(), cr: cr, response: response}, nil
 
 	case manager.ResultUnprocessed:
-		log.V(2).Info("request was unprocessed")
-		c.recorder.Event(cr, corev1.EventTypeNormal, "Unprocessed", "Request is not applicable for any policy so ignoring")
+		log.V(2).Info("request was unprocessed", "reason", response.Message)
+		c.recorder.Event(cr, corev1.EventTypeNormal, "Unprocessed", fmt.Sprintf("Request is not applicable for any policy so ignoring: %s", response.Message))
+		c.reviewMetrics.ObserveUnprocessed(unprocessedReasonLabel(response.Message))
 
-		return ctrl.Result{}, nil, nil
+		return ctrl.Result{}, nil, nil, nil, nil
+
+	case manager.ResultPending:
+		log.V(2).Info("request is awaiting external approval", "policies", response.Policies)
+		c.recorder.Event(cr, corev1.EventTypeNormal, "AwaitingExternalApproval", response.Message)
+
+		// Review again at the requeue interval, or sooner if the request would
+		// otherwise time out in the meantime.
+		requeueAfter := c.pendingRequeueInterval
+		if remaining := c.pendingTimeout - pendingFor; c.pendingTimeout > 0 && remaining < requeueAfter {
+			requeueAfter = remaining
+		}
+
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil, nil, nil, nil
 
 	default:
 		log.Error(errors.New(response.Message), "manager responded with an unknown result", "result", response.Result)
 		c.recorder.Event(cr, corev1.EventTypeWarning, "UnknownResponse", "Policy returned an unknown result. This is a bug. Please check the approver-policy logs and file an issue")
 
 		// We can do nothing but keep retrying the review here.
-		return ctrl.Result{Requeue: true, RequeueAfter: time.Second * 5}, nil, nil
+		return ctrl.Result{Requeue: true, RequeueAfter: time.Second * 5}, nil, nil, nil, nil
+
+	}
+}
+
+// review reviews the request with the approver manager, recording the latency
+// of the review. If tracing is enabled, the review is made within a span so
+// that the latency observation carries the trace as an exemplar.
+func (c *certificaterequests) review(ctx context.Context, cr *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
+	var span oteltrace.Span
+	if c.tracer != nil {
+		ctx, span = c.tracer.Start(ctx, "Review", oteltrace.WithAttributes(
+			attribute.String("namespace", cr.Namespace),
+			attribute.String("name", cr.Name),
+		))
+		defer span.End()
+	}
+
+	start := c.clock.Now()
+	response, err := c.manager.Review(ctx, cr)
+	result := reviewResultLabel(response.Result, err)
+	c.reviewMetrics.Observe(ctx, result, c.clock.Since(start))
+
+	if span != nil {
+		span.SetAttributes(attribute.String("result", result))
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+
+	return response, err
+}
+
+// recordReview creates or updates the CertificateRequestReview of the given
+// request with the result and trace of its review. The review is owned by the
+// request so that it is garbage collected alongside it. The review is readable
+// by the requester, so only policies which the requester is bound to use are
+// recorded, so as not to expose the configuration of other policies.
+func (c *certificaterequests) recordReview(ctx context.Context, cr *cmapi.CertificateRequest, response manager.ReviewResponse, trace *manager.ReviewTrace) error {
+	review := &policyapi.CertificateRequestReview{
+		ObjectMeta: metav1.ObjectMeta{Name: cr.Name, Namespace: cr.Namespace},
+	}
+
+	bound := sets.New(trace.BoundPolicies...)
+	boundPolicies := func(policies []string) []string {
+		return slices.DeleteFunc(slices.Clone(policies), func(policy string) bool {
+			return !bound.Has(policy)
+		})
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, c.client, review, func() error {
+		review.Status = policyapi.CertificateRequestReviewStatus{
+			Result:     reviewResult(response.Result),
+			Message:    response.Message,
+			ReviewTime: metav1.NewTime(c.clock.Now()),
+			Policies:   boundPolicies(trace.Policies),
+		}
+		for _, predicate := range trace.Predicates {
+			review.Status.Predicates = append(review.Status.Predicates, policyapi.CertificateRequestReviewPredicate{
+				Name:     predicate.Name,
+				Policies: boundPolicies(predicate.Policies),
+			})
+		}
+		for _, evaluation := range trace.Evaluations {
+			if !bound.Has(evaluation.Policy) {
+				continue
+			}
+			review.Status.Evaluations = append(review.Status.Evaluations, policyapi.CertificateRequestReviewEvaluation{
+				Policy:    evaluation.Policy,
+				Evaluator: evaluation.Evaluator,
+				Denied:    evaluation.Result == approver.ResultDenied,
+				Pending:   evaluation.Pending,
+				Message:   evaluation.Message,
+			})
+		}
+		return controllerutil.SetControllerReference(cr, review, c.client.Scheme())
+	})
+	return err
+}
+
+// reviewResult returns the CertificateRequestReview result for the result of
+// a review.
+func reviewResult(result manager.ReviewResult) policyapi.CertificateRequestReviewResult {
+	switch result {
+	case manager.ResultApproved:
+		return policyapi.CertificateRequestReviewResultApproved
+	case manager.ResultDenied:
+		return policyapi.CertificateRequestReviewResultDenied
+	case manager.ResultPending:
+		return policyapi.CertificateRequestReviewResultPending
+	default:
+		return policyapi.CertificateRequestReviewResultUnprocessed
+	}
+}
 
+// reviewResultLabel returns the metrics label value for the result of a
+// review.
+func reviewResultLabel(result manager.ReviewResult, err error) string {
+	if err != nil {
+		return "error"
+	}
+	switch result {
+	case manager.ResultApproved:
+		return "approved"
+	case manager.ResultDenied:
+		return "denied"
+	case manager.ResultUnprocessed:
+		return "unprocessed"
+	case manager.ResultPending:
+		return "pending"
+	default:
+		return "unknown"
+	}
+}
+
+// unprocessedReasonLabel returns the metrics label value for the message of an
+// unprocessed review.
+func unprocessedReasonLabel(message string) string {
+	switch message {
+	case manager.MessageNoPolicies:
+		return "no_policies"
+	case manager.MessageNoApplicablePolicies:
+		return "no_applicable_policies"
+	default:
+		return "unknown"
 	}
 }
 
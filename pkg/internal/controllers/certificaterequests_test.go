@@ -17,15 +17,24 @@ limitations under the License.
 package controllers
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"github.com/prometheus/client_golang/prometheus"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -33,11 +42,18 @@ import (
 	"k8s.io/klog/v2/ktesting"
 	fakeclock "k8s.io/utils/clock/testing"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+	fakeapprover "github.com/cert-manager/approver-policy/pkg/approver/fake"
 	"github.com/cert-manager/approver-policy/pkg/approver/manager"
 	fakemanager "github.com/cert-manager/approver-policy/pkg/approver/manager/fake"
+	internalmanager "github.com/cert-manager/approver-policy/pkg/internal/approver/manager"
+	"github.com/cert-manager/approver-policy/pkg/internal/audit"
+	"github.com/cert-manager/approver-policy/pkg/internal/metrics"
 )
 
 func Test_certificaterequests_Reconcile(t *testing.T) {
@@ -71,12 +87,14 @@ func Test_certificaterequests_Reconcile(t *testing.T) {
 	)
 
 	tests := map[string]struct {
-		existingObjects []runtime.Object
-		manager         manager.Interface
+		existingObjects   []runtime.Object
+		manager           manager.Interface
+		denialsAnnotation bool
 
 		expResult      ctrl.Result
 		expError       bool
 		expStatusPatch *cmapi.CertificateRequestStatus
+		expAnnotations map[string]string
 		expEvent       string
 	}{
 		"if request doesn't exist, no nothing": {
@@ -124,20 +142,82 @@ func Test_certificaterequests_Reconcile(t *testing.T) {
 			expStatusPatch: nil,
 			expEvent:       "Warning UnknownResponse Policy returned an unknown result. This is a bug. Please check the approver-policy logs and file an issue",
 		},
-		"if manager review returns an unprocessed response, fire event and do nothing": {
+		"if manager review returns an unprocessed response as no policies exist, fire event with the reason and do nothing": {
 			existingObjects: []runtime.Object{gen.CertificateRequestFrom(baseRequest)},
 			manager: fakemanager.NewFakeManager().WithReview(func(context.Context, *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
-				return manager.ReviewResponse{Result: manager.ResultUnprocessed, Message: "unprocessed result"}, nil
+				return manager.ReviewResponse{Result: manager.ResultUnprocessed, Message: manager.MessageNoPolicies}, nil
 			}),
 			expResult:      ctrl.Result{},
 			expError:       false,
 			expStatusPatch: nil,
-			expEvent:       "Normal Unprocessed Request is not applicable for any policy so ignoring",
+			expEvent:       "Normal Unprocessed Request is not applicable for any policy so ignoring: No CertificateRequestPolicies exist",
+		},
+		"if manager review returns an unprocessed response as no policies are applicable, fire event with the reason and do nothing": {
+			existingObjects: []runtime.Object{gen.CertificateRequestFrom(baseRequest)},
+			manager: fakemanager.NewFakeManager().WithReview(func(context.Context, *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
+				return manager.ReviewResponse{Result: manager.ResultUnprocessed, Message: manager.MessageNoApplicablePolicies}, nil
+			}),
+			expResult:      ctrl.Result{},
+			expError:       false,
+			expStatusPatch: nil,
+			expEvent:       "Normal Unprocessed Request is not applicable for any policy so ignoring: No CertificateRequestPolicies bound or applicable",
 		},
 		"if manager review returns denied, fire event and update request with denied": {
 			existingObjects: []runtime.Object{gen.CertificateRequestFrom(baseRequest)},
 			manager: fakemanager.NewFakeManager().WithReview(func(context.Context, *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
-				return manager.ReviewResponse{Result: manager.ResultDenied, Message: "denied due to some violation"}, nil
+				return manager.ReviewResponse{Result: manager.ResultDenied, Message: "denied due to some violation", Policies: []string{"policy-a", "policy-b"}}, nil
+			}),
+			expResult: ctrl.Result{},
+			expError:  false,
+			expStatusPatch: &cmapi.CertificateRequestStatus{
+				Conditions: []cmapi.CertificateRequestCondition{
+					{
+						Type:               cmapi.CertificateRequestConditionDenied,
+						Status:             cmmeta.ConditionTrue,
+						LastTransitionTime: fixedmetatime,
+						Reason:             "policy.cert-manager.io",
+						Message:            "denied due to some violation",
+					},
+				},
+			},
+			expAnnotations: map[string]string{
+				"policy.cert-manager.io/denied-by": "policy-a,policy-b",
+			},
+			expEvent: "Warning Denied denied due to some violation",
+		},
+		"if manager review returns denied with denials and annotation disabled, don't write denials annotation": {
+			existingObjects: []runtime.Object{gen.CertificateRequestFrom(baseRequest)},
+			manager: fakemanager.NewFakeManager().WithReview(func(context.Context, *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
+				return manager.ReviewResponse{Result: manager.ResultDenied, Message: "denied due to some violation", Policies: []string{"policy-a"},
+					Denials: []manager.Denial{{Policy: "policy-a", Errors: []string{"spec.allowed.dnsNames.values: Invalid value: []string{\"example.com\"}: example.com"}}}}, nil
+			}),
+			expResult: ctrl.Result{},
+			expError:  false,
+			expStatusPatch: &cmapi.CertificateRequestStatus{
+				Conditions: []cmapi.CertificateRequestCondition{
+					{
+						Type:               cmapi.CertificateRequestConditionDenied,
+						Status:             cmmeta.ConditionTrue,
+						LastTransitionTime: fixedmetatime,
+						Reason:             "policy.cert-manager.io",
+						Message:            "denied due to some violation",
+					},
+				},
+			},
+			expAnnotations: map[string]string{
+				"policy.cert-manager.io/denied-by": "policy-a",
+			},
+			expEvent: "Warning Denied denied due to some violation",
+		},
+		"if manager review returns denied with denials and annotation enabled, write denials annotation": {
+			existingObjects:   []runtime.Object{gen.CertificateRequestFrom(baseRequest)},
+			denialsAnnotation: true,
+			manager: fakemanager.NewFakeManager().WithReview(func(context.Context, *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
+				return manager.ReviewResponse{Result: manager.ResultDenied, Message: "denied due to some violation", Policies: []string{"policy-a", "policy-b"},
+					Denials: []manager.Denial{
+						{Policy: "policy-a", Errors: []string{"spec.allowed.commonName.value: Invalid value: \"foo\": bar"}},
+						{Policy: "policy-b", Errors: []string{"spec.constraints.maxDuration: Invalid value: \"2h\": 1h"}},
+					}}, nil
 			}),
 			expResult: ctrl.Result{},
 			expError:  false,
@@ -152,12 +232,42 @@ func Test_certificaterequests_Reconcile(t *testing.T) {
 					},
 				},
 			},
+			expAnnotations: map[string]string{
+				"policy.cert-manager.io/denied-by": "policy-a,policy-b",
+				"policy.cert-manager.io/denials":   `[{"policy":"policy-a","errors":["spec.allowed.commonName.value: Invalid value: \"foo\": bar"]},{"policy":"policy-b","errors":["spec.constraints.maxDuration: Invalid value: \"2h\": 1h"]}]`,
+			},
 			expEvent: "Warning Denied denied due to some violation",
 		},
 		"if manager review returns true, fire event and update request with approved": {
 			existingObjects: []runtime.Object{gen.CertificateRequestFrom(baseRequest)},
 			manager: fakemanager.NewFakeManager().WithReview(func(context.Context, *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
-				return manager.ReviewResponse{Result: manager.ResultApproved, Message: "policy is happy :)"}, nil
+				return manager.ReviewResponse{Result: manager.ResultApproved, Message: "policy is happy :)", Policies: []string{"policy-a"}}, nil
+			}),
+			expResult: ctrl.Result{},
+			expError:  false,
+			expStatusPatch: &cmapi.CertificateRequestStatus{
+				Conditions: []cmapi.CertificateRequestCondition{
+					{
+						Type:               cmapi.CertificateRequestConditionApproved,
+						Status:             cmmeta.ConditionTrue,
+						LastTransitionTime: fixedmetatime,
+						Reason:             "policy.cert-manager.io",
+						Message:            "policy is happy :)",
+					},
+				},
+			},
+			expAnnotations: map[string]string{
+				"policy.cert-manager.io/approved-by": "policy-a",
+			},
+			expEvent: "Normal Approved policy is happy :)",
+		},
+		"if manager review returns approved with annotations, add them alongside the approved-by annotation": {
+			existingObjects: []runtime.Object{gen.CertificateRequestFrom(baseRequest)},
+			manager: fakemanager.NewFakeManager().WithReview(func(context.Context, *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
+				return manager.ReviewResponse{Result: manager.ResultApproved, Message: "policy is happy :)", Policies: []string{"policy-a"}, Annotations: map[string]string{
+					"example.com/ticket":                 "#123",
+					"policy.cert-manager.io/approved-by": "not-policy-a",
+				}}, nil
 			}),
 			expResult: ctrl.Result{},
 			expError:  false,
@@ -172,6 +282,10 @@ func Test_certificaterequests_Reconcile(t *testing.T) {
 					},
 				},
 			},
+			expAnnotations: map[string]string{
+				"example.com/ticket":                 "#123",
+				"policy.cert-manager.io/approved-by": "policy-a",
+			},
 			expEvent: "Normal Approved policy is happy :)",
 		},
 	}
@@ -192,9 +306,11 @@ func Test_certificaterequests_Reconcile(t *testing.T) {
 				manager:  test.manager,
 				log:      ktesting.NewLogger(t, ktesting.DefaultConfig),
 				clock:    fixedclock,
+
+				denialsAnnotation: test.denialsAnnotation,
 			}
 
-			resp, statusPatch, err := c.reconcileStatusPatch(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: gen.DefaultTestNamespace, Name: requestName}})
+			resp, statusPatch, annotations, _, err := c.reconcileStatusPatch(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: gen.DefaultTestNamespace, Name: requestName}})
 			if (err != nil) != test.expError {
 				t.Errorf("unexpected error, exp=%t got=%v", test.expError, err)
 			}
@@ -215,6 +331,601 @@ func Test_certificaterequests_Reconcile(t *testing.T) {
 			if !apiequality.Semantic.DeepEqual(statusPatch, test.expStatusPatch) {
 				t.Errorf("unexpected Reconcile response, exp=%v got=%v", test.expStatusPatch, statusPatch)
 			}
+
+			if !apiequality.Semantic.DeepEqual(annotations, test.expAnnotations) {
+				t.Errorf("unexpected Reconcile annotations, exp=%v got=%v", test.expAnnotations, annotations)
+			}
+		})
+	}
+}
+
+func Test_certificaterequests_ReconcileDecisionContextLog(t *testing.T) {
+	const requestName = "test-request"
+
+	request := gen.CertificateRequest(requestName,
+		gen.SetCertificateRequestNamespace(gen.DefaultTestNamespace),
+		gen.SetCertificateRequestCSR([]byte("super secret csr")),
+	)
+
+	reviewManager := fakemanager.NewFakeManager().WithReview(func(ctx context.Context, _ *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
+		if trace := manager.ReviewTraceFromContext(ctx); trace != nil {
+			trace.Policies = []string{"policy-a", "policy-b"}
+			trace.Predicates = []manager.PredicateTrace{
+				{Name: "RBACBound", Policies: []string{"policy-a"}, Duration: time.Millisecond},
+			}
+			trace.Evaluations = []manager.EvaluationTrace{
+				{Policy: "policy-a", Evaluator: "allowed", Result: approver.ResultNotDenied, Duration: time.Millisecond},
+			}
+			trace.Duration = time.Second
+		}
+		return manager.ReviewResponse{Result: manager.ResultApproved, Message: "policy is happy :)", Policies: []string{"policy-a"}}, nil
+	})
+
+	tests := map[string]struct {
+		verbosity int
+		expLogged bool
+	}{
+		"if debug logging is disabled, don't log decision context": {
+			verbosity: 2,
+			expLogged: false,
+		},
+		"if debug logging is enabled, log decision context": {
+			verbosity: 4,
+			expLogged: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var logs []string
+			log := funcr.New(func(prefix, args string) {
+				logs = append(logs, args)
+			}, funcr.Options{Verbosity: test.verbosity})
+
+			fakeclient := fakeclient.NewClientBuilder().
+				WithScheme(policyapi.GlobalScheme).
+				WithRuntimeObjects(request).
+				Build()
+
+			c := &certificaterequests{
+				client:   fakeclient,
+				lister:   fakeclient,
+				recorder: record.NewFakeRecorder(1),
+				manager:  reviewManager,
+				log:      log,
+				clock:    fakeclock.NewFakeClock(time.Now()),
+			}
+
+			_, _, _, _, err := c.reconcileStatusPatch(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: gen.DefaultTestNamespace, Name: requestName}})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var decisionLog string
+			for _, l := range logs {
+				if strings.Contains(l, `"msg"="review decision context"`) {
+					decisionLog = l
+				}
+			}
+
+			if logged := len(decisionLog) > 0; logged != test.expLogged {
+				t.Fatalf("unexpected decision context log, exp=%t got=%t: %v", test.expLogged, logged, logs)
+			}
+			if !test.expLogged {
+				return
+			}
+
+			for _, exp := range []string{
+				`"policies"=["policy-a" "policy-b"]`,
+				`"Name"="RBACBound"`,
+				`"Evaluator"="allowed"`,
+				`"Policy"="policy-a"`,
+				`"duration"="1s"`,
+			} {
+				if !strings.Contains(decisionLog, exp) {
+					t.Errorf("expected decision context log to contain %s: %s", exp, decisionLog)
+				}
+			}
+
+			if strings.Contains(decisionLog, "super secret csr") {
+				t.Errorf("expected decision context log to not contain CSR contents: %s", decisionLog)
+			}
+		})
+	}
+}
+
+func Test_certificaterequests_ReconcileLogCorrelation(t *testing.T) {
+	const requestName = "test-request"
+
+	request := gen.CertificateRequest(requestName,
+		gen.SetCertificateRequestNamespace(gen.DefaultTestNamespace),
+		func(cr *cmapi.CertificateRequest) {
+			cr.UID = "test-uid"
+		},
+	)
+
+	reviewManager := fakemanager.NewFakeManager().WithReview(func(ctx context.Context, _ *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
+		logr.FromContextOrDiscard(ctx).Info("reviewing")
+		return manager.ReviewResponse{Result: manager.ResultApproved, Message: "policy is happy :)", Policies: []string{"policy-a"}}, nil
+	})
+
+	var logs []string
+	log := funcr.New(func(prefix, args string) {
+		logs = append(logs, args)
+	}, funcr.Options{Verbosity: 2})
+
+	fakeclient := fakeclient.NewClientBuilder().
+		WithScheme(policyapi.GlobalScheme).
+		WithRuntimeObjects(request).
+		Build()
+
+	c := &certificaterequests{
+		client:   fakeclient,
+		lister:   fakeclient,
+		recorder: record.NewFakeRecorder(1),
+		manager:  reviewManager,
+		log:      log,
+		clock:    fakeclock.NewFakeClock(time.Now()),
+	}
+
+	_, _, _, _, err := c.reconcileStatusPatch(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: gen.DefaultTestNamespace, Name: requestName}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Both the controller and the review path must log with the request's
+	// correlation values.
+	for _, msg := range []string{"approving request", "reviewing"} {
+		var found bool
+		for _, l := range logs {
+			if !strings.Contains(l, fmt.Sprintf(`"msg"=%q`, msg)) {
+				continue
+			}
+			found = true
+			for _, exp := range []string{
+				`"namespace"="` + gen.DefaultTestNamespace + `"`,
+				`"name"="` + requestName + `"`,
+				`"uid"="test-uid"`,
+			} {
+				if !strings.Contains(l, exp) {
+					t.Errorf("expected %q log to contain %s: %s", msg, exp, l)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("expected %q to be logged: %v", msg, logs)
+		}
+	}
+}
+
+func Test_certificaterequests_ReconcileTracing(t *testing.T) {
+	const requestName = "test-request"
+
+	tests := map[string]struct {
+		tracing bool
+	}{
+		"if tracing is disabled, no span should be started and no exemplar attached": {
+			tracing: false,
+		},
+		"if tracing is enabled, the review should be traced and the trace ID attached as an exemplar": {
+			tracing: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			reviewManager := fakemanager.NewFakeManager().WithReview(func(ctx context.Context, _ *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
+				return manager.ReviewResponse{Result: manager.ResultApproved, Message: "policy is happy :)", Policies: []string{"policy-a"}}, nil
+			})
+
+			fakeclient := fakeclient.NewClientBuilder().
+				WithScheme(policyapi.GlobalScheme).
+				WithRuntimeObjects(gen.CertificateRequest(requestName, gen.SetCertificateRequestNamespace(gen.DefaultTestNamespace))).
+				Build()
+
+			reviewMetrics := metrics.NewReviewRecorder(true)
+			registry := prometheus.NewRegistry()
+			if err := registry.Register(reviewMetrics); err != nil {
+				t.Fatal(err)
+			}
+
+			c := &certificaterequests{
+				client:        fakeclient,
+				lister:        fakeclient,
+				recorder:      record.NewFakeRecorder(1),
+				manager:       reviewManager,
+				log:           ktesting.NewLogger(t, ktesting.DefaultConfig),
+				clock:         fakeclock.NewFakeClock(time.Now()),
+				reviewMetrics: reviewMetrics,
+			}
+
+			spans := tracetest.NewSpanRecorder()
+			if test.tracing {
+				c.tracer = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)).Tracer("test")
+			}
+
+			_, _, _, _, err := c.reconcileStatusPatch(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: gen.DefaultTestNamespace, Name: requestName}})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var expTraceIDs []string
+			if test.tracing {
+				if len(spans.Ended()) != 1 {
+					t.Fatalf("expected a single review span, got=%d", len(spans.Ended()))
+				}
+				expTraceIDs = []string{spans.Ended()[0].SpanContext().TraceID().String()}
+			} else if len(spans.Ended()) > 0 {
+				t.Errorf("expected no spans, got=%d", len(spans.Ended()))
+			}
+
+			families, err := registry.Gather()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var traceIDs []string
+			for _, family := range families {
+				if family.GetName() != "approverpolicy_certificaterequest_review_duration_seconds" {
+					continue
+				}
+				for _, metric := range family.GetMetric() {
+					for _, bucket := range metric.GetHistogram().GetBucket() {
+						for _, label := range bucket.GetExemplar().GetLabel() {
+							if label.GetName() == "trace_id" {
+								traceIDs = append(traceIDs, label.GetValue())
+							}
+						}
+					}
+				}
+			}
+			if !apiequality.Semantic.DeepEqual(traceIDs, expTraceIDs) {
+				t.Errorf("unexpected exemplar trace IDs, exp=%v got=%v", expTraceIDs, traceIDs)
+			}
+		})
+	}
+}
+
+func Test_certificaterequests_ReconcileAuditLog(t *testing.T) {
+	const requestName = "test-request"
+
+	patchErr := errors.New("conflict")
+
+	tests := map[string]struct {
+		annotationsPatchErr error
+		statusPatchErr      error
+		expRecords          int
+	}{
+		"if the annotations patch fails, the decision should not be recorded": {
+			annotationsPatchErr: patchErr,
+			expRecords:          0,
+		},
+		"if the status patch fails, the decision should not be recorded": {
+			statusPatchErr: patchErr,
+			expRecords:     0,
+		},
+		"if both patches succeed, the decision should be recorded once": {
+			expRecords: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			reviewManager := fakemanager.NewFakeManager().WithReview(func(context.Context, *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
+				return manager.ReviewResponse{Result: manager.ResultApproved, Message: "policy is happy :)", Policies: []string{"policy-a"}}, nil
+			})
+
+			// The fake client doesn't support server-side apply, so patches
+			// are intercepted.
+			fakeclient := fakeclient.NewClientBuilder().
+				WithScheme(policyapi.GlobalScheme).
+				WithRuntimeObjects(gen.CertificateRequest(requestName, gen.SetCertificateRequestNamespace(gen.DefaultTestNamespace))).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(context.Context, client.WithWatch, client.Object, client.Patch, ...client.PatchOption) error {
+						return test.annotationsPatchErr
+					},
+					SubResourcePatch: func(context.Context, client.Client, string, client.Object, client.Patch, ...client.SubResourcePatchOption) error {
+						return test.statusPatchErr
+					},
+				}).
+				Build()
+
+			var auditLog bytes.Buffer
+			c := &certificaterequests{
+				client:   fakeclient,
+				lister:   fakeclient,
+				recorder: record.NewFakeRecorder(1),
+				manager:  reviewManager,
+				log:      ktesting.NewLogger(t, ktesting.DefaultConfig),
+				clock:    fakeclock.NewFakeClock(time.Now()),
+				auditLog: audit.New(&auditLog),
+			}
+
+			_, err := c.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: gen.DefaultTestNamespace, Name: requestName}})
+			expErr := test.annotationsPatchErr != nil || test.statusPatchErr != nil
+			if (err != nil) != expErr {
+				t.Errorf("unexpected error, exp=%t got=%v", expErr, err)
+			}
+
+			if records := strings.Count(auditLog.String(), "\n"); records != test.expRecords {
+				t.Errorf("unexpected number of audit records, exp=%d got=%d: %s", test.expRecords, records, auditLog.String())
+			}
+		})
+	}
+}
+
+func Test_certificaterequests_ReconcileReviewRecord(t *testing.T) {
+	const requestName = "test-request"
+
+	fixedTime := time.Date(2021, 01, 01, 01, 0, 0, 0, time.UTC)
+
+	reviewManager := fakemanager.NewFakeManager().WithReview(func(ctx context.Context, _ *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
+		if trace := manager.ReviewTraceFromContext(ctx); trace != nil {
+			trace.Policies = []string{"policy-a", "policy-b", "policy-c"}
+			trace.Predicates = []manager.PredicateTrace{
+				{Name: "SelectorNamespace", Policies: []string{"policy-a", "policy-b"}, Duration: time.Millisecond},
+				{Name: "RBACBound", Policies: []string{"policy-a"}, Duration: time.Millisecond},
+			}
+			trace.BoundPolicies = []string{"policy-a"}
+			trace.Evaluations = []manager.EvaluationTrace{
+				{Policy: "policy-a", Evaluator: "allowed", Result: approver.ResultDenied, Message: "not allowed", Duration: time.Millisecond},
+				{Policy: "policy-a", Evaluator: "constraints", Result: approver.ResultNotDenied, Duration: time.Millisecond},
+				{Policy: "baseline", Evaluator: "allowed", Result: approver.ResultDenied, Message: "baseline secret", Duration: time.Millisecond},
+			}
+		}
+		return manager.ReviewResponse{Result: manager.ResultDenied, Message: "No policy approved this request", Policies: []string{"policy-a"}}, nil
+	})
+
+	tests := map[string]struct {
+		debugReviews bool
+		annotations  map[string]string
+		expReview    *policyapi.CertificateRequestReviewStatus
+	}{
+		"if request has no debug annotation, don't record a review": {
+			debugReviews: true,
+			annotations:  nil,
+			expReview:    nil,
+		},
+		"if request has debug annotation set to false, don't record a review": {
+			debugReviews: true,
+			annotations:  map[string]string{policyapi.CertificateRequestAnnotationDebugReview: "false"},
+			expReview:    nil,
+		},
+		"if request has debug annotation set to true but debug reviews are disabled, don't record a review": {
+			debugReviews: false,
+			annotations:  map[string]string{policyapi.CertificateRequestAnnotationDebugReview: "true"},
+			expReview:    nil,
+		},
+		"if request has debug annotation set to true, record the review of only the bound policies": {
+			debugReviews: true,
+			annotations:  map[string]string{policyapi.CertificateRequestAnnotationDebugReview: "true"},
+			expReview: &policyapi.CertificateRequestReviewStatus{
+				Result:     policyapi.CertificateRequestReviewResultDenied,
+				Message:    "No policy approved this request",
+				ReviewTime: metav1.NewTime(fixedTime),
+				Policies:   []string{"policy-a"},
+				Predicates: []policyapi.CertificateRequestReviewPredicate{
+					{Name: "SelectorNamespace", Policies: []string{"policy-a"}},
+					{Name: "RBACBound", Policies: []string{"policy-a"}},
+				},
+				Evaluations: []policyapi.CertificateRequestReviewEvaluation{
+					{Policy: "policy-a", Evaluator: "allowed", Denied: true, Message: "not allowed"},
+					{Policy: "policy-a", Evaluator: "constraints", Denied: false},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			request := gen.CertificateRequest(requestName,
+				gen.SetCertificateRequestNamespace(gen.DefaultTestNamespace),
+				func(cr *cmapi.CertificateRequest) {
+					cr.UID = "test-uid"
+					cr.Annotations = test.annotations
+				},
+			)
+
+			fakeclient := fakeclient.NewClientBuilder().
+				WithScheme(policyapi.GlobalScheme).
+				WithRuntimeObjects(request).
+				Build()
+
+			c := &certificaterequests{
+				client:   fakeclient,
+				lister:   fakeclient,
+				recorder: record.NewFakeRecorder(1),
+				manager:  reviewManager,
+				log:      ktesting.NewLogger(t, ktesting.DefaultConfig),
+				clock:    fakeclock.NewFakeClock(fixedTime),
+
+				debugReviews: test.debugReviews,
+			}
+
+			_, _, _, _, err := c.reconcileStatusPatch(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: gen.DefaultTestNamespace, Name: requestName}})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var review policyapi.CertificateRequestReview
+			err = fakeclient.Get(context.TODO(), types.NamespacedName{Namespace: gen.DefaultTestNamespace, Name: requestName}, &review)
+			if test.expReview == nil {
+				if !apierrors.IsNotFound(err) {
+					t.Fatalf("expected no CertificateRequestReview, got err=%v review=%v", err, review)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error getting CertificateRequestReview: %v", err)
+			}
+
+			if !apiequality.Semantic.DeepEqual(review.Status, *test.expReview) {
+				t.Errorf("unexpected CertificateRequestReview status, exp=%v got=%v", *test.expReview, review.Status)
+			}
+
+			owner := metav1.GetControllerOf(&review)
+			if owner == nil || owner.Kind != "CertificateRequest" || owner.Name != requestName || owner.UID != "test-uid" {
+				t.Errorf("expected CertificateRequestReview to be controlled by the CertificateRequest, got=%v", owner)
+			}
+		})
+	}
+}
+
+func Test_certificaterequests_ReconcilePending(t *testing.T) {
+	const (
+		requestName     = "test-request"
+		requeueInterval = 30 * time.Second
+	)
+
+	createdTime := time.Date(2021, 01, 01, 01, 0, 0, 0, time.UTC)
+
+	policies := []policyapi.CertificateRequestPolicy{
+		{ObjectMeta: metav1.ObjectMeta{Name: "policy-denied"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "policy-external"}},
+	}
+
+	// newManager returns a manager which evaluates the policies with a fake
+	// plugin awaiting an external decision for policy-external, which is
+	// resolved with the given result after resolveAfter reviews.
+	newManager := func(resolveAfter int, result approver.EvaluationResult) manager.Interface {
+		var reviews int
+		plugin := fakeapprover.NewFakeEvaluator().WithEvaluate(func(_ context.Context, policy *policyapi.CertificateRequestPolicy, _ *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+			if policy.Name == "policy-denied" {
+				return approver.EvaluationResponse{Result: approver.ResultDenied, Message: "not allowed"}, nil
+			}
+			reviews++
+			if reviews <= resolveAfter {
+				return approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: "awaiting ticket approval", Pending: true}, nil
+			}
+			return approver.EvaluationResponse{Result: result, Message: "ticket resolved"}, nil
+		})
+		return fakemanager.NewFakeManager().WithReview(func(ctx context.Context, cr *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
+			return internalmanager.Evaluate(ctx, []approver.Evaluator{plugin}, policies, cr)
+		})
+	}
+
+	tests := map[string]struct {
+		resolveAfter   int
+		result         approver.EvaluationResult
+		pendingTimeout time.Duration
+		// elapsed is the time which passes between each review.
+		elapsed time.Duration
+
+		expRequeueAfter []time.Duration
+		expCondition    cmapi.CertificateRequestConditionType
+		expMessage      string
+	}{
+		"if the plugin approves after pending, the request should be requeued until approved": {
+			resolveAfter:    3,
+			result:          approver.ResultNotDenied,
+			elapsed:         requeueInterval,
+			expRequeueAfter: []time.Duration{requeueInterval, requeueInterval, requeueInterval},
+			expCondition:    cmapi.CertificateRequestConditionApproved,
+			expMessage:      `Approved by CertificateRequestPolicy: "policy-external"`,
+		},
+		"if the plugin denies after pending, the request should be requeued until denied": {
+			resolveAfter:    3,
+			result:          approver.ResultDenied,
+			elapsed:         requeueInterval,
+			expRequeueAfter: []time.Duration{requeueInterval, requeueInterval, requeueInterval},
+			expCondition:    cmapi.CertificateRequestConditionDenied,
+			expMessage:      "No policy approved this request: [policy-denied: not allowed] [policy-external: ticket resolved]",
+		},
+		"if no timeout is configured, the request should never be denied for being pending": {
+			resolveAfter:    3,
+			result:          approver.ResultNotDenied,
+			elapsed:         24 * time.Hour,
+			expRequeueAfter: []time.Duration{requeueInterval, requeueInterval, requeueInterval},
+			expCondition:    cmapi.CertificateRequestConditionApproved,
+			expMessage:      `Approved by CertificateRequestPolicy: "policy-external"`,
+		},
+		"if the request times out whilst pending, the request should be denied": {
+			resolveAfter:    10,
+			result:          approver.ResultNotDenied,
+			pendingTimeout:  time.Minute + 10*time.Second,
+			elapsed:         requeueInterval,
+			expRequeueAfter: []time.Duration{requeueInterval, requeueInterval, 10 * time.Second},
+			expCondition:    cmapi.CertificateRequestConditionDenied,
+			expMessage:      "Awaiting external approval: [policy-external: awaiting ticket approval] (timed out after 1m10s)",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			request := gen.CertificateRequest(requestName,
+				gen.SetCertificateRequestNamespace(gen.DefaultTestNamespace),
+				func(cr *cmapi.CertificateRequest) {
+					cr.CreationTimestamp = metav1.NewTime(createdTime)
+				},
+			)
+
+			fakeclient := fakeclient.NewClientBuilder().
+				WithScheme(policyapi.GlobalScheme).
+				WithRuntimeObjects(request).
+				Build()
+
+			fakeclock := fakeclock.NewFakeClock(createdTime)
+			fakerecorder := record.NewFakeRecorder(len(test.expRequeueAfter) + 1)
+			c := &certificaterequests{
+				client:                 fakeclient,
+				lister:                 fakeclient,
+				recorder:               fakerecorder,
+				manager:                newManager(test.resolveAfter, test.result),
+				log:                    ktesting.NewLogger(t, ktesting.DefaultConfig),
+				clock:                  fakeclock,
+				pendingRequeueInterval: requeueInterval,
+				pendingTimeout:         test.pendingTimeout,
+			}
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: gen.DefaultTestNamespace, Name: requestName}}
+
+			for i, expRequeueAfter := range test.expRequeueAfter {
+				result, statusPatch, annotations, _, err := c.reconcileStatusPatch(context.TODO(), req)
+				if err != nil {
+					t.Fatalf("unexpected error on review %d: %v", i, err)
+				}
+				if statusPatch != nil || annotations != nil {
+					t.Fatalf("expected pending request to be neither approved nor denied on review %d, got status=%v annotations=%v", i, statusPatch, annotations)
+				}
+				if result.RequeueAfter != expRequeueAfter {
+					t.Errorf("unexpected requeue on review %d, exp=%s got=%s", i, expRequeueAfter, result.RequeueAfter)
+				}
+				if event := <-fakerecorder.Events; event != "Normal AwaitingExternalApproval Awaiting external approval: [policy-external: awaiting ticket approval]" {
+					t.Errorf("unexpected event on review %d: %q", i, event)
+				}
+				fakeclock.Step(test.elapsed)
+			}
+
+			result, statusPatch, _, _, err := c.reconcileStatusPatch(context.TODO(), req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != (ctrl.Result{}) {
+				t.Errorf("expected no requeue once resolved, got=%v", result)
+			}
+			if statusPatch == nil || len(statusPatch.Conditions) != 1 {
+				t.Fatalf("expected a single status condition once resolved, got=%v", statusPatch)
+			}
+			if cond := statusPatch.Conditions[0]; cond.Type != test.expCondition || cond.Message != test.expMessage {
+				t.Errorf("unexpected condition, exp=%s %q got=%s %q", test.expCondition, test.expMessage, cond.Type, cond.Message)
+			}
+		})
+	}
+}
+
+func Test_unprocessedReasonLabel(t *testing.T) {
+	tests := map[string]struct {
+		message string
+		exp     string
+	}{
+		"no policies exist":          {message: manager.MessageNoPolicies, exp: "no_policies"},
+		"no policies are applicable": {message: manager.MessageNoApplicablePolicies, exp: "no_applicable_policies"},
+		"unknown message":            {message: "something else", exp: "unknown"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := unprocessedReasonLabel(test.message); got != test.exp {
+				t.Errorf("unexpected label, exp=%q got=%q", test.exp, got)
+			}
 		})
 	}
 }
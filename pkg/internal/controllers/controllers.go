@@ -19,11 +19,17 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/trace"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	"github.com/cert-manager/approver-policy/pkg/approver"
+	internalmanager "github.com/cert-manager/approver-policy/pkg/internal/approver/manager"
+	"github.com/cert-manager/approver-policy/pkg/internal/audit"
+	"github.com/cert-manager/approver-policy/pkg/internal/metrics"
 )
 
 // Options hold options for the internal approver-policy controllers.
@@ -42,10 +48,94 @@ type Options struct {
 	// Reconcilers is the list of registered Approver Reconcilers that  will be
 	// used to manager CertificateRequestPolicy Ready conditions.
 	Reconcilers []approver.Reconciler
+
+	// EnqueueChans are additional channels that, when a message is received,
+	// will reconcile the CertificateRequestPolicy with the given name.
+	EnqueueChans []<-chan string
+
+	// BaselinePolicy is the name of the CertificateRequestPolicy which every
+	// request must pass in addition to being approved by a selected policy.
+	// Empty if no baseline policy is configured.
+	BaselinePolicy string
+
+	// MaxPoliciesPerRequest is the maximum number of selected policies which
+	// are evaluated for a single request. Zero means no limit.
+	MaxPoliciesPerRequest int
+
+	// PolicyEvaluationConcurrency is the maximum number of policies which
+	// are evaluated concurrently for a single request.
+	PolicyEvaluationConcurrency int
+
+	// RequireExplicitSelectors causes policies which omit a namespace
+	// selector to match no requests, rather than requests in any namespace.
+	RequireExplicitSelectors bool
+
+	// DenyOnNoApplicablePolicy causes requests which no ready policy is bound
+	// or applicable to be denied, rather than left unprocessed.
+	DenyOnNoApplicablePolicy bool
+
+	// DependencyResyncPeriod is the period at which CertificateRequestPolicies
+	// which declare dependencies are re-synced, i.e. the maximum latency for
+	// their readiness to reflect a dependency being created or deleted.
+	DependencyResyncPeriod time.Duration
+
+	// PendingRequeueInterval is the interval at which requests awaiting an
+	// external decision are reviewed again.
+	PendingRequeueInterval time.Duration
+
+	// PendingTimeout is the duration since creation after which requests
+	// still awaiting an external decision are denied. Zero means never.
+	PendingTimeout time.Duration
+
+	// EvaluatorTimeout is the maximum duration of a single evaluator call.
+	// Zero means no timeout.
+	EvaluatorTimeout time.Duration
+
+	// CircuitBreakerThreshold is the number of consecutive timeouts after
+	// which the policies of a plugin are marked as not ready. Zero or less
+	// disables the circuit breaker.
+	CircuitBreakerThreshold int
+
+	// CircuitBreakerCooldown is the duration for which the policies of a
+	// plugin are marked as not ready once its circuit breaker opens.
+	CircuitBreakerCooldown time.Duration
+
+	// circuitBreaker is built from the circuit breaker options when
+	// EvaluatorTimeout is set, and shared by the controllers.
+	circuitBreaker *internalmanager.CircuitBreaker
+
+	// DenialsAnnotation enables writing the policies which denied a request,
+	// and their reasons, as a JSON annotation on denied requests.
+	DenialsAnnotation bool
+
+	// DebugReviews enables recording CertificateRequestReviews for requests
+	// which request one with the debug-review annotation.
+	DebugReviews bool
+
+	// ReviewMetrics records the latency of CertificateRequest reviews. May be
+	// nil, in which case no latency is recorded.
+	ReviewMetrics *metrics.ReviewRecorder
+
+	// AuditLog records every approve and deny decision. May be nil, in which
+	// case no decisions are recorded.
+	AuditLog *audit.Logger
+
+	// TracerProvider is used to start a span around each CertificateRequest
+	// review. May be nil, in which case reviews are not traced.
+	TracerProvider trace.TracerProvider
+
+	// PolicyMetrics records the readiness of CertificateRequestPolicies. May be
+	// nil, in which case no readiness is recorded.
+	PolicyMetrics *metrics.PolicyRecorder
 }
 
 // AddControllers adds all internal controllers.
 func AddControllers(ctx context.Context, opts Options) error {
+	if opts.EvaluatorTimeout > 0 && opts.CircuitBreakerThreshold > 0 {
+		opts.circuitBreaker = internalmanager.NewCircuitBreaker(opts.CircuitBreakerThreshold, opts.CircuitBreakerCooldown)
+		opts.Reconcilers = append(slices.Clone(opts.Reconcilers), opts.circuitBreaker)
+	}
+
 	if err := addCertificateRequestController(ctx, opts); err != nil {
 		return fmt.Errorf("failed to add certificaterequest controller: %w", err)
 	}
@@ -227,6 +227,8 @@ func startControllers(registry *registry.Registry) (context.Context, func(), cor
 		Manager:     mgr,
 		Evaluators:  registry.Evaluators(),
 		Reconcilers: registry.Reconcilers(),
+
+		DependencyResyncPeriod: time.Minute,
 	})).NotTo(HaveOccurred())
 
 	By("Running Policy controller")
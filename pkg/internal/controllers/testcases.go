@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/url"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/approver/manager"
+	internalmanager "github.com/cert-manager/approver-policy/pkg/internal/approver/manager"
+)
+
+// runTestCases evaluates the given policy against each of its test cases,
+// returning a message for every test case which didn't give the expected
+// result. Only the given policy is evaluated, so its selector is ignored.
+func runTestCases(ctx context.Context, evaluators []approver.Evaluator, policy *policyapi.CertificateRequestPolicy) ([]string, error) {
+	var failures []string
+	for _, testCase := range policy.Spec.TestCases {
+		cr, err := testCaseRequest(testCase.Request)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: invalid request: %s", testCase.Name, err))
+			continue
+		}
+
+		response, err := internalmanager.Evaluate(ctx, evaluators, []policyapi.CertificateRequestPolicy{*policy}, cr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate test case %q: %w", testCase.Name, err)
+		}
+
+		if got := testCaseResult(response.Result); got != testCase.Expect {
+			failures = append(failures, fmt.Sprintf("%s: expected %s, got %s: %s", testCase.Name, testCase.Expect, got, response.Message))
+		}
+	}
+	return failures, nil
+}
+
+// testCaseResult returns the test case result for the result of a review.
+func testCaseResult(result manager.ReviewResult) policyapi.CertificateRequestPolicyTestCaseResult {
+	switch result {
+	case manager.ResultApproved:
+		return policyapi.CertificateRequestPolicyTestCaseResultApproved
+	case manager.ResultDenied:
+		return policyapi.CertificateRequestPolicyTestCaseResultDenied
+	default:
+		return policyapi.CertificateRequestPolicyTestCaseResult(reviewResult(result))
+	}
+}
+
+// testCaseRequest builds a CertificateRequest, including a CSR signed by a new
+// ECDSA P-256 key, from the attributes of a test case request.
+func testCaseRequest(request policyapi.CertificateRequestPolicyTestCaseRequest) (*cmapi.CertificateRequest, error) {
+	template := &x509.CertificateRequest{
+		Subject:        pkix.Name{CommonName: request.CommonName},
+		DNSNames:       request.DNSNames,
+		EmailAddresses: request.EmailAddresses,
+	}
+	for _, ip := range request.IPAddresses {
+		parsed := net.ParseIP(ip)
+		if parsed == nil {
+			return nil, fmt.Errorf("invalid IP address %q", ip)
+		}
+		template.IPAddresses = append(template.IPAddresses, parsed)
+	}
+	for _, uri := range request.URIs {
+		parsed, err := url.Parse(uri)
+		if err != nil {
+			return nil, fmt.Errorf("invalid URI %q: %w", uri, err)
+		}
+		template.URIs = append(template.URIs, parsed)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate private key: %w", err)
+	}
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CSR: %w", err)
+	}
+
+	return &cmapi.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-case", Namespace: request.Namespace},
+		Spec: cmapi.CertificateRequestSpec{
+			Request:   pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}),
+			IssuerRef: request.IssuerRef,
+			IsCA:      request.IsCA,
+			Usages:    request.Usages,
+			Duration:  request.Duration,
+			Username:  request.Username,
+		},
+	}, nil
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2/ktesting"
+	fakeclock "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/internal/approver/allowed"
+)
+
+func Test_certificaterequestpolicies_ReconcileTestCases(t *testing.T) {
+	fixedTime := time.Date(2021, 01, 01, 01, 0, 0, 0, time.UTC)
+
+	allowedSpec := policyapi.CertificateRequestPolicySpec{
+		Allowed: &policyapi.CertificateRequestPolicyAllowed{
+			DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+				Validations: []policyapi.ValidationRule{{Rule: "self.endsWith(cr.namespace + '.svc')"}},
+			},
+		},
+	}
+	approvedCase := policyapi.CertificateRequestPolicyTestCase{
+		Name:    "local-service",
+		Request: policyapi.CertificateRequestPolicyTestCaseRequest{Namespace: "sandbox", DNSNames: []string{"app.sandbox.svc"}},
+		Expect:  policyapi.CertificateRequestPolicyTestCaseResultApproved,
+	}
+	deniedCase := policyapi.CertificateRequestPolicyTestCase{
+		Name:    "other-namespace",
+		Request: policyapi.CertificateRequestPolicyTestCaseRequest{Namespace: "sandbox", DNSNames: []string{"app.prod.svc"}},
+		Expect:  policyapi.CertificateRequestPolicyTestCaseResultDenied,
+	}
+	wrongCase := policyapi.CertificateRequestPolicyTestCase{
+		Name:    "wrong-expectation",
+		Request: policyapi.CertificateRequestPolicyTestCaseRequest{Namespace: "sandbox", DNSNames: []string{"example.com"}},
+		Expect:  policyapi.CertificateRequestPolicyTestCaseResultApproved,
+	}
+	invalidCase := policyapi.CertificateRequestPolicyTestCase{
+		Name:    "invalid-ip",
+		Request: policyapi.CertificateRequestPolicyTestCaseRequest{IPAddresses: []string{"not-an-ip"}},
+		Expect:  policyapi.CertificateRequestPolicyTestCaseResultDenied,
+	}
+
+	tests := map[string]struct {
+		testCases    []policyapi.CertificateRequestPolicyTestCase
+		expCondition *policyapi.CertificateRequestPolicyCondition
+		expEvents    []string
+	}{
+		"if policy has no test cases, don't set the TestsPassed condition": {
+			expEvents: []string{"Normal Ready CertificateRequestPolicy is ready for approval evaluation"},
+		},
+		"if all test cases pass, set TestsPassed to true": {
+			testCases: []policyapi.CertificateRequestPolicyTestCase{approvedCase, deniedCase},
+			expCondition: &policyapi.CertificateRequestPolicyCondition{
+				Type:    policyapi.CertificateRequestPolicyConditionTestsPassed,
+				Status:  corev1.ConditionTrue,
+				Reason:  "TestsPassed",
+				Message: "All 2 test cases gave the expected result",
+			},
+			expEvents: []string{"Normal Ready CertificateRequestPolicy is ready for approval evaluation"},
+		},
+		"if some test cases fail, set TestsPassed to false listing the failures": {
+			testCases: []policyapi.CertificateRequestPolicyTestCase{approvedCase, wrongCase, invalidCase},
+			expCondition: &policyapi.CertificateRequestPolicyCondition{
+				Type:   policyapi.CertificateRequestPolicyConditionTestsPassed,
+				Status: corev1.ConditionFalse,
+				Reason: "TestsFailed",
+				Message: `2 of 3 test cases failed: wrong-expectation: expected Approved, got Denied: No policy approved this request: [test-policy: spec.allowed.dnsNames.validations[0]: Invalid value: "example.com": failed rule: self.endsWith(cr.namespace + '.svc')]; ` +
+					`invalid-ip: invalid request: invalid IP address "not-an-ip"`,
+			},
+			expEvents: []string{
+				"Normal Ready CertificateRequestPolicy is ready for approval evaluation",
+				`Warning TestsFailed 2 of 3 test cases failed: wrong-expectation: expected Approved, got Denied: No policy approved this request: [test-policy: spec.allowed.dnsNames.validations[0]: Invalid value: "example.com": failed rule: self.endsWith(cr.namespace + '.svc')]; ` +
+					`invalid-ip: invalid request: invalid IP address "not-an-ip"`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			spec := *allowedSpec.DeepCopy()
+			spec.TestCases = test.testCases
+			policy := &policyapi.CertificateRequestPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-policy", Generation: 1, ResourceVersion: "3"},
+				Spec:       spec,
+			}
+
+			fakeclient := fakeclient.NewClientBuilder().
+				WithScheme(policyapi.GlobalScheme).
+				WithObjects(policy).
+				Build()
+
+			fakerecorder := record.NewFakeRecorder(2)
+
+			c := &certificaterequestpolicies{
+				log:              ktesting.NewLogger(t, ktesting.DefaultConfig),
+				clock:            fakeclock.NewFakeClock(fixedTime),
+				client:           fakeclient,
+				lister:           fakeclient,
+				recorder:         fakerecorder,
+				evaluators:       []approver.Evaluator{allowed.Approver()},
+				dependencyReader: fakeclient,
+			}
+
+			_, statusPatch, err := c.reconcileStatusPatch(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "test-policy"}})
+			assert.NoError(t, err)
+
+			var condition *policyapi.CertificateRequestPolicyCondition
+			for _, c := range statusPatch.Conditions {
+				if c.Type == policyapi.CertificateRequestPolicyConditionTestsPassed {
+					c.LastTransitionTime, c.ObservedGeneration = nil, 0
+					condition = &c
+				}
+			}
+			assert.Equal(t, test.expCondition, condition)
+
+			var events []string
+			for len(fakerecorder.Events) > 0 {
+				events = append(events, <-fakerecorder.Events)
+			}
+			assert.Equal(t, test.expEvents, events)
+		})
+	}
+}
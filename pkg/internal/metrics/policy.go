@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// PolicyRecorder records the readiness of CertificateRequestPolicies, so that
+// every policy which is currently not ready, along with the reason why, can be
+// found in one place.
+type PolicyRecorder struct {
+	ready    *prometheus.GaugeVec
+	notReady *prometheus.GaugeVec
+}
+
+// RegisterPolicyMetrics registers and returns a PolicyRecorder with the
+// controller-runtime metrics registry.
+func RegisterPolicyMetrics() *PolicyRecorder {
+	r := NewPolicyRecorder()
+	metrics.Registry.MustRegister(r)
+	return r
+}
+
+// NewPolicyRecorder returns a PolicyRecorder which has not been registered
+// with any registry.
+func NewPolicyRecorder() *PolicyRecorder {
+	return &PolicyRecorder{
+		ready: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "approverpolicy_policy_ready",
+			Help: "Whether a CertificateRequestPolicy is ready for approval evaluation (1) or not (0).",
+		}, []string{"policy"}),
+		notReady: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "approverpolicy_policy_not_ready_info",
+			Help: "Set to 1 for every CertificateRequestPolicy which is not ready, with the reason it is not ready as a label.",
+		}, []string{"policy", "reason"}),
+	}
+}
+
+// SetReady records that the named policy is ready.
+// A nil PolicyRecorder records nothing.
+func (r *PolicyRecorder) SetReady(policy string) {
+	if r == nil {
+		return
+	}
+
+	r.ready.WithLabelValues(policy).Set(1)
+	r.notReady.DeletePartialMatch(prometheus.Labels{"policy": policy})
+}
+
+// SetNotReady records that the named policy is not ready for the given
+// reason. Any previously recorded reason for the policy is replaced.
+// A nil PolicyRecorder records nothing.
+func (r *PolicyRecorder) SetNotReady(policy, reason string) {
+	if r == nil {
+		return
+	}
+
+	r.ready.WithLabelValues(policy).Set(0)
+	r.notReady.DeletePartialMatch(prometheus.Labels{"policy": policy})
+	r.notReady.WithLabelValues(policy, reason).Set(1)
+}
+
+// Delete removes all recorded metrics for the named policy, i.e. once it has
+// been deleted.
+// A nil PolicyRecorder records nothing.
+func (r *PolicyRecorder) Delete(policy string) {
+	if r == nil {
+		return
+	}
+
+	r.ready.DeleteLabelValues(policy)
+	r.notReady.DeletePartialMatch(prometheus.Labels{"policy": policy})
+}
+
+func (r *PolicyRecorder) Describe(ch chan<- *prometheus.Desc) {
+	r.ready.Describe(ch)
+	r.notReady.Describe(ch)
+}
+
+func (r *PolicyRecorder) Collect(ch chan<- prometheus.Metric) {
+	r.ready.Collect(ch)
+	r.notReady.Collect(ch)
+}
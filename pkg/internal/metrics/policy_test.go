@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PolicyRecorder(t *testing.T) {
+	r := NewPolicyRecorder()
+
+	expect := func(t *testing.T, exp string) {
+		t.Helper()
+		assert.NoError(t, testutil.CollectAndCompare(r, strings.NewReader(exp), "approverpolicy_policy_ready", "approverpolicy_policy_not_ready_info"))
+	}
+
+	r.SetReady("policy-a")
+	r.SetNotReady("policy-b", "missing secret")
+	expect(t, `
+# HELP approverpolicy_policy_not_ready_info Set to 1 for every CertificateRequestPolicy which is not ready, with the reason it is not ready as a label.
+# TYPE approverpolicy_policy_not_ready_info gauge
+approverpolicy_policy_not_ready_info{policy="policy-b",reason="missing secret"} 1
+# HELP approverpolicy_policy_ready Whether a CertificateRequestPolicy is ready for approval evaluation (1) or not (0).
+# TYPE approverpolicy_policy_ready gauge
+approverpolicy_policy_ready{policy="policy-a"} 1
+approverpolicy_policy_ready{policy="policy-b"} 0
+`)
+
+	// A changed reason replaces the previous one, and becoming ready clears it.
+	r.SetNotReady("policy-a", "bad plugin")
+	r.SetNotReady("policy-b", "missing configmap")
+	r.SetReady("policy-b")
+	expect(t, `
+# HELP approverpolicy_policy_not_ready_info Set to 1 for every CertificateRequestPolicy which is not ready, with the reason it is not ready as a label.
+# TYPE approverpolicy_policy_not_ready_info gauge
+approverpolicy_policy_not_ready_info{policy="policy-a",reason="bad plugin"} 1
+# HELP approverpolicy_policy_ready Whether a CertificateRequestPolicy is ready for approval evaluation (1) or not (0).
+# TYPE approverpolicy_policy_ready gauge
+approverpolicy_policy_ready{policy="policy-a"} 0
+approverpolicy_policy_ready{policy="policy-b"} 1
+`)
+
+	r.Delete("policy-a")
+	expect(t, `
+# HELP approverpolicy_policy_ready Whether a CertificateRequestPolicy is ready for approval evaluation (1) or not (0).
+# TYPE approverpolicy_policy_ready gauge
+approverpolicy_policy_ready{policy="policy-b"} 1
+`)
+
+	t.Run("a nil recorder should record nothing", func(t *testing.T) {
+		var r *PolicyRecorder
+		r.SetReady("policy-a")
+		r.SetNotReady("policy-a", "reason")
+		r.Delete("policy-a")
+	})
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
+)
+
+// ReviewRecorder records the latency of CertificateRequest review decisions,
+// and the reviews which were left unprocessed.
+// When exemplars are enabled, each observation made with a sampled trace in
+// its context is annotated with the trace ID, so that a latency spike can be
+// followed through to the trace of a slow review.
+type ReviewRecorder struct {
+	duration    *prometheus.HistogramVec
+	unprocessed *prometheus.CounterVec
+	exemplars   bool
+}
+
+// RegisterReviewMetrics registers and returns a ReviewRecorder with the
+// controller-runtime metrics registry. Exemplars are only attached if
+// exemplars is true.
+func RegisterReviewMetrics(exemplars bool) *ReviewRecorder {
+	r := NewReviewRecorder(exemplars)
+	metrics.Registry.MustRegister(r)
+	return r
+}
+
+// NewReviewRecorder returns a ReviewRecorder which has not been registered
+// with any registry. Exemplars are only attached if exemplars is true.
+func NewReviewRecorder(exemplars bool) *ReviewRecorder {
+	return &ReviewRecorder{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "approverpolicy_certificaterequest_review_duration_seconds",
+			Help:    "Time taken to review a CertificateRequest, partitioned by the review result.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"result"}),
+		unprocessed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "approverpolicy_certificaterequest_unprocessed_total",
+			Help: "Number of CertificateRequest reviews which were neither approved nor denied because no policy applied, partitioned by the reason.",
+		}, []string{"reason"}),
+		exemplars: exemplars,
+	}
+}
+
+// Observe records a review of the given duration which resulted in result.
+// A nil ReviewRecorder records nothing.
+func (r *ReviewRecorder) Observe(ctx context.Context, result string, duration time.Duration) {
+	if r == nil {
+		return
+	}
+
+	observer := r.duration.WithLabelValues(result)
+
+	if r.exemplars {
+		if span := trace.SpanContextFromContext(ctx); span.IsValid() && span.IsSampled() {
+			observer.(prometheus.ExemplarObserver).ObserveWithExemplar(duration.Seconds(), prometheus.Labels{
+				"trace_id": span.TraceID().String(),
+			})
+			return
+		}
+	}
+
+	observer.Observe(duration.Seconds())
+}
+
+// ObserveUnprocessed records a review which resulted in neither an approval
+// nor a denial for the given reason. Such requests are never issued unless a
+// policy is later created for them, so are worth alerting on.
+// A nil ReviewRecorder records nothing.
+func (r *ReviewRecorder) ObserveUnprocessed(reason string) {
+	if r == nil {
+		return
+	}
+	r.unprocessed.WithLabelValues(reason).Inc()
+}
+
+func (r *ReviewRecorder) Describe(ch chan<- *prometheus.Desc) {
+	r.duration.Describe(ch)
+	r.unprocessed.Describe(ch)
+}
+
+func (r *ReviewRecorder) Collect(ch chan<- prometheus.Metric) {
+	r.duration.Collect(ch)
+	r.unprocessed.Collect(ch)
+}
+
+// OpenMetricsFilterProvider returns a metrics server FilterProvider which
+// serves metrics in the OpenMetrics format when requested by the scraper.
+// Exemplars are only exposed in the OpenMetrics format.
+func OpenMetricsFilterProvider(_ *rest.Config, _ *http.Client) (server.Filter, error) {
+	return func(_ logr.Logger, _ http.Handler) (http.Handler, error) {
+		return promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{
+			ErrorHandling:     promhttp.HTTPErrorOnError,
+			EnableOpenMetrics: true,
+		}), nil
+	}, nil
+}
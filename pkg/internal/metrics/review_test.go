@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Exemplars attached from a sampled trace are tested through the
+// certificaterequests controller, which starts the review span.
+func Test_ReviewRecorder(t *testing.T) {
+	tests := map[string]struct {
+		exemplars bool
+	}{
+		"if exemplars are disabled, no exemplar should be attached": {
+			exemplars: false,
+		},
+		"if exemplars are enabled but there is no trace, no exemplar should be attached": {
+			exemplars: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := NewReviewRecorder(test.exemplars)
+			registry := prometheus.NewRegistry()
+			require.NoError(t, registry.Register(r.duration))
+
+			r.Observe(context.Background(), "approved", 20*time.Millisecond)
+
+			families, err := registry.Gather()
+			require.NoError(t, err)
+			require.Len(t, families, 1)
+			require.Len(t, families[0].GetMetric(), 1)
+
+			histogram := families[0].GetMetric()[0].GetHistogram()
+			assert.Equal(t, uint64(1), histogram.GetSampleCount())
+
+			for _, bucket := range histogram.GetBucket() {
+				assert.Nil(t, bucket.GetExemplar())
+			}
+		})
+	}
+
+	t.Run("a nil recorder should record nothing", func(t *testing.T) {
+		var r *ReviewRecorder
+		r.Observe(context.Background(), "approved", time.Second)
+	})
+}
+
+func Test_ReviewRecorderUnprocessed(t *testing.T) {
+	r := NewReviewRecorder(false)
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(r.unprocessed))
+
+	r.ObserveUnprocessed("no_policies")
+	r.ObserveUnprocessed("no_applicable_policies")
+	r.ObserveUnprocessed("no_applicable_policies")
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+	assert.Equal(t, "approverpolicy_certificaterequest_unprocessed_total", families[0].GetName())
+
+	counts := make(map[string]float64)
+	for _, metric := range families[0].GetMetric() {
+		for _, label := range metric.GetLabel() {
+			if label.GetName() == "reason" {
+				counts[label.GetValue()] = metric.GetCounter().GetValue()
+			}
+		}
+	}
+	assert.Equal(t, map[string]float64{"no_policies": 1, "no_applicable_policies": 2}, counts)
+
+	t.Run("a nil recorder should record nothing", func(t *testing.T) {
+		var r *ReviewRecorder
+		r.ObserveUnprocessed("no_policies")
+	})
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package readiness
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+// Gate is a readiness check which only passes once every StartupChecker has
+// passed its startup check. Startup checks are latched, so a StartupChecker
+// which has passed is never checked again.
+type Gate struct {
+	lock sync.Mutex
+
+	// pending are the StartupCheckers which have not yet passed their
+	// startup check.
+	pending []approver.StartupChecker
+}
+
+// New returns a Gate for the given StartupCheckers.
+func New(checkers []approver.StartupChecker) *Gate {
+	return &Gate{
+		pending: append([]approver.StartupChecker(nil), checkers...),
+	}
+}
+
+// Check is a healthz.Checker which runs the startup checks of all StartupCheckers which have not yet
+// passed, returning an error if any of them fail.
+func (g *Gate) Check(req *http.Request) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	var (
+		pending []approver.StartupChecker
+		errs    []error
+	)
+	for _, checker := range g.pending {
+		if err := checker.StartupCheck(req.Context()); err != nil {
+			pending = append(pending, checker)
+			errs = append(errs, fmt.Errorf("approver %q has not passed its startup check: %w", checkerName(checker), err))
+		}
+	}
+	g.pending = pending
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	return nil
+}
+
+// checkerName returns the name of the Approver implementing the
+// StartupChecker.
+func checkerName(checker approver.StartupChecker) string {
+	if named, ok := checker.(interface{ Name() string }); ok {
+		return named.Name()
+	}
+	return fmt.Sprintf("%T", checker)
+}
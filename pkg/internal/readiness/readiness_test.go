@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package readiness
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+// fakeChecker is a StartupChecker which fails until ready is set, counting
+// the number of times it has been checked.
+type fakeChecker struct {
+	name   string
+	ready  bool
+	checks int
+}
+
+func (f *fakeChecker) Name() string {
+	return f.name
+}
+
+func (f *fakeChecker) StartupCheck(_ context.Context) error {
+	f.checks++
+	if !f.ready {
+		return errors.New("not synced")
+	}
+	return nil
+}
+
+func Test_Gate(t *testing.T) {
+	check := func(gate *Gate) error {
+		return gate.Check(httptest.NewRequest("GET", "/readyz", nil))
+	}
+
+	t.Run("with no checkers, the gate passes", func(t *testing.T) {
+		assert.NoError(t, check(New(nil)))
+	})
+
+	t.Run("the gate only passes once every checker has passed", func(t *testing.T) {
+		a, b := &fakeChecker{name: "a"}, &fakeChecker{name: "b", ready: true}
+		gate := New([]approver.StartupChecker{a, b})
+
+		assert.EqualError(t, check(gate), `approver "a" has not passed its startup check: not synced`)
+
+		a.ready = true
+		assert.NoError(t, check(gate))
+	})
+
+	t.Run("checkers which have passed are not checked again", func(t *testing.T) {
+		a, b := &fakeChecker{name: "a", ready: true}, &fakeChecker{name: "b"}
+		gate := New([]approver.StartupChecker{a, b})
+
+		assert.Error(t, check(gate))
+		assert.Error(t, check(gate))
+		assert.Equal(t, 1, a.checks)
+		assert.Equal(t, 2, b.checks)
+
+		// Once passed, a checker which regresses doesn't fail the gate.
+		b.ready = true
+		assert.NoError(t, check(gate))
+		b.ready = false
+		assert.NoError(t, check(gate))
+		assert.Equal(t, 3, b.checks)
+	})
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reloader
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+var _ manager.LeaderElectionRunnable = &Reloader{}
+
+// Reloader is a controller-runtime Runnable that reloads the file based
+// configuration of Approvers when approver-policy receives a SIGHUP, or when
+// the files of a FileReloader change. After reloading, all
+// CertificateRequestPolicies are enqueued so that their readiness is
+// re-evaluated against the new configuration.
+type Reloader struct {
+	log logr.Logger
+
+	// lister is used to list all CertificateRequestPolicies to be re-synced
+	// after a reload.
+	lister client.Reader
+
+	// reloaders are the Approvers which are reloaded on SIGHUP.
+	reloaders []approver.Reloader
+
+	// signals receives the signals which trigger a reload. If nil, SIGHUP
+	// is used.
+	signals <-chan os.Signal
+
+	// interval is the interval at which the files of FileReloaders are
+	// checked for changes. Zero disables checking for changes.
+	interval time.Duration
+
+	// checksums holds the last observed checksum of the files of each
+	// Reloader, indexed the same as reloaders. Reloaders which are not
+	// FileReloaders have an empty checksum.
+	checksums []string
+
+	// resync is a single item buffer which coalesces re-sync requests for
+	// CertificateRequestPolicies.
+	resync chan struct{}
+
+	// enqueue sends the names of CertificateRequestPolicies which should be
+	// reconciled.
+	enqueue chan string
+}
+
+// New constructs a new Reloader which reloads the given Reloaders on SIGHUP,
+// and checks the files of FileReloaders for changes at the given interval.
+func New(log logr.Logger, lister client.Reader, reloaders []approver.Reloader, interval time.Duration) *Reloader {
+	return &Reloader{
+		log:       log,
+		lister:    lister,
+		reloaders: reloaders,
+		interval:  interval,
+		checksums: make([]string, len(reloaders)),
+		resync:    make(chan struct{}, 1),
+		enqueue:   make(chan string),
+	}
+}
+
+// EnqueueChan returns a channel which receives the names of
+// CertificateRequestPolicies that should be reconciled after a reload.
+func (r *Reloader) EnqueueChan() <-chan string {
+	return r.enqueue
+}
+
+// NeedLeaderElection returns false, as configuration must be reloaded on all
+// replicas since the webhook is served by every replica.
+func (r *Reloader) NeedLeaderElection() bool {
+	return false
+}
+
+// Start listens for reload signals until the context is cancelled.
+func (r *Reloader) Start(ctx context.Context) error {
+	signals := r.signals
+	if signals == nil {
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, syscall.SIGHUP)
+		defer signal.Stop(sigCh)
+		signals = sigCh
+	}
+
+	// The files are expected to have been read when the Approvers were
+	// prepared, so only changes from this point on trigger a reload.
+	r.updateChecksums()
+
+	var tick <-chan time.Time
+	if r.interval > 0 {
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	// Re-sync is done in a separate go routine since the enqueue channel is
+	// only consumed by the elected leader; a blocked re-sync must not prevent
+	// configuration from being reloaded.
+	go r.runResync(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-signals:
+			r.reload(ctx)
+			r.updateChecksums()
+			r.requestResync()
+		case <-tick:
+			if r.reloadChanged(ctx) {
+				r.requestResync()
+			}
+		}
+	}
+}
+
+// reload calls Reload on all Reloaders. Errors are logged, and do not prevent
+// other Reloaders from being reloaded.
+func (r *Reloader) reload(ctx context.Context) {
+	r.log.Info("reloading configuration")
+	for _, reloader := range r.reloaders {
+		if err := reloader.Reload(ctx); err != nil {
+			r.log.Error(err, "failed to reload configuration")
+		}
+	}
+}
+
+// reloadChanged calls Reload on the FileReloaders whose files have changed
+// since they were last checked. Returns true if any Reloader was reloaded.
+func (r *Reloader) reloadChanged(ctx context.Context) bool {
+	var reloaded bool
+	for i, reloader := range r.reloaders {
+		fileReloader, ok := reloader.(approver.FileReloader)
+		if !ok {
+			continue
+		}
+
+		sum, err := checksum(fileReloader.ReloadFiles())
+		if err != nil {
+			r.log.Error(err, "failed to check configuration files for changes")
+			continue
+		}
+		if sum == r.checksums[i] {
+			continue
+		}
+
+		r.log.Info("configuration files changed, reloading configuration", "files", fileReloader.ReloadFiles())
+		// The checksum is updated regardless of whether the reload succeeds,
+		// so that a broken file is not reloaded again until it is changed.
+		r.checksums[i] = sum
+		if err := reloader.Reload(ctx); err != nil {
+			r.log.Error(err, "failed to reload configuration")
+		}
+		reloaded = true
+	}
+	return reloaded
+}
+
+// updateChecksums records the current checksum of the files of all
+// FileReloaders.
+func (r *Reloader) updateChecksums() {
+	for i, reloader := range r.reloaders {
+		fileReloader, ok := reloader.(approver.FileReloader)
+		if !ok {
+			continue
+		}
+
+		sum, err := checksum(fileReloader.ReloadFiles())
+		if err != nil {
+			r.log.Error(err, "failed to check configuration files for changes")
+			continue
+		}
+		r.checksums[i] = sum
+	}
+}
+
+// requestResync requests that all CertificateRequestPolicies are re-synced.
+func (r *Reloader) requestResync() {
+	select {
+	case r.resync <- struct{}{}:
+	default:
+		// A re-sync is already pending.
+	}
+}
+
+// checksum returns a checksum of the contents of the given files. Files
+// which do not exist are included in the checksum as missing, so that their
+// creation is observed as a change.
+func checksum(paths []string) (string, error) {
+	hash := sha256.New()
+	for _, path := range paths {
+		hash.Write([]byte(path))
+		data, err := os.ReadFile(path)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			hash.Write([]byte{0})
+		case err != nil:
+			return "", err
+		default:
+			hash.Write([]byte{1})
+			hash.Write(data)
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// runResync enqueues all CertificateRequestPolicies every time a re-sync is
+// requested, until the context is cancelled.
+func (r *Reloader) runResync(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.resync:
+		}
+
+		var policies policyapi.CertificateRequestPolicyList
+		if err := r.lister.List(ctx, &policies); err != nil {
+			r.log.Error(err, "failed to list CertificateRequestPolicies for re-sync after reload")
+			continue
+		}
+
+		for _, policy := range policies.Items {
+			select {
+			case <-ctx.Done():
+				return
+			case r.enqueue <- policy.Name:
+			}
+		}
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reloader
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+var _ approver.FileReloader = &fileConfig{}
+
+// fileConfig is a Reloader which holds configuration read from a file.
+type fileConfig struct {
+	path string
+
+	lock  sync.Mutex
+	value string
+}
+
+func (f *fileConfig) Reload(_ context.Context) error {
+	value, err := os.ReadFile(f.path)
+	if err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.value = string(value)
+	return nil
+}
+
+func (f *fileConfig) ReloadFiles() []string {
+	return []string{f.path}
+}
+
+func (f *fileConfig) get() string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.value
+}
+
+func Test_Reloader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(path, []byte("old"), 0600))
+
+	config := &fileConfig{path: path}
+	require.NoError(t, config.Reload(context.TODO()))
+
+	lister := fakeclient.NewClientBuilder().
+		WithScheme(policyapi.GlobalScheme).
+		WithRuntimeObjects([]runtime.Object{
+			&policyapi.CertificateRequestPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy-a"}},
+			&policyapi.CertificateRequestPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy-b"}},
+		}...).
+		Build()
+
+	signals := make(chan os.Signal)
+	r := New(testr.New(t), lister, []approver.Reloader{config}, 0)
+	r.signals = signals
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	errCh := make(chan error)
+	go func() { errCh <- r.Start(ctx) }()
+
+	assert.Equal(t, "old", config.get())
+
+	require.NoError(t, os.WriteFile(path, []byte("new"), 0600))
+	signals <- syscall.SIGHUP
+
+	var enqueued []string
+	for len(enqueued) < 2 {
+		select {
+		case name := <-r.EnqueueChan():
+			enqueued = append(enqueued, name)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for policies to be enqueued, got=%v", enqueued)
+		}
+	}
+
+	assert.Equal(t, "new", config.get(), "expected configuration to be reloaded")
+	assert.ElementsMatch(t, []string{"policy-a", "policy-b"}, enqueued)
+
+	cancel()
+	select {
+	case err := <-errCh:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for reloader to stop")
+	}
+}
+
+func Test_Reloader_FileChanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(path, []byte("old"), 0600))
+
+	config := &fileConfig{path: path}
+	require.NoError(t, config.Reload(context.TODO()))
+
+	lister := fakeclient.NewClientBuilder().
+		WithScheme(policyapi.GlobalScheme).
+		WithRuntimeObjects(&policyapi.CertificateRequestPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy-a"}}).
+		Build()
+
+	// Never send a signal, the change must be observed from the file alone.
+	r := New(testr.New(t), lister, []approver.Reloader{config}, 10*time.Millisecond)
+	r.signals = make(chan os.Signal)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	errCh := make(chan error)
+	go func() { errCh <- r.Start(ctx) }()
+
+	// Unchanged files must not trigger a reload or re-sync.
+	select {
+	case name := <-r.EnqueueChan():
+		t.Fatalf("unexpected re-sync of %q before configuration changed", name)
+	case <-time.After(100 * time.Millisecond):
+	}
+	assert.Equal(t, "old", config.get())
+
+	require.NoError(t, os.WriteFile(path, []byte("new"), 0600))
+
+	select {
+	case name := <-r.EnqueueChan():
+		assert.Equal(t, "policy-a", name)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for policies to be enqueued")
+	}
+	assert.Equal(t, "new", config.get(), "expected configuration to be reloaded")
+
+	cancel()
+	select {
+	case err := <-errCh:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for reloader to stop")
+	}
+}
+
+func Test_checksum(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config")
+
+	missing, err := checksum([]string{path})
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(path, nil, 0600))
+	empty, err := checksum([]string{path})
+	require.NoError(t, err)
+	assert.NotEqual(t, missing, empty, "expected creating a file to change the checksum")
+
+	require.NoError(t, os.WriteFile(path, []byte("a"), 0600))
+	a, err := checksum([]string{path})
+	require.NoError(t, err)
+	assert.NotEqual(t, empty, a, "expected changing a file to change the checksum")
+
+	again, err := checksum([]string{path})
+	require.NoError(t, err)
+	assert.Equal(t, a, again, "expected an unchanged file to have the same checksum")
+
+	_, err = checksum([]string{dir})
+	assert.Error(t, err, "expected an unreadable file to return an error")
+}
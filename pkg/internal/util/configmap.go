@@ -0,0 +1,66 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConfigMapCache caches the parsed contents of ConfigMaps referenced by
+// policies, so that they are not read from the API server on every
+// evaluation. ConfigMaps are read on first use, and re-read by Refresh, which
+// approvers call from Ready so that the contents are refreshed whenever the
+// policies referencing them are re-synced.
+type ConfigMapCache[T any] struct {
+	parse   func(*corev1.ConfigMap) T
+	entries sync.Map
+}
+
+// NewConfigMapCache returns a ConfigMapCache which stores the result of
+// parsing each ConfigMap with the given function.
+func NewConfigMapCache[T any](parse func(*corev1.ConfigMap) T) *ConfigMapCache[T] {
+	return &ConfigMapCache[T]{parse: parse}
+}
+
+// Get returns the parsed contents of the ConfigMap with the given key. The
+// ConfigMap is only read with the given reader if it isn't cached.
+func (c *ConfigMapCache[T]) Get(ctx context.Context, reader client.Reader, key client.ObjectKey) (T, error) {
+	if entry, ok := c.entries.Load(key); ok {
+		return entry.(T), nil
+	}
+	return c.Refresh(ctx, reader, key)
+}
+
+// Refresh reads the ConfigMap with the given key with the given reader, and
+// replaces its cached contents. The cached contents are removed if the
+// ConfigMap can't be read, so that it is read again on next use.
+func (c *ConfigMapCache[T]) Refresh(ctx context.Context, reader client.Reader, key client.ObjectKey) (T, error) {
+	var cm corev1.ConfigMap
+	if err := reader.Get(ctx, key, &cm); err != nil {
+		c.entries.Delete(key)
+		var empty T
+		return empty, err
+	}
+
+	parsed := c.parse(&cm)
+	c.entries.Store(key, parsed)
+	return parsed, nil
+}
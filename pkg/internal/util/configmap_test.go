@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+)
+
+func Test_ConfigMapCache(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "cert-manager", Name: "values"},
+		Data:       map[string]string{"key": "a"},
+	}
+	key := client.ObjectKeyFromObject(configMap)
+
+	var gets int
+	reader := fakeclient.NewClientBuilder().
+		WithScheme(policyapi.GlobalScheme).
+		WithObjects(configMap).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				gets++
+				return c.Get(ctx, key, obj, opts...)
+			},
+		}).
+		Build()
+
+	cache := NewConfigMapCache(func(cm *corev1.ConfigMap) string { return cm.Data["key"] })
+
+	value, err := cache.Get(context.TODO(), reader, key)
+	require.NoError(t, err)
+	assert.Equal(t, "a", value)
+
+	configMap.Data["key"] = "b"
+	require.NoError(t, reader.Update(context.TODO(), configMap))
+
+	// Cached contents are returned without reading the ConfigMap again.
+	value, err = cache.Get(context.TODO(), reader, key)
+	require.NoError(t, err)
+	assert.Equal(t, "a", value)
+	assert.Equal(t, 1, gets)
+
+	value, err = cache.Refresh(context.TODO(), reader, key)
+	require.NoError(t, err)
+	assert.Equal(t, "b", value)
+
+	// A ConfigMap which can no longer be read is removed from the cache.
+	require.NoError(t, reader.Delete(context.TODO(), configMap))
+	_, err = cache.Refresh(context.TODO(), reader, key)
+	assert.True(t, apierrors.IsNotFound(err), "%v", err)
+	_, err = cache.Get(context.TODO(), reader, key)
+	assert.True(t, apierrors.IsNotFound(err), "%v", err)
+	assert.Equal(t, 4, gets)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+)
+
+// ErrInheritanceCycle is returned by ResolveInheritance when a policy
+// inherits from itself, either directly or through its parents.
+var ErrInheritanceCycle = errors.New("CertificateRequestPolicy inheritance cycle")
+
+// ResolvePolicy returns the given policy with the policies it inherits from
+// and the CertificateRequestPolicyProfile it references applied, in that
+// order. This is the policy which CertificateRequests are evaluated against.
+func ResolvePolicy(ctx context.Context, lister client.Reader, policy *policyapi.CertificateRequestPolicy) (*policyapi.CertificateRequestPolicy, error) {
+	policy, err := ResolveInheritance(ctx, lister, policy)
+	if err != nil {
+		return nil, err
+	}
+	return ResolveProfile(ctx, lister, policy)
+}
+
+// ResolveInheritance returns the given policy with the allowed and
+// constraints fields of the CertificateRequestPolicies named in
+// `spec.inheritFrom` merged in. Each parent is itself resolved using
+// ResolvePolicy before being merged, and parents earlier in the list take
+// precedence over those later in the list. The policy is returned unchanged
+// if it doesn't inherit from any policies. The error of getting a parent is
+// wrapped, so that a missing parent can be checked with apierrors.IsNotFound,
+// and an error wrapping ErrInheritanceCycle is returned if the policy
+// inherits from itself.
+func ResolveInheritance(ctx context.Context, lister client.Reader, policy *policyapi.CertificateRequestPolicy) (*policyapi.CertificateRequestPolicy, error) {
+	return resolveInheritance(ctx, lister, policy, []string{policy.Name})
+}
+
+// resolveInheritance resolves the parents of the given policy, where chain is
+// the names of the policies which inherit from the given policy, ending with
+// the given policy itself.
+func resolveInheritance(ctx context.Context, lister client.Reader, policy *policyapi.CertificateRequestPolicy, chain []string) (*policyapi.CertificateRequestPolicy, error) {
+	if len(policy.Spec.InheritFrom) == 0 {
+		return policy, nil
+	}
+
+	resolved := policy.DeepCopy()
+	for _, name := range policy.Spec.InheritFrom {
+		parentChain := append(slices.Clone(chain), name)
+		if slices.Contains(chain, name) {
+			return nil, fmt.Errorf("%w: %s", ErrInheritanceCycle, strings.Join(parentChain, " -> "))
+		}
+
+		parent := new(policyapi.CertificateRequestPolicy)
+		if err := lister.Get(ctx, client.ObjectKey{Name: name}, parent); err != nil {
+			return nil, fmt.Errorf("failed to get parent CertificateRequestPolicy %q: %w", name, err)
+		}
+
+		parent, err := resolveInheritance(ctx, lister, parent, parentChain)
+		if err != nil {
+			return nil, err
+		}
+		parent, err = ResolveProfile(ctx, lister, parent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve profile of parent CertificateRequestPolicy %q: %w", name, err)
+		}
+
+		mergeSpec(resolved, parent.Spec.Allowed, parent.Spec.Constraints)
+	}
+
+	return resolved, nil
+}
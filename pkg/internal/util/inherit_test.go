@@ -0,0 +1,170 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+)
+
+func Test_ResolveInheritance(t *testing.T) {
+	policy := func(name string, spec policyapi.CertificateRequestPolicySpec) *policyapi.CertificateRequestPolicy {
+		return &policyapi.CertificateRequestPolicy{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: spec}
+	}
+
+	lister := fakeclient.NewClientBuilder().
+		WithScheme(policyapi.GlobalScheme).
+		WithRuntimeObjects(
+			policy("base", policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					CommonName: &policyapi.CertificateRequestPolicyAllowedString{Value: ptr.To("base")},
+					IsCA:       ptr.To(false),
+				},
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{MinDuration: &metav1.Duration{Duration: 3600}},
+			}),
+			policy("intermediate", policyapi.CertificateRequestPolicySpec{
+				InheritFrom: []string{"base"},
+				ProfileRef:  &policyapi.CertificateRequestPolicyProfileReference{Name: "test-profile"},
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					CommonName: &policyapi.CertificateRequestPolicyAllowedString{Value: ptr.To("intermediate")},
+				},
+			}),
+			policy("other", policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					CommonName: &policyapi.CertificateRequestPolicyAllowedString{Value: ptr.To("other")},
+					IsCA:       ptr.To(true),
+				},
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{MaxDuration: &metav1.Duration{Duration: 7200}},
+			}),
+			policy("cycle-a", policyapi.CertificateRequestPolicySpec{InheritFrom: []string{"cycle-b"}}),
+			policy("cycle-b", policyapi.CertificateRequestPolicySpec{InheritFrom: []string{"base", "cycle-a"}}),
+			&policyapi.CertificateRequestPolicyProfile{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-profile"},
+				Spec: policyapi.CertificateRequestPolicyProfileSpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{MinDuration: &metav1.Duration{Duration: 60}},
+				},
+			},
+		).
+		Build()
+
+	tests := map[string]struct {
+		policy      *policyapi.CertificateRequestPolicy
+		exp         policyapi.CertificateRequestPolicySpec
+		expNotFound bool
+		expCycle    bool
+	}{
+		"a policy which inherits from no policies should be returned unchanged": {
+			policy: policy("test", policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{IsCA: ptr.To(true)},
+			}),
+			exp: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{IsCA: ptr.To(true)},
+			},
+		},
+		"policy fields should take precedence over the same parent fields": {
+			policy: policy("test", policyapi.CertificateRequestPolicySpec{
+				InheritFrom: []string{"base"},
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					CommonName: &policyapi.CertificateRequestPolicyAllowedString{Value: ptr.To("test")},
+				},
+			}),
+			exp: policyapi.CertificateRequestPolicySpec{
+				InheritFrom: []string{"base"},
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					CommonName: &policyapi.CertificateRequestPolicyAllowedString{Value: ptr.To("test")},
+					IsCA:       ptr.To(false),
+				},
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{MinDuration: &metav1.Duration{Duration: 3600}},
+			},
+		},
+		"earlier parents should take precedence over later parents": {
+			policy: policy("test", policyapi.CertificateRequestPolicySpec{
+				InheritFrom: []string{"base", "other"},
+			}),
+			exp: policyapi.CertificateRequestPolicySpec{
+				InheritFrom: []string{"base", "other"},
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					CommonName: &policyapi.CertificateRequestPolicyAllowedString{Value: ptr.To("base")},
+					IsCA:       ptr.To(false),
+				},
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					MinDuration: &metav1.Duration{Duration: 3600},
+					MaxDuration: &metav1.Duration{Duration: 7200},
+				},
+			},
+		},
+		"parents should be resolved with their own parents and profile applied": {
+			policy: policy("test", policyapi.CertificateRequestPolicySpec{
+				InheritFrom: []string{"intermediate"},
+			}),
+			exp: policyapi.CertificateRequestPolicySpec{
+				InheritFrom: []string{"intermediate"},
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					CommonName: &policyapi.CertificateRequestPolicyAllowedString{Value: ptr.To("intermediate")},
+					IsCA:       ptr.To(false),
+				},
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{MinDuration: &metav1.Duration{Duration: 3600}},
+			},
+		},
+		"a missing parent should return a NotFound error": {
+			policy: policy("test", policyapi.CertificateRequestPolicySpec{
+				InheritFrom: []string{"base", "missing"},
+			}),
+			expNotFound: true,
+		},
+		"a policy which inherits from itself should return a cycle error": {
+			policy:   policy("test", policyapi.CertificateRequestPolicySpec{InheritFrom: []string{"test"}}),
+			expCycle: true,
+		},
+		"a policy whose parents inherit from it should return a cycle error": {
+			policy:   policy("cycle-a", policyapi.CertificateRequestPolicySpec{InheritFrom: []string{"cycle-b"}}),
+			expCycle: true,
+		},
+		"a policy whose parents contain a cycle should return a cycle error": {
+			policy:   policy("test", policyapi.CertificateRequestPolicySpec{InheritFrom: []string{"cycle-a"}}),
+			expCycle: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			original := test.policy.DeepCopy()
+
+			got, err := ResolveInheritance(context.TODO(), lister, test.policy)
+			assert.Equal(t, original, test.policy, "expected the given policy to not be modified")
+
+			switch {
+			case test.expNotFound:
+				assert.True(t, apierrors.IsNotFound(err), "expected a NotFound error for a missing parent, got=%v", err)
+			case test.expCycle:
+				assert.True(t, errors.Is(err, ErrInheritanceCycle), "expected an inheritance cycle error, got=%v", err)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, test.exp, got.Spec)
+			}
+		})
+	}
+}
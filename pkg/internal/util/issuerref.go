@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/internal/approver/validation"
+)
+
+// issuerRefNameExpressions caches the compiled `nameExpression` CEL
+// expressions of issuerRef selectors.
+var issuerRefNameExpressions = validation.NewExpressionCache()
+
+// IssuerRefKindGroup returns the kind and group of the issuer referenced by
+// the request, defaulted as cert-manager does.
+// cert-manager applies controller defaults for issuer Kind and Group, which
+// means that default values are NOT materialized in resources if omitted. So
+// in order to make selectors addressing these default values effective, we
+// must apply cert-manager defaults on requests when matching.
+func IssuerRefKindGroup(cr *cmapi.CertificateRequest) (kind, group string) {
+	kind, group = cr.Spec.IssuerRef.Kind, cr.Spec.IssuerRef.Group
+	if len(kind) == 0 {
+		kind = cmapi.IssuerKind
+	}
+	if len(group) == 0 {
+		group = "cert-manager.io"
+	}
+	return kind, group
+}
+
+// IssuerRefMatches returns true if the given issuerRef selector matches the
+// issuer name of the request, and the defaulted issuer kind and group. A nil
+// selector matches any issuer. issuerReady is only called if the selector
+// requires the issuer to be ready.
+func IssuerRefMatches(issRefSel *policyapi.CertificateRequestPolicySelectorIssuerRef, cr *cmapi.CertificateRequest, kind, group string, issuerReady func() (bool, error)) (bool, error) {
+	if issRefSel == nil {
+		return true, nil
+	}
+	name := cr.Spec.IssuerRef.Name
+	if issRefSel.Name != nil && !WildcardMatches(*issRefSel.Name, name) {
+		return false, nil
+	}
+	if issRefSel.Kind != nil && !WildcardMatches(*issRefSel.Kind, kind) {
+		return false, nil
+	}
+	if issRefSel.Group != nil && !WildcardMatches(*issRefSel.Group, group) {
+		return false, nil
+	}
+	if WildcardContains(issRefSel.ExcludeKinds, kind) || WildcardContains(issRefSel.ExcludeGroups, group) {
+		return false, nil
+	}
+	if issRefSel.Namespaced != nil {
+		namespaced, known := isNamespacedIssuer(kind, group)
+		if !known || namespaced != *issRefSel.Namespaced {
+			return false, nil
+		}
+	}
+	if issRefSel.NameExpression != nil {
+		expression, err := issuerRefNameExpressions.Get(*issRefSel.NameExpression)
+		if err != nil {
+			return false, fmt.Errorf("failed to compile nameExpression: %w", err)
+		}
+		expected, err := expression.Evaluate(*cr)
+		if err != nil {
+			return false, fmt.Errorf("failed to evaluate nameExpression: %w", err)
+		}
+		if name != expected {
+			return false, nil
+		}
+	}
+	if issRefSel.RequireReady != nil && *issRefSel.RequireReady {
+		return issuerReady()
+	}
+	return true, nil
+}
+
+// isNamespacedIssuer returns whether the issuer of the given kind and group is
+// namespaced, and whether that is known. Only cert-manager.io Issuers and
+// ClusterIssuers are known.
+func isNamespacedIssuer(kind, group string) (namespaced, known bool) {
+	if group != "cert-manager.io" {
+		return false, false
+	}
+	switch kind {
+	case cmapi.IssuerKind:
+		return true, true
+	case cmapi.ClusterIssuerKind:
+		return false, true
+	default:
+		return false, false
+	}
+}
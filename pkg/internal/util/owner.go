@@ -0,0 +1,37 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// OwningCertificateName returns the name of the Certificate which owns the
+// given request, as given by its controller owner reference, and true. False
+// is returned if the request is not owned by a cert-manager Certificate.
+func OwningCertificateName(request *cmapi.CertificateRequest) (string, bool) {
+	owner := metav1.GetControllerOf(request)
+	if owner == nil || owner.Kind != cmapi.CertificateKind {
+		return "", false
+	}
+	if gv, err := schema.ParseGroupVersion(owner.APIVersion); err != nil || gv.Group != cmapi.SchemeGroupVersion.Group {
+		return "", false
+	}
+	return owner.Name, true
+}
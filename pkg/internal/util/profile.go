@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+)
+
+// ResolveProfile returns the given policy with the CertificateRequestPolicyProfile
+// it references applied, using ApplyProfile. The policy is returned unchanged
+// if it doesn't reference a profile. The error of getting the profile is
+// returned as is, so that a missing profile can be checked with
+// apierrors.IsNotFound.
+func ResolveProfile(ctx context.Context, lister client.Reader, policy *policyapi.CertificateRequestPolicy) (*policyapi.CertificateRequestPolicy, error) {
+	if policy.Spec.ProfileRef == nil {
+		return policy, nil
+	}
+
+	profile := new(policyapi.CertificateRequestPolicyProfile)
+	if err := lister.Get(ctx, client.ObjectKey{Name: policy.Spec.ProfileRef.Name}, profile); err != nil {
+		return nil, fmt.Errorf("failed to get CertificateRequestPolicyProfile %q: %w", policy.Spec.ProfileRef.Name, err)
+	}
+
+	return ApplyProfile(policy, profile), nil
+}
+
+// ApplyProfile returns a copy of the given policy with the allowed and
+// constraints fields of the profile merged in. Each field of `allowed` and
+// `constraints` which is set on the policy takes precedence over the same
+// field of the profile, which is otherwise used. Fields are not merged any
+// deeper, i.e. `allowed.dnsNames` set on the policy replaces
+// `allowed.dnsNames` of the profile as a whole.
+func ApplyProfile(policy *policyapi.CertificateRequestPolicy, profile *policyapi.CertificateRequestPolicyProfile) *policyapi.CertificateRequestPolicy {
+	policy = policy.DeepCopy()
+	mergeSpec(policy, profile.Spec.Allowed, profile.Spec.Constraints)
+	return policy
+}
+
+// mergeSpec merges copies of the given allowed and constraints fields into
+// the policy, keeping each field which is already set on the policy.
+func mergeSpec(policy *policyapi.CertificateRequestPolicy, allowed *policyapi.CertificateRequestPolicyAllowed, constraints *policyapi.CertificateRequestPolicyConstraints) {
+	if allowed != nil {
+		if policy.Spec.Allowed == nil {
+			policy.Spec.Allowed = new(policyapi.CertificateRequestPolicyAllowed)
+		}
+		mergeUnsetFields(policy.Spec.Allowed, allowed.DeepCopy())
+	}
+
+	if constraints != nil {
+		if policy.Spec.Constraints == nil {
+			policy.Spec.Constraints = new(policyapi.CertificateRequestPolicyConstraints)
+		}
+		mergeUnsetFields(policy.Spec.Constraints, constraints.DeepCopy())
+	}
+}
+
+// mergeUnsetFields sets each field of the struct pointed to by dst which has
+// its zero value to the same field of the struct pointed to by src.
+func mergeUnsetFields(dst, src any) {
+	dstValue, srcValue := reflect.ValueOf(dst).Elem(), reflect.ValueOf(src).Elem()
+	for i := range dstValue.NumField() {
+		if field := dstValue.Field(i); field.IsZero() {
+			field.Set(srcValue.Field(i))
+		}
+	}
+}
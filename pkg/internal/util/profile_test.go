@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+)
+
+func Test_ApplyProfile(t *testing.T) {
+	profileDNSNames := &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*.example.com"}}
+	policyDNSNames := &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*.internal.example.com"}}
+
+	tests := map[string]struct {
+		policy  policyapi.CertificateRequestPolicySpec
+		profile policyapi.CertificateRequestPolicyProfileSpec
+		exp     policyapi.CertificateRequestPolicySpec
+	}{
+		"an empty profile should not change the policy": {
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{DNSNames: policyDNSNames},
+			},
+			profile: policyapi.CertificateRequestPolicyProfileSpec{},
+			exp: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{DNSNames: policyDNSNames},
+			},
+		},
+		"profile fields should be used when the policy omits allowed and constraints": {
+			policy: policyapi.CertificateRequestPolicySpec{},
+			profile: policyapi.CertificateRequestPolicyProfileSpec{
+				Allowed:     &policyapi.CertificateRequestPolicyAllowed{DNSNames: profileDNSNames},
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{MinDuration: &metav1.Duration{Duration: 3600}},
+			},
+			exp: policyapi.CertificateRequestPolicySpec{
+				Allowed:     &policyapi.CertificateRequestPolicyAllowed{DNSNames: profileDNSNames},
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{MinDuration: &metav1.Duration{Duration: 3600}},
+			},
+		},
+		"policy fields should take precedence over the same profile fields": {
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed:     &policyapi.CertificateRequestPolicyAllowed{DNSNames: policyDNSNames, IsCA: ptr.To(false)},
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{MinDuration: &metav1.Duration{Duration: 60}},
+			},
+			profile: policyapi.CertificateRequestPolicyProfileSpec{
+				Allowed:     &policyapi.CertificateRequestPolicyAllowed{DNSNames: profileDNSNames, IsCA: ptr.To(true)},
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{MinDuration: &metav1.Duration{Duration: 3600}},
+			},
+			exp: policyapi.CertificateRequestPolicySpec{
+				Allowed:     &policyapi.CertificateRequestPolicyAllowed{DNSNames: policyDNSNames, IsCA: ptr.To(false)},
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{MinDuration: &metav1.Duration{Duration: 60}},
+			},
+		},
+		"profile fields should be merged with different policy fields": {
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{DNSNames: policyDNSNames},
+			},
+			profile: policyapi.CertificateRequestPolicyProfileSpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{IsCA: ptr.To(true)},
+			},
+			exp: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{DNSNames: policyDNSNames, IsCA: ptr.To(true)},
+			},
+		},
+		"policy fields should replace profile fields as a whole rather than being merged": {
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Required: ptr.To(true)},
+				},
+			},
+			profile: policyapi.CertificateRequestPolicyProfileSpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{DNSNames: profileDNSNames},
+			},
+			exp: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Required: ptr.To(true)},
+				},
+			},
+		},
+		"an explicitly empty policy field should take precedence over the profile field": {
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{Usages: &[]cmapi.KeyUsage{}},
+			},
+			profile: policyapi.CertificateRequestPolicyProfileSpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{Usages: &[]cmapi.KeyUsage{cmapi.UsageServerAuth}},
+			},
+			exp: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{Usages: &[]cmapi.KeyUsage{}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			policy := &policyapi.CertificateRequestPolicy{Spec: test.policy}
+			profile := &policyapi.CertificateRequestPolicyProfile{Spec: test.profile}
+			original := policy.DeepCopy()
+
+			got := ApplyProfile(policy, profile)
+			assert.Equal(t, test.exp, got.Spec)
+			assert.Equal(t, original, policy, "expected the given policy to not be modified")
+		})
+	}
+}
+
+func Test_ResolveProfile(t *testing.T) {
+	profile := &policyapi.CertificateRequestPolicyProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-profile"},
+		Spec: policyapi.CertificateRequestPolicyProfileSpec{
+			Allowed: &policyapi.CertificateRequestPolicyAllowed{IsCA: ptr.To(true)},
+		},
+	}
+	lister := fakeclient.NewClientBuilder().
+		WithScheme(policyapi.GlobalScheme).
+		WithRuntimeObjects(profile).
+		Build()
+
+	policy := &policyapi.CertificateRequestPolicy{}
+	got, err := ResolveProfile(context.TODO(), lister, policy)
+	assert.NoError(t, err)
+	assert.Same(t, policy, got, "expected a policy without a profileRef to be returned unchanged")
+
+	policy.Spec.ProfileRef = &policyapi.CertificateRequestPolicyProfileReference{Name: profile.Name}
+	got, err = ResolveProfile(context.TODO(), lister, policy)
+	assert.NoError(t, err)
+	assert.Equal(t, &policyapi.CertificateRequestPolicyAllowed{IsCA: ptr.To(true)}, got.Spec.Allowed)
+
+	policy.Spec.ProfileRef.Name = "missing-profile"
+	_, err = ResolveProfile(context.TODO(), lister, policy)
+	assert.True(t, apierrors.IsNotFound(err), "expected a NotFound error for a missing profile, got=%v", err)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// CompileRegexp compiles the given RE2 regular expression so that it must
+// match the whole of a string, consistent with wildcard patterns.
+func CompileRegexp(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile(fmt.Sprintf("^(?:%s)$", pattern))
+}
+
+// CompileRegexpIgnoreCase compiles the given RE2 regular expression as
+// CompileRegexp, so that it matches case-insensitively.
+func CompileRegexpIgnoreCase(pattern string) (*regexp.Regexp, error) {
+	return CompileRegexp("(?i)" + pattern)
+}
+
+// RegexpCache caches compiled regular expressions, so that patterns referenced
+// by policies are not recompiled on every evaluation. Compile errors are cached
+// alongside the pattern.
+type RegexpCache struct {
+	compile func(string) (*regexp.Regexp, error)
+	entries sync.Map
+}
+
+type regexpCacheEntry struct {
+	re  *regexp.Regexp
+	err error
+}
+
+// NewRegexpCache returns a RegexpCache which compiles patterns with the given
+// function.
+func NewRegexpCache(compile func(string) (*regexp.Regexp, error)) *RegexpCache {
+	return &RegexpCache{compile: compile}
+}
+
+// Get returns the compiled regular expression for the given pattern,
+// compiling it if it isn't cached.
+func (c *RegexpCache) Get(pattern string) (*regexp.Regexp, error) {
+	if entry, ok := c.entries.Load(pattern); ok {
+		return entry.(*regexpCacheEntry).re, entry.(*regexpCacheEntry).err
+	}
+
+	re, err := c.compile(pattern)
+	entry, _ := c.entries.LoadOrStore(pattern, &regexpCacheEntry{re: re, err: err})
+	return entry.(*regexpCacheEntry).re, entry.(*regexpCacheEntry).err
+}
+
+// Subset returns whether the members is a subset of patterns which are RE2
+// regular expressions. Members is a subset of patterns if all members match at
+// least one passed pattern. An error is returned if any pattern fails to
+// compile. Patterns must match the whole of a member when the cache compiles
+// them with CompileRegexp.
+func (c *RegexpCache) Subset(patterns, members []string) (bool, error) {
+	regexps := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := c.Get(pattern)
+		if err != nil {
+			return false, err
+		}
+		regexps = append(regexps, re)
+	}
+
+	for _, member := range members {
+		var matched bool
+		for _, re := range regexps {
+			if re.MatchString(member) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// Matches will return true if the given string matches the pattern. Pattern
+// is an RE2 regular expression, which must match the whole string when the
+// cache compiles it with CompileRegexp.
+func (c *RegexpCache) Matches(pattern, str string) (bool, error) {
+	re, err := c.Get(pattern)
+	if err != nil {
+		return false, err
+	}
+	return re.MatchString(str), nil
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func Test_RegexpCacheSubset(t *testing.T) {
+	tests := []struct {
+		patterns []string
+		texts    []string
+		exp      bool
+		expErr   bool
+	}{
+		{
+			patterns: []string{},
+			texts:    []string{},
+			exp:      true,
+		},
+		{
+			patterns: []string{`[a-z]+\.example\.com`},
+			texts:    []string{"foo.example.com", "bar.example.com"},
+			exp:      true,
+		},
+		{
+			patterns: []string{`[a-z]+\.example\.com`, `10\.0\.0\.[0-9]+`},
+			texts:    []string{"foo.example.com", "10.0.0.1"},
+			exp:      true,
+		},
+		{
+			patterns: []string{`[a-z]+\.example\.com`},
+			texts:    []string{"foo.example.com", "foo-1.example.com"},
+			exp:      false,
+		},
+		{
+			patterns: []string{`[a-z`},
+			texts:    []string{"foo.example.com"},
+			exp:      false,
+			expErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v: %v", test.patterns, test.texts), func(t *testing.T) {
+			match, err := NewRegexpCache(CompileRegexp).Subset(test.patterns, test.texts)
+			if (err != nil) != test.expErr {
+				t.Errorf("unexpected error (%v, %v): exp=%t got=%v",
+					test.patterns, test.texts, test.expErr, err)
+			}
+			if match != test.exp {
+				t.Errorf("unexpected subset (%v, %v): exp=%t got=%t",
+					test.patterns, test.texts, test.exp, match)
+			}
+		})
+	}
+}
+
+func Test_RegexpCacheMatches(t *testing.T) {
+	tests := map[string]struct {
+		pattern string
+		text    string
+		exp     bool
+		expErr  bool
+	}{
+		"matching pattern: true": {
+			pattern: `cert-[a-z]+\.io`,
+			text:    "cert-manager.io",
+			exp:     true,
+		},
+		"pattern matching only a prefix: false": {
+			pattern: `cert-[a-z]+`,
+			text:    "cert-manager.io",
+			exp:     false,
+		},
+		"pattern matching only a suffix: false": {
+			pattern: `[a-z]+\.io`,
+			text:    "cert-manager.io",
+			exp:     false,
+		},
+		"alternation is anchored as a whole: false": {
+			pattern: `foo|cert-manager`,
+			text:    "cert-manager.io",
+			exp:     false,
+		},
+		"invalid pattern: error": {
+			pattern: `(cert-manager`,
+			text:    "cert-manager",
+			exp:     false,
+			expErr:  true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			match, err := NewRegexpCache(CompileRegexp).Matches(test.pattern, test.text)
+			if (err != nil) != test.expErr {
+				t.Errorf("unexpected error (%q, %q): exp=%t got=%v",
+					test.pattern, test.text, test.expErr, err)
+			}
+			if match != test.exp {
+				t.Errorf("unexpected match (%q, %q): exp=%t got=%t",
+					test.pattern, test.text, test.exp, match)
+			}
+		})
+	}
+}
+
+func Test_RegexpCache(t *testing.T) {
+	var compiled int
+	cache := NewRegexpCache(func(pattern string) (*regexp.Regexp, error) {
+		compiled++
+		return CompileRegexp(pattern)
+	})
+
+	re, err := cache.Get(`cert-[a-z]+\.io`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again, _ := cache.Get(`cert-[a-z]+\.io`); again != re {
+		t.Errorf("expected cached regexp to be returned")
+	}
+
+	// Compile errors are cached alongside the pattern.
+	for range 2 {
+		if _, err := cache.Get(`(cert-manager`); err == nil {
+			t.Errorf("expected error compiling invalid pattern")
+		}
+	}
+
+	if compiled != 2 {
+		t.Errorf("unexpected number of compilations: exp=2 got=%d", compiled)
+	}
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+)
+
+// SelectorWarnings returns a list of suspicious configurations in the given
+// selector which are likely to prevent it from ever matching a request. The
+// returned errors are warnings only; the selector remains valid.
+func SelectorWarnings(selector policyapi.CertificateRequestPolicySelector, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	if selector.IssuerRef != nil {
+		el = append(el, issuerRefWarnings(*selector.IssuerRef, fldPath.Child("issuerRef"))...)
+	}
+	for i, issuerRef := range selector.IssuerRefs {
+		el = append(el, issuerRefWarnings(issuerRef, fldPath.Child("issuerRefs").Index(i))...)
+	}
+
+	if selector.Namespace != nil {
+		for i, name := range selector.Namespace.MatchNames {
+			if len(name) == 0 {
+				el = append(el, field.Invalid(fldPath.Child("namespace", "matchNames").Index(i), name, "an empty name never matches a namespace"))
+			}
+		}
+		for i, name := range selector.Namespace.ExcludeNames {
+			if name == "*" {
+				el = append(el, field.Invalid(fldPath.Child("namespace", "excludeNames").Index(i), name, "excluding all namespaces means the selector never matches a request"))
+			}
+		}
+	}
+
+	return el
+}
+
+// SelectorMatchesAll returns true if the given selector places no restriction
+// on which CertificateRequests it matches, i.e. it matches requests for any
+// issuer, in any namespace, with any labels or annotations. A certificate
+// selector always restricts requests to those owned by a Certificate.
+func SelectorMatchesAll(selector policyapi.CertificateRequestPolicySelector) bool {
+	issuerMatchesAll := selector.IssuerRef == nil && selector.IssuerRefs == nil
+	if selector.IssuerRef != nil {
+		issuerMatchesAll = issuerRefMatchesAll(*selector.IssuerRef)
+	}
+	for _, issuerRef := range selector.IssuerRefs {
+		issuerMatchesAll = issuerMatchesAll || issuerRefMatchesAll(issuerRef)
+	}
+	if !issuerMatchesAll {
+		return false
+	}
+
+	if ns := selector.Namespace; ns != nil {
+		if len(ns.MatchLabels) > 0 || len(ns.MatchExpressions) > 0 || len(ns.ExcludeNames) > 0 || len(ns.ExcludeLabels) > 0 {
+			return false
+		}
+		if len(ns.MatchNames) > 0 && !slices.Contains(ns.MatchNames, "*") {
+			return false
+		}
+	}
+
+	if cr := selector.CertificateRequest; cr != nil {
+		if len(cr.MatchLabels) > 0 || len(cr.MatchExpressions) > 0 || len(cr.MatchAnnotations) > 0 || len(cr.MatchAnnotationExpressions) > 0 {
+			return false
+		}
+	}
+
+	if selector.Certificate != nil {
+		return false
+	}
+
+	return true
+}
+
+// issuerRefMatchesAll returns true if the issuerRef selector matches any
+// issuer.
+func issuerRefMatchesAll(issuerRef policyapi.CertificateRequestPolicySelectorIssuerRef) bool {
+	for _, value := range []*string{issuerRef.Name, issuerRef.Kind, issuerRef.Group} {
+		if value != nil && *value != "*" {
+			return false
+		}
+	}
+	return issuerRef.NameExpression == nil && (issuerRef.RequireReady == nil || !*issuerRef.RequireReady) &&
+		len(issuerRef.ExcludeKinds) == 0 && len(issuerRef.ExcludeGroups) == 0 && issuerRef.Namespaced == nil
+}
+
+// SelectorIssuerRefs returns the issuerRef selectors of the given selector. A
+// selector which doesn't select by issuer is returned as a single selector
+// which matches any issuer.
+func SelectorIssuerRefs(selector policyapi.CertificateRequestPolicySelector) []policyapi.CertificateRequestPolicySelectorIssuerRef {
+	if selector.IssuerRef != nil {
+		return []policyapi.CertificateRequestPolicySelectorIssuerRef{*selector.IssuerRef}
+	}
+	if selector.IssuerRefs != nil {
+		return selector.IssuerRefs
+	}
+	return []policyapi.CertificateRequestPolicySelectorIssuerRef{{}}
+}
+
+// IssuerRefCovers returns true if every issuer matched by the issuerRef
+// selector is also matched by the covering selector. Name expressions are
+// only known to cover each other if they are identical, and exclusions of the
+// covering selector must also be excluded by the issuerRef selector.
+func IssuerRefCovers(covering, issuerRef policyapi.CertificateRequestPolicySelectorIssuerRef) bool {
+	if covering.RequireReady != nil && *covering.RequireReady {
+		return false
+	}
+
+	if covering.Namespaced != nil && (issuerRef.Namespaced == nil || *covering.Namespaced != *issuerRef.Namespaced) {
+		return false
+	}
+
+	for _, exclusions := range [][2][]string{
+		{covering.ExcludeKinds, issuerRef.ExcludeKinds},
+		{covering.ExcludeGroups, issuerRef.ExcludeGroups},
+	} {
+		for _, excluded := range exclusions[0] {
+			if !slices.Contains(exclusions[1], excluded) {
+				return false
+			}
+		}
+	}
+
+	if covering.NameExpression != nil {
+		if issuerRef.NameExpression == nil || *covering.NameExpression != *issuerRef.NameExpression {
+			return false
+		}
+	} else if issuerRef.NameExpression != nil {
+		if covering.Name != nil && *covering.Name != "*" {
+			return false
+		}
+	} else if !issuerRefFieldCovers(covering.Name, issuerRef.Name) {
+		return false
+	}
+
+	return issuerRefFieldCovers(covering.Kind, issuerRef.Kind) &&
+		issuerRefFieldCovers(covering.Group, issuerRef.Group)
+}
+
+// issuerRefFieldCovers returns true if every value matched by the wildcard
+// pattern is also matched by the covering wildcard pattern. A nil pattern
+// matches any value.
+func issuerRefFieldCovers(covering, pattern *string) bool {
+	if covering == nil || *covering == "*" {
+		return true
+	}
+	if pattern == nil {
+		return false
+	}
+	if *covering == *pattern {
+		return true
+	}
+	// Only a literal value is known to be covered by a different pattern.
+	return !strings.Contains(*pattern, "*") && WildcardMatches(*covering, *pattern)
+}
+
+// issuerRefWarnings returns suspicious configurations of a single issuerRef
+// selector.
+func issuerRefWarnings(issuerRef policyapi.CertificateRequestPolicySelectorIssuerRef, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	if issuerRef.Name != nil && len(*issuerRef.Name) == 0 {
+		el = append(el, field.Invalid(fldPath.Child("name"), *issuerRef.Name, "an empty name never matches an issuer, hint: omit the field or use `*` to match any name"))
+	}
+
+	// Requests which omit the issuer kind or group are matched using the
+	// cert-manager defaults, so an empty kind or group never matches.
+	if issuerRef.Kind != nil && len(*issuerRef.Kind) == 0 {
+		el = append(el, field.Invalid(fldPath.Child("kind"), *issuerRef.Kind, "an empty kind never matches an issuer, since requests which omit the issuer kind are matched as \"Issuer\", hint: omit the field or use `*` to match any kind"))
+	}
+	if issuerRef.Group != nil && len(*issuerRef.Group) == 0 {
+		el = append(el, field.Invalid(fldPath.Child("group"), *issuerRef.Group, "an empty group never matches an issuer, since requests which omit the issuer group are matched as \"cert-manager.io\", hint: omit the field or use `*` to match any group"))
+	}
+
+	if issuerRef.Namespaced != nil && issuerRef.Kind != nil {
+		if *issuerRef.Namespaced && *issuerRef.Kind == "ClusterIssuer" {
+			el = append(el, field.Invalid(fldPath.Child("namespaced"), *issuerRef.Namespaced, "a ClusterIssuer is never namespaced, so the selector never matches a request"))
+		} else if !*issuerRef.Namespaced && *issuerRef.Kind == "Issuer" {
+			el = append(el, field.Invalid(fldPath.Child("namespaced"), *issuerRef.Namespaced, "an Issuer is always namespaced, so the selector never matches a request"))
+		}
+	}
+
+	for i, kind := range issuerRef.ExcludeKinds {
+		if kind == "*" {
+			el = append(el, field.Invalid(fldPath.Child("excludeKinds").Index(i), kind, "excluding all kinds means the selector never matches a request"))
+		}
+	}
+	for i, group := range issuerRef.ExcludeGroups {
+		if group == "*" {
+			el = append(el, field.Invalid(fldPath.Child("excludeGroups").Index(i), group, "excluding all groups means the selector never matches a request"))
+		}
+	}
+
+	return el
+}
@@ -0,0 +1,346 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+)
+
+func Test_SelectorWarnings(t *testing.T) {
+	fldPath := field.NewPath("spec", "selector")
+
+	tests := map[string]struct {
+		selector policyapi.CertificateRequestPolicySelector
+		expWarns field.ErrorList
+	}{
+		"an empty selector should return no warnings": {
+			selector: policyapi.CertificateRequestPolicySelector{},
+			expWarns: nil,
+		},
+		"an empty issuerRef should return no warnings": {
+			selector: policyapi.CertificateRequestPolicySelector{
+				IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{},
+			},
+			expWarns: nil,
+		},
+		"a fully defined issuerRef should return no warnings": {
+			selector: policyapi.CertificateRequestPolicySelector{
+				IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{
+					Name: ptr.To("prod"), Kind: ptr.To("Issuer"), Group: ptr.To("cert-manager.io"),
+				},
+			},
+			expWarns: nil,
+		},
+		"an empty group with a wildcard name should return a warning": {
+			selector: policyapi.CertificateRequestPolicySelector{
+				IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{
+					Name: ptr.To("*"), Group: ptr.To(""),
+				},
+			},
+			expWarns: field.ErrorList{
+				field.Invalid(fldPath.Child("issuerRef", "group"), "", "an empty group never matches an issuer, since requests which omit the issuer group are matched as \"cert-manager.io\", hint: omit the field or use `*` to match any group"),
+			},
+		},
+		"an empty group without a name should return a warning": {
+			selector: policyapi.CertificateRequestPolicySelector{
+				IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{
+					Kind: ptr.To("Issuer"), Group: ptr.To(""),
+				},
+			},
+			expWarns: field.ErrorList{
+				field.Invalid(fldPath.Child("issuerRef", "group"), "", "an empty group never matches an issuer, since requests which omit the issuer group are matched as \"cert-manager.io\", hint: omit the field or use `*` to match any group"),
+			},
+		},
+		"an empty kind should return a warning": {
+			selector: policyapi.CertificateRequestPolicySelector{
+				IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{
+					Kind: ptr.To(""), Group: ptr.To("cert-manager.io"),
+				},
+			},
+			expWarns: field.ErrorList{
+				field.Invalid(fldPath.Child("issuerRef", "kind"), "", "an empty kind never matches an issuer, since requests which omit the issuer kind are matched as \"Issuer\", hint: omit the field or use `*` to match any kind"),
+			},
+		},
+		"an empty group with a name should return a warning": {
+			selector: policyapi.CertificateRequestPolicySelector{
+				IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{
+					Name: ptr.To("prod"), Kind: ptr.To("Issuer"), Group: ptr.To(""),
+				},
+			},
+			expWarns: field.ErrorList{
+				field.Invalid(fldPath.Child("issuerRef", "group"), "", "an empty group never matches an issuer, since requests which omit the issuer group are matched as \"cert-manager.io\", hint: omit the field or use `*` to match any group"),
+			},
+		},
+		"an empty name should return a warning": {
+			selector: policyapi.CertificateRequestPolicySelector{
+				IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{
+					Name: ptr.To(""),
+				},
+			},
+			expWarns: field.ErrorList{
+				field.Invalid(fldPath.Child("issuerRef", "name"), "", "an empty name never matches an issuer, hint: omit the field or use `*` to match any name"),
+			},
+		},
+		"suspicious issuerRefs entries should return warnings with their index": {
+			selector: policyapi.CertificateRequestPolicySelector{
+				IssuerRefs: []policyapi.CertificateRequestPolicySelectorIssuerRef{
+					{Name: ptr.To("prod"), Group: ptr.To("cert-manager.io")},
+					{Name: ptr.To("staging"), Group: ptr.To("")},
+				},
+			},
+			expWarns: field.ErrorList{
+				field.Invalid(fldPath.Child("issuerRefs").Index(1).Child("group"), "", "an empty group never matches an issuer, since requests which omit the issuer group are matched as \"cert-manager.io\", hint: omit the field or use `*` to match any group"),
+			},
+		},
+		"an empty namespace name should return a warning": {
+			selector: policyapi.CertificateRequestPolicySelector{
+				Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{
+					MatchNames: []string{"sandbox", ""},
+				},
+			},
+			expWarns: field.ErrorList{
+				field.Invalid(fldPath.Child("namespace", "matchNames").Index(1), "", "an empty name never matches a namespace"),
+			},
+		},
+		"excluding all namespace names should return a warning": {
+			selector: policyapi.CertificateRequestPolicySelector{
+				Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{
+					ExcludeNames: []string{"kube-system", "*"},
+				},
+			},
+			expWarns: field.ErrorList{
+				field.Invalid(fldPath.Child("namespace", "excludeNames").Index(1), "*", "excluding all namespaces means the selector never matches a request"),
+			},
+		},
+		"an issuerRef selector whose kind contradicts namespaced should warn": {
+			selector: policyapi.CertificateRequestPolicySelector{
+				IssuerRefs: []policyapi.CertificateRequestPolicySelectorIssuerRef{
+					{Kind: ptr.To("ClusterIssuer"), Namespaced: ptr.To(true)},
+					{Kind: ptr.To("Issuer"), Namespaced: ptr.To(false)},
+					{Kind: ptr.To("Issuer"), Namespaced: ptr.To(true)},
+				},
+			},
+			expWarns: field.ErrorList{
+				field.Invalid(fldPath.Child("issuerRefs").Index(0).Child("namespaced"), true, "a ClusterIssuer is never namespaced, so the selector never matches a request"),
+				field.Invalid(fldPath.Child("issuerRefs").Index(1).Child("namespaced"), false, "an Issuer is always namespaced, so the selector never matches a request"),
+			},
+		},
+		"an issuerRef selector excluding all kinds or groups should warn": {
+			selector: policyapi.CertificateRequestPolicySelector{
+				IssuerRefs: []policyapi.CertificateRequestPolicySelectorIssuerRef{
+					{ExcludeKinds: []string{"*"}, ExcludeGroups: []string{"example.com", "*"}},
+				},
+			},
+			expWarns: field.ErrorList{
+				field.Invalid(fldPath.Child("issuerRefs").Index(0).Child("excludeKinds").Index(0), "*", "excluding all kinds means the selector never matches a request"),
+				field.Invalid(fldPath.Child("issuerRefs").Index(0).Child("excludeGroups").Index(1), "*", "excluding all groups means the selector never matches a request"),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expWarns, SelectorWarnings(test.selector, fldPath))
+		})
+	}
+}
+
+func Test_SelectorMatchesAll(t *testing.T) {
+	tests := map[string]struct {
+		selector policyapi.CertificateRequestPolicySelector
+		exp      bool
+	}{
+		"an empty issuerRef should match all": {
+			selector: policyapi.CertificateRequestPolicySelector{
+				IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{},
+			},
+			exp: true,
+		},
+		"a wildcard issuerRef with an empty namespace selector should match all": {
+			selector: policyapi.CertificateRequestPolicySelector{
+				IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("*"), Kind: ptr.To("*"), Group: ptr.To("*")},
+				Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{MatchNames: []string{"*"}},
+			},
+			exp: true,
+		},
+		"a named issuerRef should not match all": {
+			selector: policyapi.CertificateRequestPolicySelector{
+				IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("prod")},
+			},
+			exp: false,
+		},
+		"an issuerRef with a name expression should not match all": {
+			selector: policyapi.CertificateRequestPolicySelector{
+				IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{NameExpression: ptr.To("cr.namespace")},
+			},
+			exp: false,
+		},
+		"an issuerRef requiring a ready issuer should not match all": {
+			selector: policyapi.CertificateRequestPolicySelector{
+				IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{RequireReady: ptr.To(true)},
+			},
+			exp: false,
+		},
+		"issuerRefs with one wildcard entry should match all": {
+			selector: policyapi.CertificateRequestPolicySelector{
+				IssuerRefs: []policyapi.CertificateRequestPolicySelectorIssuerRef{{Name: ptr.To("prod")}, {}},
+			},
+			exp: true,
+		},
+		"a namespace selector with names should not match all": {
+			selector: policyapi.CertificateRequestPolicySelector{
+				Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{MatchNames: []string{"sandbox"}},
+			},
+			exp: false,
+		},
+		"a namespace selector with labels should not match all": {
+			selector: policyapi.CertificateRequestPolicySelector{
+				Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{MatchLabels: map[string]string{"foo": "bar"}},
+			},
+			exp: false,
+		},
+		"an issuerRef selector selecting namespaced issuers should not match all": {
+			selector: policyapi.CertificateRequestPolicySelector{
+				IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{Namespaced: ptr.To(true)},
+			},
+			exp: false,
+		},
+		"an issuerRef selector with excluded kinds should not match all": {
+			selector: policyapi.CertificateRequestPolicySelector{
+				IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{ExcludeKinds: []string{"ClusterIssuer"}},
+			},
+			exp: false,
+		},
+		"a namespace selector with excluded names should not match all": {
+			selector: policyapi.CertificateRequestPolicySelector{
+				Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{MatchNames: []string{"*"}, ExcludeNames: []string{"kube-system"}},
+			},
+			exp: false,
+		},
+		"a certificateRequest selector with labels should not match all": {
+			selector: policyapi.CertificateRequestPolicySelector{
+				IssuerRef:          &policyapi.CertificateRequestPolicySelectorIssuerRef{},
+				CertificateRequest: &policyapi.CertificateRequestPolicySelectorCertificateRequest{MatchLabels: map[string]string{"foo": "bar"}},
+			},
+			exp: false,
+		},
+		"an empty certificate selector should not match all": {
+			selector: policyapi.CertificateRequestPolicySelector{
+				IssuerRef:   &policyapi.CertificateRequestPolicySelectorIssuerRef{},
+				Certificate: &policyapi.CertificateRequestPolicySelectorCertificate{},
+			},
+			exp: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.exp, SelectorMatchesAll(test.selector))
+		})
+	}
+}
+
+func Test_IssuerRefCovers(t *testing.T) {
+	tests := map[string]struct {
+		covering  policyapi.CertificateRequestPolicySelectorIssuerRef
+		issuerRef policyapi.CertificateRequestPolicySelectorIssuerRef
+		exp       bool
+	}{
+		"an empty selector should cover any selector": {
+			covering:  policyapi.CertificateRequestPolicySelectorIssuerRef{},
+			issuerRef: policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("prod-*"), Kind: ptr.To("Issuer")},
+			exp:       true,
+		},
+		"an identical selector should cover": {
+			covering:  policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("prod-*"), Kind: ptr.To("Issuer"), Group: ptr.To("cert-manager.io")},
+			issuerRef: policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("prod-*"), Kind: ptr.To("Issuer"), Group: ptr.To("cert-manager.io")},
+			exp:       true,
+		},
+		"a wildcard selector should cover a matching literal name": {
+			covering:  policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("prod-*")},
+			issuerRef: policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("prod-ca"), Kind: ptr.To("Issuer")},
+			exp:       true,
+		},
+		"a namespaced selector should not cover a selector of any scope": {
+			covering:  policyapi.CertificateRequestPolicySelectorIssuerRef{Namespaced: ptr.To(true)},
+			issuerRef: policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("prod-ca")},
+			exp:       false,
+		},
+		"a namespaced selector should cover a namespaced selector": {
+			covering:  policyapi.CertificateRequestPolicySelectorIssuerRef{Namespaced: ptr.To(true)},
+			issuerRef: policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("prod-ca"), Namespaced: ptr.To(true)},
+			exp:       true,
+		},
+		"a selector excluding a kind should not cover a selector which doesn't exclude it": {
+			covering:  policyapi.CertificateRequestPolicySelectorIssuerRef{ExcludeKinds: []string{"ClusterIssuer"}},
+			issuerRef: policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("prod-ca")},
+			exp:       false,
+		},
+		"a selector excluding a group should cover a selector which also excludes it": {
+			covering:  policyapi.CertificateRequestPolicySelectorIssuerRef{ExcludeGroups: []string{"example.com"}},
+			issuerRef: policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("prod-ca"), ExcludeGroups: []string{"example.com", "example.org"}},
+			exp:       true,
+		},
+		"a wildcard selector should not cover a different wildcard": {
+			covering:  policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("prod-*")},
+			issuerRef: policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("prod-ca-*")},
+			exp:       false,
+		},
+		"a named selector should not cover an empty selector": {
+			covering:  policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("prod")},
+			issuerRef: policyapi.CertificateRequestPolicySelectorIssuerRef{},
+			exp:       false,
+		},
+		"a selector with a different kind should not cover": {
+			covering:  policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("prod"), Kind: ptr.To("ClusterIssuer")},
+			issuerRef: policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("prod"), Kind: ptr.To("Issuer")},
+			exp:       false,
+		},
+		"a selector requiring a ready issuer should not cover": {
+			covering:  policyapi.CertificateRequestPolicySelectorIssuerRef{RequireReady: ptr.To(true)},
+			issuerRef: policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("prod")},
+			exp:       false,
+		},
+		"an identical name expression should cover": {
+			covering:  policyapi.CertificateRequestPolicySelectorIssuerRef{NameExpression: ptr.To("cr.namespace")},
+			issuerRef: policyapi.CertificateRequestPolicySelectorIssuerRef{NameExpression: ptr.To("cr.namespace")},
+			exp:       true,
+		},
+		"a name expression should not cover a literal name": {
+			covering:  policyapi.CertificateRequestPolicySelectorIssuerRef{NameExpression: ptr.To("cr.namespace")},
+			issuerRef: policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("prod")},
+			exp:       false,
+		},
+		"a wildcard name should cover a name expression": {
+			covering:  policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("*")},
+			issuerRef: policyapi.CertificateRequestPolicySelectorIssuerRef{NameExpression: ptr.To("cr.namespace")},
+			exp:       true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.exp, IssuerRefCovers(test.covering, test.issuerRef))
+		})
+	}
+}
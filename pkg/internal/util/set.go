@@ -0,0 +1,39 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateSet returns a Duplicate error for each value of the given list which
+// is equal to an earlier value in the list. This is the validation the API
+// server applies to list fields marked with `x-kubernetes-list-type: set`, so
+// that list fields of v1alpha1 which are not marked as such are validated the
+// same way.
+func ValidateSet[T comparable](fldPath *field.Path, values []T) field.ErrorList {
+	var el field.ErrorList
+	seen := make(map[T]struct{}, len(values))
+	for i, value := range values {
+		if _, ok := seen[value]; ok {
+			el = append(el, field.Duplicate(fldPath.Index(i), value))
+			continue
+		}
+		seen[value] = struct{}{}
+	}
+	return el
+}
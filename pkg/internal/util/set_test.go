@@ -0,0 +1,56 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func Test_ValidateSet(t *testing.T) {
+	fldPath := field.NewPath("spec", "allowed", "dnsNames", "values")
+
+	tests := map[string]struct {
+		values []string
+		expEl  field.ErrorList
+	}{
+		"if no values are given, return no errors": {
+			values: nil,
+			expEl:  nil,
+		},
+		"if values are unique, return no errors": {
+			values: []string{"*.example.com", "example.com", "*.EXAMPLE.com"},
+			expEl:  nil,
+		},
+		"if values contain duplicates, return an error for each repeated value": {
+			values: []string{"a", "b", "a", "c", "b", "a"},
+			expEl: field.ErrorList{
+				field.Duplicate(fldPath.Index(2), "a"),
+				field.Duplicate(fldPath.Index(4), "b"),
+				field.Duplicate(fldPath.Index(5), "a"),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expEl, ValidateSet(fldPath, test.values))
+		})
+	}
+}
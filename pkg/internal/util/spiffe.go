@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const spiffeScheme = "spiffe://"
+
+// SPIFFETrustDomain returns the trust domain of the given SPIFFE ID. An error
+// is returned if the ID is not a valid SPIFFE ID, as defined by the SPIFFE ID
+// specification.
+func SPIFFETrustDomain(id string) (string, error) {
+	if !strings.HasPrefix(id, spiffeScheme) {
+		return "", errors.New(`scheme must be "spiffe"`)
+	}
+
+	trustDomain, path, hasPath := strings.Cut(strings.TrimPrefix(id, spiffeScheme), "/")
+	if err := ValidateSPIFFETrustDomain(trustDomain); err != nil {
+		return "", err
+	}
+
+	if hasPath {
+		for _, segment := range strings.Split(path, "/") {
+			if err := validateSPIFFEPathSegment(segment); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	return trustDomain, nil
+}
+
+// ValidateSPIFFETrustDomain returns an error if the given trust domain name is
+// not valid. Trust domain names may only contain lowercase letters, numbers,
+// dots, dashes and underscores.
+func ValidateSPIFFETrustDomain(trustDomain string) error {
+	if len(trustDomain) == 0 {
+		return errors.New("trust domain is missing")
+	}
+	for _, c := range trustDomain {
+		if !isSPIFFETrustDomainChar(c) {
+			return fmt.Errorf("trust domain %q contains invalid character %q", trustDomain, c)
+		}
+	}
+	return nil
+}
+
+func validateSPIFFEPathSegment(segment string) error {
+	switch segment {
+	case "":
+		return errors.New("path cannot contain empty segments or a trailing slash")
+	case ".", "..":
+		return fmt.Errorf("path cannot contain dot segment %q", segment)
+	}
+	for _, c := range segment {
+		if !isSPIFFEPathChar(c) {
+			return fmt.Errorf("path segment %q contains invalid character %q", segment, c)
+		}
+	}
+	return nil
+}
+
+func isSPIFFETrustDomainChar(c rune) bool {
+	return (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '.' || c == '-' || c == '_'
+}
+
+func isSPIFFEPathChar(c rune) bool {
+	return isSPIFFETrustDomainChar(c) || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+)
+
+func Test_SPIFFETrustDomain(t *testing.T) {
+	tests := map[string]struct {
+		id             string
+		expTrustDomain string
+		expErr         bool
+	}{
+		"trust domain only":                 {id: "spiffe://example.org", expTrustDomain: "example.org"},
+		"trust domain with path":            {id: "spiffe://cluster.local/ns/foo/sa/bar", expTrustDomain: "cluster.local"},
+		"path with uppercase letters":       {id: "spiffe://example.org/Workload_1", expTrustDomain: "example.org"},
+		"wrong scheme":                      {id: "https://example.org/foo", expErr: true},
+		"uppercase scheme":                  {id: "SPIFFE://example.org/foo", expErr: true},
+		"missing trust domain":              {id: "spiffe:///foo", expErr: true},
+		"uppercase trust domain":            {id: "spiffe://Example.org/foo", expErr: true},
+		"trust domain with port":            {id: "spiffe://example.org:8080/foo", expErr: true},
+		"trust domain with userinfo":        {id: "spiffe://user@example.org/foo", expErr: true},
+		"trailing slash":                    {id: "spiffe://example.org/", expErr: true},
+		"empty path segment":                {id: "spiffe://example.org/foo//bar", expErr: true},
+		"dot path segment":                  {id: "spiffe://example.org/foo/../bar", expErr: true},
+		"query":                             {id: "spiffe://example.org/foo?bar=baz", expErr: true},
+		"fragment":                          {id: "spiffe://example.org/foo#bar", expErr: true},
+		"percent encoded character in path": {id: "spiffe://example.org/foo%20bar", expErr: true},
+		"not a URI":                         {id: "foo.bar.com", expErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			trustDomain, err := SPIFFETrustDomain(test.id)
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+			if trustDomain != test.expTrustDomain {
+				t.Errorf("unexpected trust domain, exp=%q got=%q", test.expTrustDomain, trustDomain)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"strings"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// keyUsageAliases maps usage spellings which cert-manager encodes identically
+// in a CSR to a single canonical spelling.
+var keyUsageAliases = map[cmapi.KeyUsage]cmapi.KeyUsage{
+	cmapi.UsageSigning: cmapi.UsageDigitalSignature,
+	cmapi.UsageSMIME:   cmapi.UsageEmailProtection,
+}
+
+// CanonicalKeyUsage returns the canonical spelling of the given usage, so
+// that equivalent usages with different spellings compare as equal.
+func CanonicalKeyUsage(usage cmapi.KeyUsage) string {
+	usage = cmapi.KeyUsage(strings.ToLower(strings.TrimSpace(string(usage))))
+	if alias, ok := keyUsageAliases[usage]; ok {
+		return string(alias)
+	}
+	return string(usage)
+}
@@ -65,6 +65,51 @@ func WildcardMatches(pattern, str string) bool {
 	return matchRunes([]rune(pattern), []rune(str))
 }
 
+// DNSWildcardContains will return true if the given DNS name matches at least
+// one of the passed patterns. Wildcards ('*') in patterns only match within a
+// single DNS label.
+func DNSWildcardContains(patterns []string, member string) bool {
+	for _, pattern := range patterns {
+		if DNSWildcardMatches(pattern, member) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DNSWildcardMatches will return true if the given DNS name matches the
+// pattern. Wildcards ('*') in the pattern match any string which doesn't
+// contain a '.', so that "*.example.com" matches "foo.example.com" but not
+// "foo.bar.example.com", as per RFC 6125.
+func DNSWildcardMatches(pattern, str string) bool {
+	return matchDNSRunes([]rune(pattern), []rune(str))
+}
+
+// matchDNSRunes will return whether the given rune slice matches the given
+// pattern using wildcards ('*') which don't match across DNS labels.
+func matchDNSRunes(pattern, str []rune) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+
+		// If '*' then branch with recursive check for before and after '*',
+		// never consuming a label separator.
+		case '*':
+			return matchDNSRunes(pattern[1:], str) || (len(str) > 0 && str[0] != '.' && matchDNSRunes(pattern, str[1:]))
+
+		default:
+			if len(str) == 0 || str[0] != pattern[0] {
+				return false
+			}
+		}
+
+		str = str[1:]
+		pattern = pattern[1:]
+	}
+
+	return len(str) == 0
+}
+
 // matchRunes will return whether the given rune slice matches the given
 // pattern using wildcards ('*').
 func matchRunes(pattern, str []rune) bool {
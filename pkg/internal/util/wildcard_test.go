@@ -304,3 +304,75 @@ func Test_WildcardMatches(t *testing.T) {
 		})
 	}
 }
+
+func Test_DNSWildcardMatches(t *testing.T) {
+	tests := map[string]struct {
+		pattern string
+		text    string
+		exp     bool
+	}{
+		"leading wildcard matches a single label: true": {
+			pattern: "*.example.com",
+			text:    "foo.example.com",
+			exp:     true,
+		},
+		"leading wildcard doesn't match multiple labels: false": {
+			pattern: "*.example.com",
+			text:    "foo.bar.example.com",
+			exp:     false,
+		},
+		"leading wildcard doesn't match the parent domain: false": {
+			pattern: "*.example.com",
+			text:    "example.com",
+			exp:     false,
+		},
+		"leading wildcard matches a wildcard DNS name: true": {
+			pattern: "*.example.com",
+			text:    "*.example.com",
+			exp:     true,
+		},
+		"partial label wildcard matches within the label: true": {
+			pattern: "foo-*.example.com",
+			text:    "foo-bar.example.com",
+			exp:     true,
+		},
+		"partial label wildcard doesn't match multiple labels: false": {
+			pattern: "foo-*.example.com",
+			text:    "foo-bar.baz.example.com",
+			exp:     false,
+		},
+		"inner label wildcard matches a single label: true": {
+			pattern: "foo.*.example.com",
+			text:    "foo.bar.example.com",
+			exp:     true,
+		},
+		"only wildcard pattern matches a single label: true": {
+			pattern: "*",
+			text:    "localhost",
+			exp:     true,
+		},
+		"only wildcard pattern doesn't match multiple labels: false": {
+			pattern: "*",
+			text:    "example.com",
+			exp:     false,
+		},
+		"empty pattern and text: true": {
+			pattern: "",
+			text:    "",
+			exp:     true,
+		},
+		"same pattern and text: true": {
+			pattern: "example.com",
+			text:    "example.com",
+			exp:     true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if match := DNSWildcardMatches(test.pattern, test.text); match != test.exp {
+				t.Errorf("unexpected match (%q, %q): exp=%t got=%t",
+					test.pattern, test.text, test.exp, match)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+)
+
+// defaulter defaults omitted fields of CertificateRequestPolicies, so that
+// basic policies can be written without boilerplate. Explicitly set fields
+// are never changed.
+type defaulter struct{}
+
+var _ admission.CustomDefaulter = &defaulter{}
+
+func (d *defaulter) Default(_ context.Context, obj runtime.Object) error {
+	policy, ok := obj.(*policyapi.CertificateRequestPolicy)
+	if !ok {
+		return fmt.Errorf("expected a CertificateRequestPolicy, but got a %T", obj)
+	}
+
+	setDefaults(&policy.Spec)
+	return nil
+}
+
+// setDefaults defaults the omitted fields of the given policy spec:
+//   - `spec.selector.issuerRef` is defaulted to `{}`, matching all issuers,
+//     if neither `issuerRef`, `issuerRefs` nor `namespace` are set, since
+//     the policy would otherwise be rejected for selecting nothing.
+//   - `spec.enforcement` is defaulted to `Permissive`, which is how an
+//     omitted enforcement is treated.
+func setDefaults(spec *policyapi.CertificateRequestPolicySpec) {
+	sel := &spec.Selector
+	if sel.IssuerRef == nil && sel.IssuerRefs == nil && sel.Namespace == nil {
+		sel.IssuerRef = &policyapi.CertificateRequestPolicySelectorIssuerRef{}
+	}
+
+	if spec.Enforcement == nil {
+		spec.Enforcement = ptr.To(policyapi.CertificateRequestPolicyEnforcementPermissive)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+)
+
+func Test_Default(t *testing.T) {
+	var (
+		permissive = ptr.To(policyapi.CertificateRequestPolicyEnforcementPermissive)
+		strict     = ptr.To(policyapi.CertificateRequestPolicyEnforcementStrict)
+	)
+
+	tests := map[string]struct {
+		spec    policyapi.CertificateRequestPolicySpec
+		expSpec policyapi.CertificateRequestPolicySpec
+	}{
+		"if selector and enforcement are omitted, default to matching all issuers and Permissive": {
+			spec: policyapi.CertificateRequestPolicySpec{},
+			expSpec: policyapi.CertificateRequestPolicySpec{
+				Selector: policyapi.CertificateRequestPolicySelector{
+					IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{},
+				},
+				Enforcement: permissive,
+			},
+		},
+		"if only a label selector is set, default issuerRef to match all issuers": {
+			spec: policyapi.CertificateRequestPolicySpec{
+				Selector: policyapi.CertificateRequestPolicySelector{
+					CertificateRequest: &policyapi.CertificateRequestPolicySelectorCertificateRequest{},
+				},
+			},
+			expSpec: policyapi.CertificateRequestPolicySpec{
+				Selector: policyapi.CertificateRequestPolicySelector{
+					IssuerRef:          &policyapi.CertificateRequestPolicySelectorIssuerRef{},
+					CertificateRequest: &policyapi.CertificateRequestPolicySelectorCertificateRequest{},
+				},
+				Enforcement: permissive,
+			},
+		},
+		"if namespace selector is set, don't default issuerRef": {
+			spec: policyapi.CertificateRequestPolicySpec{
+				Selector: policyapi.CertificateRequestPolicySelector{
+					Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{MatchNames: []string{"foo"}},
+				},
+			},
+			expSpec: policyapi.CertificateRequestPolicySpec{
+				Selector: policyapi.CertificateRequestPolicySelector{
+					Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{MatchNames: []string{"foo"}},
+				},
+				Enforcement: permissive,
+			},
+		},
+		"if issuerRefs is set, even if empty, don't default issuerRef": {
+			spec: policyapi.CertificateRequestPolicySpec{
+				Selector: policyapi.CertificateRequestPolicySelector{
+					IssuerRefs: []policyapi.CertificateRequestPolicySelectorIssuerRef{},
+				},
+			},
+			expSpec: policyapi.CertificateRequestPolicySpec{
+				Selector: policyapi.CertificateRequestPolicySelector{
+					IssuerRefs: []policyapi.CertificateRequestPolicySelectorIssuerRef{},
+				},
+				Enforcement: permissive,
+			},
+		},
+		"if issuerRef and enforcement are set, keep them unchanged": {
+			spec: policyapi.CertificateRequestPolicySpec{
+				Selector: policyapi.CertificateRequestPolicySelector{
+					IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("my-issuer")},
+				},
+				Enforcement: strict,
+			},
+			expSpec: policyapi.CertificateRequestPolicySpec{
+				Selector: policyapi.CertificateRequestPolicySelector{
+					IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("my-issuer")},
+				},
+				Enforcement: strict,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			policy := &policyapi.CertificateRequestPolicy{Spec: test.spec}
+			assert.NoError(t, new(defaulter).Default(context.TODO(), policy))
+			assert.Equal(t, test.expSpec, policy.Spec)
+		})
+	}
+}
+
+func Test_DefaultWrongType(t *testing.T) {
+	assert.Error(t, new(defaulter).Default(context.TODO(), &policyapi.CertificateRequestPolicyProfile{}))
+}
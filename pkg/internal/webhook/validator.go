@@ -20,9 +20,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
+	"strings"
 
 	"github.com/go-logr/logr"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -32,6 +35,8 @@ import (
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
 	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/internal/approver/validation"
+	"github.com/cert-manager/approver-policy/pkg/internal/util"
 )
 
 // validator validates against policy.cert-manager.io resources.
@@ -42,10 +47,18 @@ type validator struct {
 	webhooks          []approver.Webhook
 
 	lister client.Reader
+
+	// denySolePolicyDeletion causes the deletion of a policy which is the only
+	// policy selecting some issuers to be denied, rather than warned about.
+	denySolePolicyDeletion bool
 }
 
 var _ admission.CustomValidator = &validator{}
 
+// nameExpressions caches the compiled `nameExpression` CEL expressions of
+// issuerRef selectors.
+var nameExpressions = validation.NewExpressionCache()
+
 func (v *validator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	return v.validate(ctx, obj)
 }
@@ -55,8 +68,69 @@ func (v *validator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.O
 }
 
 func (v *validator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
-	// always allow deletes
-	return nil, nil
+	policy, ok := obj.(*policyapi.CertificateRequestPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected a CertificateRequestPolicy, but got a %T", obj)
+	}
+
+	orphaned, err := v.orphanedIssuerRefs(ctx, policy)
+	if err != nil {
+		// Deletes are never blocked on being unable to list policies.
+		v.log.Error(err, "failed to check for sibling policies of deleted policy", "name", policy.Name)
+		return admission.Warnings{fmt.Sprintf("unable to check whether other policies select the same issuers: %s", err)}, nil
+	}
+	if len(orphaned) == 0 {
+		return nil, nil
+	}
+
+	var messages []string
+	for _, fldPath := range orphaned {
+		messages = append(messages, field.Forbidden(fldPath,
+			"no other CertificateRequestPolicy selects these issuers, so their requests will be neither approved nor denied once this policy is deleted").Error())
+	}
+
+	if v.denySolePolicyDeletion {
+		return nil, errors.New(strings.Join(messages, ", "))
+	}
+	return messages, nil
+}
+
+// orphanedIssuerRefs returns the paths of the issuerRef selectors of the given
+// policy which no other policy selects, i.e. the issuers for which the policy
+// is the only approver. Other policies which are being deleted are ignored.
+func (v *validator) orphanedIssuerRefs(ctx context.Context, policy *policyapi.CertificateRequestPolicy) ([]*field.Path, error) {
+	var policies policyapi.CertificateRequestPolicyList
+	if err := v.lister.List(ctx, &policies); err != nil {
+		return nil, err
+	}
+
+	var siblingIssuerRefs []policyapi.CertificateRequestPolicySelectorIssuerRef
+	for _, sibling := range policies.Items {
+		if sibling.Name == policy.Name || sibling.DeletionTimestamp != nil {
+			continue
+		}
+		siblingIssuerRefs = append(siblingIssuerRefs, util.SelectorIssuerRefs(sibling.Spec.Selector)...)
+	}
+
+	var orphaned []*field.Path
+	for i, issuerRef := range util.SelectorIssuerRefs(policy.Spec.Selector) {
+		if slices.ContainsFunc(siblingIssuerRefs, func(sibling policyapi.CertificateRequestPolicySelectorIssuerRef) bool {
+			return util.IssuerRefCovers(sibling, issuerRef)
+		}) {
+			continue
+		}
+
+		fldPath := field.NewPath("spec", "selector")
+		switch {
+		case policy.Spec.Selector.IssuerRef != nil:
+			fldPath = fldPath.Child("issuerRef")
+		case policy.Spec.Selector.IssuerRefs != nil:
+			fldPath = fldPath.Child("issuerRefs").Index(i)
+		}
+		orphaned = append(orphaned, fldPath)
+	}
+
+	return orphaned, nil
 }
 
 // certificateRequestPolicy validates the given CertificateRequestPolicy with
@@ -96,8 +170,23 @@ func (v *validator) validate(ctx context.Context, obj runtime.Object) (admission
 		}
 	}
 
-	if policy.Spec.Selector.IssuerRef == nil && policy.Spec.Selector.Namespace == nil {
-		fieldErrs = append(fieldErrs, field.Required(fldPath.Child("selector"), "one of issuerRef or namespace must be defined, hint: `{}` on either matches everything"))
+	if policy.Spec.Selector.IssuerRef == nil && policy.Spec.Selector.IssuerRefs == nil && policy.Spec.Selector.Namespace == nil {
+		fieldErrs = append(fieldErrs, field.Required(fldPath.Child("selector"), "one of issuerRef, issuerRefs or namespace must be defined, hint: `{}` on either matches everything"))
+	}
+
+	if policy.Spec.Selector.IssuerRef != nil && policy.Spec.Selector.IssuerRefs != nil {
+		fieldErrs = append(fieldErrs, field.Forbidden(fldPath.Child("selector", "issuerRefs"), "only one of issuerRef or issuerRefs may be defined"))
+	}
+
+	if policy.Spec.Selector.IssuerRefs != nil && len(policy.Spec.Selector.IssuerRefs) == 0 {
+		fieldErrs = append(fieldErrs, field.Required(fldPath.Child("selector", "issuerRefs"), "must contain at least one issuerRef if defined"))
+	}
+
+	if issRefSel := policy.Spec.Selector.IssuerRef; issRefSel != nil {
+		fieldErrs = append(fieldErrs, validateNameExpression(issRefSel, fldPath.Child("selector", "issuerRef"))...)
+	}
+	for i, issRefSel := range policy.Spec.Selector.IssuerRefs {
+		fieldErrs = append(fieldErrs, validateNameExpression(&issRefSel, fldPath.Child("selector", "issuerRefs").Index(i))...)
 	}
 
 	if nsSel := policy.Spec.Selector.Namespace; nsSel != nil && len(nsSel.MatchLabels) > 0 {
@@ -106,6 +195,62 @@ func (v *validator) validate(ctx context.Context, obj runtime.Object) (admission
 		}
 	}
 
+	if nsSel := policy.Spec.Selector.Namespace; nsSel != nil && len(nsSel.MatchExpressions) > 0 {
+		if _, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchExpressions: nsSel.MatchExpressions}); err != nil {
+			fieldErrs = append(fieldErrs, field.Invalid(fldPath.Child("selector", "namespace", "matchExpressions"), nsSel.MatchExpressions, err.Error()))
+		}
+	}
+
+	if nsSel := policy.Spec.Selector.Namespace; nsSel != nil && len(nsSel.ExcludeLabels) > 0 {
+		if _, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchLabels: nsSel.ExcludeLabels}); err != nil {
+			fieldErrs = append(fieldErrs, field.Invalid(fldPath.Child("selector", "namespace", "excludeLabels"), nsSel.ExcludeLabels, err.Error()))
+		}
+	}
+
+	if crSel := policy.Spec.Selector.CertificateRequest; crSel != nil {
+		if _, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchLabels: crSel.MatchLabels}); err != nil {
+			fieldErrs = append(fieldErrs, field.Invalid(fldPath.Child("selector", "certificateRequest", "matchLabels"), crSel.MatchLabels, err.Error()))
+		}
+		if _, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchExpressions: crSel.MatchExpressions}); err != nil {
+			fieldErrs = append(fieldErrs, field.Invalid(fldPath.Child("selector", "certificateRequest", "matchExpressions"), crSel.MatchExpressions, err.Error()))
+		}
+		fieldErrs = append(fieldErrs, apivalidation.ValidateAnnotations(crSel.MatchAnnotations, fldPath.Child("selector", "certificateRequest", "matchAnnotations"))...)
+		if _, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchExpressions: crSel.MatchAnnotationExpressions}); err != nil {
+			fieldErrs = append(fieldErrs, field.Invalid(fldPath.Child("selector", "certificateRequest", "matchAnnotationExpressions"), crSel.MatchAnnotationExpressions, err.Error()))
+		}
+	}
+
+	if certSel := policy.Spec.Selector.Certificate; certSel != nil {
+		if _, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchLabels: certSel.MatchLabels}); err != nil {
+			fieldErrs = append(fieldErrs, field.Invalid(fldPath.Child("selector", "certificate", "matchLabels"), certSel.MatchLabels, err.Error()))
+		}
+		if _, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchExpressions: certSel.MatchExpressions}); err != nil {
+			fieldErrs = append(fieldErrs, field.Invalid(fldPath.Child("selector", "certificate", "matchExpressions"), certSel.MatchExpressions, err.Error()))
+		}
+	}
+
+	for _, warning := range util.SelectorWarnings(policy.Spec.Selector, fldPath.Child("selector")) {
+		warnings = append(warnings, warning.Error())
+	}
+
+	for i, name := range policy.Spec.InheritFrom {
+		switch {
+		case len(name) == 0:
+			fieldErrs = append(fieldErrs, field.Required(fldPath.Child("inheritFrom").Index(i), "must be the name of a CertificateRequestPolicy"))
+		case name == policy.Name:
+			fieldErrs = append(fieldErrs, field.Invalid(fldPath.Child("inheritFrom").Index(i), name, "a policy cannot inherit from itself"))
+		}
+	}
+	fieldErrs = append(fieldErrs, util.ValidateSet(fldPath.Child("inheritFrom"), policy.Spec.InheritFrom)...)
+
+	// An explicitly empty list of usages denies every request with key usages,
+	// which combined with a selector matching everything blocks most requests
+	// in the cluster.
+	if allowed := policy.Spec.Allowed; allowed != nil && allowed.Usages != nil && len(*allowed.Usages) == 0 && util.SelectorMatchesAll(policy.Spec.Selector) {
+		warnings = append(warnings, field.Invalid(fldPath.Child("allowed", "usages"), *allowed.Usages,
+			"an empty list denies every request with key usages, and the selector matches every request, so most requests will be denied").Error())
+	}
+
 	allAllowed := true
 	for _, webhook := range v.webhooks {
 		response, err := webhook.Validate(ctx, policy)
@@ -135,3 +280,15 @@ func (v *validator) validate(ctx context.Context, obj runtime.Object) (admission
 
 	return warnings, utilerrors.NewAggregate(errs)
 }
+
+// validateNameExpression validates that the `nameExpression` of the given
+// issuerRef selector, if set, is a CEL expression which outputs a string.
+func validateNameExpression(issRefSel *policyapi.CertificateRequestPolicySelectorIssuerRef, fldPath *field.Path) field.ErrorList {
+	if issRefSel.NameExpression == nil {
+		return nil
+	}
+	if _, err := nameExpressions.Get(*issRefSel.NameExpression); err != nil {
+		return field.ErrorList{field.Invalid(fldPath.Child("nameExpression"), *issRefSel.NameExpression, err.Error())}
+	}
+	return nil
+}
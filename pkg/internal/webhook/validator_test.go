@@ -21,6 +21,7 @@ import (
 	"errors"
 	"testing"
 
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -78,7 +79,7 @@ func Test_validate(t *testing.T) {
 			},
 			registeredPlugins: []string{"foo", "baz"},
 
-			expectedError: ptr.To("[spec.plugins: Unsupported value: \"bar\": supported values: \"foo\", \"baz\", spec.selector: Required value: one of issuerRef or namespace must be defined, hint: `{}` on either matches everything]"),
+			expectedError: ptr.To("[spec.plugins: Unsupported value: \"bar\": supported values: \"foo\", \"baz\", spec.selector: Required value: one of issuerRef, issuerRefs or namespace must be defined, hint: `{}` on either matches everything]"),
 		},
 		"if neither issuer ref nor namespace are defined, return error": {
 			crp: &policyapi.CertificateRequestPolicy{
@@ -90,7 +91,47 @@ func Test_validate(t *testing.T) {
 			},
 			registeredPlugins: []string{"foo", "bar"},
 
-			expectedError: ptr.To("spec.selector: Required value: one of issuerRef or namespace must be defined, hint: `{}` on either matches everything"),
+			expectedError: ptr.To("spec.selector: Required value: one of issuerRef, issuerRefs or namespace must be defined, hint: `{}` on either matches everything"),
+		},
+		"if both issuerRef and issuerRefs are defined, return error": {
+			crp: &policyapi.CertificateRequestPolicy{
+				TypeMeta:   testTypeMeta,
+				ObjectMeta: testObjectMeta,
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{
+						IssuerRef:  &policyapi.CertificateRequestPolicySelectorIssuerRef{},
+						IssuerRefs: []policyapi.CertificateRequestPolicySelectorIssuerRef{{Name: ptr.To("my-ca")}},
+					},
+				},
+			},
+
+			expectedError: ptr.To("spec.selector.issuerRefs: Forbidden: only one of issuerRef or issuerRefs may be defined"),
+		},
+		"if issuerRefs is defined but empty, return error": {
+			crp: &policyapi.CertificateRequestPolicy{
+				TypeMeta:   testTypeMeta,
+				ObjectMeta: testObjectMeta,
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{
+						IssuerRefs: []policyapi.CertificateRequestPolicySelectorIssuerRef{},
+					},
+				},
+			},
+
+			expectedError: ptr.To("spec.selector.issuerRefs: Required value: must contain at least one issuerRef if defined"),
+		},
+		"if only issuerRefs is defined, return no error": {
+			crp: &policyapi.CertificateRequestPolicy{
+				TypeMeta:   testTypeMeta,
+				ObjectMeta: testObjectMeta,
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{
+						IssuerRefs: []policyapi.CertificateRequestPolicySelectorIssuerRef{{Name: ptr.To("my-ca")}, {Kind: ptr.To("ClusterIssuer")}},
+					},
+				},
+			},
+
+			expectedError: nil,
 		},
 		"if an invalid namespace label selector is defined, return error": {
 			crp: &policyapi.CertificateRequestPolicy{
@@ -109,6 +150,134 @@ func Test_validate(t *testing.T) {
 
 			expectedError: ptr.To("spec.selector.namespace.matchLabels: Invalid value: map[string]string{\"$%234\":\"8dsdk\"}: key: Invalid value: \"$%234\": name part must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]')"),
 		},
+		"if an invalid namespace exclude label selector is defined, return error": {
+			crp: &policyapi.CertificateRequestPolicy{
+				TypeMeta:   testTypeMeta,
+				ObjectMeta: testObjectMeta,
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{
+						Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{
+							ExcludeLabels: map[string]string{"$%234": "8dsdk"},
+						},
+					},
+				},
+			},
+
+			expectedError: ptr.To("spec.selector.namespace.excludeLabels: Invalid value: map[string]string{\"$%234\":\"8dsdk\"}: key: Invalid value: \"$%234\": name part must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]')"),
+		},
+		"if an invalid namespace match expression is defined, return error": {
+			crp: &policyapi.CertificateRequestPolicy{
+				TypeMeta:   testTypeMeta,
+				ObjectMeta: testObjectMeta,
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{
+						Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{
+							MatchExpressions: []metav1.LabelSelectorRequirement{
+								{Key: "foo", Operator: metav1.LabelSelectorOpExists, Values: []string{"bar"}},
+							},
+						},
+					},
+				},
+			},
+
+			expectedError: ptr.To("spec.selector.namespace.matchExpressions: Invalid value: []v1.LabelSelectorRequirement{v1.LabelSelectorRequirement{Key:\"foo\", Operator:\"Exists\", Values:[]string{\"bar\"}}}: values: Invalid value: []string{\"bar\"}: values set must be empty for exists and does not exist"),
+		},
+		"if an invalid certificateRequest match expression is defined, return error": {
+			crp: &policyapi.CertificateRequestPolicy{
+				TypeMeta:   testTypeMeta,
+				ObjectMeta: testObjectMeta,
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{
+						IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{},
+						CertificateRequest: &policyapi.CertificateRequestPolicySelectorCertificateRequest{
+							MatchExpressions: []metav1.LabelSelectorRequirement{
+								{Key: "foo", Operator: metav1.LabelSelectorOpExists, Values: []string{"bar"}},
+							},
+						},
+					},
+				},
+			},
+
+			expectedError: ptr.To("spec.selector.certificateRequest.matchExpressions: Invalid value: []v1.LabelSelectorRequirement{v1.LabelSelectorRequirement{Key:\"foo\", Operator:\"Exists\", Values:[]string{\"bar\"}}}: values: Invalid value: []string{\"bar\"}: values set must be empty for exists and does not exist"),
+		},
+		"if an invalid certificateRequest match annotation is defined, return error": {
+			crp: &policyapi.CertificateRequestPolicy{
+				TypeMeta:   testTypeMeta,
+				ObjectMeta: testObjectMeta,
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{
+						IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{},
+						CertificateRequest: &policyapi.CertificateRequestPolicySelectorCertificateRequest{
+							MatchAnnotations: map[string]string{"not a key": "1"},
+						},
+					},
+				},
+			},
+
+			expectedError: ptr.To("spec.selector.certificateRequest.matchAnnotations: Invalid value: \"not a key\": name part must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]')"),
+		},
+		"if an invalid certificateRequest match annotation expression is defined, return error": {
+			crp: &policyapi.CertificateRequestPolicy{
+				TypeMeta:   testTypeMeta,
+				ObjectMeta: testObjectMeta,
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{
+						IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{},
+						CertificateRequest: &policyapi.CertificateRequestPolicySelectorCertificateRequest{
+							MatchAnnotations: map[string]string{"cert-manager.io/certificate-revision": "a value which is not restricted to label value syntax"},
+							MatchAnnotationExpressions: []metav1.LabelSelectorRequirement{
+								{Key: "cert-manager.io/certificate-revision", Operator: metav1.LabelSelectorOpNotIn},
+							},
+						},
+					},
+				},
+			},
+
+			expectedError: ptr.To("spec.selector.certificateRequest.matchAnnotationExpressions: Invalid value: []v1.LabelSelectorRequirement{v1.LabelSelectorRequirement{Key:\"cert-manager.io/certificate-revision\", Operator:\"NotIn\", Values:[]string(nil)}}: values: Invalid value: []string(nil): for 'in', 'notin' operators, values set can't be empty"),
+		},
+		"if inheritFrom contains an empty name, itself or duplicate names, return error": {
+			crp: &policyapi.CertificateRequestPolicy{
+				TypeMeta:   testTypeMeta,
+				ObjectMeta: testObjectMeta,
+				Spec: policyapi.CertificateRequestPolicySpec{
+					InheritFrom: []string{"", testObjectMeta.Name, "parent", "parent"},
+					Selector: policyapi.CertificateRequestPolicySelector{
+						IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{},
+					},
+				},
+			},
+			webhooks:      []approver.Webhook{passingWebhook},
+			expectedError: ptr.To("[spec.inheritFrom[0]: Required value: must be the name of a CertificateRequestPolicy, spec.inheritFrom[1]: Invalid value: \"test-policy\": a policy cannot inherit from itself, spec.inheritFrom[3]: Duplicate value: \"parent\"]"),
+		},
+		"if inheritFrom contains other policies, allow it": {
+			crp: &policyapi.CertificateRequestPolicy{
+				TypeMeta:   testTypeMeta,
+				ObjectMeta: testObjectMeta,
+				Spec: policyapi.CertificateRequestPolicySpec{
+					InheritFrom: []string{"parent-a", "parent-b"},
+					Selector: policyapi.CertificateRequestPolicySelector{
+						IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{},
+					},
+				},
+			},
+			webhooks: []approver.Webhook{passingWebhook},
+		},
+		"if an issuerRef name expression doesn't output a string, return error": {
+			crp: &policyapi.CertificateRequestPolicy{
+				TypeMeta:   testTypeMeta,
+				ObjectMeta: testObjectMeta,
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{
+						IssuerRefs: []policyapi.CertificateRequestPolicySelectorIssuerRef{
+							{NameExpression: ptr.To("cr.namespace + '-issuer'")},
+							{NameExpression: ptr.To("size(cr.namespace)")},
+						},
+					},
+				},
+			},
+
+			expectedError: ptr.To("spec.selector.issuerRefs[1].nameExpression: Invalid value: \"size(cr.namespace)\": got int, wanted string result type"),
+		},
 		"if a registered webhook does not allow CertificateRequestPolicy, return an error": {
 			crp: &policyapi.CertificateRequestPolicy{
 				TypeMeta:   testTypeMeta,
@@ -178,6 +347,78 @@ func Test_validate(t *testing.T) {
 			webhooks:          []approver.Webhook{passingWebhook, warningsWebhook},
 			expectedWarnings:  admission.Warnings{"some warning"},
 		},
+		"if the selector is suspicious, allow it but return warnings": {
+			crp: &policyapi.CertificateRequestPolicy{
+				TypeMeta:   testTypeMeta,
+				ObjectMeta: testObjectMeta,
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{
+						IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{
+							Name:  ptr.To("prod"),
+							Kind:  ptr.To("Issuer"),
+							Group: ptr.To(""),
+						},
+					},
+				},
+			},
+			webhooks: []approver.Webhook{passingWebhook},
+			expectedWarnings: admission.Warnings{
+				"spec.selector.issuerRef.group: Invalid value: \"\": an empty group never matches an issuer, since requests which omit the issuer group are matched as \"cert-manager.io\", hint: omit the field or use `*` to match any group",
+			},
+		},
+		"if allowed usages is empty and the selector matches everything, allow it but return a warning": {
+			crp: &policyapi.CertificateRequestPolicy{
+				TypeMeta:   testTypeMeta,
+				ObjectMeta: testObjectMeta,
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{
+						Usages: &[]cmapi.KeyUsage{},
+					},
+					Selector: policyapi.CertificateRequestPolicySelector{
+						IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{
+							Name: ptr.To("*"),
+						},
+					},
+				},
+			},
+			webhooks: []approver.Webhook{passingWebhook},
+			expectedWarnings: admission.Warnings{
+				`spec.allowed.usages: Invalid value: []v1.KeyUsage{}: an empty list denies every request with key usages, and the selector matches every request, so most requests will be denied`,
+			},
+		},
+		"if allowed usages is empty and the selector is narrow, allow it without warnings": {
+			crp: &policyapi.CertificateRequestPolicy{
+				TypeMeta:   testTypeMeta,
+				ObjectMeta: testObjectMeta,
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{
+						Usages: &[]cmapi.KeyUsage{},
+					},
+					Selector: policyapi.CertificateRequestPolicySelector{
+						IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{},
+						Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{
+							MatchNames: []string{"sandbox"},
+						},
+					},
+				},
+			},
+			webhooks: []approver.Webhook{passingWebhook},
+		},
+		"if allowed usages is not empty and the selector matches everything, allow it without warnings": {
+			crp: &policyapi.CertificateRequestPolicy{
+				TypeMeta:   testTypeMeta,
+				ObjectMeta: testObjectMeta,
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{
+						Usages: &[]cmapi.KeyUsage{cmapi.UsageServerAuth},
+					},
+					Selector: policyapi.CertificateRequestPolicySelector{
+						IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{},
+					},
+				},
+			},
+			webhooks: []approver.Webhook{passingWebhook},
+		},
 		"if a  CertificateRequestPolicy with a defined issuer ref passes validation, allow it": {
 			crp: &policyapi.CertificateRequestPolicy{
 				TypeMeta:   testTypeMeta,
@@ -225,3 +466,96 @@ func Test_validate(t *testing.T) {
 		})
 	}
 }
+
+func Test_ValidateDelete(t *testing.T) {
+	policy := func(name string, selector policyapi.CertificateRequestPolicySelector) *policyapi.CertificateRequestPolicy {
+		return &policyapi.CertificateRequestPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       policyapi.CertificateRequestPolicySpec{Selector: selector},
+		}
+	}
+	prodSelector := policyapi.CertificateRequestPolicySelector{
+		IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("prod"), Kind: ptr.To("ClusterIssuer"), Group: ptr.To("cert-manager.io")},
+	}
+	soleMessage := func(path *field.Path) string {
+		return field.Forbidden(path, "no other CertificateRequestPolicy selects these issuers, so their requests will be neither approved nor denied once this policy is deleted").Error()
+	}
+
+	tests := map[string]struct {
+		existingObjects        []runtime.Object
+		crp                    *policyapi.CertificateRequestPolicy
+		denySolePolicyDeletion bool
+		expectedWarnings       admission.Warnings
+		expectedError          *string
+	}{
+		"if the policy is the sole policy for its issuer, warn": {
+			existingObjects: []runtime.Object{
+				policy("test-policy", prodSelector),
+				policy("other-policy", policyapi.CertificateRequestPolicySelector{
+					IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("dev")},
+				}),
+			},
+			crp:              policy("test-policy", prodSelector),
+			expectedWarnings: admission.Warnings{soleMessage(field.NewPath("spec", "selector", "issuerRef"))},
+		},
+		"if the policy is the sole policy for its issuer and deletion is denied, return an error": {
+			existingObjects:        []runtime.Object{policy("test-policy", prodSelector)},
+			crp:                    policy("test-policy", prodSelector),
+			denySolePolicyDeletion: true,
+			expectedError:          ptr.To(soleMessage(field.NewPath("spec", "selector", "issuerRef"))),
+		},
+		"if a sibling policy selects the same issuer, allow without warnings": {
+			existingObjects: []runtime.Object{
+				policy("test-policy", prodSelector),
+				policy("other-policy", prodSelector),
+			},
+			crp:                    policy("test-policy", prodSelector),
+			denySolePolicyDeletion: true,
+		},
+		"if a sibling policy selects all issuers, allow without warnings": {
+			existingObjects: []runtime.Object{
+				policy("test-policy", prodSelector),
+				policy("other-policy", policyapi.CertificateRequestPolicySelector{
+					Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{MatchNames: []string{"sandbox"}},
+				}),
+			},
+			crp: policy("test-policy", prodSelector),
+		},
+		"if only some issuerRefs have a sibling policy, warn for the others": {
+			existingObjects: []runtime.Object{
+				policy("other-policy", prodSelector),
+			},
+			crp: policy("test-policy", policyapi.CertificateRequestPolicySelector{
+				IssuerRefs: []policyapi.CertificateRequestPolicySelectorIssuerRef{*prodSelector.IssuerRef, {Name: ptr.To("dev")}},
+			}),
+			expectedWarnings: admission.Warnings{soleMessage(field.NewPath("spec", "selector", "issuerRefs").Index(1))},
+		},
+		"if the policy selects all issuers and no sibling does, warn": {
+			existingObjects: []runtime.Object{
+				policy("other-policy", prodSelector),
+			},
+			crp: policy("test-policy", policyapi.CertificateRequestPolicySelector{
+				Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{},
+			}),
+			expectedWarnings: admission.Warnings{soleMessage(field.NewPath("spec", "selector"))},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fakeclient := fakeclient.NewClientBuilder().
+				WithScheme(policyapi.GlobalScheme).
+				WithRuntimeObjects(test.existingObjects...).
+				Build()
+
+			v := &validator{lister: fakeclient, log: ktesting.NewLogger(t, ktesting.DefaultConfig), denySolePolicyDeletion: test.denySolePolicyDeletion}
+			gotWarnings, gotErr := v.ValidateDelete(context.Background(), test.crp)
+			if test.expectedError == nil && gotErr != nil {
+				t.Errorf("unexpected error: %v", gotErr)
+			} else if test.expectedError != nil && (gotErr == nil || *test.expectedError != gotErr.Error()) {
+				t.Errorf("wants error: %v got: %v", *test.expectedError, gotErr)
+			}
+			assert.Equal(t, test.expectedWarnings, gotWarnings)
+		})
+	}
+}
@@ -42,6 +42,11 @@ type Options struct {
 	// approver-policy instance. The webhook will register its endpoints and
 	// runnables against.
 	Manager manager.Manager
+
+	// DenySolePolicyDeletion causes the deletion of a CertificateRequestPolicy
+	// which is the only policy selecting some issuers to be denied, rather
+	// than only warned about.
+	DenySolePolicyDeletion bool
 }
 
 // Register the approver-policy Webhook endpoints against the
@@ -58,15 +63,17 @@ func Register(ctx context.Context, opts Options) error {
 
 	log.Info("registering webhook endpoints")
 	validator := &validator{
-		log:               log.WithName("validation"),
-		lister:            opts.Manager.GetCache(),
-		webhooks:          opts.Webhooks,
-		registeredPlugins: registerdPlugins,
+		log:                    log.WithName("validation"),
+		lister:                 opts.Manager.GetCache(),
+		webhooks:               opts.Webhooks,
+		registeredPlugins:      registerdPlugins,
+		denySolePolicyDeletion: opts.DenySolePolicyDeletion,
 	}
 
 	err := builder.WebhookManagedBy(opts.Manager).
 		For(&policyapi.CertificateRequestPolicy{}).
 		WithValidator(validator).
+		WithDefaulter(&defaulter{}).
 		Complete()
 	if err != nil {
 		return fmt.Errorf("error registering webhook: %v", err)
@@ -17,6 +17,8 @@ limitations under the License.
 package registry
 
 import (
+	"cmp"
+	"slices"
 	"sync"
 
 	"github.com/cert-manager/approver-policy/pkg/approver"
@@ -58,8 +60,23 @@ func (r *Registry) Approvers() []approver.Interface {
 	return r.approvers
 }
 
+// List returns the names of the Approvers that have been registered to the
+// registry, sorted by name.
+func (r *Registry) List() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	names := make([]string, 0, len(r.approvers))
+	for _, approver := range r.approvers {
+		names = append(names, approver.Name())
+	}
+	slices.Sort(names)
+	return names
+}
+
 // Evaluators returns the list of Evaluators that have been registered as
-// Approvers to the registry.
+// Approvers to the registry, ordered by their evaluation stage so that cheap
+// evaluators are run first. Evaluators of the same stage are returned in the
+// order they were registered.
 func (r *Registry) Evaluators() []approver.Evaluator {
 	r.lock.RLock()
 	defer r.lock.RUnlock()
@@ -67,6 +84,9 @@ func (r *Registry) Evaluators() []approver.Evaluator {
 	for _, approver := range r.approvers {
 		evaluators = append(evaluators, approver)
 	}
+	slices.SortStableFunc(evaluators, func(a, b approver.Evaluator) int {
+		return cmp.Compare(approver.StageOf(a), approver.StageOf(b))
+	})
 	return evaluators
 }
 
@@ -93,3 +113,31 @@ func (r *Registry) Reconcilers() []approver.Reconciler {
 	}
 	return reconcilers
 }
+
+// Reloaders returns the list of Approvers registered to the registry which
+// also implement Reloader.
+func (r *Registry) Reloaders() []approver.Reloader {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	var reloaders []approver.Reloader
+	for _, a := range r.approvers {
+		if reloader, ok := a.(approver.Reloader); ok {
+			reloaders = append(reloaders, reloader)
+		}
+	}
+	return reloaders
+}
+
+// StartupCheckers returns the list of Approvers registered to the registry
+// which also implement StartupChecker.
+func (r *Registry) StartupCheckers() []approver.StartupChecker {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	var checkers []approver.StartupChecker
+	for _, a := range r.approvers {
+		if checker, ok := a.(approver.StartupChecker); ok {
+			checkers = append(checkers, checker)
+		}
+	}
+	return checkers
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/approver/fake"
+)
+
+func Test_List(t *testing.T) {
+	named := func(name string) *fake.FakeApprover {
+		return fake.NewFakeApprover().WithReconciler(fake.NewFakeReconciler().WithName(name))
+	}
+
+	tests := map[string]struct {
+		approvers []string
+		expNames  []string
+	}{
+		"if no approvers are registered, return no names": {
+			approvers: nil,
+			expNames:  []string{},
+		},
+		"if a single approver is registered, return its name": {
+			approvers: []string{"allowed"},
+			expNames:  []string{"allowed"},
+		},
+		"if multiple approvers are registered, return their names sorted": {
+			approvers: []string{"validations", "allowed", "constraints"},
+			expNames:  []string{"allowed", "constraints", "validations"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := new(Registry)
+			for _, name := range test.approvers {
+				r.Store(named(name))
+			}
+			assert.Equal(t, test.expNames, r.List())
+		})
+	}
+}
+
+func Test_Evaluators(t *testing.T) {
+	staged := func(name string, stage approver.EvaluationStage) *fake.FakeApprover {
+		return fake.NewFakeApprover().
+			WithEvaluator(fake.NewFakeEvaluator().WithEvaluationStage(stage)).
+			WithReconciler(fake.NewFakeReconciler().WithName(name))
+	}
+	unstaged := fake.NewFakeApprover().WithReconciler(fake.NewFakeReconciler().WithName("unstaged"))
+
+	r := new(Registry).Store(
+		staged("plugin-a", approver.EvaluationStagePlugin),
+		unstaged,
+		staged("cel", approver.EvaluationStageCEL),
+		staged("allowed", approver.EvaluationStageBuiltin),
+		staged("plugin-b", approver.EvaluationStagePlugin),
+		staged("constraints", approver.EvaluationStageBuiltin),
+	)
+
+	var names []string
+	for _, evaluator := range r.Evaluators() {
+		names = append(names, evaluator.(*fake.FakeApprover).Name())
+	}
+	assert.Equal(t, []string{"allowed", "constraints", "cel", "plugin-a", "unstaged", "plugin-b"}, names)
+}
+
+func Test_StoreDuplicateName(t *testing.T) {
+	r := new(Registry).Store(fake.NewFakeApprover().WithReconciler(fake.NewFakeReconciler().WithName("allowed")))
+	assert.PanicsWithValue(t, "approver already registered with same name: allowed", func() {
+		r.Store(fake.NewFakeApprover().WithReconciler(fake.NewFakeReconciler().WithName("allowed")))
+	})
+	assert.Equal(t, []string{"allowed"}, r.List())
+}